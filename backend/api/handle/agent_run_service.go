@@ -3,6 +3,9 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
 
 	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
@@ -32,20 +35,41 @@ func AgentRun(c *gin.Context) {
 		invalidParamRequestResponse(c, checkErr.Error())
 		return
 	}
+	// stream=false 时运行进入队列异步执行，直接返回运行记录
+	if req.Stream != nil && !*req.Stream {
+		resp, err := application.ConversationSVC.AsyncRun(ctx, &req)
+		if err != nil {
+			internalServerErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
 	// 起一个 SSE Server
 	sseSender := sseimpl.NewSSESender(c)
 
 	err = application.ConversationSVC.Run(ctx, sseSender, &req)
 	if err != nil {
-		errData := run.ErrorData{
-			Code: errno.ErrConversationAgentRunError,
-			Msg:  err.Error(),
-		}
-		ed, _ := json.Marshal(errData)
-		_ = sseSender.Send(ctx, &sse.Event{
-			Event: run.RunEventError,
-			Data:  ed,
-		})
+		_ = sseSender.Send(ctx, buildRunErrorEvent(err))
+	}
+}
+
+// buildRunErrorEvent 业务错误返回对应的错误码，客户端据此区分如事件已过期等情况
+func buildRunErrorEvent(err error) *sse.Event {
+	errData := run.ErrorData{
+		Code: errno.ErrConversationAgentRunError,
+		Msg:  err.Error(),
+	}
+	var statusErr errorx.StatusError
+	if errors.As(err, &statusErr) {
+		errData.Code = int64(statusErr.Code())
+		errData.Msg = statusErr.Msg()
+	}
+	ed, _ := json.Marshal(errData)
+	return &sse.Event{
+		Event: run.RunEventError,
+		Data:  ed,
 	}
 }
 
@@ -63,3 +87,95 @@ func checkParams(_ context.Context, ar *run.AgentRunRequest) error {
 	}
 	return nil
 }
+
+// CancelChatApi .
+// @router /v3/chat/cancel [POST]
+func CancelChatApi(c *gin.Context) {
+	var req run.CancelChatApiRequest
+	ctx := c.Request.Context()
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.ChatID == 0 || req.ConversationID == 0 {
+		invalidParamRequestResponse(c, "ChatID and ConversationID are required")
+		return
+	}
+
+	resp, err := application.ConversationSVC.CancelRun(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ReattachChatApi 客户端断线后重新接入运行，从 Last-Event-ID 之后续传
+func ReattachChatApi(c *gin.Context) {
+	var req run.ReattachChatApiRequest
+	ctx := c.Request.Context()
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.ChatID == 0 || req.ConversationID == 0 {
+		invalidParamRequestResponse(c, "chat_id and conversation_id are required")
+		return
+	}
+	if req.LastEventID == nil {
+		if lastEventID, err := strconv.ParseInt(c.GetHeader("Last-Event-ID"), 10, 64); err == nil {
+			req.LastEventID = &lastEventID
+		}
+	}
+
+	sseSender := sseimpl.NewSSESender(c)
+	if err := application.ConversationSVC.ReattachRun(ctx, sseSender, &req); err != nil {
+		_ = sseSender.Send(ctx, buildRunErrorEvent(err))
+	}
+}
+
+// RetrieveChatApi 查询运行状态
+func RetrieveChatApi(c *gin.Context) {
+	var req run.RetrieveChatApiRequest
+	ctx := c.Request.Context()
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.ChatID == 0 || req.ConversationID == 0 {
+		invalidParamRequestResponse(c, "chat_id and conversation_id are required")
+		return
+	}
+
+	resp, err := application.ConversationSVC.RetrieveRun(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListChatMessageApi 查询运行产生的消息
+func ListChatMessageApi(c *gin.Context) {
+	var req run.ListChatMessageApiRequest
+	ctx := c.Request.Context()
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.ChatID == 0 || req.ConversationID == 0 {
+		invalidParamRequestResponse(c, "chat_id and conversation_id are required")
+		return
+	}
+
+	resp, err := application.ConversationSVC.ListRunMessages(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
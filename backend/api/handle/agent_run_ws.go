@@ -0,0 +1,245 @@
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/api/model/conversation/run"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/application"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/http/ws"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+	"github.com/kiosk404/airi-go/backend/types/consts"
+)
+
+// WebSocket 对话协议，每条消息为一个 JSON 文本帧。
+//
+// 客户端帧 {"id": "客户端生成的帧 ID", "type": "类型", "data": {...}}：
+//   - chat：发起对话，data 与 POST /api/conversation/chat 的请求体相同
+//   - resume：回答智能体的提问中断，data 同 chat，query 为回答内容，conversation_id 为中断所在的会话
+//   - cancel：取消对话，data 为 {"chat_id": "...", "conversation_id": "..."}
+//   - ping：心跳，服务端回复 pong
+//
+// 服务端帧 {"type": "类型", "reply_to": "对应的客户端帧 ID", ...}：
+//   - event：对话事件，event、data 与 SSE 的事件名和数据相同，id 为事件序号，断线后可通过 reattach 续传
+//   - result：cancel 的结果，data 与 POST /v3/chat/cancel 的响应相同
+//   - error：请求处理失败，code、msg 为错误码与错误信息
+//   - pong：心跳回复
+//
+// 同一连接上可以同时进行多个对话，以 reply_to 区分。
+const (
+	wsFrameChat   = "chat"
+	wsFrameResume = "resume"
+	wsFrameCancel = "cancel"
+	wsFramePing   = "ping"
+
+	wsFrameEvent  = "event"
+	wsFrameResult = "result"
+	wsFrameError  = "error"
+	wsFramePong   = "pong"
+
+	// wsMaxConcurrentChats 单个连接同时进行的对话数量上限
+	wsMaxConcurrentChats = 4
+)
+
+type wsClientFrame struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type wsServerFrame struct {
+	Type    string `json:"type"`
+	ReplyTo string `json:"reply_to,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Event   string `json:"event,omitempty"`
+	Data    any    `json:"data,omitempty"`
+	Code    int64  `json:"code,omitempty"`
+	Msg     string `json:"msg,omitempty"`
+}
+
+// ChatWebSocket 实时双向对话，角色回答过程中客户端可以随时取消或发起新的对话
+// @router /api/conversation/chat/ws [GET]
+// @router /v3/chat/ws [GET]
+func ChatWebSocket(c *gin.Context) {
+	conn, err := ws.Upgrade(c, ws.Options{
+		AllowedOrigins: wsAllowedOrigins(),
+	})
+	if err != nil {
+		logs.Warn("upgrade chat websocket failed, err: %v", err)
+		return
+	}
+
+	// 连接断开后停止推送，已开始的运行继续执行，可通过 reattach 续传
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	wsc := &wsChatConn{
+		conn:  conn,
+		slots: make(chan struct{}, wsMaxConcurrentChats),
+	}
+	defer func() {
+		cancel()
+		conn.Close()
+		wsc.wg.Wait()
+	}()
+
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		frame := &wsClientFrame{}
+		if err = json.Unmarshal(data, frame); err != nil {
+			wsc.sendError("", errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", "invalid frame")))
+			continue
+		}
+		wsc.dispatch(ctx, frame)
+	}
+}
+
+func wsAllowedOrigins() []string {
+	var origins []string
+	for _, o := range strings.Split(os.Getenv(consts.WSAllowedOrigins), ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			origins = append(origins, o)
+		}
+	}
+	return origins
+}
+
+type wsChatConn struct {
+	conn  *ws.Conn
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+func (w *wsChatConn) dispatch(ctx context.Context, frame *wsClientFrame) {
+	switch frame.Type {
+	case wsFrameChat, wsFrameResume:
+		w.chat(ctx, frame)
+	case wsFrameCancel:
+		w.cancel(ctx, frame)
+	case wsFramePing:
+		w.send(&wsServerFrame{Type: wsFramePong, ReplyTo: frame.ID})
+	default:
+		w.sendError(frame.ID, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", "unknown frame type: "+frame.Type)))
+	}
+}
+
+// chat 对话在单独的 goroutine 中进行，读循环可以继续处理取消等请求
+func (w *wsChatConn) chat(ctx context.Context, frame *wsClientFrame) {
+	req := &run.AgentRunRequest{}
+	if err := json.Unmarshal(frame.Data, req); err != nil {
+		w.sendError(frame.ID, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", err.Error())))
+		return
+	}
+	if err := checkParams(ctx, req); err != nil {
+		w.sendError(frame.ID, err)
+		return
+	}
+	if frame.Type == wsFrameResume && req.ConversationID == 0 {
+		w.sendError(frame.ID, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", "conversation_id is required")))
+		return
+	}
+
+	select {
+	case w.slots <- struct{}{}:
+	default:
+		w.sendError(frame.ID, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", "too many concurrent chats on this connection")))
+		return
+	}
+
+	w.wg.Add(1)
+	safego.Go(ctx, func() {
+		defer func() {
+			<-w.slots
+			w.wg.Done()
+		}()
+
+		sender := &wsEventSender{chatConn: w, replyTo: frame.ID}
+		if err := application.ConversationSVC.Run(ctx, sender, req); err != nil {
+			w.sendError(frame.ID, err)
+		}
+	})
+}
+
+func (w *wsChatConn) cancel(ctx context.Context, frame *wsClientFrame) {
+	req := &run.CancelChatApiRequest{}
+	if err := json.Unmarshal(frame.Data, req); err != nil {
+		w.sendError(frame.ID, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", err.Error())))
+		return
+	}
+	if req.ChatID == 0 || req.ConversationID == 0 {
+		w.sendError(frame.ID, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", "ChatID and ConversationID are required")))
+		return
+	}
+
+	resp, err := application.ConversationSVC.CancelRun(ctx, req)
+	if err != nil {
+		w.sendError(frame.ID, err)
+		return
+	}
+	w.send(&wsServerFrame{Type: wsFrameResult, ReplyTo: frame.ID, Data: resp})
+}
+
+func (w *wsChatConn) send(frame *wsServerFrame) error {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	return w.conn.Send(data)
+}
+
+// sendError 业务错误返回对应的错误码，与 SSE 的错误事件一致
+func (w *wsChatConn) sendError(replyTo string, err error) {
+	frame := &wsServerFrame{
+		Type:    wsFrameError,
+		ReplyTo: replyTo,
+		Code:    errno.ErrConversationAgentRunError,
+		Msg:     err.Error(),
+	}
+	var statusErr errorx.StatusError
+	if errors.As(err, &statusErr) {
+		frame.Code = int64(statusErr.Code())
+		frame.Msg = statusErr.Msg()
+	}
+	_ = w.send(frame)
+}
+
+// wsEventSender 将对话事件转为 event 帧，复用 SSE 的事件推送流程
+type wsEventSender struct {
+	chatConn *wsChatConn
+	replyTo  string
+}
+
+func (s *wsEventSender) Send(_ context.Context, event *sse.Event) error {
+	frame := &wsServerFrame{
+		Type:    wsFrameEvent,
+		ReplyTo: s.replyTo,
+		ID:      event.Id,
+		Event:   event.Event,
+		Data:    event.Data,
+	}
+	// 事件数据为 JSON 时原样嵌入，[DONE] 等非 JSON 数据作为字符串
+	if data, ok := event.Data.([]byte); ok {
+		if json.Valid(data) {
+			frame.Data = json.RawMessage(data)
+		} else {
+			frame.Data = string(data)
+		}
+	}
+	return s.chatConn.send(frame)
+}
+
+// Close 对话结束不关闭连接，连接由客户端或读循环关闭
+func (s *wsEventSender) Close() error {
+	return nil
+}
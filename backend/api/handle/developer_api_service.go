@@ -14,6 +14,7 @@ import (
 	"github.com/kiosk404/airi-go/backend/application/ctxutil"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/application/singleagent"
 	uploadapp "github.com/kiosk404/airi-go/backend/modules/data/upload/application"
+	uploadpkg "github.com/kiosk404/airi-go/backend/modules/data/upload/pkg"
 	"github.com/kiosk404/airi-go/backend/modules/data/upload/pkg/errno"
 	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/application"
 	"github.com/kiosk404/airi-go/backend/pkg/errorx"
@@ -152,8 +153,7 @@ func UploadFile(c *gin.Context) {
 		return
 	}
 	secret := createSecret(ptr.From(userID), req.FileHead.FileType)
-	fileName := fmt.Sprintf("%d_%d_%s.%s", ptr.From(userID), time.Now().UnixNano(), secret, req.FileHead.FileType)
-	objectName := fmt.Sprintf("%s/%s", req.FileHead.BizType.String(), fileName)
+	objectName := uploadpkg.UserFileObjectKey(req.FileHead.BizType, ptr.From(userID), secret, req.FileHead.FileType)
 	resp, err = uploadapp.SVC.UploadFile(ctx, fileContent, objectName)
 	if err != nil {
 		internalServerErrorResponse(c, err)
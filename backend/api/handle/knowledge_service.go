@@ -0,0 +1,225 @@
+package handle
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/api/model/data/knowledge"
+	knowledgeapp "github.com/kiosk404/airi-go/backend/modules/data/knowledge/application"
+)
+
+// CreateKnowledge .
+// @router /api/knowledge/create [POST]
+func CreateKnowledge(c *gin.Context) {
+	var err error
+	var req knowledge.CreateKnowledgeRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetName() == "" {
+		invalidParamRequestResponse(c, "name is required")
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.CreateKnowledge(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateKnowledge .
+// @router /api/knowledge/update [POST]
+func UpdateKnowledge(c *gin.Context) {
+	var err error
+	var req knowledge.UpdateKnowledgeRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetKnowledgeID() <= 0 {
+		invalidParamRequestResponse(c, "knowledge_id is required")
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.UpdateKnowledge(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteKnowledge .
+// @router /api/knowledge/delete [POST]
+func DeleteKnowledge(c *gin.Context) {
+	var err error
+	var req knowledge.DeleteKnowledgeRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetKnowledgeID() <= 0 {
+		invalidParamRequestResponse(c, "knowledge_id is required")
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.DeleteKnowledge(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListKnowledge .
+// @router /api/knowledge/list [POST]
+func ListKnowledge(c *gin.Context) {
+	var err error
+	var req knowledge.ListKnowledgeRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.ListKnowledge(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateDocument .
+// @router /api/knowledge/document/create [POST]
+func CreateDocument(c *gin.Context) {
+	var err error
+	var req knowledge.CreateDocumentRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetKnowledgeID() <= 0 {
+		invalidParamRequestResponse(c, "knowledge_id is required")
+		return
+	}
+	if req.GetContent() == "" && req.GetURI() == "" {
+		invalidParamRequestResponse(c, "content or uri is required")
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.CreateDocument(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteDocument .
+// @router /api/knowledge/document/delete [POST]
+func DeleteDocument(c *gin.Context) {
+	var err error
+	var req knowledge.DeleteDocumentRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetDocumentID() <= 0 {
+		invalidParamRequestResponse(c, "document_id is required")
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.DeleteDocument(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListDocument .
+// @router /api/knowledge/document/list [POST]
+func ListDocument(c *gin.Context) {
+	var err error
+	var req knowledge.ListDocumentRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetKnowledgeID() <= 0 {
+		invalidParamRequestResponse(c, "knowledge_id is required")
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.ListDocument(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListSlice .
+// @router /api/knowledge/slice/list [POST]
+func ListSlice(c *gin.Context) {
+	var err error
+	var req knowledge.ListSliceRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetDocumentID() <= 0 {
+		invalidParamRequestResponse(c, "document_id is required")
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.ListSlice(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Retrieve .
+// @router /api/knowledge/retrieve [POST]
+func Retrieve(c *gin.Context) {
+	var err error
+	var req knowledge.RetrieveRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetQuery() == "" || len(req.GetKnowledgeIds()) == 0 {
+		invalidParamRequestResponse(c, "query and knowledge_ids are required")
+		return
+	}
+
+	resp, err := knowledgeapp.KnowledgeSVC.Retrieve(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
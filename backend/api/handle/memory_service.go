@@ -0,0 +1,85 @@
+package handle
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/api/model/data/memory"
+	memoryapp "github.com/kiosk404/airi-go/backend/modules/data/memory/application"
+)
+
+// ListMemories .
+// @router /api/memory/list [POST]
+func ListMemories(c *gin.Context) {
+	var err error
+	var req memory.ListMemoriesRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetAgentID() <= 0 {
+		invalidParamRequestResponse(c, "agent_id is required")
+		return
+	}
+
+	resp, err := memoryapp.MemorySVC.ListMemories(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateMemory .
+// @router /api/memory/update [POST]
+func UpdateMemory(c *gin.Context) {
+	var err error
+	var req memory.UpdateMemoryRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetMemoryID() <= 0 {
+		invalidParamRequestResponse(c, "memory_id is required")
+		return
+	}
+	if !req.IsSetContent() && !req.IsSetKind() && !req.IsSetImportance() {
+		invalidParamRequestResponse(c, "nothing to update")
+		return
+	}
+
+	resp, err := memoryapp.MemorySVC.UpdateMemory(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ForgetMemories .
+// @router /api/memory/forget [POST]
+func ForgetMemories(c *gin.Context) {
+	var err error
+	var req memory.ForgetMemoriesRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetAgentID() <= 0 {
+		invalidParamRequestResponse(c, "agent_id is required")
+		return
+	}
+
+	resp, err := memoryapp.MemorySVC.ForgetMemories(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
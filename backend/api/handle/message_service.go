@@ -105,6 +105,29 @@ func checkDMParams(_ context.Context, req *message.DeleteMessageRequest) error {
 	return nil
 }
 
+// SwitchBranch .
+// @router /api/conversation/switch_branch [POST]
+func SwitchBranch(c *gin.Context) {
+	var req message.SwitchBranchRequest
+	ctx := c.Request.Context()
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.ConversationID == 0 || req.ChatID == 0 {
+		invalidParamRequestResponse(c, "conversation_id and chat_id are required")
+		return
+	}
+
+	resp, err := application.ConversationSVC.SwitchBranch(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
 func checkBMParams(_ context.Context, req *message.BreakMessageRequest) error {
 	if req.AnswerMessageID == nil {
 		return errors.New("answer message id is required")
@@ -0,0 +1,104 @@
+package handle
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/api/model/conversation/run"
+	llmruntime "github.com/kiosk404/airi-go/backend/api/model/llm/runtime"
+	modelmgrapp "github.com/kiosk404/airi-go/backend/modules/llm/application"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	sseimpl "github.com/kiosk404/airi-go/backend/pkg/http/sse"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+// Chat .
+// @router /api/llm/v1/chat [POST]
+func Chat(c *gin.Context) {
+	var err error
+	var req llmruntime.ChatRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+
+	resp, err := modelmgrapp.ModelMgrSVC.Chat(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ChatStream .
+// @router /api/llm/v1/chat/stream [POST]
+func ChatStream(c *gin.Context) {
+	var err error
+	var req llmruntime.ChatRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+
+	sseSender := sseimpl.NewSSESender(c)
+
+	sr, err := modelmgrapp.ModelMgrSVC.ChatStream(ctx, &req)
+	if err != nil {
+		_ = sseSender.Send(ctx, buildChatErrorEvent(err))
+		return
+	}
+	defer sr.Close()
+
+	for {
+		msg, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			_ = sseSender.Send(ctx, &sse.Event{Event: run.RunEventDone, Data: "[DONE]"})
+			return
+		}
+		if err != nil {
+			_ = sseSender.Send(ctx, buildChatErrorEvent(err))
+			return
+		}
+
+		data, err := json.Marshal(&llmruntime.ChatResponse{Message: msg})
+		if err != nil {
+			logs.Error("[ChatStream] marshal message failed, err: %v", err)
+			continue
+		}
+		// 客户端断开时停止读取，关闭流后模型请求随之结束
+		if err = sseSender.Send(ctx, &sse.Event{Event: run.RunEventMessage, Data: data}); err != nil {
+			return
+		}
+	}
+}
+
+// buildChatErrorEvent 配额超限时携带建议的重试等待时间
+func buildChatErrorEvent(err error) *sse.Event {
+	errData := run.ErrorData{
+		Code: http.StatusInternalServerError,
+		Msg:  err.Error(),
+	}
+	var statusErr errorx.StatusError
+	if errors.As(err, &statusErr) {
+		errData.Code = int64(statusErr.Code())
+		errData.Msg = statusErr.Msg()
+	}
+	if retryAfter, ok := modelmgr.QuotaExceeded(err); ok {
+		errData.RetryAfterMs = ptr.Of(retryAfter.Milliseconds())
+	}
+	ed, _ := json.Marshal(errData)
+
+	return &sse.Event{
+		Event: run.RunEventError,
+		Data:  ed,
+	}
+}
@@ -0,0 +1,69 @@
+package handle
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/usage"
+	modelmgrapp "github.com/kiosk404/airi-go/backend/modules/llm/application"
+)
+
+// GetDailyUsage .
+// @router /api/admin/usage/daily [POST]
+func GetDailyUsage(c *gin.Context) {
+	var err error
+	var req usage.GetDailyUsageRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+
+	resp, err := modelmgrapp.ModelMgrSVC.GetDailyUsage(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTopAgents .
+// @router /api/admin/usage/top_agents [POST]
+func GetTopAgents(c *gin.Context) {
+	var err error
+	var req usage.GetTopAgentsRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+
+	resp, err := modelmgrapp.ModelMgrSVC.GetTopAgents(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetUserSpend .
+// @router /api/admin/usage/user_spend [POST]
+func GetUserSpend(c *gin.Context) {
+	var err error
+	var req usage.GetUserSpendRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+
+	resp, err := modelmgrapp.ModelMgrSVC.GetUserSpend(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,129 @@
+package handle
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/api/model/openai"
+	agenterrno "github.com/kiosk404/airi-go/backend/modules/component/agent/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/application"
+	conversationerrno "github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	llmerrno "github.com/kiosk404/airi-go/backend/modules/llm/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	sseimpl "github.com/kiosk404/airi-go/backend/pkg/http/sse"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+// ChatCompletions OpenAI 兼容的对话接口，model 为 agent:<agent_id>
+// @router /v1/chat/completions [POST]
+func ChatCompletions(c *gin.Context) {
+	var req openai.ChatCompletionRequest
+	ctx := c.Request.Context()
+	if err := c.ShouldBindJSON(&req); err != nil {
+		openaiErrorResponse(c, http.StatusBadRequest, openai.ErrorTypeInvalidRequest, err.Error())
+		return
+	}
+
+	if !req.Stream {
+		resp, err := application.ConversationSVC.ChatCompletion(ctx, &req)
+		if err != nil {
+			openaiInternalErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	sr, err := application.ConversationSVC.ChatCompletionStream(ctx, &req)
+	if err != nil {
+		openaiInternalErrorResponse(c, err)
+		return
+	}
+	defer sr.Close()
+
+	sseSender := sseimpl.NewSSESender(c)
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			_ = sseSender.Send(ctx, &sse.Event{Data: "[DONE]"})
+			return
+		}
+		if err != nil {
+			// 已经开始输出时无法再修改状态码，与 OpenAI 一致以 error 对象作为最后一个分片
+			_, errResp := toOpenAIError(err)
+			data, _ := json.Marshal(errResp)
+			_ = sseSender.Send(ctx, &sse.Event{Data: data})
+			return
+		}
+
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			logs.Error("[ChatCompletions] marshal chunk failed, err: %v", err)
+			continue
+		}
+		if err = sseSender.Send(ctx, &sse.Event{Data: data}); err != nil {
+			return
+		}
+	}
+}
+
+// ListModels 列出可以通过 OpenAI 兼容接口调用的智能体
+// @router /v1/models [GET]
+func ListModels(c *gin.Context) {
+	resp, err := application.ConversationSVC.ListOpenAIModels(c.Request.Context())
+	if err != nil {
+		openaiInternalErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func openaiErrorResponse(c *gin.Context, status int, typ, msg string) {
+	c.AbortWithStatusJSON(status, &openai.ErrorResponse{Error: &openai.Error{Message: msg, Type: typ}})
+}
+
+func openaiInternalErrorResponse(c *gin.Context, err error) {
+	status, resp := toOpenAIError(err)
+	if retryAfter, ok := modelmgr.QuotaExceeded(err); ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	c.AbortWithStatusJSON(status, resp)
+}
+
+// toOpenAIError 按错误码映射为 OpenAI 的错误类型与 HTTP 状态码，客户端 SDK 依赖状态码决定是否重试
+func toOpenAIError(err error) (int, *openai.ErrorResponse) {
+	var statusErr errorx.StatusError
+	if !errors.As(err, &statusErr) {
+		logs.Error("[OpenAI] internal error: %v", err)
+		return http.StatusInternalServerError, &openai.ErrorResponse{Error: &openai.Error{
+			Message: "internal server error",
+			Type:    openai.ErrorTypeServer,
+		}}
+	}
+
+	status, typ := http.StatusInternalServerError, openai.ErrorTypeServer
+	switch statusErr.Code() {
+	case conversationerrno.ErrConversationInvalidParamCode, llmerrno.ErrModelInvalidParamCode:
+		status, typ = http.StatusBadRequest, openai.ErrorTypeInvalidRequest
+	case conversationerrno.ErrConversationPermissionCode:
+		status, typ = http.StatusUnauthorized, openai.ErrorTypeAuthentication
+	case conversationerrno.ErrAgentNotExists, agenterrno.ErrAgentInvalidParamCode:
+		status, typ = http.StatusNotFound, openai.ErrorTypeInvalidRequest
+	case llmerrno.ErrModelQuotaExceededCode, llmerrno.ErrModelBudgetExceededCode:
+		status, typ = http.StatusTooManyRequests, openai.ErrorTypeRateLimit
+	}
+
+	return status, &openai.ErrorResponse{Error: &openai.Error{
+		Message: statusErr.Msg(),
+		Type:    typ,
+		Code:    strconv.Itoa(int(statusErr.Code())),
+	}}
+}
@@ -0,0 +1,85 @@
+package handle
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/api/model/data/variables"
+	variablesapp "github.com/kiosk404/airi-go/backend/modules/data/variables/application"
+)
+
+// ListVariables .
+// @router /api/variables/list [POST]
+func ListVariables(c *gin.Context) {
+	var err error
+	var req variables.ListVariablesRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetAgentID() <= 0 {
+		invalidParamRequestResponse(c, "agent_id is required")
+		return
+	}
+
+	resp, err := variablesapp.VariablesSVC.ListVariables(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateVariables .
+// @router /api/variables/update [POST]
+func UpdateVariables(c *gin.Context) {
+	var err error
+	var req variables.UpdateVariablesRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetAgentID() <= 0 {
+		invalidParamRequestResponse(c, "agent_id is required")
+		return
+	}
+	if len(req.GetValues()) == 0 {
+		invalidParamRequestResponse(c, "values is required")
+		return
+	}
+
+	resp, err := variablesapp.VariablesSVC.UpdateVariables(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ResetVariables .
+// @router /api/variables/reset [POST]
+func ResetVariables(c *gin.Context) {
+	var err error
+	var req variables.ResetVariablesRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetAgentID() <= 0 {
+		invalidParamRequestResponse(c, "agent_id is required")
+		return
+	}
+
+	resp, err := variablesapp.VariablesSVC.ResetVariables(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,225 @@
+package handle
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/api/model/component/workflow"
+	workflowapp "github.com/kiosk404/airi-go/backend/modules/component/workflow/application"
+)
+
+// CreateWorkflow .
+// @router /api/workflow/create [POST]
+func CreateWorkflow(c *gin.Context) {
+	var err error
+	var req workflow.CreateWorkflowRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetName() == "" {
+		invalidParamRequestResponse(c, "name is required")
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.CreateWorkflow(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateWorkflow .
+// @router /api/workflow/update [POST]
+func UpdateWorkflow(c *gin.Context) {
+	var err error
+	var req workflow.UpdateWorkflowRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetWorkflowID() <= 0 {
+		invalidParamRequestResponse(c, "workflow_id is required")
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.UpdateWorkflow(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SaveCanvas .
+// @router /api/workflow/save_canvas [POST]
+func SaveCanvas(c *gin.Context) {
+	var err error
+	var req workflow.SaveCanvasRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetWorkflowID() <= 0 {
+		invalidParamRequestResponse(c, "workflow_id is required")
+		return
+	}
+	if req.GetCanvas() == "" {
+		invalidParamRequestResponse(c, "canvas is required")
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.SaveCanvas(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteWorkflow .
+// @router /api/workflow/delete [POST]
+func DeleteWorkflow(c *gin.Context) {
+	var err error
+	var req workflow.DeleteWorkflowRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetWorkflowID() <= 0 {
+		invalidParamRequestResponse(c, "workflow_id is required")
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.DeleteWorkflow(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetWorkflow .
+// @router /api/workflow/get [POST]
+func GetWorkflow(c *gin.Context) {
+	var err error
+	var req workflow.GetWorkflowRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetWorkflowID() <= 0 {
+		invalidParamRequestResponse(c, "workflow_id is required")
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.GetWorkflow(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListWorkflow .
+// @router /api/workflow/list [POST]
+func ListWorkflow(c *gin.Context) {
+	var err error
+	var req workflow.ListWorkflowRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.ListWorkflow(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// PublishWorkflow .
+// @router /api/workflow/publish [POST]
+func PublishWorkflow(c *gin.Context) {
+	var err error
+	var req workflow.PublishWorkflowRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetWorkflowID() <= 0 {
+		invalidParamRequestResponse(c, "workflow_id is required")
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.PublishWorkflow(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListVersion .
+// @router /api/workflow/version/list [POST]
+func ListVersion(c *gin.Context) {
+	var err error
+	var req workflow.ListVersionRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetWorkflowID() <= 0 {
+		invalidParamRequestResponse(c, "workflow_id is required")
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.ListVersion(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RunWorkflow .
+// @router /api/workflow/run [POST]
+func RunWorkflow(c *gin.Context) {
+	var err error
+	var req workflow.RunWorkflowRequest
+	ctx := c.Request.Context()
+	if err = c.ShouldBindJSON(&req); err != nil {
+		invalidParamRequestResponse(c, err.Error())
+		return
+	}
+	if req.GetWorkflowID() <= 0 {
+		invalidParamRequestResponse(c, "workflow_id is required")
+		return
+	}
+
+	resp, err := workflowapp.WorkflowSVC.RunWorkflow(ctx, &req)
+	if err != nil {
+		internalServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	httpwarp "github.com/kiosk404/airi-go/backend/api/http"
+	user "github.com/kiosk404/airi-go/backend/modules/foundation/user/application"
+)
+
+// AdminAuthMW 用量统计接口只允许 ADMIN_ACCOUNTS 中的账号访问，需在 SessionAuthMW 之后执行
+func AdminAuthMW() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := user.UserApplicationSVC.CheckAdmin(c.Request.Context()); err != nil {
+			httpwarp.InternalError(c, err)
+			return
+		}
+
+		c.Next()
+	}
+}
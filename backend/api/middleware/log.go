@@ -10,7 +10,6 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/kiosk404/airi-go/backend/pkg/http/ginutil"
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
 )
 
@@ -31,7 +30,7 @@ func AccessLogMW() gin.HandlerFunc {
 			handleName = handlerPkgPath[len(handlerPkgPath)-1]
 		}
 
-		requestType := ginutil.GetInt32(c, RequestAuthTypeStr)
+		requestType := c.GetInt32(RequestAuthTypeStr)
 		baseLog := fmt.Sprintf("| %s | %s | %s | %d | %v | %s | %v | %s | %d ",
 			c.Request.Proto, c.Request.Host, method, status,
 			latency, clientIP, path, handleName, requestType)
@@ -3,6 +3,7 @@ package middleware
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"net/http"
 	"regexp"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	httpwarp "github.com/kiosk404/airi-go/backend/api/http"
+	"github.com/kiosk404/airi-go/backend/api/model/openai"
 	"github.com/kiosk404/airi-go/backend/modules/foundation/user/pkg/errno"
 	"github.com/kiosk404/airi-go/backend/pkg/ctxcache"
 	"github.com/kiosk404/airi-go/backend/pkg/errorx"
@@ -34,6 +36,15 @@ var needAuthPath = map[string]bool{
 	"/v1/workflows/chat":               true,
 	"/v1/workflow/conversation/create": true,
 	"/v3/chat/cancel":                  true,
+	"/v3/chat/ws":                      true,
+	"/v1/chat/completions":             true,
+	"/v1/models":                       true,
+}
+
+// openaiCompatiblePath OpenAI 兼容接口鉴权失败时按 OpenAI 的错误格式返回 401，客户端 SDK 才能识别
+var openaiCompatiblePath = map[string]bool{
+	"/v1/chat/completions": true,
+	"/v1/models":           true,
 }
 
 var needAuthFunc = map[string]bool{
@@ -83,8 +94,8 @@ func isNeedOpenapiAuth(c *gin.Context) bool {
 
 func OpenapiAuthMW() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestAuthType := c.GetInt64(RequestAuthTypeStr)
-		if requestAuthType != int64(RequestAuthTypeOpenAPI) {
+		requestAuthType := c.GetInt32(RequestAuthTypeStr)
+		if requestAuthType != RequestAuthTypeOpenAPI {
 			c.Next()
 			return
 		}
@@ -93,15 +104,13 @@ func OpenapiAuthMW() gin.HandlerFunc {
 
 		// open api auth
 		if len(c.Request.Header.Get(HeaderAuthorizationKey)) == 0 {
-			httpwarp.InternalError(c,
-				errorx.New(errno.ErrUserAuthenticationFailed, errorx.KV("reason", "missing authorization in header")))
+			authFailed(c, "missing authorization in header")
 			return
 		}
 
 		apiKey := parseBearerAuthToken(c.Request.Header.Get(HeaderAuthorizationKey))
 		if len(apiKey) == 0 {
-			httpwarp.InternalError(c,
-				errorx.New(errno.ErrUserAuthenticationFailed, errorx.KV("reason", "missing api_key in request")))
+			authFailed(c, "missing api_key in request")
 			return
 		}
 
@@ -111,14 +120,12 @@ func OpenapiAuthMW() gin.HandlerFunc {
 
 		if err != nil {
 			logs.Error("OpenAuthApplication.CheckPermission failed, err=%v", err)
-			httpwarp.InternalError(c,
-				errorx.New(errno.ErrUserAuthenticationFailed, errorx.KV("reason", err.Error())))
+			authFailed(c, err.Error())
 			return
 		}
 
 		if apiKeyInfo == nil {
-			httpwarp.InternalError(c,
-				errorx.New(errno.ErrUserAuthenticationFailed, errorx.KV("reason", "api key invalid")))
+			authFailed(c, "api key invalid")
 			return
 		}
 
@@ -131,3 +138,16 @@ func OpenapiAuthMW() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func authFailed(c *gin.Context, reason string) {
+	if openaiCompatiblePath[c.Request.URL.Path] {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, &openai.ErrorResponse{Error: &openai.Error{
+			Message: reason,
+			Type:    openai.ErrorTypeAuthentication,
+		}})
+		return
+	}
+
+	httpwarp.InternalError(c,
+		errorx.New(errno.ErrUserAuthenticationFailed, errorx.KV("reason", reason)))
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/kiosk404/airi-go/backend/modules/foundation/user/pkg/errno"
 	"github.com/kiosk404/airi-go/backend/pkg/ctxcache"
 	"github.com/kiosk404/airi-go/backend/pkg/errorx"
-	"github.com/kiosk404/airi-go/backend/pkg/http/ginutil"
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
 	"github.com/kiosk404/airi-go/backend/types/consts"
 )
@@ -28,8 +27,8 @@ var noNeedSessionCheckPatterns = []*regexp.Regexp{
 
 func SessionAuthMW() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestAuthType := ginutil.GetInt32(c, RequestAuthTypeStr)
-		if requestAuthType != int32(RequestAuthTypeWebAPI) {
+		requestAuthType := c.GetInt32(RequestAuthTypeStr)
+		if requestAuthType != RequestAuthTypeWebAPI {
 			c.Next()
 			return
 		}
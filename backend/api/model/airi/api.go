@@ -7,14 +7,19 @@ import (
 	"github.com/kiosk404/airi-go/backend/api/model/app/developer_api"
 	"github.com/kiosk404/airi-go/backend/api/model/app/intelligence"
 	"github.com/kiosk404/airi-go/backend/api/model/component/plugin_develop"
+	"github.com/kiosk404/airi-go/backend/api/model/component/workflow"
 	"github.com/kiosk404/airi-go/backend/api/model/conversation/agentrun"
 	"github.com/kiosk404/airi-go/backend/api/model/conversation/conversation"
 	"github.com/kiosk404/airi-go/backend/api/model/conversation/message"
+	"github.com/kiosk404/airi-go/backend/api/model/data/knowledge"
+	"github.com/kiosk404/airi-go/backend/api/model/data/memory"
+	"github.com/kiosk404/airi-go/backend/api/model/data/variables"
 	"github.com/kiosk404/airi-go/backend/api/model/file/upload"
 	"github.com/kiosk404/airi-go/backend/api/model/foundation/openapiauth"
 	"github.com/kiosk404/airi-go/backend/api/model/foundation/user"
 	"github.com/kiosk404/airi-go/backend/api/model/llm/manage"
 	"github.com/kiosk404/airi-go/backend/api/model/llm/runtime"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/usage"
 	"github.com/kiosk404/airi-go/backend/api/model/modelapi"
 	"github.com/kiosk404/airi-go/backend/api/model/playground"
 	"github.com/kiosk404/airi-go/backend/api/model/resource"
@@ -48,6 +53,10 @@ type LLMRuntimeService interface {
 	runtime.LLMRuntimeService
 }
 
+type LLMUsageService interface {
+	usage.LLMUsageService
+}
+
 type PluginDevelopService interface {
 	plugin_develop.PluginDevelopService
 }
@@ -79,3 +88,19 @@ type ResourceService interface {
 type UploadService interface {
 	upload.UploadService
 }
+
+type KnowledgeService interface {
+	knowledge.KnowledgeService
+}
+
+type VariablesService interface {
+	variables.VariablesService
+}
+
+type MemoryService interface {
+	memory.MemoryService
+}
+
+type WorkflowService interface {
+	workflow.WorkflowService
+}
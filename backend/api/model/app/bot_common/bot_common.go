@@ -114,6 +114,8 @@ const (
 	ContextMode_FunctionCall_1 ContextMode = 1
 	ContextMode_FunctionCall_2 ContextMode = 2
 	ContextMode_FunctionCall_3 ContextMode = 3
+	ContextMode_TruncateOldest ContextMode = 4
+	ContextMode_RollingSummary ContextMode = 5
 )
 
 func (p ContextMode) String() string {
@@ -126,6 +128,10 @@ func (p ContextMode) String() string {
 		return "FunctionCall_2"
 	case ContextMode_FunctionCall_3:
 		return "FunctionCall_3"
+	case ContextMode_TruncateOldest:
+		return "TruncateOldest"
+	case ContextMode_RollingSummary:
+		return "RollingSummary"
 	}
 	return "<UNSET>"
 }
@@ -140,6 +146,10 @@ func ContextModeFromString(s string) (ContextMode, error) {
 		return ContextMode_FunctionCall_2, nil
 	case "FunctionCall_3":
 		return ContextMode_FunctionCall_3, nil
+	case "TruncateOldest":
+		return ContextMode_TruncateOldest, nil
+	case "RollingSummary":
+		return ContextMode_RollingSummary, nil
 	}
 	return ContextMode(0), fmt.Errorf("not a valid ContextMode string")
 }
@@ -1964,6 +1974,7 @@ type ModelInfo struct {
 	TopK              *int32               `thrift:"TopK,8,optional" json:"top_k"`
 	ResponseFormat    *ModelResponseFormat `thrift:"ResponseFormat,9,optional,ModelResponseFormat" json:"response_format"`
 	ModelStyle        *ModelStyle          `thrift:"ModelStyle,10,optional,ModelStyle" json:"model_style"`
+	FallbackModelIds  []int64              `thrift:"FallbackModelIds,11,optional,list<i64>" json:"fallback_model_ids"`
 }
 
 func NewModelInfo() *ModelInfo {
@@ -2062,6 +2073,15 @@ func (p *ModelInfo) GetModelStyle() (v ModelStyle) {
 	}
 	return *p.ModelStyle
 }
+
+var ModelInfo_FallbackModelIds_DEFAULT []int64
+
+func (p *ModelInfo) GetFallbackModelIds() (v []int64) {
+	if !p.IsSetFallbackModelIds() {
+		return ModelInfo_FallbackModelIds_DEFAULT
+	}
+	return p.FallbackModelIds
+}
 func (p *ModelInfo) SetModelId(val *int64) {
 	p.ModelId = val
 }
@@ -2092,6 +2112,9 @@ func (p *ModelInfo) SetResponseFormat(val *ModelResponseFormat) {
 func (p *ModelInfo) SetModelStyle(val *ModelStyle) {
 	p.ModelStyle = val
 }
+func (p *ModelInfo) SetFallbackModelIds(val []int64) {
+	p.FallbackModelIds = val
+}
 
 func (p *ModelInfo) IsSetModelId() bool {
 	return p.ModelId != nil
@@ -2133,6 +2156,10 @@ func (p *ModelInfo) IsSetModelStyle() bool {
 	return p.ModelStyle != nil
 }
 
+func (p *ModelInfo) IsSetFallbackModelIds() bool {
+	return p.FallbackModelIds != nil
+}
+
 func (p *ModelInfo) String() string {
 	if p == nil {
 		return "<nil>"
@@ -2871,6 +2898,91 @@ func (p *VoicesInfo) String() string {
 	return fmt.Sprintf("VoicesInfo(%+v)", *p)
 }
 
+type AvatarInfo struct {
+	Enabled     *bool    `thrift:"Enabled,1,optional" json:"Enabled,omitempty"`
+	Emotions    []string `thrift:"Emotions,2,optional,list<string>" json:"Emotions,omitempty"`
+	Motions     []string `thrift:"Motions,3,optional,list<string>" json:"Motions,omitempty"`
+	Expressions []string `thrift:"Expressions,4,optional,list<string>" json:"Expressions,omitempty"`
+}
+
+func NewAvatarInfo() *AvatarInfo {
+	return &AvatarInfo{}
+}
+
+func (p *AvatarInfo) InitDefault() {
+}
+
+var AvatarInfo_Enabled_DEFAULT bool
+
+func (p *AvatarInfo) GetEnabled() (v bool) {
+	if !p.IsSetEnabled() {
+		return AvatarInfo_Enabled_DEFAULT
+	}
+	return *p.Enabled
+}
+
+var AvatarInfo_Emotions_DEFAULT []string
+
+func (p *AvatarInfo) GetEmotions() (v []string) {
+	if !p.IsSetEmotions() {
+		return AvatarInfo_Emotions_DEFAULT
+	}
+	return p.Emotions
+}
+
+var AvatarInfo_Motions_DEFAULT []string
+
+func (p *AvatarInfo) GetMotions() (v []string) {
+	if !p.IsSetMotions() {
+		return AvatarInfo_Motions_DEFAULT
+	}
+	return p.Motions
+}
+
+var AvatarInfo_Expressions_DEFAULT []string
+
+func (p *AvatarInfo) GetExpressions() (v []string) {
+	if !p.IsSetExpressions() {
+		return AvatarInfo_Expressions_DEFAULT
+	}
+	return p.Expressions
+}
+func (p *AvatarInfo) SetEnabled(val *bool) {
+	p.Enabled = val
+}
+func (p *AvatarInfo) SetEmotions(val []string) {
+	p.Emotions = val
+}
+func (p *AvatarInfo) SetMotions(val []string) {
+	p.Motions = val
+}
+func (p *AvatarInfo) SetExpressions(val []string) {
+	p.Expressions = val
+}
+
+func (p *AvatarInfo) IsSetEnabled() bool {
+	return p.Enabled != nil
+}
+
+func (p *AvatarInfo) IsSetEmotions() bool {
+	return p.Emotions != nil
+}
+
+func (p *AvatarInfo) IsSetMotions() bool {
+	return p.Motions != nil
+}
+
+func (p *AvatarInfo) IsSetExpressions() bool {
+	return p.Expressions != nil
+}
+
+func (p *AvatarInfo) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AvatarInfo(%+v)", *p)
+}
+
 type AnswerActionTriggerRule struct {
 	Type           AnswerActionTriggerType `thrift:"Type,1,default,AnswerActionTriggerType" json:"Type"`
 	NeedPreloading bool                    `thrift:"NeedPreloading,2" json:"NeedPreloading"`
@@ -4530,6 +4642,7 @@ type BotInfo struct {
 	UserQueryCollectConf    *UserQueryCollectConf  `thrift:"UserQueryCollectConf,33" json:"user_query_collect_conf"`
 	LayoutInfo              *LayoutInfo            `thrift:"LayoutInfo,34" json:"layout_info"`
 	BusinessType            BusinessType           `thrift:"BusinessType,35,default,BusinessType" json:"business_type"`
+	AvatarInfo              *AvatarInfo            `thrift:"AvatarInfo,36" json:"avatar_info"`
 }
 
 func NewBotInfo() *BotInfo {
@@ -4748,6 +4861,15 @@ func (p *BotInfo) GetLayoutInfo() (v *LayoutInfo) {
 func (p *BotInfo) GetBusinessType() (v BusinessType) {
 	return p.BusinessType
 }
+
+var BotInfo_AvatarInfo_DEFAULT *AvatarInfo
+
+func (p *BotInfo) GetAvatarInfo() (v *AvatarInfo) {
+	if !p.IsSetAvatarInfo() {
+		return BotInfo_AvatarInfo_DEFAULT
+	}
+	return p.AvatarInfo
+}
 func (p *BotInfo) SetBotId(val int64) {
 	p.BotId = val
 }
@@ -4853,6 +4975,9 @@ func (p *BotInfo) SetLayoutInfo(val *LayoutInfo) {
 func (p *BotInfo) SetBusinessType(val BusinessType) {
 	p.BusinessType = val
 }
+func (p *BotInfo) SetAvatarInfo(val *AvatarInfo) {
+	p.AvatarInfo = val
+}
 
 func (p *BotInfo) IsSetModelInfo() bool {
 	return p.ModelInfo != nil
@@ -4910,6 +5035,10 @@ func (p *BotInfo) IsSetLayoutInfo() bool {
 	return p.LayoutInfo != nil
 }
 
+func (p *BotInfo) IsSetAvatarInfo() bool {
+	return p.AvatarInfo != nil
+}
+
 func (p *BotInfo) String() string {
 	if p == nil {
 		return "<nil>"
@@ -5912,6 +6041,7 @@ type BotInfoForUpdate struct {
 	HookInfo                *HookInfo              `thrift:"HookInfo,31,optional" json:"hook_info,omitempty"`
 	UserQueryCollectConf    *UserQueryCollectConf  `thrift:"UserQueryCollectConf,32,optional" json:"user_query_collect_conf,omitempty"`
 	LayoutInfo              *LayoutInfo            `thrift:"LayoutInfo,33,optional" json:"layout_info,omitempty"`
+	AvatarInfo              *AvatarInfo            `thrift:"AvatarInfo,34,optional" json:"avatar_info,omitempty"`
 }
 
 func NewBotInfoForUpdate() *BotInfoForUpdate {
@@ -6212,6 +6342,15 @@ func (p *BotInfoForUpdate) GetLayoutInfo() (v *LayoutInfo) {
 	}
 	return p.LayoutInfo
 }
+
+var BotInfoForUpdate_AvatarInfo_DEFAULT *AvatarInfo
+
+func (p *BotInfoForUpdate) GetAvatarInfo() (v *AvatarInfo) {
+	if !p.IsSetAvatarInfo() {
+		return BotInfoForUpdate_AvatarInfo_DEFAULT
+	}
+	return p.AvatarInfo
+}
 func (p *BotInfoForUpdate) SetBotId(val *int64) {
 	p.BotId = val
 }
@@ -6311,6 +6450,9 @@ func (p *BotInfoForUpdate) SetUserQueryCollectConf(val *UserQueryCollectConf) {
 func (p *BotInfoForUpdate) SetLayoutInfo(val *LayoutInfo) {
 	p.LayoutInfo = val
 }
+func (p *BotInfoForUpdate) SetAvatarInfo(val *AvatarInfo) {
+	p.AvatarInfo = val
+}
 
 func (p *BotInfoForUpdate) IsSetBotId() bool {
 	return p.BotId != nil
@@ -6440,6 +6582,10 @@ func (p *BotInfoForUpdate) IsSetLayoutInfo() bool {
 	return p.LayoutInfo != nil
 }
 
+func (p *BotInfoForUpdate) IsSetAvatarInfo() bool {
+	return p.AvatarInfo != nil
+}
+
 func (p *BotInfoForUpdate) String() string {
 	if p == nil {
 		return "<nil>"
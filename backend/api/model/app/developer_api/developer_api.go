@@ -864,13 +864,14 @@ func (p *ModelScene) Value() (driver.Value, error) {
 type ModelClass int64
 
 const (
-	ModelClass_GPT      ModelClass = 1
-	ModelClass_QWen     ModelClass = 2
-	ModelClass_Gemini   ModelClass = 3
-	ModelClass_DeepSeek ModelClass = 4
-	ModelClass_Ollama   ModelClass = 5
-	ModelClass_Claude   ModelClass = 6
-	ModelClass_Other    ModelClass = 999
+	ModelClass_GPT              ModelClass = 1
+	ModelClass_QWen             ModelClass = 2
+	ModelClass_Gemini           ModelClass = 3
+	ModelClass_DeepSeek         ModelClass = 4
+	ModelClass_Ollama           ModelClass = 5
+	ModelClass_Claude           ModelClass = 6
+	ModelClass_OpenAICompatible ModelClass = 7
+	ModelClass_Other            ModelClass = 999
 )
 
 func (p ModelClass) String() string {
@@ -887,6 +888,8 @@ func (p ModelClass) String() string {
 		return "Ollama"
 	case ModelClass_Claude:
 		return "Claude"
+	case ModelClass_OpenAICompatible:
+		return "OpenAICompatible"
 	case ModelClass_Other:
 		return "Other"
 	}
@@ -907,6 +910,8 @@ func ModelClassFromString(s string) (ModelClass, error) {
 		return ModelClass_Ollama, nil
 	case "Claude":
 		return ModelClass_Claude, nil
+	case "OpenAICompatible":
+		return ModelClass_OpenAICompatible, nil
 	case "Other":
 		return ModelClass_Other, nil
 	}
@@ -13,4 +13,10 @@ type AgentRunService interface {
 	ChatV3(ctx context.Context, request *run.ChatV3Request) (r *run.ChatV3Response, err error)
 
 	CancelChatApi(ctx context.Context, request *run.CancelChatApiRequest) (r *run.CancelChatApiResponse, err error)
+
+	RetrieveChatApi(ctx context.Context, request *run.RetrieveChatApiRequest) (r *run.RetrieveChatApiResponse, err error)
+
+	ReattachChatApi(ctx context.Context, request *run.ReattachChatApiRequest) (r *run.AgentRunResponse, err error)
+
+	ListChatMessageApi(ctx context.Context, request *run.ListChatMessageApiRequest) (r *run.ListChatMessageApiResponse, err error)
 }
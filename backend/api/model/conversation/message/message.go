@@ -619,6 +619,7 @@ type ChatMessage struct {
 	MetaInfos        []*ChatMessageMetaInfo `thrift:"meta_infos,18,optional,list<ChatMessageMetaInfo>" json:"meta_infos,omitempty"`
 	CardStatus       map[string]string      `thrift:"card_status,19,optional" json:"card_status,omitempty"`
 	ReasoningContent *string                `thrift:"reasoning_content,20,optional" json:"reasoning_content,omitempty"`
+	BranchInfo       *BranchInfo            `thrift:"branch_info,21,optional" json:"branch_info,omitempty"`
 }
 
 func NewChatMessage() *ChatMessage {
@@ -752,6 +753,15 @@ func (p *ChatMessage) GetReasoningContent() (v string) {
 	}
 	return *p.ReasoningContent
 }
+
+var ChatMessage_BranchInfo_DEFAULT *BranchInfo
+
+func (p *ChatMessage) GetBranchInfo() (v *BranchInfo) {
+	if !p.IsSetBranchInfo() {
+		return ChatMessage_BranchInfo_DEFAULT
+	}
+	return p.BranchInfo
+}
 func (p *ChatMessage) SetRole(val string) {
 	p.Role = val
 }
@@ -812,6 +822,9 @@ func (p *ChatMessage) SetCardStatus(val map[string]string) {
 func (p *ChatMessage) SetReasoningContent(val *string) {
 	p.ReasoningContent = val
 }
+func (p *ChatMessage) SetBranchInfo(val *BranchInfo) {
+	p.BranchInfo = val
+}
 
 func (p *ChatMessage) IsSetExtraInfo() bool {
 	return p.ExtraInfo != nil
@@ -849,6 +862,10 @@ func (p *ChatMessage) IsSetReasoningContent() bool {
 	return p.ReasoningContent != nil
 }
 
+func (p *ChatMessage) IsSetBranchInfo() bool {
+	return p.BranchInfo != nil
+}
+
 func (p *ChatMessage) String() string {
 	if p == nil {
 		return "<nil>"
@@ -856,6 +873,47 @@ func (p *ChatMessage) String() string {
 	return fmt.Sprintf("ChatMessage(%+v)", *p)
 }
 
+type BranchInfo struct {
+	Index   int32    `thrift:"index,1" json:"index"`
+	Total   int32    `thrift:"total,2" json:"total"`
+	ChatIds []string `thrift:"chat_ids,3,default,list<string>" json:"chat_ids"`
+}
+
+func NewBranchInfo() *BranchInfo {
+	return &BranchInfo{}
+}
+
+func (p *BranchInfo) InitDefault() {
+}
+
+func (p *BranchInfo) GetIndex() (v int32) {
+	return p.Index
+}
+
+func (p *BranchInfo) GetTotal() (v int32) {
+	return p.Total
+}
+
+func (p *BranchInfo) GetChatIds() (v []string) {
+	return p.ChatIds
+}
+func (p *BranchInfo) SetIndex(val int32) {
+	p.Index = val
+}
+func (p *BranchInfo) SetTotal(val int32) {
+	p.Total = val
+}
+func (p *BranchInfo) SetChatIds(val []string) {
+	p.ChatIds = val
+}
+
+func (p *BranchInfo) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("BranchInfo(%+v)", *p)
+}
+
 type GetMessageListRequest struct {
 	ConversationID           string         `thrift:"conversation_id,1" json:"conversation_id"`
 	Cursor                   string         `thrift:"cursor,2,required" json:"cursor"`
@@ -1389,6 +1447,72 @@ func (p *BreakMessageResponse) String() string {
 	return fmt.Sprintf("BreakMessageResponse(%+v)", *p)
 }
 
+type SwitchBranchRequest struct {
+	ConversationID int64 `thrift:"conversation_id,1,required" json:"conversation_id,string"`
+	ChatID         int64 `thrift:"chat_id,2,required" json:"chat_id,string"`
+}
+
+func NewSwitchBranchRequest() *SwitchBranchRequest {
+	return &SwitchBranchRequest{}
+}
+
+func (p *SwitchBranchRequest) InitDefault() {
+}
+
+func (p *SwitchBranchRequest) GetConversationID() (v int64) {
+	return p.ConversationID
+}
+
+func (p *SwitchBranchRequest) GetChatID() (v int64) {
+	return p.ChatID
+}
+func (p *SwitchBranchRequest) SetConversationID(val int64) {
+	p.ConversationID = val
+}
+func (p *SwitchBranchRequest) SetChatID(val int64) {
+	p.ChatID = val
+}
+
+func (p *SwitchBranchRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SwitchBranchRequest(%+v)", *p)
+}
+
+type SwitchBranchResponse struct {
+	Code int64  `thrift:"code,1" json:"code"`
+	Msg  string `thrift:"msg,2" json:"msg"`
+}
+
+func NewSwitchBranchResponse() *SwitchBranchResponse {
+	return &SwitchBranchResponse{}
+}
+
+func (p *SwitchBranchResponse) InitDefault() {
+}
+
+func (p *SwitchBranchResponse) GetCode() (v int64) {
+	return p.Code
+}
+
+func (p *SwitchBranchResponse) GetMsg() (v string) {
+	return p.Msg
+}
+func (p *SwitchBranchResponse) SetCode(val int64) {
+	p.Code = val
+}
+func (p *SwitchBranchResponse) SetMsg(val string) {
+	p.Msg = val
+}
+
+func (p *SwitchBranchResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SwitchBranchResponse(%+v)", *p)
+}
+
 type ListMessageApiRequest struct {
 	ConversationID int64      `thrift:"conversation_id,1,required" json:"conversation_id,string"`
 	Limit          *int64     `thrift:"limit,2,optional" json:"limit,omitempty"`
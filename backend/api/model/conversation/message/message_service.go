@@ -13,5 +13,7 @@ type MessageService interface {
 
 	BreakMessage(ctx context.Context, request *BreakMessageRequest) (r *BreakMessageResponse, err error)
 
+	SwitchBranch(ctx context.Context, request *SwitchBranchRequest) (r *SwitchBranchResponse, err error)
+
 	GetApiMessageList(ctx context.Context, request *ListMessageApiRequest) (r *ListMessageApiResponse, err error)
 }
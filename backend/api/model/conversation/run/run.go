@@ -39,6 +39,10 @@ const (
 	RunEventDone = "done"
 
 	RunEventError = "error"
+
+	RunEventDirective = "directive"
+
+	RunEventAudio = "audio"
 )
 
 type DiffModeIdentifier int64
@@ -518,6 +522,8 @@ type AgentRunRequest struct {
 	SubScene                 *string                       `thrift:"sub_scene,21,optional" json:"sub_scene,omitempty"`
 	DiffModeIdentifier       *DiffModeIdentifier           `thrift:"diff_mode_identifier,22,optional,DiffModeIdentifier" json:"diff_mode_identifier,omitempty"`
 	ShortcutCmdID            *int64                        `thrift:"shortcut_cmd_id,23,optional" json:"shortcut_cmd_id,omitempty"`
+	Stream                   *bool                         `thrift:"stream,24,optional" json:"stream,omitempty"`
+	EditMessageID            *int64                        `thrift:"edit_message_id,25,optional" json:"edit_message_id,omitempty"`
 }
 
 func NewAgentRunRequest() *AgentRunRequest {
@@ -672,6 +678,24 @@ func (p *AgentRunRequest) GetShortcutCmdID() (v int64) {
 	}
 	return *p.ShortcutCmdID
 }
+
+var AgentRunRequest_Stream_DEFAULT bool
+
+func (p *AgentRunRequest) GetStream() (v bool) {
+	if !p.IsSetStream() {
+		return AgentRunRequest_Stream_DEFAULT
+	}
+	return *p.Stream
+}
+
+var AgentRunRequest_EditMessageID_DEFAULT int64
+
+func (p *AgentRunRequest) GetEditMessageID() (v int64) {
+	if !p.IsSetEditMessageID() {
+		return AgentRunRequest_EditMessageID_DEFAULT
+	}
+	return *p.EditMessageID
+}
 func (p *AgentRunRequest) SetBotID(val int64) {
 	p.BotID = val
 }
@@ -729,6 +753,12 @@ func (p *AgentRunRequest) SetDiffModeIdentifier(val *DiffModeIdentifier) {
 func (p *AgentRunRequest) SetShortcutCmdID(val *int64) {
 	p.ShortcutCmdID = val
 }
+func (p *AgentRunRequest) SetStream(val *bool) {
+	p.Stream = val
+}
+func (p *AgentRunRequest) SetEditMessageID(val *int64) {
+	p.EditMessageID = val
+}
 
 func (p *AgentRunRequest) IsSetDraftMode() bool {
 	return p.DraftMode != nil
@@ -786,6 +816,14 @@ func (p *AgentRunRequest) IsSetShortcutCmdID() bool {
 	return p.ShortcutCmdID != nil
 }
 
+func (p *AgentRunRequest) IsSetStream() bool {
+	return p.Stream != nil
+}
+
+func (p *AgentRunRequest) IsSetEditMessageID() bool {
+	return p.EditMessageID != nil
+}
+
 func (p *AgentRunRequest) String() string {
 	if p == nil {
 		return "<nil>"
@@ -869,8 +907,9 @@ func (p *RunStreamResponse) String() string {
 }
 
 type AgentRunResponse struct {
-	Code int64  `thrift:"code,1" json:"code"`
-	Msg  string `thrift:"msg,2" json:"msg"`
+	Code int64             `thrift:"code,1" json:"code"`
+	Msg  string            `thrift:"msg,2" json:"msg"`
+	Data *ChatV3ChatDetail `thrift:"data,3,optional" json:"data,omitempty"`
 }
 
 func NewAgentRunResponse() *AgentRunResponse {
@@ -887,12 +926,28 @@ func (p *AgentRunResponse) GetCode() (v int64) {
 func (p *AgentRunResponse) GetMsg() (v string) {
 	return p.Msg
 }
+
+var AgentRunResponse_Data_DEFAULT *ChatV3ChatDetail
+
+func (p *AgentRunResponse) GetData() (v *ChatV3ChatDetail) {
+	if !p.IsSetData() {
+		return AgentRunResponse_Data_DEFAULT
+	}
+	return p.Data
+}
 func (p *AgentRunResponse) SetCode(val int64) {
 	p.Code = val
 }
 func (p *AgentRunResponse) SetMsg(val string) {
 	p.Msg = val
 }
+func (p *AgentRunResponse) SetData(val *ChatV3ChatDetail) {
+	p.Data = val
+}
+
+func (p *AgentRunResponse) IsSetData() bool {
+	return p.Data != nil
+}
 
 func (p *AgentRunResponse) String() string {
 	if p == nil {
@@ -902,8 +957,9 @@ func (p *AgentRunResponse) String() string {
 }
 
 type ErrorData struct {
-	Code int64  `thrift:"code,1" json:"code"`
-	Msg  string `thrift:"msg,2" json:"msg"`
+	Code         int64  `thrift:"code,1" json:"code"`
+	Msg          string `thrift:"msg,2" json:"msg"`
+	RetryAfterMs *int64 `thrift:"retry_after_ms,3,optional" json:"retry_after_ms,omitempty"`
 }
 
 func NewErrorData() *ErrorData {
@@ -920,12 +976,28 @@ func (p *ErrorData) GetCode() (v int64) {
 func (p *ErrorData) GetMsg() (v string) {
 	return p.Msg
 }
+
+var ErrorData_RetryAfterMs_DEFAULT int64
+
+func (p *ErrorData) GetRetryAfterMs() (v int64) {
+	if !p.IsSetRetryAfterMs() {
+		return ErrorData_RetryAfterMs_DEFAULT
+	}
+	return *p.RetryAfterMs
+}
 func (p *ErrorData) SetCode(val int64) {
 	p.Code = val
 }
 func (p *ErrorData) SetMsg(val string) {
 	p.Msg = val
 }
+func (p *ErrorData) SetRetryAfterMs(val *int64) {
+	p.RetryAfterMs = val
+}
+
+func (p *ErrorData) IsSetRetryAfterMs() bool {
+	return p.RetryAfterMs != nil
+}
 
 func (p *ErrorData) String() string {
 	if p == nil {
@@ -934,6 +1006,152 @@ func (p *ErrorData) String() string {
 	return fmt.Sprintf("ErrorData(%+v)", *p)
 }
 
+type DirectiveData struct {
+	ConversationID string `thrift:"conversation_id,1" json:"conversation_id"`
+	MessageID      string `thrift:"message_id,2" json:"message_id"`
+	Type           string `thrift:"type,3" json:"type"`
+	Name           string `thrift:"name,4" json:"name"`
+	Offset         int32  `thrift:"offset,5" json:"offset"`
+	ElapsedMs      int64  `thrift:"elapsed_ms,6" json:"elapsed_ms"`
+}
+
+func NewDirectiveData() *DirectiveData {
+	return &DirectiveData{}
+}
+
+func (p *DirectiveData) InitDefault() {
+}
+
+func (p *DirectiveData) GetConversationID() (v string) {
+	return p.ConversationID
+}
+
+func (p *DirectiveData) GetMessageID() (v string) {
+	return p.MessageID
+}
+
+func (p *DirectiveData) GetType() (v string) {
+	return p.Type
+}
+
+func (p *DirectiveData) GetName() (v string) {
+	return p.Name
+}
+
+func (p *DirectiveData) GetOffset() (v int32) {
+	return p.Offset
+}
+
+func (p *DirectiveData) GetElapsedMs() (v int64) {
+	return p.ElapsedMs
+}
+func (p *DirectiveData) SetConversationID(val string) {
+	p.ConversationID = val
+}
+func (p *DirectiveData) SetMessageID(val string) {
+	p.MessageID = val
+}
+func (p *DirectiveData) SetType(val string) {
+	p.Type = val
+}
+func (p *DirectiveData) SetName(val string) {
+	p.Name = val
+}
+func (p *DirectiveData) SetOffset(val int32) {
+	p.Offset = val
+}
+func (p *DirectiveData) SetElapsedMs(val int64) {
+	p.ElapsedMs = val
+}
+
+func (p *DirectiveData) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DirectiveData(%+v)", *p)
+}
+
+type AudioData struct {
+	ConversationID string `thrift:"conversation_id,1" json:"conversation_id"`
+	MessageID      string `thrift:"message_id,2" json:"message_id"`
+	Index          int32  `thrift:"index,3" json:"index"`
+	Text           string `thrift:"text,4" json:"text"`
+	Offset         int32  `thrift:"offset,5" json:"offset"`
+	URL            string `thrift:"url,6" json:"url"`
+	ContentType    string `thrift:"content_type,7" json:"content_type"`
+	Autoplay       bool   `thrift:"autoplay,8" json:"autoplay"`
+}
+
+func NewAudioData() *AudioData {
+	return &AudioData{}
+}
+
+func (p *AudioData) InitDefault() {
+}
+
+func (p *AudioData) GetConversationID() (v string) {
+	return p.ConversationID
+}
+
+func (p *AudioData) GetMessageID() (v string) {
+	return p.MessageID
+}
+
+func (p *AudioData) GetIndex() (v int32) {
+	return p.Index
+}
+
+func (p *AudioData) GetText() (v string) {
+	return p.Text
+}
+
+func (p *AudioData) GetOffset() (v int32) {
+	return p.Offset
+}
+
+func (p *AudioData) GetURL() (v string) {
+	return p.URL
+}
+
+func (p *AudioData) GetContentType() (v string) {
+	return p.ContentType
+}
+
+func (p *AudioData) GetAutoplay() (v bool) {
+	return p.Autoplay
+}
+func (p *AudioData) SetConversationID(val string) {
+	p.ConversationID = val
+}
+func (p *AudioData) SetMessageID(val string) {
+	p.MessageID = val
+}
+func (p *AudioData) SetIndex(val int32) {
+	p.Index = val
+}
+func (p *AudioData) SetText(val string) {
+	p.Text = val
+}
+func (p *AudioData) SetOffset(val int32) {
+	p.Offset = val
+}
+func (p *AudioData) SetURL(val string) {
+	p.URL = val
+}
+func (p *AudioData) SetContentType(val string) {
+	p.ContentType = val
+}
+func (p *AudioData) SetAutoplay(val bool) {
+	p.Autoplay = val
+}
+
+func (p *AudioData) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AudioData(%+v)", *p)
+}
+
 type CustomConfig struct {
 	ModelConfig *ModelConfig `thrift:"ModelConfig,1,optional" json:"ModelConfig,omitempty"`
 	BotConfig   *BotConfig   `thrift:"BotConfig,2,optional" json:"BotConfig,omitempty"`
@@ -1290,19 +1508,19 @@ func (p *ChatV3Request) String() string {
 }
 
 type ChatV3MessageDetail struct {
-	ID               string            `thrift:"ID,1,required" json:"ID"`
-	ConversationID   string            `thrift:"ConversationID,2,required" json:"ConversationID"`
-	BotID            string            `thrift:"BotID,3,required" json:"BotID"`
-	Role             string            `thrift:"Role,4,required" json:"Role"`
-	Type             string            `thrift:"Type,5,required" json:"Type"`
-	Content          string            `thrift:"Content,6,required" json:"Content"`
-	ContentType      string            `thrift:"ContentType,7,required" json:"ContentType"`
-	MetaData         map[string]string `thrift:"MetaData,8,optional" json:"MetaData,omitempty"`
-	ChatID           string            `thrift:"ChatID,9,required" json:"ChatID"`
-	SectionID        *string           `thrift:"SectionID,10,optional" json:"SectionID,omitempty"`
-	CreatedAt        *int64            `thrift:"CreatedAt,11,optional" json:"CreatedAt,omitempty"`
-	UpdatedAt        *int64            `thrift:"UpdatedAt,12,optional" json:"UpdatedAt,omitempty"`
-	ReasoningContent *string           `thrift:"ReasoningContent,13,optional" json:"ReasoningContent,omitempty"`
+	ID               string            `thrift:"ID,1,required" json:"id"`
+	ConversationID   string            `thrift:"ConversationID,2,required" json:"conversation_id"`
+	BotID            string            `thrift:"BotID,3,required" json:"bot_id"`
+	Role             string            `thrift:"Role,4,required" json:"role"`
+	Type             string            `thrift:"Type,5,required" json:"type"`
+	Content          string            `thrift:"Content,6,required" json:"content"`
+	ContentType      string            `thrift:"ContentType,7,required" json:"content_type"`
+	MetaData         map[string]string `thrift:"MetaData,8,optional" json:"meta_data,omitempty"`
+	ChatID           string            `thrift:"ChatID,9,required" json:"chat_id"`
+	SectionID        *string           `thrift:"SectionID,10,optional" json:"section_id,omitempty"`
+	CreatedAt        *int64            `thrift:"CreatedAt,11,optional" json:"created_at,omitempty"`
+	UpdatedAt        *int64            `thrift:"UpdatedAt,12,optional" json:"updated_at,omitempty"`
+	ReasoningContent *string           `thrift:"ReasoningContent,13,optional" json:"reasoning_content,omitempty"`
 }
 
 func NewChatV3MessageDetail() *ChatV3MessageDetail {
@@ -2166,3 +2384,210 @@ func (p *CancelChatApiResponse) String() string {
 	}
 	return fmt.Sprintf("CancelChatApiResponse(%+v)", *p)
 }
+
+type RetrieveChatApiRequest struct {
+	ChatID         int64 `thrift:"ChatID,1,required" form:"chat_id" json:"chat_id,string" query:"chat_id"`
+	ConversationID int64 `thrift:"ConversationID,2,required" form:"conversation_id" json:"conversation_id,string" query:"conversation_id"`
+}
+
+func NewRetrieveChatApiRequest() *RetrieveChatApiRequest {
+	return &RetrieveChatApiRequest{}
+}
+
+func (p *RetrieveChatApiRequest) InitDefault() {
+}
+
+func (p *RetrieveChatApiRequest) GetChatID() (v int64) {
+	return p.ChatID
+}
+
+func (p *RetrieveChatApiRequest) GetConversationID() (v int64) {
+	return p.ConversationID
+}
+func (p *RetrieveChatApiRequest) SetChatID(val int64) {
+	p.ChatID = val
+}
+func (p *RetrieveChatApiRequest) SetConversationID(val int64) {
+	p.ConversationID = val
+}
+
+func (p *RetrieveChatApiRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RetrieveChatApiRequest(%+v)", *p)
+}
+
+type RetrieveChatApiResponse struct {
+	Code int64             `thrift:"code,1" json:"code"`
+	Msg  string            `thrift:"msg,2" json:"msg"`
+	Data *ChatV3ChatDetail `thrift:"data,3,optional" json:"data,omitempty"`
+}
+
+func NewRetrieveChatApiResponse() *RetrieveChatApiResponse {
+	return &RetrieveChatApiResponse{}
+}
+
+func (p *RetrieveChatApiResponse) InitDefault() {
+}
+
+func (p *RetrieveChatApiResponse) GetCode() (v int64) {
+	return p.Code
+}
+
+func (p *RetrieveChatApiResponse) GetMsg() (v string) {
+	return p.Msg
+}
+
+var RetrieveChatApiResponse_Data_DEFAULT *ChatV3ChatDetail
+
+func (p *RetrieveChatApiResponse) GetData() (v *ChatV3ChatDetail) {
+	if !p.IsSetData() {
+		return RetrieveChatApiResponse_Data_DEFAULT
+	}
+	return p.Data
+}
+func (p *RetrieveChatApiResponse) SetCode(val int64) {
+	p.Code = val
+}
+func (p *RetrieveChatApiResponse) SetMsg(val string) {
+	p.Msg = val
+}
+func (p *RetrieveChatApiResponse) SetData(val *ChatV3ChatDetail) {
+	p.Data = val
+}
+
+func (p *RetrieveChatApiResponse) IsSetData() bool {
+	return p.Data != nil
+}
+
+func (p *RetrieveChatApiResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RetrieveChatApiResponse(%+v)", *p)
+}
+
+type ListChatMessageApiRequest struct {
+	ChatID         int64 `thrift:"ChatID,1,required" form:"chat_id" json:"chat_id,string" query:"chat_id"`
+	ConversationID int64 `thrift:"ConversationID,2,required" form:"conversation_id" json:"conversation_id,string" query:"conversation_id"`
+}
+
+func NewListChatMessageApiRequest() *ListChatMessageApiRequest {
+	return &ListChatMessageApiRequest{}
+}
+
+func (p *ListChatMessageApiRequest) InitDefault() {
+}
+
+func (p *ListChatMessageApiRequest) GetChatID() (v int64) {
+	return p.ChatID
+}
+
+func (p *ListChatMessageApiRequest) GetConversationID() (v int64) {
+	return p.ConversationID
+}
+func (p *ListChatMessageApiRequest) SetChatID(val int64) {
+	p.ChatID = val
+}
+func (p *ListChatMessageApiRequest) SetConversationID(val int64) {
+	p.ConversationID = val
+}
+
+func (p *ListChatMessageApiRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListChatMessageApiRequest(%+v)", *p)
+}
+
+type ListChatMessageApiResponse struct {
+	Code int64                  `thrift:"code,1" json:"code"`
+	Msg  string                 `thrift:"msg,2" json:"msg"`
+	Data []*ChatV3MessageDetail `thrift:"data,3,default,list<ChatV3MessageDetail>" json:"data"`
+}
+
+func NewListChatMessageApiResponse() *ListChatMessageApiResponse {
+	return &ListChatMessageApiResponse{}
+}
+
+func (p *ListChatMessageApiResponse) InitDefault() {
+}
+
+func (p *ListChatMessageApiResponse) GetCode() (v int64) {
+	return p.Code
+}
+
+func (p *ListChatMessageApiResponse) GetMsg() (v string) {
+	return p.Msg
+}
+
+func (p *ListChatMessageApiResponse) GetData() (v []*ChatV3MessageDetail) {
+	return p.Data
+}
+func (p *ListChatMessageApiResponse) SetCode(val int64) {
+	p.Code = val
+}
+func (p *ListChatMessageApiResponse) SetMsg(val string) {
+	p.Msg = val
+}
+func (p *ListChatMessageApiResponse) SetData(val []*ChatV3MessageDetail) {
+	p.Data = val
+}
+
+func (p *ListChatMessageApiResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListChatMessageApiResponse(%+v)", *p)
+}
+
+type ReattachChatApiRequest struct {
+	ChatID         int64  `thrift:"ChatID,1,required" form:"chat_id" json:"chat_id,string" query:"chat_id"`
+	ConversationID int64  `thrift:"ConversationID,2,required" form:"conversation_id" json:"conversation_id,string" query:"conversation_id"`
+	LastEventID    *int64 `thrift:"LastEventID,3,optional" form:"last_event_id" json:"last_event_id,string,omitempty" query:"last_event_id"`
+}
+
+func NewReattachChatApiRequest() *ReattachChatApiRequest {
+	return &ReattachChatApiRequest{}
+}
+
+func (p *ReattachChatApiRequest) InitDefault() {
+}
+
+func (p *ReattachChatApiRequest) GetChatID() (v int64) {
+	return p.ChatID
+}
+
+func (p *ReattachChatApiRequest) GetConversationID() (v int64) {
+	return p.ConversationID
+}
+
+var ReattachChatApiRequest_LastEventID_DEFAULT int64
+
+func (p *ReattachChatApiRequest) GetLastEventID() (v int64) {
+	if !p.IsSetLastEventID() {
+		return ReattachChatApiRequest_LastEventID_DEFAULT
+	}
+	return *p.LastEventID
+}
+func (p *ReattachChatApiRequest) SetChatID(val int64) {
+	p.ChatID = val
+}
+func (p *ReattachChatApiRequest) SetConversationID(val int64) {
+	p.ConversationID = val
+}
+func (p *ReattachChatApiRequest) SetLastEventID(val *int64) {
+	p.LastEventID = val
+}
+
+func (p *ReattachChatApiRequest) IsSetLastEventID() bool {
+	return p.LastEventID != nil
+}
+
+func (p *ReattachChatApiRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ReattachChatApiRequest(%+v)", *p)
+}
@@ -832,13 +832,14 @@ func (p *Model) String() string {
 }
 
 type Connection struct {
-	BaseConnInfo *BaseConnectionInfo `thrift:"base_conn_info,1" json:"base_conn_info"`
-	Openai       *OpenAIConnInfo     `thrift:"openai,2,optional" json:"openai,omitempty"`
-	Deepseek     *DeepseekConnInfo   `thrift:"deepseek,3,optional" json:"deepseek,omitempty"`
-	Gemini       *GeminiConnInfo     `thrift:"gemini,4,optional" json:"gemini,omitempty"`
-	Qwen         *QwenConnInfo       `thrift:"qwen,5,optional" json:"qwen,omitempty"`
-	Ollama       *OllamaConnInfo     `thrift:"ollama,6,optional" json:"ollama,omitempty"`
-	Claude       *ClaudeConnInfo     `thrift:"claude,7,optional" json:"claude,omitempty"`
+	BaseConnInfo     *BaseConnectionInfo       `thrift:"base_conn_info,1" json:"base_conn_info"`
+	Openai           *OpenAIConnInfo           `thrift:"openai,2,optional" json:"openai,omitempty"`
+	Deepseek         *DeepseekConnInfo         `thrift:"deepseek,3,optional" json:"deepseek,omitempty"`
+	Gemini           *GeminiConnInfo           `thrift:"gemini,4,optional" json:"gemini,omitempty"`
+	Qwen             *QwenConnInfo             `thrift:"qwen,5,optional" json:"qwen,omitempty"`
+	Ollama           *OllamaConnInfo           `thrift:"ollama,6,optional" json:"ollama,omitempty"`
+	Claude           *ClaudeConnInfo           `thrift:"claude,7,optional" json:"claude,omitempty"`
+	OpenaiCompatible *OpenAICompatibleConnInfo `thrift:"openai_compatible,8,optional" json:"openai_compatible,omitempty"`
 }
 
 func NewConnection() *Connection {
@@ -910,6 +911,15 @@ func (p *Connection) GetClaude() (v *ClaudeConnInfo) {
 	}
 	return p.Claude
 }
+
+var Connection_OpenaiCompatible_DEFAULT *OpenAICompatibleConnInfo
+
+func (p *Connection) GetOpenaiCompatible() (v *OpenAICompatibleConnInfo) {
+	if !p.IsSetOpenaiCompatible() {
+		return Connection_OpenaiCompatible_DEFAULT
+	}
+	return p.OpenaiCompatible
+}
 func (p *Connection) SetBaseConnInfo(val *BaseConnectionInfo) {
 	p.BaseConnInfo = val
 }
@@ -931,6 +941,9 @@ func (p *Connection) SetOllama(val *OllamaConnInfo) {
 func (p *Connection) SetClaude(val *ClaudeConnInfo) {
 	p.Claude = val
 }
+func (p *Connection) SetOpenaiCompatible(val *OpenAICompatibleConnInfo) {
+	p.OpenaiCompatible = val
+}
 
 func (p *Connection) IsSetBaseConnInfo() bool {
 	return p.BaseConnInfo != nil
@@ -960,6 +973,10 @@ func (p *Connection) IsSetClaude() bool {
 	return p.Claude != nil
 }
 
+func (p *Connection) IsSetOpenaiCompatible() bool {
+	return p.OpenaiCompatible != nil
+}
+
 func (p *Connection) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1183,6 +1200,79 @@ func (p *ClaudeConnInfo) String() string {
 	return fmt.Sprintf("ClaudeConnInfo(%+v)", *p)
 }
 
+type OpenAICompatibleConnInfo struct {
+	ExtraHeaders          map[string]string `thrift:"extra_headers,1" json:"extra_headers"`
+	ChatCompletionsPath   string            `thrift:"chat_completions_path,2" json:"chat_completions_path"`
+	DisableStreamOptions  bool              `thrift:"disable_stream_options,3" json:"disable_stream_options"`
+	DisableToolCall       bool              `thrift:"disable_tool_call,4" json:"disable_tool_call"`
+	DisableToolChoice     bool              `thrift:"disable_tool_choice,5" json:"disable_tool_choice"`
+	DisableResponseFormat bool              `thrift:"disable_response_format,6" json:"disable_response_format"`
+	ReasoningField        string            `thrift:"reasoning_field,7" json:"reasoning_field"`
+}
+
+func NewOpenAICompatibleConnInfo() *OpenAICompatibleConnInfo {
+	return &OpenAICompatibleConnInfo{}
+}
+
+func (p *OpenAICompatibleConnInfo) InitDefault() {
+}
+
+func (p *OpenAICompatibleConnInfo) GetExtraHeaders() (v map[string]string) {
+	return p.ExtraHeaders
+}
+
+func (p *OpenAICompatibleConnInfo) GetChatCompletionsPath() (v string) {
+	return p.ChatCompletionsPath
+}
+
+func (p *OpenAICompatibleConnInfo) GetDisableStreamOptions() (v bool) {
+	return p.DisableStreamOptions
+}
+
+func (p *OpenAICompatibleConnInfo) GetDisableToolCall() (v bool) {
+	return p.DisableToolCall
+}
+
+func (p *OpenAICompatibleConnInfo) GetDisableToolChoice() (v bool) {
+	return p.DisableToolChoice
+}
+
+func (p *OpenAICompatibleConnInfo) GetDisableResponseFormat() (v bool) {
+	return p.DisableResponseFormat
+}
+
+func (p *OpenAICompatibleConnInfo) GetReasoningField() (v string) {
+	return p.ReasoningField
+}
+func (p *OpenAICompatibleConnInfo) SetExtraHeaders(val map[string]string) {
+	p.ExtraHeaders = val
+}
+func (p *OpenAICompatibleConnInfo) SetChatCompletionsPath(val string) {
+	p.ChatCompletionsPath = val
+}
+func (p *OpenAICompatibleConnInfo) SetDisableStreamOptions(val bool) {
+	p.DisableStreamOptions = val
+}
+func (p *OpenAICompatibleConnInfo) SetDisableToolCall(val bool) {
+	p.DisableToolCall = val
+}
+func (p *OpenAICompatibleConnInfo) SetDisableToolChoice(val bool) {
+	p.DisableToolChoice = val
+}
+func (p *OpenAICompatibleConnInfo) SetDisableResponseFormat(val bool) {
+	p.DisableResponseFormat = val
+}
+func (p *OpenAICompatibleConnInfo) SetReasoningField(val string) {
+	p.ReasoningField = val
+}
+
+func (p *OpenAICompatibleConnInfo) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("OpenAICompatibleConnInfo(%+v)", *p)
+}
+
 type CreateModelReq struct {
 	ModelClass      developer_api.ModelClass `thrift:"model_class,1,default,ModelClass" json:"model_class"`
 	ModelName       string                   `thrift:"model_name,2" json:"model_name"`
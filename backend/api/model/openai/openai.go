@@ -0,0 +1,181 @@
+// Package openai OpenAI Chat Completions 兼容接口的请求与响应结构
+//
+// 字段与 OpenAI 官方接口保持一致，其中 content 既可以是字符串也可以是分片数组，无法用 thrift 描述，因此不由 IDL 生成
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const (
+	ObjectChatCompletion      = "chat.completion"
+	ObjectChatCompletionChunk = "chat.completion.chunk"
+	ObjectModel               = "model"
+	ObjectList                = "list"
+
+	RoleSystem    = "system"
+	RoleDeveloper = "developer"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+
+	ContentPartTypeText     = "text"
+	ContentPartTypeImageURL = "image_url"
+
+	ToolTypeFunction = "function"
+
+	FinishReasonStop   = "stop"
+	FinishReasonLength = "length"
+
+	ErrorTypeInvalidRequest = "invalid_request_error"
+	ErrorTypeAuthentication = "authentication_error"
+	ErrorTypeRateLimit      = "rate_limit_error"
+	ErrorTypeServer         = "server_error"
+)
+
+type ChatCompletionRequest struct {
+	// Model 智能体，格式为 agent:<agent_id>
+	Model         string         `json:"model"`
+	Messages      []*ChatMessage `json:"messages"`
+	Stream        bool           `json:"stream,omitempty"`
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+	User          string         `json:"user,omitempty"`
+}
+
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
+type ChatMessage struct {
+	Role             string         `json:"role"`
+	Content          MessageContent `json:"content"`
+	ReasoningContent string         `json:"reasoning_content,omitempty"`
+	Name             string         `json:"name,omitempty"`
+	ToolCalls        []*ToolCall    `json:"tool_calls,omitempty"`
+	ToolCallID       string         `json:"tool_call_id,omitempty"`
+}
+
+// MessageContent 消息内容，请求中为字符串时只设置 Text，为数组时只设置 Parts
+type MessageContent struct {
+	Text  string
+	Parts []*ContentPart
+}
+
+func (m *MessageContent) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		return nil
+	case data[0] == '[':
+		return json.Unmarshal(data, &m.Parts)
+	default:
+		return json.Unmarshal(data, &m.Text)
+	}
+}
+
+func (m MessageContent) MarshalJSON() ([]byte, error) {
+	if len(m.Parts) > 0 {
+		return json.Marshal(m.Parts)
+	}
+	return json.Marshal(m.Text)
+}
+
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type ToolCall struct {
+	Index    *int         `json:"index,omitempty"`
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Function FunctionCall `json:"function"`
+}
+
+type FunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+type ChatCompletion struct {
+	ID      string    `json:"id"`
+	Object  string    `json:"object"`
+	Created int64     `json:"created"`
+	Model   string    `json:"model"`
+	Choices []*Choice `json:"choices"`
+	Usage   *Usage    `json:"usage,omitempty"`
+}
+
+type Choice struct {
+	Index        int      `json:"index"`
+	Message      *Message `json:"message"`
+	FinishReason string   `json:"finish_reason"`
+}
+
+// Message 应答消息，与 ChatMessage 不同的是 content 始终为字符串
+type Message struct {
+	Role             string      `json:"role"`
+	Content          string      `json:"content"`
+	ReasoningContent string      `json:"reasoning_content,omitempty"`
+	ToolCalls        []*ToolCall `json:"tool_calls,omitempty"`
+}
+
+type ChatCompletionChunk struct {
+	ID      string         `json:"id"`
+	Object  string         `json:"object"`
+	Created int64          `json:"created"`
+	Model   string         `json:"model"`
+	Choices []*ChunkChoice `json:"choices"`
+	Usage   *Usage         `json:"usage,omitempty"`
+}
+
+type ChunkChoice struct {
+	Index        int     `json:"index"`
+	Delta        *Delta  `json:"delta"`
+	FinishReason *string `json:"finish_reason"`
+}
+
+type Delta struct {
+	Role             string      `json:"role,omitempty"`
+	Content          string      `json:"content,omitempty"`
+	ReasoningContent string      `json:"reasoning_content,omitempty"`
+	ToolCalls        []*ToolCall `json:"tool_calls,omitempty"`
+}
+
+type Usage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
+type ModelList struct {
+	Object string   `json:"object"`
+	Data   []*Model `json:"data"`
+}
+
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+	// Name 与 Description 为智能体的名称与描述，不在 OpenAI 接口定义中
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type ErrorResponse struct {
+	Error *Error `json:"error"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Code    string `json:"code,omitempty"`
+}
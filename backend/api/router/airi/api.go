@@ -51,8 +51,58 @@ func Register(r *gin.Engine) {
 			_conversation.POST("/chat", append(_agentrunMw(), handle.AgentRun)...)
 			_conversation.POST("/clear_message", append(_clearMw(), handle.ClearConversationHistory)...)
 			_conversation.POST("/break_message", append(_breakmessageMw(), handle.BreakMessage)...)
+			_conversation.POST("/switch_branch", append(_switchbranchMw(), handle.SwitchBranch)...)
 			_conversation.POST("/delete_message", append(_deletemessageMw(), handle.DeleteMessage)...)
 			_conversation.POST("/get_message_list", append(_getmessagelistMw(), handle.GetMessageList)...)
+			_conversation.GET("/chat/reattach", append(_reattachchatapiMw(), handle.ReattachChatApi)...)
+			_conversation.GET("/chat/retrieve", append(_retrievechatapiMw(), handle.RetrieveChatApi)...)
+			_conversation.GET("/chat/message/list", append(_listchatmessageapiMw(), handle.ListChatMessageApi)...)
+			_conversation.GET("/chat/ws", append(_chatwebsocketMw(), handle.ChatWebSocket)...)
+		}
+		{
+			_knowledge := _api.Group("/knowledge", _knowledgeMw()...)
+			_knowledge.POST("/create", append(_createknowledgeMw(), handle.CreateKnowledge)...)
+			_knowledge.POST("/update", append(_updateknowledgeMw(), handle.UpdateKnowledge)...)
+			_knowledge.POST("/delete", append(_deleteknowledgeMw(), handle.DeleteKnowledge)...)
+			_knowledge.POST("/list", append(_listknowledgeMw(), handle.ListKnowledge)...)
+			_knowledge.POST("/retrieve", append(_retrieveMw(), handle.Retrieve)...)
+			{
+				_document := _knowledge.Group("/document", _documentMw()...)
+				_document.POST("/create", append(_createdocumentMw(), handle.CreateDocument)...)
+				_document.POST("/delete", append(_deletedocumentMw(), handle.DeleteDocument)...)
+				_document.POST("/list", append(_listdocumentMw(), handle.ListDocument)...)
+			}
+			{
+				_slice := _knowledge.Group("/slice", _sliceMw()...)
+				_slice.POST("/list", append(_listsliceMw(), handle.ListSlice)...)
+			}
+		}
+		{
+			_workflow := _api.Group("/workflow", _workflowMw()...)
+			_workflow.POST("/create", append(_createworkflowMw(), handle.CreateWorkflow)...)
+			_workflow.POST("/update", append(_updateworkflowMw(), handle.UpdateWorkflow)...)
+			_workflow.POST("/save_canvas", append(_savecanvasMw(), handle.SaveCanvas)...)
+			_workflow.POST("/delete", append(_deleteworkflowMw(), handle.DeleteWorkflow)...)
+			_workflow.POST("/get", append(_getworkflowMw(), handle.GetWorkflow)...)
+			_workflow.POST("/list", append(_listworkflowMw(), handle.ListWorkflow)...)
+			_workflow.POST("/publish", append(_publishworkflowMw(), handle.PublishWorkflow)...)
+			_workflow.POST("/run", append(_runworkflowMw(), handle.RunWorkflow)...)
+			{
+				_version := _workflow.Group("/version", _versionMw()...)
+				_version.POST("/list", append(_listversionMw(), handle.ListVersion)...)
+			}
+		}
+		{
+			_variables := _api.Group("/variables", _variablesMw()...)
+			_variables.POST("/list", append(_listvariablesMw(), handle.ListVariables)...)
+			_variables.POST("/update", append(_updatevariablesMw(), handle.UpdateVariables)...)
+			_variables.POST("/reset", append(_resetvariablesMw(), handle.ResetVariables)...)
+		}
+		{
+			_memory := _api.Group("/memory", _memoryMw()...)
+			_memory.POST("/forget", append(_forgetmemoriesMw(), handle.ForgetMemories)...)
+			_memory.POST("/list", append(_listmemoriesMw(), handle.ListMemories)...)
+			_memory.POST("/update", append(_updatememoryMw(), handle.UpdateMemory)...)
 		}
 		{
 			_foundation := _api.Group("/foundation", _foundationMw()...)
@@ -68,6 +118,14 @@ func Register(r *gin.Engine) {
 				}
 			}
 		}
+		{
+			_llm := _api.Group("/llm", _llmMw()...)
+			{
+				_llm_v1 := _llm.Group("/v1", _llmV1Mw()...)
+				_llm_v1.POST("/chat", append(_chat0Mw(), handle.Chat)...)
+				_llm_v1.POST("/chat/stream", append(_chatstreamMw(), handle.ChatStream)...)
+			}
+		}
 		{
 			_admin := _api.Group("/admin", _adminMw()...)
 			{
@@ -79,14 +137,29 @@ func Register(r *gin.Engine) {
 					_model.POST("/set_default", append(_setdefaultmodelMw(), handle.SetDefaultModel)...)
 					_model.GET("/list", append(_listmodelMw(), handle.GetModelList)...)
 				}
+				{
+					_usage := _admin.Group("/usage", _usageMw()...)
+					_usage.POST("/daily", append(_getdailyusageMw(), handle.GetDailyUsage)...)
+					_usage.POST("/top_agents", append(_gettopagentsMw(), handle.GetTopAgents)...)
+					_usage.POST("/user_spend", append(_getuserspendMw(), handle.GetUserSpend)...)
+				}
 			}
 		}
 		{
 			_v1 := root.Group("/v1", _v1Mw()...)
 			_v1.GET("/conversations", append(_listconversationsapiMw(), handle.ListConversationsApi)...)
+			_v1.GET("/models", append(_listmodelsMw(), handle.ListModels)...)
+			_v1_chat := _v1.Group("/chat", _v1chatMw()...)
+			_v1_chat.POST("/completions", append(_chatcompletionsMw(), handle.ChatCompletions)...)
 			_conversations := _v1.Group("/conversations", _conversationsMw()...)
 			_conversations.POST("/create", append(_createMw(), handle.CreateConversation)...)
 		}
+		{
+			_v3 := root.Group("/v3", _v3Mw()...)
+			_v3_chat := _v3.Group("/chat", _v3chatMw()...)
+			_v3_chat.POST("/cancel", append(_cancelchatapiMw(), handle.CancelChatApi)...)
+			_v3_chat.GET("/ws", append(_chatwebsocketapiMw(), handle.ChatWebSocket)...)
+		}
 		root.GET("/health", Health)
 	}
 }
@@ -11,15 +11,27 @@ import (
 
 	"github.com/kiosk404/airi-go/backend/api/model/llm/manage"
 	"github.com/kiosk404/airi-go/backend/infra/contract/cache"
+	"github.com/kiosk404/airi-go/backend/infra/contract/embedding"
 	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
 	"github.com/kiosk404/airi-go/backend/infra/contract/imagex"
 	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	"github.com/kiosk404/airi-go/backend/infra/contract/stt"
+	"github.com/kiosk404/airi-go/backend/infra/contract/tts"
+	"github.com/kiosk404/airi-go/backend/infra/contract/vectorstore"
 	"github.com/kiosk404/airi-go/backend/infra/impl/cache/local"
+	embeddingimpl "github.com/kiosk404/airi-go/backend/infra/impl/embedding"
 	idgenimpl "github.com/kiosk404/airi-go/backend/infra/impl/idgen"
 	"github.com/kiosk404/airi-go/backend/infra/impl/rdb/mysql"
 	"github.com/kiosk404/airi-go/backend/infra/impl/storage"
+	sttimpl "github.com/kiosk404/airi-go/backend/infra/impl/stt"
+	ttsimpl "github.com/kiosk404/airi-go/backend/infra/impl/tts"
+	vectorstorelocal "github.com/kiosk404/airi-go/backend/infra/impl/vectorstore/local"
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model/config"
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model/knowledge"
 	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/domain/service"
+	"github.com/kiosk404/airi-go/backend/pkg/checkpoint"
 	"github.com/kiosk404/airi-go/backend/pkg/conf"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
 	"github.com/kiosk404/airi-go/backend/types/consts"
 )
 
@@ -31,6 +43,13 @@ type AppDependencies struct {
 	ImageXClient  imagex.ImageX
 	ConfigFactory conf.IConfigLoaderFactory
 	ModelMgr      manage.LLMManageService
+	Embedder      embedding.Embedder
+	VectorStore   vectorstore.VectorStore
+	CPStore       checkpoint.Store
+	// TTS 未配置时为空，不合成语音
+	TTS tts.Synthesizer
+	// STT 未配置时为空，不识别语音
+	STT stt.Transcriber
 }
 
 func Init(ctx context.Context) (*AppDependencies, error) {
@@ -54,10 +73,106 @@ func Init(ctx context.Context) (*AppDependencies, error) {
 	if deps.ConfigFactory, err = modelmgr.ModelMetaConfFactory(getApplicationProjectRoot()); err != nil {
 		return nil, fmt.Errorf("init model meta conf factory failed, err=%w", err)
 	}
+	if deps.Embedder, err = initEmbedding(ctx, deps.DB); err != nil {
+		return nil, fmt.Errorf("init embedding failed, err=%w", err)
+	}
+	if deps.VectorStore, err = vectorstorelocal.New(getVectorStorePath()); err != nil {
+		return nil, fmt.Errorf("init vector store failed, err=%w", err)
+	}
+	if deps.CPStore, err = initCheckPointStore(ctx, deps); err != nil {
+		return nil, fmt.Errorf("init checkpoint store failed, err=%w", err)
+	}
+	if deps.TTS, err = ttsimpl.New(ttsConfig()); err != nil {
+		return nil, fmt.Errorf("init tts failed, err=%w", err)
+	}
+	if deps.STT, err = sttimpl.New(sttConfig()); err != nil {
+		return nil, fmt.Errorf("init stt failed, err=%w", err)
+	}
 
 	return deps, err
 }
 
+// initEmbedding 未配置 embedding 模型时返回 nil，知识库检索退化为全文检索
+func initEmbedding(ctx context.Context, db rdb.Provider) (embedding.Embedder, error) {
+	kc, err := knowledge.NewKnowledgeConfig(db.NewSession(ctx).DB()).GetKnowledgeConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ec := kc.EmbeddingConfig
+	if ec == nil || ec.Connection == nil {
+		logs.Warn("embedding is not configured, knowledge retrieval falls back to full text search")
+		return nil, nil
+	}
+	if ec.Type != config.EmbeddingType_HTTP && (ec.Connection.BaseConnInfo == nil || ec.Connection.BaseConnInfo.Model == "") {
+		logs.Warn("embedding model is not configured, knowledge retrieval falls back to full text search")
+		return nil, nil
+	}
+
+	return embeddingimpl.New(ctx, ec)
+}
+
+// initCheckPointStore 智能体与工作流中断的执行现场默认保存在数据库中，重启后仍可恢复
+func initCheckPointStore(ctx context.Context, deps *AppDependencies) (checkpoint.Store, error) {
+	conf := checkPointStoreConfig()
+	store, err := checkpoint.New(conf, deps.CacheCli, deps.DB.NewSession(ctx).DB())
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint.StartGC(ctx, store, conf.GCInterval)
+	return store, nil
+}
+
+func checkPointStoreConfig() *checkpoint.Config {
+	return &checkpoint.Config{
+		Type:       os.Getenv(consts.CheckPointStoreType),
+		TTL:        getDurationEnv(consts.CheckPointTTL, checkpoint.DefaultTTL),
+		GCInterval: getDurationEnv(consts.CheckPointGCInterval, checkpoint.DefaultGCInterval),
+	}
+}
+
+func ttsConfig() *ttsimpl.Config {
+	return &ttsimpl.Config{
+		Type:    os.Getenv(consts.TTSType),
+		BaseURL: os.Getenv(consts.TTSBaseURL),
+		APIKey:  os.Getenv(consts.TTSAPIKey),
+		Model:   os.Getenv(consts.TTSModel),
+		Voice:   os.Getenv(consts.TTSVoice),
+		Format:  os.Getenv(consts.TTSFormat),
+	}
+}
+
+func sttConfig() *sttimpl.Config {
+	return &sttimpl.Config{
+		Type:     os.Getenv(consts.STTType),
+		BaseURL:  os.Getenv(consts.STTBaseURL),
+		APIKey:   os.Getenv(consts.STTAPIKey),
+		Model:    os.Getenv(consts.STTModel),
+		Language: os.Getenv(consts.STTLanguage),
+	}
+}
+
+func getDurationEnv(key string, defaultVal time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultVal
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		logs.Warn("invalid duration %s=%s, use default %s", key, v, defaultVal)
+		return defaultVal
+	}
+	return d
+}
+
+func getVectorStorePath() string {
+	if p := os.Getenv(consts.VectorStorePath); p != "" {
+		return p
+	}
+	return filepath.Join(os.Getenv(consts.LocalStoragePath), "vector_store")
+}
+
 func mysqlDBConfig() *mysql.Config {
 	return &mysql.Config{
 		DBHostname:   getMysqlDomain(),
@@ -12,42 +12,63 @@ import (
 	crossagentimpl "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/impl"
 	crossplugin "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin"
 	crosspluginimpl "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/impl"
+	crossworkflow "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow"
+	crossworkflowimpl "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/impl"
 	pluginapp "github.com/kiosk404/airi-go/backend/modules/component/plugin/application"
+	workflowapp "github.com/kiosk404/airi-go/backend/modules/component/workflow/application"
 	conversationapp "github.com/kiosk404/airi-go/backend/modules/conversation/conversation/application"
 	crossmessage "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message"
 	crossmessageimpl "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message/impl"
+	crossdatabase "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/database"
+	crossdatabaseimpl "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/database/impl"
+	crossknowledge "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/knowledge"
+	crossknowledgeimpl "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/knowledge/impl"
+	crossmemory "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory"
+	crossmemoryimpl "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/impl"
 	crosssearch "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/search"
 	searchImpl "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/search/impl"
+	crossvariables "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/variables"
+	crossvariablesimpl "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/variables/impl"
+	databaseapp "github.com/kiosk404/airi-go/backend/modules/data/database/application"
+	knowledgeapp "github.com/kiosk404/airi-go/backend/modules/data/knowledge/application"
+	memoryapp "github.com/kiosk404/airi-go/backend/modules/data/memory/application"
 	searchapp "github.com/kiosk404/airi-go/backend/modules/data/search/application"
 	search "github.com/kiosk404/airi-go/backend/modules/data/search/domain/service"
 	uploadapp "github.com/kiosk404/airi-go/backend/modules/data/upload/application"
+	variablesapp "github.com/kiosk404/airi-go/backend/modules/data/variables/application"
 	openauthapp "github.com/kiosk404/airi-go/backend/modules/foundation/openauth/application"
 	userapp "github.com/kiosk404/airi-go/backend/modules/foundation/user/application"
 	modelmgrapp "github.com/kiosk404/airi-go/backend/modules/llm/application"
 	crossmodelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr"
 	crossmodelmgrimpl "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/impl"
-	"github.com/kiosk404/airi-go/backend/pkg/checkpoint"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
 )
 
 type eventbusImpl struct {
-	resourceEventBus search.ResourceEventBus
-	projectEventBus  search.ProjectEventBus
+	resourceEventBus   search.ResourceEventBus
+	projectEventBus    search.ProjectEventBus
+	chatCancelProducer eventbus.Producer
+	asyncRunProducer   eventbus.Producer
 }
 
 type basicServices struct {
-	infra       *appinfra.AppDependencies
-	eventbus    *eventbusImpl
-	userSVC     *userapp.UserApplicationService
-	openAuthSVC *openauthapp.OpenAuthApplicationService
-	modelMgrSVC *modelmgrapp.ModelManagerApplicationService
-	uploadSVC   *uploadapp.UploadService
+	infra        *appinfra.AppDependencies
+	eventbus     *eventbusImpl
+	userSVC      *userapp.UserApplicationService
+	openAuthSVC  *openauthapp.OpenAuthApplicationService
+	modelMgrSVC  *modelmgrapp.ModelManagerApplicationService
+	uploadSVC    *uploadapp.UploadService
+	knowledgeSVC *knowledgeapp.KnowledgeApplicationService
+	databaseSVC  *databaseapp.DatabaseApplicationService
+	variablesSVC *variablesapp.VariablesApplicationService
+	memorySVC    *memoryapp.MemoryApplicationService
+	workflowSVC  *workflowapp.WorkflowApplicationService
 }
 
 type primaryServices struct {
 	basicServices *basicServices
 
 	pluginSVC *pluginapp.PluginApplicationService
-	//memorySVC    *memory.MemoryApplicationServices
 }
 
 type complexServices struct {
@@ -86,6 +107,11 @@ func Init(ctx context.Context) (err error) {
 	crossagent.SetDefaultSVC(crossagentimpl.InitDomainService(complexServices.singleAgentSVC.DomainSVC, infra.ImageXClient))
 	crossmessage.SetDefaultSVC(crossmessageimpl.InitDomainService(complexServices.conversationSVC.MessageDomainSVC))
 	crosssearch.SetDefaultSVC(searchImpl.InitDomainService(complexServices.searchSVC.DomainSVC))
+	crossknowledge.SetDefaultSVC(crossknowledgeimpl.InitDomainService(basicServices.knowledgeSVC.DomainSVC))
+	crossdatabase.SetDefaultSVC(crossdatabaseimpl.InitDomainService(basicServices.databaseSVC.DomainSVC))
+	crossvariables.SetDefaultSVC(crossvariablesimpl.InitDomainService(basicServices.variablesSVC.DomainSVC))
+	crossmemory.SetDefaultSVC(crossmemoryimpl.InitDomainService(basicServices.memorySVC.DomainSVC))
+	crossworkflow.SetDefaultSVC(crossworkflowimpl.InitDomainService(basicServices.workflowSVC.DomainSVC))
 
 	return nil
 }
@@ -93,6 +119,20 @@ func Init(ctx context.Context) (err error) {
 func initEventBus(infra *appinfra.AppDependencies) *eventbusImpl {
 	e := &eventbusImpl{}
 	eventbus.SetDefaultSVC(implEventbus.NewConsumerService())
+
+	chatCancelProducer, err := implEventbus.InitChatCancelProducer()
+	if err != nil {
+		logs.Warn("chat cancel event bus is disabled, runs can only be cancelled on the instance executing them, err: %v", err)
+	} else {
+		e.chatCancelProducer = chatCancelProducer
+	}
+
+	asyncRunProducer, err := implEventbus.InitChatAsyncRunProducer()
+	if err != nil {
+		logs.Warn("chat async run event bus is disabled, async runs are executed on the instance accepting them, err: %v", err)
+	} else {
+		e.asyncRunProducer = asyncRunProducer
+	}
 	return e
 }
 
@@ -102,16 +142,45 @@ func initBasicServices(ctx context.Context, infra *appinfra.AppDependencies, e *
 
 	openAuthSVC := openauthapp.InitService(infra.DB, infra.IDGenSVC)
 	userSVC := userapp.InitService(ctx, infra.DB, infra.TOSClient, infra.IDGenSVC)
-	modelSVC := modelmgrapp.InitService(ctx, infra.IDGenSVC, infra.DB, infra.TOSClient, infra.ConfigFactory)
+	modelSVC := modelmgrapp.InitService(ctx, infra.IDGenSVC, infra.DB, infra.TOSClient, infra.ConfigFactory, infra.CacheCli)
 	uploadSVC := uploadapp.InitService(ctx, infra.TOSClient, infra.CacheCli, infra.DB, infra.IDGenSVC)
+	knowledgeSVC := knowledgeapp.InitService(ctx, &knowledgeapp.ServiceComponents{
+		DB:          infra.DB,
+		IDGen:       infra.IDGenSVC,
+		Storage:     infra.TOSClient,
+		Embedder:    infra.Embedder,
+		VectorStore: infra.VectorStore,
+	})
+	databaseSVC := databaseapp.InitService(ctx, &databaseapp.ServiceComponents{
+		DB: infra.DB,
+	})
+	variablesSVC := variablesapp.InitService(ctx, &variablesapp.ServiceComponents{
+		DB: infra.DB,
+	})
+	memorySVC := memoryapp.InitService(ctx, &memoryapp.ServiceComponents{
+		DB:          infra.DB,
+		IDGen:       infra.IDGenSVC,
+		Embedder:    infra.Embedder,
+		VectorStore: infra.VectorStore,
+	})
+	workflowSVC := workflowapp.InitService(ctx, &workflowapp.ServiceComponents{
+		DB:      infra.DB,
+		IDGen:   infra.IDGenSVC,
+		CPStore: infra.CPStore,
+	})
 
 	return &basicServices{
-		eventbus:    e,
-		infra:       infra,
-		userSVC:     userSVC,
-		openAuthSVC: openAuthSVC,
-		modelMgrSVC: modelSVC,
-		uploadSVC:   uploadSVC,
+		eventbus:     e,
+		infra:        infra,
+		userSVC:      userSVC,
+		openAuthSVC:  openAuthSVC,
+		modelMgrSVC:  modelSVC,
+		uploadSVC:    uploadSVC,
+		knowledgeSVC: knowledgeSVC,
+		databaseSVC:  databaseSVC,
+		variablesSVC: variablesSVC,
+		memorySVC:    memorySVC,
+		workflowSVC:  workflowSVC,
 	}, err
 }
 
@@ -163,7 +232,7 @@ func (p *primaryServices) toSingleAgentServiceComponents() *singleagentapp.Servi
 		DB:          p.basicServices.infra.DB,
 		Cache:       p.basicServices.infra.CacheCli,
 		TosClient:   p.basicServices.infra.TOSClient,
-		CPStore:     checkpoint.NewInMemoryStore(),
+		CPStore:     p.basicServices.infra.CPStore,
 		ModelMgrSVC: p.basicServices.modelMgrSVC.DomainSVC,
 	}
 }
@@ -174,8 +243,14 @@ func (p *primaryServices) toConversationComponents(singleAgentSVC *singleagentap
 	return &conversationapp.ServiceComponents{
 		DB:                   infra.DB,
 		IDGen:                infra.IDGenSVC,
+		Cache:                infra.CacheCli,
 		TosClient:            infra.TOSClient,
 		ImageX:               infra.ImageXClient,
+		CPStore:              infra.CPStore,
+		TTS:                  infra.TTS,
+		STT:                  infra.STT,
+		CancelProducer:       p.basicServices.eventbus.chatCancelProducer,
+		AsyncRunProducer:     p.basicServices.eventbus.asyncRunProducer,
 		SingleAgentDomainSVC: singleAgentSVC.DomainSVC,
 	}
 }
@@ -0,0 +1,12 @@
+-- Create "checkpoint" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`checkpoint` (
+    `id` varchar(128) NOT NULL COMMENT "checkpoint ID",
+    `data` longblob NULL COMMENT "序列化的中断现场",
+    `expired_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "过期时间, 0 表示不过期",
+    `created_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "创建时间",
+    `updated_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "更新时间",
+    PRIMARY KEY (`id`),
+    INDEX `idx_expired_at` (`expired_at`)
+) ENGINE = InnoDB
+DEFAULT CHARSET = utf8mb4
+COLLATE utf8mb4_unicode_ci COMMENT "智能体与工作流中断的执行现场";
@@ -62,8 +62,28 @@ CREATE TABLE IF NOT EXISTS `airi_go`.`run_record` (
     `completed_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "结束时间",
     `chat_request` text NULL COMMENT "保存原始请求的部分字段" COLLATE utf8mb4_general_ci,
     `ext` text NULL COMMENT "扩展字段" COLLATE utf8mb4_general_ci,
+    `checkpoint_id` varchar(128) NOT NULL DEFAULT "" COMMENT "中断时保存执行现场的 checkpoint ID",
+    `parent_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "所在分支上的前一个运行 ID",
+    `branch_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "分支组 ID，同一位置的各分支相同",
+    `branch_selected` tinyint(1) NOT NULL DEFAULT 1 COMMENT "是否为分支组中选中的分支",
+    `branch_active` tinyint(1) NOT NULL DEFAULT 1 COMMENT "是否在当前展示的分支路径上",
     PRIMARY KEY (`id`),
-    INDEX `idx_c_s` (`conversation_id`, `section_id`)
+    INDEX `idx_c_s` (`conversation_id`, `section_id`),
+    INDEX `idx_branch_id` (`branch_id`)
 ) ENGINE = InnoDB
 DEFAULT CHARSET = utf8mb4
 COLLATE utf8mb4_unicode_ci COMMENT "执行记录表";
+
+-- Create "history_summary" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`history_summary` (
+    `section_id` bigint unsigned NOT NULL COMMENT "section ID",
+    `conversation_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "会话 ID",
+    `summary` mediumtext NULL COMMENT "历史对话摘要" COLLATE utf8mb4_general_ci,
+    `last_run_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "摘要覆盖到的最后一个运行 ID",
+    `created_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "创建时间",
+    `updated_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "更新时间",
+    PRIMARY KEY (`section_id`),
+    INDEX `idx_conversation_id` (`conversation_id`)
+) ENGINE = InnoDB
+DEFAULT CHARSET = utf8mb4
+COLLATE utf8mb4_unicode_ci COMMENT "历史对话滚动摘要表";
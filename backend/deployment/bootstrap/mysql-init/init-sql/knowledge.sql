@@ -0,0 +1,55 @@
+-- Create "knowledge" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`knowledge` (
+    `id` bigint unsigned NOT NULL COMMENT "主键ID",
+    `name` varchar(150) NOT NULL DEFAULT "" COMMENT "名称",
+    `description` text NULL COMMENT "描述",
+    `creator_id` bigint NOT NULL DEFAULT 0 COMMENT "创建者ID",
+    `status` tinyint NOT NULL DEFAULT 1 COMMENT "状态,0无效,1有效",
+    `chunk_strategy` json NULL COMMENT "切片规则",
+    `created_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Create Time in Milliseconds",
+    `updated_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Update Time in Milliseconds",
+    `deleted_at` datetime(3) NULL COMMENT "Delete Time",
+    PRIMARY KEY (`id`),
+    INDEX `idx_creator_id` (`creator_id`)
+) ENGINE = InnoDB
+DEFAULT CHARSET utf8mb4
+COLLATE utf8mb4_general_ci COMMENT "知识库表";
+
+-- Create "knowledge_document" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`knowledge_document` (
+    `id` bigint unsigned NOT NULL COMMENT "主键ID",
+    `knowledge_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "所属知识库ID",
+    `name` varchar(255) NOT NULL DEFAULT "" COMMENT "文档名称",
+    `file_extension` varchar(20) NOT NULL DEFAULT "" COMMENT "文档类型, txt/md/csv/json",
+    `uri` text NULL COMMENT "资源 URI，直接写入文本时为空",
+    `size` bigint unsigned NOT NULL DEFAULT 0 COMMENT "文档大小，字节",
+    `slice_count` bigint unsigned NOT NULL DEFAULT 0 COMMENT "切片数量",
+    `char_count` bigint unsigned NOT NULL DEFAULT 0 COMMENT "字符数",
+    `status` tinyint NOT NULL DEFAULT 0 COMMENT "状态,0处理中,1可用,2失败",
+    `fail_reason` text NULL COMMENT "失败原因",
+    `creator_id` bigint NOT NULL DEFAULT 0 COMMENT "创建者ID",
+    `created_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Create Time in Milliseconds",
+    `updated_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Update Time in Milliseconds",
+    `deleted_at` datetime(3) NULL COMMENT "Delete Time",
+    PRIMARY KEY (`id`),
+    INDEX `idx_knowledge_id` (`knowledge_id`)
+) ENGINE = InnoDB
+DEFAULT CHARSET utf8mb4
+COLLATE utf8mb4_general_ci COMMENT "知识库文档表";
+
+-- Create "knowledge_document_slice" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`knowledge_document_slice` (
+    `id` bigint unsigned NOT NULL COMMENT "主键ID",
+    `knowledge_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "所属知识库ID",
+    `document_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "所属文档ID",
+    `sequence` int NOT NULL DEFAULT 0 COMMENT "切片在文档中的序号",
+    `content` mediumtext NULL COMMENT "切片内容",
+    `created_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Create Time in Milliseconds",
+    `updated_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Update Time in Milliseconds",
+    `deleted_at` datetime(3) NULL COMMENT "Delete Time",
+    PRIMARY KEY (`id`),
+    INDEX `idx_document_id_sequence` (`document_id`, `sequence`),
+    INDEX `idx_knowledge_id` (`knowledge_id`)
+) ENGINE = InnoDB
+DEFAULT CHARSET utf8mb4
+COLLATE utf8mb4_general_ci COMMENT "知识库文档切片表";
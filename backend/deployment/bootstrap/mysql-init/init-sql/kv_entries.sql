@@ -0,0 +1,11 @@
+-- Create "kv_entries" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`kv_entries` (
+    `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT "主键ID",
+    `namespace` varchar(255) NOT NULL COMMENT "命名空间",
+    `key_data` varchar(255) NOT NULL COMMENT "键",
+    `value_data` longblob NULL COMMENT "值，JSON 序列化",
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `uniq_namespace_key` (`namespace`, `key_data`)
+) ENGINE = InnoDB
+DEFAULT CHARSET utf8mb4
+COLLATE utf8mb4_general_ci COMMENT "通用 kv 存储";
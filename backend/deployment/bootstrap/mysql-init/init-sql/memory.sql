@@ -0,0 +1,19 @@
+-- Create "agent_memory" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`agent_memory` (
+    `id` bigint unsigned NOT NULL COMMENT "主键ID",
+    `agent_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "智能体ID",
+    `user_id` varchar(128) NOT NULL DEFAULT "" COMMENT "用户ID",
+    `conversation_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "来源会话ID",
+    `source_run_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "来源运行ID",
+    `kind` tinyint unsigned NOT NULL DEFAULT 1 COMMENT "记忆类型 1:事实 2:事件",
+    `content` text NULL COMMENT "记忆内容",
+    `importance` int NOT NULL DEFAULT 5 COMMENT "重要程度 1~10",
+    `recalled_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Last Recall Time in Milliseconds",
+    `created_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Create Time in Milliseconds",
+    `updated_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Update Time in Milliseconds",
+    `deleted_at` datetime(3) NULL COMMENT "Delete Time",
+    PRIMARY KEY (`id`),
+    INDEX `idx_agent_id_user_id` (`agent_id`, `user_id`)
+) ENGINE = InnoDB
+DEFAULT CHARSET utf8mb4
+COLLATE utf8mb4_general_ci COMMENT "智能体长期记忆表";
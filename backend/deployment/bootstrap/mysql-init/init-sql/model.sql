@@ -52,13 +52,20 @@ CREATE TABLE IF NOT EXISTS `airi_go`.`model_request_record`
     `model_name`            varchar(1024)   NOT NULL DEFAULT '' COMMENT '模型展示名称',
     `input_token`           bigint unsigned NOT NULL DEFAULT '0' COMMENT '输入token数量',
     `output_token`          bigint unsigned NOT NULL DEFAULT '0' COMMENT '输出token数量',
+    `reasoning_token`       bigint unsigned NOT NULL DEFAULT '0' COMMENT '推理token数量，包含在输出token中',
+    `agent_id`              bigint unsigned NOT NULL DEFAULT '0' COMMENT 'agent id',
+    `conversation_id`       bigint unsigned NOT NULL DEFAULT '0' COMMENT 'conversation id',
+    `latency_ms`            bigint unsigned NOT NULL DEFAULT '0' COMMENT '调用耗时，单位毫秒',
+    `cost`                  decimal(20, 8)  NOT NULL DEFAULT '0' COMMENT '按模型价格计算的费用',
     `logid`                 varchar(128)    NOT NULL DEFAULT '' COMMENT 'logid',
     `error_code`            varchar(128)    NOT NULL DEFAULT '' COMMENT 'error_code',
     `error_msg`             text COLLATE utf8mb4_general_ci COMMENT 'error_msg',
     `created_at`            datetime        NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `updated_at`            datetime        NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
     PRIMARY KEY (`id`),
-    KEY `idx_create_time` (`created_at`) USING BTREE COMMENT 'create_time'
+    KEY `idx_create_time` (`created_at`) USING BTREE COMMENT 'create_time',
+    KEY `idx_user_create_time` (`user_id`, `created_at`) COMMENT 'user_id, create_time',
+    KEY `idx_agent_create_time` (`agent_id`, `created_at`) COMMENT 'agent_id, create_time'
 ) ENGINE = InnoDB
 DEFAULT CHARSET = utf8mb4
 COLLATE = utf8mb4_general_ci COMMENT ='模型流量记录表';
@@ -23,6 +23,8 @@ CREATE TABLE IF NOT EXISTS `airi_go`.`single_agent_draft` (
     `bot_mode` tinyint NOT NULL DEFAULT 0 COMMENT 'bot mode,0:single mode 2:chatflow mode',
     `layout_info` text NULL COMMENT 'chatflow layout info',
     `shortcut_command` json NULL COMMENT 'shortcut command',
+    `avatar_info` json NULL COMMENT 'avatar cue configuration',
+    `voices_info` json NULL COMMENT 'voices configuration',
     PRIMARY KEY (`id`),
     UNIQUE INDEX `uniq_agent_id` (`agent_id`)
 ) ENGINE=InnoDB CHARSET utf8mb4
@@ -71,6 +73,8 @@ CREATE TABLE IF NOT EXISTS `airi_go`.`single_agent_version` (
     `background_image_info_list` json NULL COMMENT 'Background image',
     `database_config` json NULL COMMENT 'Agent Database Base Configuration',
     `shortcut_command` json NULL COMMENT 'shortcut command',
+    `avatar_info` json NULL COMMENT 'avatar cue configuration',
+    `voices_info` json NULL COMMENT 'voices configuration',
     PRIMARY KEY (`id`),
     UNIQUE INDEX `uniq_agent_id_and_version_id` (`agent_id`, `version`)
 ) ENGINE=InnoDB CHARSET utf8mb4
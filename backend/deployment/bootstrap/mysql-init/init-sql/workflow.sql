@@ -0,0 +1,34 @@
+-- Create "workflow" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`workflow` (
+    `id` bigint unsigned NOT NULL COMMENT "主键ID",
+    `name` varchar(64) NOT NULL DEFAULT "" COMMENT "名称，同时作为 Agent 调用时的工具名",
+    `description` text NULL COMMENT "描述",
+    `icon_uri` varchar(255) NOT NULL DEFAULT "" COMMENT "图标 URI",
+    `mode` tinyint NOT NULL DEFAULT 0 COMMENT "类型,0工作流,3对话流",
+    `canvas` json NULL COMMENT "草稿画布",
+    `latest_version` varchar(50) NOT NULL DEFAULT "" COMMENT "最新发布的版本，为空表示未发布",
+    `creator_id` bigint NOT NULL DEFAULT 0 COMMENT "创建者ID",
+    `created_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Create Time in Milliseconds",
+    `updated_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Update Time in Milliseconds",
+    `deleted_at` datetime(3) NULL COMMENT "Delete Time",
+    PRIMARY KEY (`id`),
+    INDEX `idx_creator_id` (`creator_id`)
+) ENGINE = InnoDB
+DEFAULT CHARSET utf8mb4
+COLLATE utf8mb4_general_ci COMMENT "工作流草稿表";
+
+-- Create "workflow_version" table
+CREATE TABLE IF NOT EXISTS `airi_go`.`workflow_version` (
+    `id` bigint unsigned NOT NULL COMMENT "主键ID",
+    `workflow_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT "工作流ID",
+    `version` varchar(50) NOT NULL DEFAULT "" COMMENT "版本号",
+    `version_description` text NULL COMMENT "版本描述",
+    `canvas` json NULL COMMENT "发布时的画布快照",
+    `creator_id` bigint NOT NULL DEFAULT 0 COMMENT "发布者ID",
+    `created_at` bigint unsigned NOT NULL DEFAULT 0 COMMENT "Create Time in Milliseconds",
+    `deleted_at` datetime(3) NULL COMMENT "Delete Time",
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `uniq_workflow_id_version` (`workflow_id`, `version`)
+) ENGINE = InnoDB
+DEFAULT CHARSET utf8mb4
+COLLATE utf8mb4_general_ci COMMENT "工作流版本表";
@@ -0,0 +1,13 @@
+package embedding
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=mocks/embedding.go -package=mocks . Embedder
+type Embedder interface {
+	// EmbedStrings 将文本批量转换为稠密向量，返回顺序与入参一致
+	EmbedStrings(ctx context.Context, texts []string) ([][]float64, error)
+	// Dimensions 向量维度，未知时返回 0
+	Dimensions() int64
+}
@@ -55,6 +55,8 @@ type Result struct {
 	Allowed   bool
 	OriginKey string
 	LimitKey  string
+	// RetryAfter is the time until the current window resets, only set when not allowed.
+	RetryAfter time.Duration
 }
 
 type Rule struct {
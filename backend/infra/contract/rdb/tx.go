@@ -0,0 +1,20 @@
+package rdb
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txCtxKey struct{}
+
+// WithTx 将事务绑定到 context，跨仓储的多次写入通过 TxFromCtx 使用同一个事务
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txCtxKey{}, tx)
+}
+
+// TxFromCtx 返回 context 中绑定的事务
+func TxFromCtx(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txCtxKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
@@ -32,6 +32,15 @@ const (
 	OperationTypeUnknown  OperationType = "UNKNOWN"
 )
 
+// InsertSource represents where an INSERT statement takes its rows from
+type InsertSource string
+
+const (
+	InsertSourceValues InsertSource = "VALUES"
+	InsertSourceSet    InsertSource = "SET"
+	InsertSourceSelect InsertSource = "SELECT"
+)
+
 type SQLFilterOp string
 
 const (
@@ -61,4 +70,20 @@ type SQLParser interface {
 
 	// AddSelectFieldsToSelectSQL add select fields to select sql
 	AddSelectFieldsToSelectSQL(origSQL string, cols []string) (string, error)
+
+	// GetTableNames extracts every table referenced by a SQL statement, including joins and subqueries.
+	// A table referenced more than once appears once per reference. Schema-qualified names are returned as "schema.table".
+	GetTableNames(sql string) ([]string, error)
+
+	// GetColumnNames extracts every column name referenced by a SQL statement, e.g. select fields, conditions, insert columns and update assignments.
+	GetColumnNames(sql string) ([]string, error)
+
+	// GetParamMarkerNums counts the '?' parameter markers in a SQL statement.
+	GetParamMarkerNums(sql string) (int, error)
+
+	// GetInsertSource identifies where an INSERT statement takes its rows from: VALUES, SET, or a query (INSERT ... SELECT / INSERT ... TABLE).
+	GetInsertSource(sql string) (InsertSource, error)
+
+	// GetSelectIntoNums counts the SELECT ... INTO clauses (OUTFILE, DUMPFILE or variables) in a SQL statement, including subqueries and unions.
+	GetSelectIntoNums(sql string) (int, error)
 }
@@ -0,0 +1,24 @@
+package stt
+
+import (
+	"context"
+)
+
+type Transcriber interface {
+	// Transcribe 将一段语音识别为文本
+	Transcribe(ctx context.Context, req *TranscribeRequest) (*Transcript, error)
+}
+
+type TranscribeRequest struct {
+	Data []byte
+	// FileName 带扩展名的文件名，部分服务据此识别音频格式
+	FileName string
+	// Language 如 zh，为空时由服务自动识别
+	Language string
+}
+
+type Transcript struct {
+	Text string
+	// Language 服务识别出的语言，服务未返回时为空
+	Language string
+}
@@ -0,0 +1,25 @@
+package tts
+
+import (
+	"context"
+)
+
+type Synthesizer interface {
+	// Synthesize 将一段文本合成为语音
+	Synthesize(ctx context.Context, req *SynthesizeRequest) (*Audio, error)
+}
+
+type SynthesizeRequest struct {
+	Text string
+	// Voice 为空时使用服务配置的默认音色
+	Voice string
+	// Language 如 zh-CN，部分服务据此选择发音
+	Language string
+}
+
+type Audio struct {
+	Data        []byte
+	ContentType string
+	// Format 音频格式，同时作为存储时的文件扩展名，如 mp3
+	Format string
+}
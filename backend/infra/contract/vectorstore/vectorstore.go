@@ -0,0 +1,43 @@
+package vectorstore
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=mocks/vectorstore.go -package=mocks . VectorStore
+type VectorStore interface {
+	// Upsert 写入或覆盖文档，collection 不存在时自动创建
+	Upsert(ctx context.Context, collection string, docs []*Document) error
+	// Delete 按文档 ID 删除
+	Delete(ctx context.Context, collection string, ids []string) error
+	// DropCollection 删除整个 collection
+	DropCollection(ctx context.Context, collection string) error
+
+	// SearchVector 语义检索，Score 为余弦相似度
+	SearchVector(ctx context.Context, req *SearchRequest) ([]*SearchResult, error)
+	// SearchText 全文检索，Score 为 BM25 分数
+	SearchText(ctx context.Context, req *SearchRequest) ([]*SearchResult, error)
+}
+
+type Document struct {
+	ID      string
+	Content string
+	Vector  []float64
+	// Fields 附加字段，可用于过滤
+	Fields map[string]string
+}
+
+type SearchRequest struct {
+	Collections []string
+	QueryVector []float64
+	QueryText   string
+	TopK        int
+	// Filter 字段过滤，同一字段内取并集，不同字段取交集
+	Filter map[string][]string
+}
+
+type SearchResult struct {
+	Collection string
+	Document   *Document
+	Score      float64
+}
@@ -42,6 +42,11 @@ type CacheValue struct {
 	TTL      time.Duration
 }
 
+// expired TTL 为 0 时永不过期，与 Redis 一致
+func (v *CacheValue) expired(now time.Time) bool {
+	return v.TTL > 0 && !v.CreateAt.IsZero() && now.After(v.CreateAt.Add(v.TTL))
+}
+
 // RistrettoClient Ristretto v2缓存客户端
 type RistrettoClient struct {
 	cache    *ristretto.Cache[string, *CacheValue]
@@ -85,7 +90,7 @@ func (c *RistrettoClient) expireCleanup() {
 		// 清理Hash数据
 		for key := range c.hashData {
 			if value, found := c.cache.Get(key); found {
-				if !value.CreateAt.IsZero() && now.After(value.CreateAt.Add(value.TTL)) {
+				if value.expired(now) {
 					c.cache.Del(key)
 					delete(c.hashData, key)
 				}
@@ -97,7 +102,7 @@ func (c *RistrettoClient) expireCleanup() {
 		// 清理List数据
 		for key := range c.listData {
 			if value, found := c.cache.Get(key); found {
-				if !value.CreateAt.IsZero() && now.After(value.CreateAt.Add(value.TTL)) {
+				if value.expired(now) {
 					c.cache.Del(key)
 					delete(c.listData, key)
 				}
@@ -118,7 +123,7 @@ func (c *RistrettoClient) isExpired(key string) bool {
 	}
 
 	now := time.Now()
-	if !value.CreateAt.IsZero() && now.After(value.CreateAt.Add(value.TTL)) {
+	if value.expired(now) {
 		c.cache.Del(key)
 		c.mu.Lock()
 		delete(c.hashData, key)
@@ -468,13 +473,30 @@ func (c *RistrettoClient) Expire(ctx context.Context, key string, ttl time.Durat
 	}
 
 	value.TTL = ttl
+	value.CreateAt = time.Now()
 	c.cache.SetWithTTL(key, value, cost(value), value.TTL)
+	c.cache.Wait()
 
 	return newBoolCmd(true, nil)
 }
 
 // ListCmdable 实现
 
+// setList 列表变更后写回缓存，与 Redis 一致保留原有的过期时间，调用方需持有写锁
+func (c *RistrettoClient) setList(key string) {
+	cacheValue := &CacheValue{
+		Data:     c.listData[key],
+		Type:     CacheTypeList,
+		CreateAt: time.Now(),
+	}
+	if old, found := c.cache.Get(key); found && old.TTL > 0 {
+		cacheValue.CreateAt = old.CreateAt
+		cacheValue.TTL = old.TTL
+	}
+	c.cache.Set(key, cacheValue, cost(cacheValue))
+	c.cache.Wait()
+}
+
 // LIndex 获取列表指定索引的元素
 func (c *RistrettoClient) LIndex(ctx context.Context, key string, index int64) cache.StringCmd {
 	if c.isExpired(key) {
@@ -519,13 +541,7 @@ func (c *RistrettoClient) LPush(ctx context.Context, key string, values ...inter
 	// 在开头插入新元素
 	c.listData[key] = append(newElements, c.listData[key]...)
 
-	// 在缓存中标记为list类型
-	cacheValue := &CacheValue{
-		Data:     c.listData[key],
-		Type:     CacheTypeList,
-		CreateAt: time.Now(),
-	}
-	c.cache.Set(key, cacheValue, cost(cacheValue))
+	c.setList(key)
 
 	return newIntCmd(int64(len(c.listData[key])), nil)
 }
@@ -544,13 +560,7 @@ func (c *RistrettoClient) RPush(ctx context.Context, key string, values ...inter
 		c.listData[key] = append(c.listData[key], fmt.Sprintf("%v", value))
 	}
 
-	// 在缓存中标记为list类型
-	cacheValue := &CacheValue{
-		Data:     c.listData[key],
-		Type:     CacheTypeList,
-		CreateAt: time.Now(),
-	}
-	c.cache.Set(key, cacheValue, cost(cacheValue))
+	c.setList(key)
 
 	return newIntCmd(int64(len(c.listData[key])), nil)
 }
@@ -580,13 +590,7 @@ func (c *RistrettoClient) LSet(ctx context.Context, key string, index int64, val
 
 	c.listData[key][index] = fmt.Sprintf("%v", value)
 
-	// 更新缓存
-	cacheValue := &CacheValue{
-		Data:     c.listData[key],
-		Type:     CacheTypeList,
-		CreateAt: time.Now(),
-	}
-	c.cache.Set(key, cacheValue, cost(cacheValue))
+	c.setList(key)
 
 	return newStatusCmd("OK", nil)
 }
@@ -614,13 +618,7 @@ func (c *RistrettoClient) LPop(ctx context.Context, key string) cache.StringCmd
 		delete(c.listData, key)
 		c.cache.Del(key)
 	} else {
-		// 更新缓存
-		cacheValue := &CacheValue{
-			Data:     c.listData[key],
-			Type:     CacheTypeList,
-			CreateAt: time.Now(),
-		}
-		c.cache.Set(key, cacheValue, cost(cacheValue))
+		c.setList(key)
 	}
 
 	return newStringCmd(value, nil)
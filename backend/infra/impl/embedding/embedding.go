@@ -0,0 +1,139 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/embedding"
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model/config"
+)
+
+const (
+	defaultMaxBatchSize = 100
+	defaultTimeout      = 60 * time.Second
+)
+
+// New 根据知识库的 embedding 配置构建向量化组件
+func New(ctx context.Context, conf *config.EmbeddingConfig) (contract.Embedder, error) {
+	if conf == nil || conf.Connection == nil {
+		return nil, fmt.Errorf("[embedding] config is empty")
+	}
+
+	batchSize := int(conf.MaxBatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultMaxBatchSize
+	}
+
+	var dims int64
+	if conf.Connection.EmbeddingInfo != nil {
+		dims = int64(conf.Connection.EmbeddingInfo.Dims)
+	}
+
+	base := conf.Connection.BaseConnInfo
+	if base == nil {
+		base = &config.BaseConnectionInfo{}
+	}
+
+	var embedder batchEmbedder
+	switch conf.Type {
+	case config.EmbeddingType_OpenAI, config.EmbeddingType_Ark:
+		embedder = newOpenAIEmbedder(base, conf.Connection.Openai, dims)
+	case config.EmbeddingType_Ollama:
+		embedder = newOllamaEmbedder(base)
+	case config.EmbeddingType_HTTP:
+		if conf.Connection.HTTP == nil || conf.Connection.HTTP.Address == "" {
+			return nil, fmt.Errorf("[embedding] http address is empty")
+		}
+		embedder = newHTTPEmbedder(conf.Connection.HTTP.Address)
+	default:
+		return nil, fmt.Errorf("[embedding] unsupported embedding type: %s", conf.Type)
+	}
+
+	return &batchedEmbedder{
+		embedder:  embedder,
+		batchSize: batchSize,
+		dims:      dims,
+	}, nil
+}
+
+// batchEmbedder 单次请求的向量化实现，由 batchedEmbedder 负责按 MaxBatchSize 切分
+type batchEmbedder interface {
+	embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+type batchedEmbedder struct {
+	embedder  batchEmbedder
+	batchSize int
+	dims      int64
+}
+
+func (b *batchedEmbedder) EmbedStrings(ctx context.Context, texts []string) ([][]float64, error) {
+	result := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += b.batchSize {
+		end := min(start+b.batchSize, len(texts))
+		vectors, err := b.embedder.embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != end-start {
+			return nil, fmt.Errorf("[embedding] expect %d vectors, got %d", end-start, len(vectors))
+		}
+		result = append(result, vectors...)
+	}
+	return result, nil
+}
+
+func (b *batchedEmbedder) Dimensions() int64 {
+	return b.dims
+}
+
+var httpClient = &http.Client{Timeout: defaultTimeout}
+
+func postJSON(ctx context.Context, url string, headers map[string]string, reqBody, respBody any) error {
+	body, err := sonic.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("[embedding] request %s failed, status=%d, body=%s", url, resp.StatusCode, truncate(string(data), 512))
+	}
+
+	return sonic.Unmarshal(data, respBody)
+}
+
+func joinURL(baseURL, path string) string {
+	return strings.TrimRight(baseURL, "/") + path
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,32 @@
+package embedding
+
+import (
+	"context"
+)
+
+// httpEmbedder 对接自建的向量化服务
+// 请求: POST {address}  {"texts": ["..."]}
+// 响应: {"embeddings": [[0.1, ...]]}
+type httpEmbedder struct {
+	address string
+}
+
+func newHTTPEmbedder(address string) *httpEmbedder {
+	return &httpEmbedder{address: address}
+}
+
+type httpEmbedRequest struct {
+	Texts []string `json:"texts"`
+}
+
+type httpEmbedResponse struct {
+	Embeddings [][]float64 `json:"embeddings"`
+}
+
+func (e *httpEmbedder) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	resp := &httpEmbedResponse{}
+	if err := postJSON(ctx, e.address, nil, &httpEmbedRequest{Texts: texts}, resp); err != nil {
+		return nil, err
+	}
+	return resp.Embeddings, nil
+}
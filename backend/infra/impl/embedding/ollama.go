@@ -0,0 +1,44 @@
+package embedding
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model/config"
+)
+
+type ollamaEmbedder struct {
+	baseURL string
+	model   string
+}
+
+func newOllamaEmbedder(base *config.BaseConnectionInfo) *ollamaEmbedder {
+	e := &ollamaEmbedder{
+		baseURL: base.BaseURL,
+		model:   base.Model,
+	}
+	if e.baseURL == "" {
+		e.baseURL = "http://127.0.0.1:11434"
+	}
+	return e
+}
+
+type ollamaEmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type ollamaEmbedResponse struct {
+	Embeddings [][]float64 `json:"embeddings"`
+}
+
+func (e *ollamaEmbedder) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	resp := &ollamaEmbedResponse{}
+	err := postJSON(ctx, joinURL(e.baseURL, "/api/embed"), nil, &ollamaEmbedRequest{
+		Model: e.model,
+		Input: texts,
+	}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Embeddings, nil
+}
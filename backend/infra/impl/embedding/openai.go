@@ -0,0 +1,91 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model/config"
+)
+
+const defaultAzureAPIVersion = "2024-02-01"
+
+// openaiEmbedder 兼容 OpenAI /embeddings 协议，方舟（Ark）同样走该协议
+type openaiEmbedder struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	dims       int64
+	byAzure    bool
+	apiVersion string
+}
+
+func newOpenAIEmbedder(base *config.BaseConnectionInfo, oc *config.OpenAIConnInfo, dims int64) *openaiEmbedder {
+	e := &openaiEmbedder{
+		baseURL: base.BaseURL,
+		apiKey:  base.APIKey,
+		model:   base.Model,
+		dims:    dims,
+	}
+	if e.baseURL == "" {
+		e.baseURL = "https://api.openai.com/v1"
+	}
+	if oc != nil {
+		e.byAzure = oc.ByAzure
+		e.apiVersion = oc.APIVersion
+	}
+	return e
+}
+
+type openaiEmbeddingRequest struct {
+	Input          []string `json:"input"`
+	Model          string   `json:"model"`
+	EncodingFormat string   `json:"encoding_format"`
+	Dimensions     *int64   `json:"dimensions,omitempty"`
+}
+
+type openaiEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (e *openaiEmbedder) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	req := &openaiEmbeddingRequest{
+		Input:          texts,
+		Model:          e.model,
+		EncodingFormat: "float",
+	}
+
+	headers := map[string]string{}
+	url := joinURL(e.baseURL, "/embeddings")
+	if e.byAzure {
+		apiVersion := e.apiVersion
+		if apiVersion == "" {
+			apiVersion = defaultAzureAPIVersion
+		}
+		url = fmt.Sprintf("%s/openai/deployments/%s/embeddings?api-version=%s", e.baseURL, e.model, apiVersion)
+		headers["api-key"] = e.apiKey
+	} else {
+		headers["Authorization"] = "Bearer " + e.apiKey
+		if e.dims > 0 {
+			req.Dimensions = &e.dims
+		}
+	}
+
+	resp := &openaiEmbeddingResponse{}
+	if err := postJSON(ctx, url, headers, req, resp); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(resp.Data, func(i, j int) bool {
+		return resp.Data[i].Index < resp.Data[j].Index
+	})
+
+	vectors := make([][]float64, 0, len(resp.Data))
+	for _, d := range resp.Data {
+		vectors = append(vectors, d.Embedding)
+	}
+	return vectors, nil
+}
@@ -89,3 +89,23 @@ func InitKnowledgeEventBusProducer() (eventbus.Producer, error) {
 
 	return knowledgeProducer, nil
 }
+
+func InitChatCancelProducer() (eventbus.Producer, error) {
+	nameServer := os.Getenv(consts.MQServer)
+	chatCancelProducer, err := NewProducer(nameServer, consts.RMQTopicChatCancel, consts.RMQConsumeGroupChatCancel, 1)
+	if err != nil {
+		return nil, fmt.Errorf("init chat cancel producer failed, err=%w", err)
+	}
+
+	return chatCancelProducer, nil
+}
+
+func InitChatAsyncRunProducer() (eventbus.Producer, error) {
+	nameServer := os.Getenv(consts.MQServer)
+	asyncRunProducer, err := NewProducer(nameServer, consts.RMQTopicChatAsyncRun, consts.RMQConsumeGroupAsyncRun, 1)
+	if err != nil {
+		return nil, fmt.Errorf("init chat async run producer failed, err=%w", err)
+	}
+
+	return asyncRunProducer, nil
+}
@@ -1,6 +1,7 @@
 package gochannel
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ThreeDotsLabs/watermill"
@@ -66,6 +67,11 @@ func RegisterConsumer(nameServer, topic, group string, consumerHandler eventbus.
 		handlerFunc,
 	)
 
+	// 路由器已经启动时，新注册的处理函数需要单独启动
+	if router.IsRunning() {
+		return router.RunHandlers(context.Background())
+	}
+
 	return nil
 }
 
@@ -108,7 +108,9 @@ func (rl *rateLimiter) AllowN(ctx context.Context, key string, n int, opts ...li
 
 	// The core logic of the fixed-window rate limiter.
 	window := limit.Period
-	windowKey := fmt.Sprintf("%s:%d", limitKey, time.Now().UnixNano()/window.Nanoseconds())
+	now := time.Now().UnixNano()
+	windowIdx := now / window.Nanoseconds()
+	windowKey := fmt.Sprintf("%s:%d", limitKey, windowIdx)
 
 	res := rl.store.IncrBy(ctx, windowKey, int64(n))
 	count, err := res.Result()
@@ -124,10 +126,16 @@ func (rl *rateLimiter) AllowN(ctx context.Context, key string, n int, opts ...li
 
 	allowed := count <= int64(limit.Burst)
 
+	var retryAfter time.Duration
+	if !allowed {
+		retryAfter = time.Duration((windowIdx+1)*window.Nanoseconds() - now)
+	}
+
 	return &limiter.Result{
-		Allowed:   allowed,
-		OriginKey: key,
-		LimitKey:  limitKey,
+		Allowed:    allowed,
+		OriginKey:  key,
+		LimitKey:   limitKey,
+		RetryAfter: retryAfter,
 	}, nil
 }
 
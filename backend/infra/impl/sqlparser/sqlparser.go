@@ -455,6 +455,9 @@ func mergeExpr(left, right ast.ExprNode, op sqlparser.SQLFilterOp) ast.ExprNode
 	if right == nil {
 		return left
 	}
+	// wrap both sides to keep their precedence, otherwise "a OR b" AND filter becomes a OR (b AND filter)
+	left = &ast.ParenthesesExpr{Expr: left}
+	right = &ast.ParenthesesExpr{Expr: right}
 
 	switch op {
 	case sqlparser.SQLFilterOpAnd:
@@ -542,3 +545,115 @@ func isSelectAll(stmt *ast.SelectStmt) bool {
 	}
 	return false
 }
+
+// GetTableNames implements the SQLParser interface
+func (p *Impl) GetTableNames(sql string) ([]string, error) {
+	if sql == "" {
+		return nil, fmt.Errorf("empty SQL statement")
+	}
+	stmt, err := p.parser.ParseOneStmt(sql, mysql.UTF8MB4Charset, mysql.UTF8MB4GeneralCICollation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SQL: %v", err)
+	}
+
+	visitor := &nameCollector{}
+	stmt.Accept(visitor)
+	return visitor.tables, nil
+}
+
+// GetColumnNames implements the SQLParser interface
+func (p *Impl) GetColumnNames(sql string) ([]string, error) {
+	if sql == "" {
+		return nil, fmt.Errorf("empty SQL statement")
+	}
+	stmt, err := p.parser.ParseOneStmt(sql, mysql.UTF8MB4Charset, mysql.UTF8MB4GeneralCICollation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SQL: %v", err)
+	}
+
+	visitor := &nameCollector{}
+	stmt.Accept(visitor)
+	return visitor.columns, nil
+}
+
+// GetParamMarkerNums implements the SQLParser interface
+func (p *Impl) GetParamMarkerNums(sql string) (int, error) {
+	if sql == "" {
+		return 0, fmt.Errorf("empty SQL statement")
+	}
+	stmt, err := p.parser.ParseOneStmt(sql, mysql.UTF8MB4Charset, mysql.UTF8MB4GeneralCICollation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse SQL: %v", err)
+	}
+
+	visitor := &nameCollector{}
+	stmt.Accept(visitor)
+	return visitor.paramMarkers, nil
+}
+
+func (p *Impl) GetInsertSource(sql string) (sqlparser.InsertSource, error) {
+	stmt, err := p.parser.ParseOneStmt(sql, mysql.UTF8MB4Charset, mysql.UTF8MB4GeneralCICollation)
+	if err != nil {
+		return "", err
+	}
+
+	insert, ok := stmt.(*ast.InsertStmt)
+	if !ok {
+		return "", fmt.Errorf("not an insert statement")
+	}
+
+	switch {
+	case insert.Select != nil:
+		return sqlparser.InsertSourceSelect, nil
+	case insert.Setlist:
+		return sqlparser.InsertSourceSet, nil
+	default:
+		return sqlparser.InsertSourceValues, nil
+	}
+}
+
+func (p *Impl) GetSelectIntoNums(sql string) (int, error) {
+	if sql == "" {
+		return 0, fmt.Errorf("empty SQL statement")
+	}
+	stmt, err := p.parser.ParseOneStmt(sql, mysql.UTF8MB4Charset, mysql.UTF8MB4GeneralCICollation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse SQL: %v", err)
+	}
+
+	visitor := &nameCollector{}
+	stmt.Accept(visitor)
+	return visitor.selectIntos, nil
+}
+
+// nameCollector collects table and column names referenced in the AST
+type nameCollector struct {
+	tables       []string
+	columns      []string
+	paramMarkers int
+	selectIntos  int
+}
+
+func (c *nameCollector) Enter(n ast.Node) (ast.Node, bool) {
+	switch node := n.(type) {
+	case *ast.TableName:
+		if node.Schema.O != "" {
+			c.tables = append(c.tables, node.Schema.O+"."+node.Name.O)
+		} else {
+			c.tables = append(c.tables, node.Name.O)
+		}
+	case *ast.ColumnName:
+		c.columns = append(c.columns, node.Name.O)
+	case ast.ParamMarkerExpr:
+		c.paramMarkers++
+	case *ast.SelectStmt:
+		if node.SelectIntoOpt != nil {
+			c.selectIntos++
+		}
+	}
+	return n, false
+}
+
+func (c *nameCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
@@ -0,0 +1,49 @@
+package stt
+
+import (
+	"context"
+
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/stt"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "whisper-1"
+)
+
+// openaiTranscriber 兼容 OpenAI /audio/transcriptions 协议
+type openaiTranscriber struct {
+	baseURL  string
+	apiKey   string
+	model    string
+	language string
+}
+
+func newOpenAITranscriber(conf *Config) *openaiTranscriber {
+	t := &openaiTranscriber{
+		baseURL:  conf.BaseURL,
+		apiKey:   conf.APIKey,
+		model:    conf.Model,
+		language: conf.Language,
+	}
+	if t.baseURL == "" {
+		t.baseURL = defaultOpenAIBaseURL
+	}
+	if t.model == "" {
+		t.model = defaultOpenAIModel
+	}
+	return t
+}
+
+func (t *openaiTranscriber) Transcribe(ctx context.Context, req *contract.TranscribeRequest) (*contract.Transcript, error) {
+	headers := map[string]string{}
+	if t.apiKey != "" {
+		headers["Authorization"] = "Bearer " + t.apiKey
+	}
+
+	return postForTranscript(ctx, joinURL(t.baseURL, "/audio/transcriptions"), headers, map[string]string{
+		"model":           t.model,
+		"language":        languageOf(req, t.language),
+		"response_format": "json",
+	}, req)
+}
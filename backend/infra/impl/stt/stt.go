@@ -0,0 +1,145 @@
+package stt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/stt"
+)
+
+const (
+	TypeOpenAI  = "openai"
+	TypeWhisper = "whisper"
+	TypeStub    = "stub"
+
+	defaultTimeout = 120 * time.Second
+)
+
+type Config struct {
+	// Type openai / whisper / stub，为空时不识别语音
+	Type string
+	// BaseURL openai 为 API 地址，whisper 为 whisper.cpp server 地址
+	BaseURL string
+	APIKey  string
+	Model   string
+	// Language 请求未指定语言时使用的默认语言，为空时由服务自动识别
+	Language string
+}
+
+// New 根据配置构建语音识别组件，未配置时返回 nil
+func New(conf *Config) (contract.Transcriber, error) {
+	if conf == nil || conf.Type == "" {
+		return nil, nil
+	}
+
+	switch strings.ToLower(conf.Type) {
+	case TypeOpenAI:
+		return newOpenAITranscriber(conf), nil
+	case TypeWhisper:
+		if conf.BaseURL == "" {
+			return nil, fmt.Errorf("[stt] whisper server address is empty")
+		}
+		return newWhisperTranscriber(conf), nil
+	case TypeStub:
+		return NewStub(), nil
+	default:
+		return nil, fmt.Errorf("[stt] unsupported stt type: %s", conf.Type)
+	}
+}
+
+var httpClient = &http.Client{Timeout: defaultTimeout}
+
+// transcriptionResponse OpenAI 与 whisper.cpp server 在 response_format=json 时的响应
+type transcriptionResponse struct {
+	Text     string `json:"text"`
+	Language string `json:"language,omitempty"`
+}
+
+// postForTranscript 以 multipart 表单上传音频，file 为音频文件字段
+func postForTranscript(ctx context.Context, url string, headers map[string]string, fields map[string]string, req *contract.TranscribeRequest) (*contract.Transcript, error) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for k, v := range fields {
+		if v == "" {
+			continue
+		}
+		if err := mw.WriteField(k, v); err != nil {
+			return nil, err
+		}
+	}
+	fw, err := mw.CreateFormFile("file", fileNameOf(req))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = fw.Write(req.Data); err != nil {
+		return nil, err
+	}
+	if err = mw.Close(); err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("[stt] request %s failed, status=%d, body=%s", url, resp.StatusCode, truncate(string(data), 512))
+	}
+
+	var tr transcriptionResponse
+	if err = sonic.Unmarshal(data, &tr); err != nil {
+		return nil, fmt.Errorf("[stt] unmarshal response of %s failed, body=%s, err=%w", url, truncate(string(data), 512), err)
+	}
+
+	return &contract.Transcript{
+		Text:     strings.TrimSpace(tr.Text),
+		Language: tr.Language,
+	}, nil
+}
+
+func fileNameOf(req *contract.TranscribeRequest) string {
+	if req.FileName != "" {
+		return req.FileName
+	}
+	return "audio"
+}
+
+func languageOf(req *contract.TranscribeRequest, defaultLanguage string) string {
+	if req.Language != "" {
+		return req.Language
+	}
+	return defaultLanguage
+}
+
+func joinURL(baseURL, path string) string {
+	return strings.TrimRight(baseURL, "/") + path
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
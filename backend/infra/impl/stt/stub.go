@@ -0,0 +1,30 @@
+package stt
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/stt"
+)
+
+const stubTranscript = "这是一段语音消息"
+
+// stubTranscriber 不依赖外部服务，用于测试和本地联调。
+// 上传的内容本身是 UTF-8 文本时原样作为识别结果，便于构造不同的输入；否则返回固定文本
+type stubTranscriber struct{}
+
+func NewStub() contract.Transcriber {
+	return &stubTranscriber{}
+}
+
+func (t *stubTranscriber) Transcribe(ctx context.Context, req *contract.TranscribeRequest) (*contract.Transcript, error) {
+	text := stubTranscript
+	if len(req.Data) > 0 && utf8.Valid(req.Data) && !strings.ContainsRune(string(req.Data), 0) {
+		text = strings.TrimSpace(string(req.Data))
+	}
+	return &contract.Transcript{
+		Text:     text,
+		Language: req.Language,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package stt
+
+import (
+	"context"
+
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/stt"
+)
+
+// whisperTranscriber 对接 whisper.cpp 自带的 HTTP server
+// 请求: POST {address}/inference  multipart 表单，file 为音频文件
+// 响应: {"text": "..."}
+type whisperTranscriber struct {
+	address  string
+	language string
+}
+
+func newWhisperTranscriber(conf *Config) *whisperTranscriber {
+	return &whisperTranscriber{
+		address:  conf.BaseURL,
+		language: conf.Language,
+	}
+}
+
+func (t *whisperTranscriber) Transcribe(ctx context.Context, req *contract.TranscribeRequest) (*contract.Transcript, error) {
+	language := languageOf(req, t.language)
+	if language == "" {
+		// whisper.cpp server 未指定语言时按启动参数处理，auto 才会自动识别
+		language = "auto"
+	}
+
+	return postForTranscript(ctx, joinURL(t.address, "/inference"), nil, map[string]string{
+		"language":        language,
+		"response_format": "json",
+		"temperature":     "0.0",
+	}, req)
+}
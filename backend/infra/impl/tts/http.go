@@ -0,0 +1,57 @@
+package tts
+
+import (
+	"context"
+
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/tts"
+)
+
+// httpSynthesizer 对接自建的语音合成服务
+// 请求: POST {address}  {"text": "...", "voice": "...", "language": "zh-CN", "format": "mp3"}
+// 响应: 音频数据，Content-Type 为音频类型
+type httpSynthesizer struct {
+	address string
+	voice   string
+	format  string
+}
+
+func newHTTPSynthesizer(conf *Config) *httpSynthesizer {
+	return &httpSynthesizer{
+		address: conf.BaseURL,
+		voice:   conf.Voice,
+		format:  conf.Format,
+	}
+}
+
+type httpSynthesizeRequest struct {
+	Text     string `json:"text"`
+	Voice    string `json:"voice,omitempty"`
+	Language string `json:"language,omitempty"`
+	Format   string `json:"format"`
+}
+
+func (s *httpSynthesizer) Synthesize(ctx context.Context, req *contract.SynthesizeRequest) (*contract.Audio, error) {
+	voice := req.Voice
+	if voice == "" {
+		voice = s.voice
+	}
+
+	data, contentType, err := postForAudio(ctx, s.address, nil, &httpSynthesizeRequest{
+		Text:     req.Text,
+		Voice:    voice,
+		Language: req.Language,
+		Format:   s.format,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if contentType == "" {
+		contentType = contentTypeOf(s.format)
+	}
+
+	return &contract.Audio{
+		Data:        data,
+		ContentType: contentType,
+		Format:      s.format,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package tts
+
+import (
+	"context"
+
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/tts"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "tts-1"
+	defaultOpenAIVoice   = "alloy"
+)
+
+// openaiSynthesizer 兼容 OpenAI /audio/speech 协议
+type openaiSynthesizer struct {
+	baseURL string
+	apiKey  string
+	model   string
+	voice   string
+	format  string
+}
+
+func newOpenAISynthesizer(conf *Config) *openaiSynthesizer {
+	s := &openaiSynthesizer{
+		baseURL: conf.BaseURL,
+		apiKey:  conf.APIKey,
+		model:   conf.Model,
+		voice:   conf.Voice,
+		format:  conf.Format,
+	}
+	if s.baseURL == "" {
+		s.baseURL = defaultOpenAIBaseURL
+	}
+	if s.model == "" {
+		s.model = defaultOpenAIModel
+	}
+	if s.voice == "" {
+		s.voice = defaultOpenAIVoice
+	}
+	return s
+}
+
+type openaiSpeechRequest struct {
+	Model          string `json:"model"`
+	Input          string `json:"input"`
+	Voice          string `json:"voice"`
+	ResponseFormat string `json:"response_format"`
+}
+
+func (s *openaiSynthesizer) Synthesize(ctx context.Context, req *contract.SynthesizeRequest) (*contract.Audio, error) {
+	voice := req.Voice
+	if voice == "" {
+		voice = s.voice
+	}
+
+	headers := map[string]string{}
+	if s.apiKey != "" {
+		headers["Authorization"] = "Bearer " + s.apiKey
+	}
+
+	data, _, err := postForAudio(ctx, joinURL(s.baseURL, "/audio/speech"), headers, &openaiSpeechRequest{
+		Model:          s.model,
+		Input:          req.Text,
+		Voice:          voice,
+		ResponseFormat: s.format,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &contract.Audio{
+		Data:        data,
+		ContentType: contentTypeOf(s.format),
+		Format:      s.format,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package tts
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+	"unicode/utf8"
+
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/tts"
+)
+
+const (
+	stubSampleRate  = 8000
+	stubPerRune     = 60 * time.Millisecond
+	stubMaxDuration = 10 * time.Second
+)
+
+// stubSynthesizer 不依赖外部服务，按文本长度生成静音的 wav，用于测试和本地联调
+type stubSynthesizer struct{}
+
+func NewStub() contract.Synthesizer {
+	return &stubSynthesizer{}
+}
+
+func (s *stubSynthesizer) Synthesize(ctx context.Context, req *contract.SynthesizeRequest) (*contract.Audio, error) {
+	duration := min(time.Duration(utf8.RuneCountInString(req.Text))*stubPerRune, stubMaxDuration)
+	return &contract.Audio{
+		Data:        silentWAV(duration),
+		ContentType: contentTypeOf("wav"),
+		Format:      "wav",
+	}, nil
+}
+
+// silentWAV 8kHz、16bit 单声道的静音 wav
+func silentWAV(duration time.Duration) []byte {
+	dataSize := int(duration.Seconds()*stubSampleRate) * 2
+	buf := make([]byte, 44+dataSize)
+
+	copy(buf[0:], "RIFF")
+	binary.LittleEndian.PutUint32(buf[4:], uint32(36+dataSize))
+	copy(buf[8:], "WAVE")
+	copy(buf[12:], "fmt ")
+	binary.LittleEndian.PutUint32(buf[16:], 16)
+	binary.LittleEndian.PutUint16(buf[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(buf[22:], 1) // 单声道
+	binary.LittleEndian.PutUint32(buf[24:], stubSampleRate)
+	binary.LittleEndian.PutUint32(buf[28:], stubSampleRate*2)
+	binary.LittleEndian.PutUint16(buf[32:], 2)
+	binary.LittleEndian.PutUint16(buf[34:], 16)
+	copy(buf[36:], "data")
+	binary.LittleEndian.PutUint32(buf[40:], uint32(dataSize))
+
+	return buf
+}
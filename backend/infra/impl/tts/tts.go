@@ -0,0 +1,125 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	contract "github.com/kiosk404/airi-go/backend/infra/contract/tts"
+)
+
+const (
+	TypeOpenAI = "openai"
+	TypeHTTP   = "http"
+	TypeStub   = "stub"
+
+	defaultFormat  = "mp3"
+	defaultTimeout = 60 * time.Second
+)
+
+type Config struct {
+	// Type openai / http / stub，为空时不合成语音
+	Type string
+	// BaseURL openai 为 API 地址，http 为合成服务地址
+	BaseURL string
+	APIKey  string
+	Model   string
+	// Voice 智能体未配置音色时使用的默认音色
+	Voice string
+	// Format 输出的音频格式，默认 mp3
+	Format string
+}
+
+// New 根据配置构建语音合成组件，未配置时返回 nil
+func New(conf *Config) (contract.Synthesizer, error) {
+	if conf == nil || conf.Type == "" {
+		return nil, nil
+	}
+	if conf.Format == "" {
+		conf.Format = defaultFormat
+	}
+
+	switch strings.ToLower(conf.Type) {
+	case TypeOpenAI:
+		return newOpenAISynthesizer(conf), nil
+	case TypeHTTP:
+		if conf.BaseURL == "" {
+			return nil, fmt.Errorf("[tts] http address is empty")
+		}
+		return newHTTPSynthesizer(conf), nil
+	case TypeStub:
+		return NewStub(), nil
+	default:
+		return nil, fmt.Errorf("[tts] unsupported tts type: %s", conf.Type)
+	}
+}
+
+var contentTypes = map[string]string{
+	"mp3":  "audio/mpeg",
+	"wav":  "audio/wav",
+	"opus": "audio/opus",
+	"aac":  "audio/aac",
+	"flac": "audio/flac",
+	"pcm":  "audio/pcm",
+}
+
+func contentTypeOf(format string) string {
+	if ct, ok := contentTypes[format]; ok {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+var httpClient = &http.Client{Timeout: defaultTimeout}
+
+// postForAudio 发送 JSON 请求，响应体为音频数据
+func postForAudio(ctx context.Context, url string, headers map[string]string, reqBody any) ([]byte, string, error) {
+	body, err := sonic.Marshal(reqBody)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("[tts] request %s failed, status=%d, body=%s", url, resp.StatusCode, truncate(string(data), 512))
+	}
+	if len(data) == 0 {
+		return nil, "", fmt.Errorf("[tts] request %s returned empty audio", url)
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+func joinURL(baseURL, path string) string {
+	return strings.TrimRight(baseURL, "/") + path
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
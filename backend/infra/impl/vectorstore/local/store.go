@@ -0,0 +1,322 @@
+package local
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/vectorstore"
+)
+
+var collectionNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_\-]+$`)
+
+// store 基于本地文件的向量库，数据全量常驻内存，每个 collection 持久化为一个 gob 文件。
+// 适用于单机部署和中小规模知识库。
+type store struct {
+	dir string
+
+	mu          sync.Mutex
+	collections map[string]*collection
+}
+
+type collection struct {
+	mu   sync.RWMutex
+	docs map[string]*vectorstore.Document
+	// tokens 文档分词结果的缓存，不落盘
+	tokens map[string][]string
+}
+
+func New(dir string) (vectorstore.VectorStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("[vectorstore] local dir is empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("[vectorstore] create dir failed, err=%w", err)
+	}
+	return &store{
+		dir:         dir,
+		collections: map[string]*collection{},
+	}, nil
+}
+
+func (s *store) Upsert(ctx context.Context, name string, docs []*vectorstore.Document) error {
+	c, err := s.getCollection(name)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, doc := range docs {
+		c.docs[doc.ID] = doc
+		c.tokens[doc.ID] = tokenize(doc.Content)
+	}
+	return s.persist(name, c)
+}
+
+func (s *store) Delete(ctx context.Context, name string, ids []string) error {
+	c, err := s.getCollection(name)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		delete(c.docs, id)
+		delete(c.tokens, id)
+	}
+	return s.persist(name, c)
+}
+
+func (s *store) DropCollection(ctx context.Context, name string) error {
+	if !collectionNameRegexp.MatchString(name) {
+		return fmt.Errorf("[vectorstore] invalid collection name: %s", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.collections, name)
+	err := os.Remove(s.filePath(name))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *store) SearchVector(ctx context.Context, req *vectorstore.SearchRequest) ([]*vectorstore.SearchResult, error) {
+	if len(req.QueryVector) == 0 {
+		return nil, fmt.Errorf("[vectorstore] query vector is empty")
+	}
+	queryNorm := norm(req.QueryVector)
+	if queryNorm == 0 {
+		return nil, nil
+	}
+
+	var results []*vectorstore.SearchResult
+	for _, name := range req.Collections {
+		c, err := s.getCollection(name)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.RLock()
+		for _, doc := range c.docs {
+			if !matchFilter(doc, req.Filter) || len(doc.Vector) != len(req.QueryVector) {
+				continue
+			}
+			docNorm := norm(doc.Vector)
+			if docNorm == 0 {
+				continue
+			}
+			results = append(results, &vectorstore.SearchResult{
+				Collection: name,
+				Document:   doc,
+				Score:      dot(req.QueryVector, doc.Vector) / (queryNorm * docNorm),
+			})
+		}
+		c.mu.RUnlock()
+	}
+
+	return topK(results, req.TopK), nil
+}
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+func (s *store) SearchText(ctx context.Context, req *vectorstore.SearchRequest) ([]*vectorstore.SearchResult, error) {
+	queryTokens := uniq(tokenize(req.QueryText))
+	if len(queryTokens) == 0 {
+		return nil, nil
+	}
+
+	var results []*vectorstore.SearchResult
+	for _, name := range req.Collections {
+		c, err := s.getCollection(name)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.RLock()
+		results = append(results, c.bm25(name, queryTokens, req.Filter)...)
+		c.mu.RUnlock()
+	}
+
+	return topK(results, req.TopK), nil
+}
+
+// bm25 在单个 collection 内计算 BM25，调用方需持有读锁
+func (c *collection) bm25(name string, queryTokens []string, filter map[string][]string) []*vectorstore.SearchResult {
+	var (
+		candidates []string
+		totalLen   int
+		df         = make(map[string]int, len(queryTokens))
+		tf         = make(map[string]map[string]int)
+	)
+	for id, doc := range c.docs {
+		if !matchFilter(doc, filter) {
+			continue
+		}
+		tokens := c.tokens[id]
+		candidates = append(candidates, id)
+		totalLen += len(tokens)
+
+		counts := map[string]int{}
+		for _, t := range tokens {
+			counts[t]++
+		}
+		for _, q := range queryTokens {
+			if counts[q] > 0 {
+				df[q]++
+			}
+		}
+		tf[id] = counts
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	n := float64(len(candidates))
+	avgLen := float64(totalLen) / n
+	if avgLen == 0 {
+		avgLen = 1
+	}
+
+	var results []*vectorstore.SearchResult
+	for _, id := range candidates {
+		docLen := float64(len(c.tokens[id]))
+		var score float64
+		for _, q := range queryTokens {
+			f := float64(tf[id][q])
+			if f == 0 {
+				continue
+			}
+			idf := math.Log(1 + (n-float64(df[q])+0.5)/(float64(df[q])+0.5))
+			score += idf * f * (bm25K1 + 1) / (f + bm25K1*(1-bm25B+bm25B*docLen/avgLen))
+		}
+		if score <= 0 {
+			continue
+		}
+		results = append(results, &vectorstore.SearchResult{
+			Collection: name,
+			Document:   c.docs[id],
+			Score:      score,
+		})
+	}
+	return results
+}
+
+func (s *store) getCollection(name string) (*collection, error) {
+	if !collectionNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("[vectorstore] invalid collection name: %s", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.collections[name]; ok {
+		return c, nil
+	}
+
+	c, err := s.load(name)
+	if err != nil {
+		return nil, err
+	}
+	s.collections[name] = c
+	return c, nil
+}
+
+func (s *store) filePath(name string) string {
+	return filepath.Join(s.dir, name+".gob")
+}
+
+func (s *store) load(name string) (*collection, error) {
+	c := &collection{
+		docs:   map[string]*vectorstore.Document{},
+		tokens: map[string][]string{},
+	}
+
+	f, err := os.Open(s.filePath(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err = gob.NewDecoder(f).Decode(&c.docs); err != nil {
+		return nil, fmt.Errorf("[vectorstore] decode collection %s failed, err=%w", name, err)
+	}
+	for id, doc := range c.docs {
+		c.tokens[id] = tokenize(doc.Content)
+	}
+	return c, nil
+}
+
+// persist 先写临时文件再 rename，避免写一半进程退出导致文件损坏。调用方需持有写锁
+func (s *store) persist(name string, c *collection) error {
+	tmp := s.filePath(name) + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err = gob.NewEncoder(f).Encode(c.docs); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.filePath(name))
+}
+
+func matchFilter(doc *vectorstore.Document, filter map[string][]string) bool {
+	for field, values := range filter {
+		v, ok := doc.Fields[field]
+		if !ok {
+			return false
+		}
+		matched := false
+		for _, want := range values {
+			if v == want {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func topK(results []*vectorstore.SearchResult, k int) []*vectorstore.SearchResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if k > 0 && len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
+
+func dot(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func norm(v []float64) float64 {
+	return math.Sqrt(dot(v, v))
+}
@@ -13,9 +13,12 @@ import (
 	singleagent "github.com/kiosk404/airi-go/backend/modules/component/agent/domain/service"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg/errno"
+	crossworkflow "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow"
+	crossknowledge "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/knowledge"
 	"github.com/kiosk404/airi-go/backend/pkg/errorx"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
 )
 
@@ -94,6 +97,14 @@ func (s *SingleAgentApplicationService) applyAgentUpdates(target *entity.SingleA
 		target.BackgroundImageInfoList = patch.BackgroundImageInfoList
 	}
 
+	if patch.AvatarInfo != nil {
+		target.AvatarInfo = patch.AvatarInfo
+	}
+
+	if patch.VoicesInfo != nil {
+		target.VoicesInfo = patch.VoicesInfo
+	}
+
 	if patch.Agents != nil && len(patch.Agents) > 0 && patch.Agents[0].JumpConfig != nil {
 		target.JumpConfig = patch.Agents[0].JumpConfig
 	}
@@ -135,6 +146,42 @@ func (s *SingleAgentApplicationService) UpdateSingleAgentDraft(ctx context.Conte
 	userID := ctxutil.MustGetUIDFromCtx(ctx)
 	logs.InfoX(pkg.ModelName, "update single agent info %s draft by user %d", agentID, userID)
 
+	// 只能绑定自己创建的工作流，运行时按 Agent 创建者再次校验
+	if req.BotInfo.WorkflowInfoList != nil {
+		workflowIDs := slices.Transform(req.BotInfo.WorkflowInfoList, func(w *bot_common.WorkflowInfo) int64 {
+			return w.GetWorkflowId()
+		})
+		if err = crossworkflow.DefaultSVC().CheckWorkflowOwner(ctx, userID, workflowIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	// 编排布局中指定的对话流同样只能是自己创建的
+	if layout := req.BotInfo.LayoutInfo; layout != nil && layout.WorkflowId != "" {
+		workflowID, err := conv.StrToInt64(layout.WorkflowId)
+		if err != nil {
+			return nil, errorx.New(errno.ErrAgentInvalidParamCode, errorx.KVf("msg", "invalid layout workflow id: %s", layout.WorkflowId))
+		}
+		if err = crossworkflow.DefaultSVC().CheckWorkflowOwner(ctx, userID, []int64{workflowID}); err != nil {
+			return nil, err
+		}
+	}
+
+	// 只能绑定自己创建的知识库，检索时按 Agent 创建者再次校验
+	if req.BotInfo.Knowledge != nil {
+		knowledgeIDs := make([]int64, 0, len(req.BotInfo.Knowledge.KnowledgeInfo))
+		for _, info := range req.BotInfo.Knowledge.KnowledgeInfo {
+			id, err := conv.StrToInt64(info.GetId())
+			if err != nil {
+				return nil, errorx.New(errno.ErrAgentInvalidParamCode, errorx.KVf("msg", "invalid knowledge id: %s", info.GetId()))
+			}
+			knowledgeIDs = append(knowledgeIDs, id)
+		}
+		if err = crossknowledge.DefaultSVC().CheckKnowledgeOwner(ctx, userID, knowledgeIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	updateAgentInfo, err := s.applyAgentUpdates(currentAgentInfo, req.BotInfo)
 	if err != nil {
 		return nil, err
@@ -343,6 +390,8 @@ func (s *SingleAgentApplicationService) singleAgentDraftDo2Vo(ctx context.Contex
 		DatabaseList:            do.Database,
 		ShortcutSort:            do.ShortcutCommand,
 		LayoutInfo:              do.LayoutInfo,
+		AvatarInfo:              do.AvatarInfo,
+		VoicesInfo:              do.VoicesInfo,
 	}
 
 	if do.VariablesMetaID != nil {
@@ -13,6 +13,10 @@ type AgentIdentity = model.AgentIdentity
 
 type ExecuteRequest = model.ExecuteRequest
 
+type SummarizeRequest = model.SummarizeRequest
+
+type ExtractMemoriesRequest = model.ExtractMemoriesRequest
+
 type DuplicateInfo struct {
 	UserID     int64
 	NewAgentID int64
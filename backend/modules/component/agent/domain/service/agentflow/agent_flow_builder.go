@@ -12,7 +12,9 @@ import (
 	"github.com/cloudwego/eino/schema"
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	memorymodel "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
 	"github.com/kiosk404/airi-go/backend/modules/llm/application"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/maps"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
 )
@@ -34,15 +36,23 @@ const (
 	keyOfKnowledgeRetriever = "knowledge_retriever"
 	// keyOfKnowledgeRetrieverPack 知识库检索节点，用于将检索到的知识库文档打包到上下文变量中
 	keyOfKnowledgeRetrieverPack = "knowledge_retriever_pack"
+	// keyOfMemoryRetriever 长期记忆召回节点
+	keyOfMemoryRetriever = "memory_retriever"
+	// keyOfMemoryRetrieverPack 长期记忆打包节点，用于将召回的记忆注入到提示词变量中
+	keyOfMemoryRetrieverPack = "memory_retriever_pack"
 	// keyOfPromptVariables 提示词变量组装节点
 	keyOfPromptVariables = "prompt_variables"
 	// keyOfPromptTemplate 提示词模板节点
-	keyOfPromptTemplate      = "prompt_template"
+	keyOfPromptTemplate = "prompt_template"
+	// keyOfContextWindow 上下文预算节点，按模型的上下文长度裁剪历史消息
+	keyOfContextWindow       = "context_window"
 	keyOfReActAgent          = "react_agent"
 	keyOfReActAgentToolsNode = "agent_tool"
 	keyOfReActAgentChatModel = "re_act_chat_model"
 	keyOfLLM                 = "llm"
 	keyOfToolsPreRetriever   = "tools_pre_retriever"
+	// keyOfToolsPreRetrieverPack 预调用工具结果打包节点，用于将工具结果注入到提示词变量中
+	keyOfToolsPreRetrieverPack = "tools_pre_retriever_pack"
 )
 
 // BuildAgent 是 Agent 构建的核心函数，负责组装完整的 Agent 执行图。
@@ -50,11 +60,12 @@ const (
 // 该函数实现了一个基于 DAG (有向无环图) 的 Agent 执行流程，主要包含以下步骤：
 //  1. 加载并处理变量（用户变量、自定义变量）
 //  2. 构建人格/角色渲染器（处理 Jinja2 风格的模板变量）
-//  3. 初始化知识库检索器
+//  3. 初始化知识库检索器与长期记忆召回器
 //  4. 构建 LLM 聊天模型
 //  5. 加载各类工具（插件工具、数据库工具、变量工具）
-//  6. 根据工具配置决定使用 ReAct Agent 还是普通 LLM
-//  7. 构建并编译执行图
+//  6. 根据模型的上下文长度计算上下文预算
+//  7. 根据工具配置决定使用 ReAct Agent 还是普通 LLM
+//  8. 构建并编译执行图
 //
 // 执行图的拓扑结构如下：
 //
@@ -62,26 +73,31 @@ const (
 //	                 │                   START                     │
 //	                 └─────────────────────────────────────────────┘
 //	                                      │
-//	      ┌───────────────┬───────────────┼───────────────┬───────────────┐
-//	      ▼               ▼               ▼               ▼               │
-//	┌───────────┐  ┌───────────┐  ┌───────────────┐  ┌─────────────┐      │
-//	│  persona  │  │  prompt   │  │  knowledge    │  │ tools_pre   │      │
-//	│  render   │  │ variables │  │  retriever    │  │ retriever   │      │
-//	└───────────┘  └───────────┘  └───────────────┘  └─────────────┘      │
-//	      │               │               │                   │           │
-//	      │               │               ▼                   │           │
-//	      │               │        ┌───────────────┐          │           │
-//	      │               │        │  knowledge    │          │           │
-//	      │               │        │retriever_pack │          │           │
-//	      │               │        └───────────────┘          │           │
-//	      │               │               │                   │           │
-//	      └───────────────┴───────────────┼───────────────────┘           │
+//	     ┌───────────┬────────────┬───────┼────┬───────────┬──────────────┐
+//	     ▼           ▼            ▼            ▼           ▼              │
+//	┌─────────┐ ┌─────────┐ ┌───────────┐ ┌─────────┐ ┌─────────┐         │
+//	│ persona │ │  prompt │ │ knowledge │ │  memory │ │tools_pre│         │
+//	│  render │ │variables│ │ retriever │ │retriever│ │retriever│         │
+//	└─────────┘ └─────────┘ └───────────┘ └─────────┘ └─────────┘         │
+//	     │           │            │            │           │              │
+//	     │           │            ▼            ▼           ▼              │
+//	     │           │      ┌───────────┐ ┌─────────┐ ┌─────────┐         │
+//	     │           │      │ knowledge │ │  memory │ │tools_pre│         │
+//	     │           │      │    pack   │ │   pack  │ │   pack  │         │
+//	     │           │      └───────────┘ └─────────┘ └─────────┘         │
+//	     │           │            │            │           │              │
+//	     └───────────┴────────────┴───────┬────┴───────────┘              │
 //	                                      ▼                               │
 //	                            ┌───────────────────┐                     │
 //	                            │  prompt_template  │                     │
 //	                            └───────────────────┘                     │
 //	                                      │                               │
 //	                                      ▼                               │
+//	                            ┌───────────────────┐                     │
+//	                            │  context_window   │                     │
+//	                            └───────────────────┘                     │
+//	                                      │                               │
+//	                                      ▼                               │
 //	                        ┌───────────────────────────┐                 │
 //	                        │  ReAct Agent 或 LLM 节点   │                 │
 //	                        │  (根据是否有工具决定)       │                 │
@@ -145,13 +161,25 @@ func BuildAgent(ctx context.Context, conf *Config) (r *AgentRunner, err error) {
 	// 加载知识库
 	kr, err := newKnowledgeRetriever(ctx, &retrieverConfig{
 		knowledgeConfig: conf.Agent.Knowledge,
+		creatorID:       conf.Agent.CreatorID,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 生成LLM模型 (聊天模型)
-	chatModel, modelInfo, err := application.BuildModelBySettings(ctx, conf.Agent.ModelInfo)
+	// 长期记忆按智能体与用户隔离
+	mr := newMemoryRetriever(ctx, &memoryRetrieverConfig{
+		agentID: conf.Agent.AgentID,
+		userID:  conf.UserID,
+	})
+
+	// 生成LLM模型 (聊天模型)，模型配额、用量与预算按用户与智能体统计
+	scopeCtx := modelmgr.WithCallScope(ctx, &modelmgr.CallScope{
+		UserID:         conf.UserID,
+		AgentID:        conf.Agent.AgentID,
+		ConversationID: conf.ConversationID,
+	})
+	chatModel, modelInfo, err := application.BuildModelBySettings(scopeCtx, conf.Agent.ModelInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -167,10 +195,33 @@ func BuildAgent(ctx context.Context, conf *Config) (r *AgentRunner, err error) {
 		return nil, err
 	}
 	// 预处理词加载注入到最终的提示词
-	tr := newPreToolRetriever(&toolPreCallConf{})
+	tr := newPreToolRetriever(&toolPreCallConf{
+		userID:         conf.UserID,
+		agentIdentity:  conf.Identity,
+		creatorID:      conf.Agent.CreatorID,
+		pluginConf:     conf.Agent.Plugin,
+		workflowConf:   conf.Agent.Workflow,
+		conversationID: conf.ConversationID,
+	})
 
-	// todo: 加载工作流 workflow
+	// 加载工作流工具
+	// TerminatePlan 为 use_answer_content 的工作流直接将回答返回给用户
 	returnDirectlyToolSets := mapset.NewSet[string]()
+	var wfTools []tool.BaseTool
+	if len(conf.Agent.Workflow) > 0 {
+		var wfReturnDirectly map[string]bool
+		wfTools, wfReturnDirectly, err = newWorkflowTools(ctx, &workflowConfig{
+			agentIdentity: conf.Identity,
+			creatorID:     conf.Agent.CreatorID,
+			workflowConf:  conf.Agent.Workflow,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for name := range wfReturnDirectly {
+			returnDirectlyToolSets.Add(name)
+		}
+	}
 	returnDirectlyTools := maps.MapFromSet(returnDirectlyToolSets)
 
 	// 加载数据库工具
@@ -194,18 +245,27 @@ func BuildAgent(ctx context.Context, conf *Config) (r *AgentRunner, err error) {
 			return nil, err
 		}
 	}
-	containWfTool := false
+	containWfTool := len(wfTools) > 0
 
-	agentTools := make([]tool.BaseTool, 0, len(pluginTools)+len(dbTools)+len(avTools))
+	agentTools := make([]tool.BaseTool, 0, len(pluginTools)+len(wfTools)+len(dbTools)+len(avTools))
 	agentTools = append(agentTools, slices.Transform(pluginTools, func(a tool.InvokableTool) tool.BaseTool {
 		return a
 	})...)
+	agentTools = append(agentTools, wfTools...)
 	agentTools = append(agentTools, slices.Transform(dbTools, func(a tool.InvokableTool) tool.BaseTool {
 		return a
 	})...)
 	agentTools = append(agentTools, slices.Transform(avTools, func(a tool.InvokableTool) tool.BaseTool {
 		return a
 	})...)
+	// 启用了工具的智能体额外提供内置的提问工具，缺少信息时可中断运行向用户提问
+	if len(agentTools) > 0 {
+		askUserTool, err := newAskUserTool()
+		if err != nil {
+			return nil, err
+		}
+		agentTools = append(agentTools, askUserTool)
+	}
 
 	// 根据是否有可用的工具决定使用 ReAct Agent 还是普通 LLM
 	// 如果有工具，则使用 ReAct Agent，否则使用普通 LLM
@@ -250,6 +310,9 @@ func BuildAgent(ctx context.Context, conf *Config) (r *AgentRunner, err error) {
 		agentNodeName = keyOfLLM
 	}
 
+	// 人格、知识库、工具与历史消息共享模型的上下文窗口
+	cw := newContextWindow(ctx, modelInfo, conf.Agent.ModelInfo, agentTools)
+
 	// 生成问题建议，比如在回答完成任务后，建议生成后续的问题
 	suggestGraph, nsg := newSuggestGraph(ctx, conf, chatModel)
 
@@ -277,19 +340,37 @@ func BuildAgent(ctx context.Context, conf *Config) (r *AgentRunner, err error) {
 		compose.InvokableLambda[*AgentRequest, []*schema.Document](kr.Retrieve),
 		compose.WithNodeName(keyOfKnowledgeRetriever))
 
+	// 长期记忆召回节点 (根据用户输入召回以往对话中提取的记忆)
+	_ = g.AddLambdaNode(keyOfMemoryRetriever,
+		compose.InvokableLambda[*AgentRequest, []*memorymodel.Memory](mr.Retrieve),
+		compose.WithNodeName(keyOfMemoryRetriever))
+
 	// 工具预检索节点 (加载工具相关信息，注入到提示词中)
 	_ = g.AddLambdaNode(keyOfToolsPreRetriever,
 		compose.InvokableLambda[*AgentRequest, []*schema.Message](tr.toolPreRetrieve),
-		compose.WithOutputKey(keyOfToolsPreRetriever),
 		compose.WithNodeName(keyOfToolsPreRetriever),
 	)
+	// 预调用结果打包节点 (将工具结果打包为字符串，注入到提示词中)
+	_ = g.AddLambdaNode(keyOfToolsPreRetrieverPack,
+		compose.InvokableLambda[[]*schema.Message, string](tr.PackPreCallResult),
+		compose.WithOutputKey(placeholderOfPreCall),
+	)
 	// 知识库文档打包节点 (将知识库文档打包为字符串，为 LLM 提供背景知识)
 	_ = g.AddLambdaNode(keyOfKnowledgeRetrieverPack,
 		compose.InvokableLambda[[]*schema.Document, string](kr.PackRetrieveResultInfo),
 		compose.WithOutputKey(placeholderOfKnowledge),
 	)
+	// 长期记忆打包节点 (将召回的记忆打包为字符串，注入到提示词中)
+	_ = g.AddLambdaNode(keyOfMemoryRetrieverPack,
+		compose.InvokableLambda[[]*memorymodel.Memory, string](mr.PackRecallResult),
+		compose.WithOutputKey(placeholderOfLongTermMemory),
+	)
 	// 提示词模板节点 (根据变量生成提示词模板)
 	_ = g.AddChatTemplateNode(keyOfPromptTemplate, chatPrompt)
+	// 上下文预算节点 (超出模型上下文长度时丢弃最早的历史消息)
+	_ = g.AddLambdaNode(keyOfContextWindow,
+		compose.InvokableLambda[[]*schema.Message, []*schema.Message](cw.Fit),
+		compose.WithNodeName(keyOfContextWindow))
 
 	agentNodeOpts = append(agentNodeOpts, compose.WithNodeName(agentNodeName))
 
@@ -313,16 +394,21 @@ func BuildAgent(ctx context.Context, conf *Config) (r *AgentRunner, err error) {
 	_ = g.AddEdge(compose.START, keyOfPersonRender)
 	_ = g.AddEdge(compose.START, keyOfPromptVariables)
 	_ = g.AddEdge(compose.START, keyOfKnowledgeRetriever)
+	_ = g.AddEdge(compose.START, keyOfMemoryRetriever)
 	_ = g.AddEdge(compose.START, keyOfToolsPreRetriever)
 
 	_ = g.AddEdge(keyOfPersonRender, keyOfPromptTemplate)
 	_ = g.AddEdge(keyOfPromptVariables, keyOfPromptTemplate)
 	_ = g.AddEdge(keyOfKnowledgeRetriever, keyOfKnowledgeRetrieverPack)
 	_ = g.AddEdge(keyOfKnowledgeRetrieverPack, keyOfPromptTemplate)
-	_ = g.AddEdge(keyOfToolsPreRetriever, keyOfPromptTemplate)
-
-	// 提示词模板 -> Agent 执行
-	_ = g.AddEdge(keyOfPromptTemplate, agentNodeName)
+	_ = g.AddEdge(keyOfMemoryRetriever, keyOfMemoryRetrieverPack)
+	_ = g.AddEdge(keyOfMemoryRetrieverPack, keyOfPromptTemplate)
+	_ = g.AddEdge(keyOfToolsPreRetriever, keyOfToolsPreRetrieverPack)
+	_ = g.AddEdge(keyOfToolsPreRetrieverPack, keyOfPromptTemplate)
+
+	// 提示词模板 -> 上下文预算 -> Agent 执行
+	_ = g.AddEdge(keyOfPromptTemplate, keyOfContextWindow)
+	_ = g.AddEdge(keyOfContextWindow, agentNodeName)
 
 	if nsg {
 		_ = g.AddEdge(agentNodeName, keyOfSuggestPreInputParse)
@@ -3,6 +3,8 @@ package agentflow
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
 
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
@@ -11,6 +13,8 @@ import (
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg"
 	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	crossworkflow "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow"
+	workflowModel "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/model"
 	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/agentrun/model"
 	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
@@ -29,12 +33,16 @@ type AgentRequest struct {
 	UserID  string
 	Input   *schema.Message
 	History []*schema.Message
+	// HistorySummary 已从 History 中移出的早期对话的摘要
+	HistorySummary string
 
 	Identity *singleagent.AgentIdentity
 
 	ResumeInfo   *singleagent.InterruptInfo // 中断恢复信息
 	PreCallTools []*agentrun.ToolsRetriever // 预调用信息
 	Variables    map[string]string          // 变量集合
+
+	ConversationID int64
 }
 
 type AgentRunner struct {
@@ -47,52 +55,151 @@ type AgentRunner struct {
 }
 
 func (r *AgentRunner) StreamExecute(ctx context.Context, req *AgentRequest) (sr *schema.StreamReader[*entity.AgentEvent], err error) {
-	executeID := uuid.New()
+	// 每次运行使用新的 checkpoint，运行记录各自关联，清理某次运行的执行现场不影响其他运行
+	checkPointID := uuid.New().String()
 
 	// 创建流式传输管道
-	hdl, sr, sw := newReplyCallback(ctx, executeID.String(), r.returnDirectlyTools)
+	hdl, sr, sw := newReplyCallback(ctx, checkPointID, r.returnDirectlyTools)
 	var composeOpts []compose.Option
-	//var pipeMsgOpt compose.Option
-	//var workflowMsgSr *schema.StreamReader[*crossworkflow.WorkflowMessage]
+	var pipeMsgOpt compose.Option
+	var workflowMsgSr *schema.StreamReader[*workflowModel.WorkflowMessage]
 	var workflowMsgCloser func()
 
 	if r.containWfTool {
-		// todo: 添加 workflow 相关
+		wfConfig := crossworkflow.DefaultSVC().WithExecuteConfig(&workflowModel.ExecuteConfig{
+			UserID:         req.UserID,
+			AgentID:        req.Identity.AgentID,
+			ConversationID: req.ConversationID,
+		})
+		composeOpts = append(composeOpts, wfConfig)
+		pipeMsgOpt, workflowMsgSr, workflowMsgCloser = crossworkflow.DefaultSVC().WithMessagePipe()
+		composeOpts = append(composeOpts, pipeMsgOpt)
 	}
 
 	composeOpts = append(composeOpts, compose.WithCallbacks(hdl))
 	_ = compose.RegisterSerializableType[*AgentState]("agent_state")
 	if r.requireCheckpoint {
-		defaultCheckPointID := executeID.String()
-		if req.ResumeInfo != nil {
-			resumeInfo := req.ResumeInfo
-			if resumeInfo.InterruptType != singleagent.InterruptEventType_OauthPlugin {
-
-			}
+		if req.ResumeInfo != nil && req.ResumeInfo.InterruptID != "" &&
+			req.ResumeInfo.InterruptType != singleagent.InterruptEventType_OauthPlugin {
+			// 从中断时的执行现场恢复，再次中断时写入本次运行的 checkpoint
+			composeOpts = append(composeOpts, compose.WithCheckPointID(req.ResumeInfo.InterruptID),
+				compose.WithWriteToCheckPointID(checkPointID))
+		} else {
+			composeOpts = append(composeOpts, compose.WithCheckPointID(checkPointID))
 		}
+		// 工具向用户提问后中断，用户本轮的输入作为回答投递到中断点，其余节点从 checkpoint 恢复
+		if req.ResumeInfo != nil && req.ResumeInfo.Question != nil && req.ResumeInfo.Question.ResumeID != "" {
+			ctx = compose.ResumeWithData(ctx, req.ResumeInfo.Question.ResumeID, inputText(req.Input))
+		}
+	}
 
-		composeOpts = append(composeOpts, compose.WithCheckPointID(defaultCheckPointID))
+	// 工作流节点的中间消息转为 ToolMidAnswer 事件推送，需在 sw 关闭前消费完毕
+	var wfMidAnswerDone chan struct{}
+	if workflowMsgSr != nil {
+		wfMidAnswerDone = make(chan struct{})
+		safego.Go(ctx, func() {
+			defer close(wfMidAnswerDone)
+			r.processWfMidAnswerStream(ctx, sw, workflowMsgSr)
+		})
 	}
 
 	safego.Go(ctx, func() {
 		defer func() {
 			if pe := recover(); pe != nil {
-				logs.Error(pkg.ModelName, "[AgentRunner] StreamExecute recover, err: %v", pe)
+				logs.ErrorX(pkg.ModelName, "[AgentRunner] StreamExecute recover, err: %v", pe)
 
 				sw.Send(nil, errors.New("internal server error"))
 			}
 			if workflowMsgCloser != nil {
 				workflowMsgCloser()
+				<-wfMidAnswerDone
 			}
 			sw.Close()
 		}()
-		_, _ = r.runner.Stream(ctx, req, composeOpts...)
+		// 运行被取消时图可能在回调触发前退出，需要显式通知下游结束
+		if _, sErr := r.runner.Stream(ctx, req, composeOpts...); sErr != nil && ctx.Err() != nil {
+			sw.Send(nil, context.Cause(ctx))
+		}
 	})
 
 	return sr, nil
 
 }
 
+// processWfMidAnswerStream 将工作流节点的中间消息转换为 ToolMidAnswer 事件
+//
+// 每个节点的输出对应一个独立的消息流，节点推送 Last 消息后关闭，使下游可以按节点拆分消息
+func (r *AgentRunner) processWfMidAnswerStream(_ context.Context, sw *schema.StreamWriter[*entity.AgentEvent],
+	wfStream *schema.StreamReader[*workflowModel.WorkflowMessage],
+) {
+	defer wfStream.Close()
+
+	// key 为 executeID + nodeID，并行执行的工作流工具各自维护消息流
+	midAnswers := make(map[string]*schema.StreamWriter[*schema.Message])
+	defer func() {
+		for _, w := range midAnswers {
+			w.Close()
+		}
+	}()
+
+	for {
+		msg, err := wfStream.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				logs.WarnX(pkg.ModelName, "[AgentRunner] receive workflow message failed, err: %v", err)
+			}
+			return
+		}
+		if msg == nil {
+			continue
+		}
+
+		key := msg.ExecuteID + ":" + msg.NodeID
+		w, ok := midAnswers[key]
+		if !ok {
+			var midSr *schema.StreamReader[*schema.Message]
+			midSr, w = schema.Pipe[*schema.Message](5)
+			midAnswers[key] = w
+			sw.Send(&entity.AgentEvent{
+				EventType:     singleagent.EventTypeOfToolMidAnswer,
+				ToolMidAnswer: midSr,
+			}, nil)
+		}
+
+		w.Send(&schema.Message{
+			Role:    schema.Assistant,
+			Content: msg.Content,
+			Extra: map[string]any{
+				"workflow_node_name": msg.NodeName,
+				"is_finish":          msg.Last,
+			},
+		}, nil)
+
+		if msg.Last {
+			w.Close()
+			delete(midAnswers, key)
+		}
+	}
+}
+
+// inputText 多模态输入只取其中的文本作为回答
+func inputText(input *schema.Message) string {
+	if input == nil {
+		return ""
+	}
+	if input.Content != "" || len(input.MultiContent) == 0 {
+		return input.Content
+	}
+
+	texts := make([]string, 0, len(input.MultiContent))
+	for _, part := range input.MultiContent {
+		if part.Type == schema.ChatMessagePartTypeText && part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
 func (r *AgentRunner) PreHandlerReq(ctx context.Context, req *AgentRequest) *AgentRequest {
 	req.Input = r.preHandlerInput(req.Input)
 	req.History = r.preHandlerHistory(req.History)
@@ -0,0 +1,34 @@
+package agentflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+)
+
+const avatarCuePromptHeader = `**Avatar Cues**
+Your replies drive an animated avatar. You may insert cues in the form [type:name] right before the sentence they belong to, for example "[emotion:happy] Nice to meet you!".
+- Only use the cues listed below, at most one of each type per sentence, and never explain or mention them.
+- Cues are removed from the text shown to the user, so the reply must read naturally without them.
+Available cues:`
+
+// buildAvatarCuePrompt 列出智能体允许的形象指令，未开启时返回空串，提示词中不出现该段落
+func buildAvatarCuePrompt(info *bot_common.AvatarInfo) string {
+	cues := singleagent.AllowedAvatarCues(info)
+	if len(cues) == 0 {
+		return ""
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(avatarCuePromptHeader)
+	for _, cueType := range singleagent.AvatarCueTypes {
+		names, ok := cues[cueType]
+		if !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n- %s: %s", cueType, strings.Join(names, ", ")))
+	}
+	return sb.String()
+}
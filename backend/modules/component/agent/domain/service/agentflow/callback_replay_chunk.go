@@ -15,20 +15,21 @@ import (
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg"
 	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/consts"
 	model2 "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/model"
 	"github.com/kiosk404/airi-go/backend/pkg/json"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
 )
 
-func newReplyCallback(_ context.Context, executeID string, returnDirectlyTools mapset.Set[string]) (
+func newReplyCallback(_ context.Context, checkPointID string, returnDirectlyTools mapset.Set[string]) (
 	clb callbacks.Handler, sr *schema.StreamReader[*entity.AgentEvent], sw *schema.StreamWriter[*entity.AgentEvent],
 ) {
 	sr, sw = schema.Pipe[*entity.AgentEvent](10)
 
 	rcc := &replyChunkCallback{
 		sw:                  sw,
-		executeID:           executeID,
+		checkPointID:        checkPointID,
 		returnDirectlyTools: returnDirectlyTools,
 	}
 
@@ -44,7 +45,7 @@ func newReplyCallback(_ context.Context, executeID string, returnDirectlyTools m
 
 type replyChunkCallback struct {
 	sw                  *schema.StreamWriter[*entity.AgentEvent]
-	executeID           string
+	checkPointID        string
 	returnDirectlyTools mapset.Set[string]
 }
 
@@ -61,7 +62,7 @@ func (r *replyChunkCallback) OnError(ctx context.Context, info *callbacks.RunInf
 
 			// 提取中断的信息
 			interruptData := convInterruptInfo(ctx, interruptInfo)
-			interruptData.InterruptID = r.executeID
+			interruptData.InterruptID = r.checkPointID
 
 			// 发送中断事件给客户端，提示需要用户回应（如输入什么信息, 等待授权什么的）
 			toolMessageEvent := &entity.AgentEvent{
@@ -126,22 +127,22 @@ func (r *replyChunkCallback) OnEnd(ctx context.Context, info *callbacks.RunInfo,
 	case keyOfToolsPreRetriever:
 		result := convToolsPreRetrieverCallbackInput(output)
 
-		if len(result) > 0 {
-			for _, item := range result {
-				var event *entity.AgentEvent
-				if item.Role == schema.Tool {
-					event = &entity.AgentEvent{
-						EventType:    singleagent.EventTypeOfToolsMessage,
-						ToolsMessage: []*schema.Message{item},
-					}
-				} else {
-					event = &entity.AgentEvent{
-						EventType: singleagent.EventTypeOfFuncCall,
-						FuncCall:  item,
-					}
-				}
-				r.sw.Send(event, nil)
+		// 预调用结果以 ToolCall + Tool 响应成对出现，每一对作为一条预调用事件发送
+		var funcCall *schema.Message
+		for _, item := range result {
+			if item.Role != schema.Tool {
+				funcCall = item
+				continue
 			}
+			if funcCall == nil {
+				continue
+			}
+			r.sw.Send(&entity.AgentEvent{
+				EventType:    singleagent.EventTypeOfPreToolCall,
+				FuncCall:     funcCall,
+				ToolsMessage: []*schema.Message{item},
+			}, nil)
+			funcCall = nil
 		}
 
 	case keyOfSuggestParser:
@@ -210,42 +211,43 @@ func convToolsNodeCallbackInput(input callbacks.CallbackInput) *schema.Message {
 	}
 }
 
-func convInterruptInfo(ctx context.Context, interruptInfo *compose.InterruptInfo) *singleagent.InterruptInfo {
-	var output *compose.InterruptInfo
-	output = interruptInfo.SubGraphs[keyOfReActAgent]
-	var extra any
-
-	for i := range output.RerunNodesExtra {
-		extra = output.RerunNodesExtra[i]
-		break
+// convInterruptInfo 取出首个作为根因的工具中断，工具调用 ID 来自中断点地址中的工具段
+func convInterruptInfo(_ context.Context, interruptInfo *compose.InterruptInfo) *singleagent.InterruptInfo {
+	interrupt := &singleagent.InterruptInfo{
+		AllToolInterruptData: make(map[string]*model2.ToolInterruptEvent),
 	}
-	toolsNodeExtra, ok := extra.(*compose.ToolsInterruptAndRerunExtra)
-	logs.InfoX(pkg.ModelName, "toolsNodeExtra=%v, err=%v", toolsNodeExtra, ok)
-
-	var toolCallID string
-
-	//wfResumeData := make(map[string]*crossworkflow.ToolInterruptEvent)
-	toolResultData := make(map[string]*model2.ToolInterruptEvent)
-	var interruptEventType singleagent.InterruptEventType
-	//for k, v := range toolsNodeExtra.RerunExtraMap {
-	//	toolCallID = k
-	//
-	//	interruptEventType = convInterruptEventType(v)
-	//
-	//	if interruptEventType == singleagent.InterruptEventType_OauthPlugin {
-	//		toolResultData[k] = v.(*plugin.ToolInterruptEvent)
-	//	} else {
-	//		wfResumeData[k] = v.(*crossworkflow.ToolInterruptEvent)
-	//	}
-	//	break
-	//}
 
-	interrupt := &singleagent.InterruptInfo{
-		AllToolInterruptData: toolResultData,
-		//AllWfInterruptData:   wfResumeData,
-		ToolCallID:    toolCallID,
-		InterruptType: interruptEventType,
+	for _, ic := range interruptInfo.InterruptContexts {
+		if !ic.IsRootCause {
+			continue
+		}
+		for _, seg := range ic.Address {
+			if seg.Type == compose.AddressSegmentTool {
+				interrupt.ToolCallID = seg.SubID
+			}
+		}
+
+		switch info := ic.Info.(type) {
+		case *singleagent.QuestionInterrupt:
+			info.ResumeID = ic.ID
+			interrupt.Question = info
+			interrupt.InterruptType = singleagent.InterruptEventType_Question
+			if len(info.Fields) > 0 {
+				interrupt.InterruptType = singleagent.InterruptEventType_RequireInfos
+			}
+		case *model2.ToolInterruptEvent:
+			interrupt.AllToolInterruptData[interrupt.ToolCallID] = info
+			interrupt.InterruptType = singleagent.InterruptEventType_LocalPlugin
+			if info.Event == consts.InterruptEventTypeOfToolNeedOAuth {
+				interrupt.InterruptType = singleagent.InterruptEventType_OauthPlugin
+			}
+		default:
+			logs.WarnX(pkg.ModelName, "unknown interrupt info, id=%v, info=%v", ic.ID, conv.DebugJsonToStr(ic.Info))
+			continue
+		}
+		return interrupt
 	}
+
 	return interrupt
 }
 
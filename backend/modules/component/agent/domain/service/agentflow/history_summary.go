@@ -0,0 +1,83 @@
+package agentflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/llm/application"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+)
+
+const (
+	// summaryMaxWords 摘要的篇幅上限，摘要每次注入系统提示词，需要控制长度
+	summaryMaxWords = 400
+	// summaryMaxMessageRunes 单条消息参与摘要的最大字符数，过长的工具结果等只取开头
+	summaryMaxMessageRunes = 2000
+)
+
+var summaryPrompt = prompt.FromMessages(schema.Jinja2,
+	schema.SystemMessage(HISTORY_SUMMARY_PROMPT_JINJA2),
+	schema.UserMessage(HISTORY_SUMMARY_INPUT_JINJA2),
+)
+
+type SummaryConfig struct {
+	Agent          *entity.SingleAgent
+	UserID         string
+	ConversationID int64
+}
+
+// SummarizeHistory 将早期对话合并进已有的摘要，使用智能体自身配置的模型
+func SummarizeHistory(ctx context.Context, conf *SummaryConfig, prevSummary string, history []*schema.Message) (string, error) {
+	scopeCtx := modelmgr.WithCallScope(ctx, &modelmgr.CallScope{
+		UserID:         conf.UserID,
+		AgentID:        conf.Agent.AgentID,
+		ConversationID: conf.ConversationID,
+	})
+	chatModel, _, err := application.BuildModelBySettings(scopeCtx, conf.Agent.ModelInfo)
+	if err != nil {
+		return "", err
+	}
+
+	msgs, err := summaryPrompt.Format(ctx, map[string]any{
+		placeholderOfAgentName:    conf.Agent.Name,
+		placeholderOfPrevSummary:  prevSummary,
+		placeholderOfConversation: formatTranscript(history),
+		placeholderOfMaxWords:     summaryMaxWords,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	out, err := chatModel.Generate(scopeCtx, msgs)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.Content), nil
+}
+
+// formatTranscript 将消息整理为按角色标注的纯文本对话记录
+func formatTranscript(history []*schema.Message) string {
+	var sb strings.Builder
+	for _, msg := range history {
+		text := inputText(msg)
+		for _, tc := range msg.ToolCalls {
+			text += fmt.Sprintf("\n[call %s] %s", tc.Function.Name, tc.Function.Arguments)
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			continue
+		}
+		if utf8.RuneCountInString(text) > summaryMaxMessageRunes {
+			text = string([]rune(text)[:summaryMaxMessageRunes]) + "..."
+		}
+		sb.WriteString(string(msg.Role))
+		sb.WriteString(": ")
+		sb.WriteString(text)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package agentflow
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+	memorymodel "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/application"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+)
+
+// memoryMaxExtract 单次最多提取的记忆条数
+const memoryMaxExtract = 10
+
+var memoryExtractPrompt = prompt.FromMessages(schema.Jinja2,
+	schema.SystemMessage(MEMORY_EXTRACT_PROMPT_JINJA2),
+	schema.UserMessage(MEMORY_EXTRACT_INPUT_JINJA2),
+)
+
+type extractedMemory struct {
+	Kind       string `json:"kind"`
+	Content    string `json:"content"`
+	Importance int32  `json:"importance"`
+}
+
+// ExtractMemories 从对话中提取值得长期记住的事实与事件，使用智能体自身配置的模型
+func ExtractMemories(ctx context.Context, conf *SummaryConfig, history []*schema.Message) ([]*memorymodel.MemoryDraft, error) {
+	scopeCtx := modelmgr.WithCallScope(ctx, &modelmgr.CallScope{
+		UserID:         conf.UserID,
+		AgentID:        conf.Agent.AgentID,
+		ConversationID: conf.ConversationID,
+	})
+	chatModel, _, err := application.BuildModelBySettings(scopeCtx, conf.Agent.ModelInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := memoryExtractPrompt.Format(ctx, map[string]any{
+		placeholderOfAgentName:    conf.Agent.Name,
+		placeholderOfConversation: formatTranscript(history),
+		placeholderOfMaxMemories:  memoryMaxExtract,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := chatModel.Generate(scopeCtx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return parseExtractedMemories(out.Content)
+}
+
+// parseExtractedMemories 模型可能在 JSON 数组前后附带代码块标记或说明文字，只解析第一个 [ 到最后一个 ] 之间的内容
+func parseExtractedMemories(content string) ([]*memorymodel.MemoryDraft, error) {
+	start, end := strings.Index(content, "["), strings.LastIndex(content, "]")
+	if start < 0 || end < start {
+		return nil, nil
+	}
+
+	var extracted []*extractedMemory
+	if err := json.Unmarshal([]byte(content[start:end+1]), &extracted); err != nil {
+		return nil, err
+	}
+
+	drafts := make([]*memorymodel.MemoryDraft, 0, len(extracted))
+	for _, e := range extracted {
+		if e == nil || strings.TrimSpace(e.Content) == "" {
+			continue
+		}
+		kind := memorymodel.MemoryKindFact
+		if strings.EqualFold(strings.TrimSpace(e.Kind), "event") {
+			kind = memorymodel.MemoryKindEvent
+		}
+		drafts = append(drafts, &memorymodel.MemoryDraft{
+			Kind:       kind,
+			Content:    strings.TrimSpace(e.Content),
+			Importance: e.Importance,
+		})
+		if len(drafts) == memoryMaxExtract {
+			break
+		}
+	}
+	return drafts, nil
+}
@@ -0,0 +1,38 @@
+package agentflow
+
+import (
+	"testing"
+
+	memorymodel "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExtractedMemories(t *testing.T) {
+	t.Run("json array with code fence", func(t *testing.T) {
+		drafts, err := parseExtractedMemories("```json\n" +
+			`[{"kind": "fact", "content": "The user's cat is named Mochi.", "importance": 6},` +
+			`{"kind": "Event", "content": " They watched the stars together. ", "importance": 8},` +
+			`{"kind": "fact", "content": "  ", "importance": 3}]` +
+			"\n```")
+		assert.NoError(t, err)
+		assert.Equal(t, []*memorymodel.MemoryDraft{
+			{Kind: memorymodel.MemoryKindFact, Content: "The user's cat is named Mochi.", Importance: 6},
+			{Kind: memorymodel.MemoryKindEvent, Content: "They watched the stars together.", Importance: 8},
+		}, drafts)
+	})
+
+	t.Run("nothing to remember", func(t *testing.T) {
+		drafts, err := parseExtractedMemories("[]")
+		assert.NoError(t, err)
+		assert.Empty(t, drafts)
+
+		drafts, err = parseExtractedMemories("Nothing worth remembering.")
+		assert.NoError(t, err)
+		assert.Empty(t, drafts)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := parseExtractedMemories(`[{"content": }]`)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,28 @@
+package agentflow
+
+const (
+	placeholderOfMaxMemories = "max_memories"
+)
+
+const MEMORY_EXTRACT_PROMPT_JINJA2 = `
+You maintain the long-term memory that {{ agent_name }} keeps about the user across conversations.
+Extract the information worth remembering from the conversation given by the user.
+
+### Requirements
+- fact: stable information about the user or the relationship, such as names, preferences, experiences, plans and promises.
+- event: things that happened between the user and {{ agent_name }} in this conversation that are worth recalling later.
+- Each memory is one short, self-contained sentence in the third person, refer to the user as "the user".
+- Rate the importance of each memory from 1 (trivial) to 10 (core to the relationship).
+- Ignore greetings, small talk, questions answered from general knowledge and anything already obvious from the persona.
+- Extract at most {{ max_memories }} memories, return an empty array when nothing is worth remembering.
+- The content language must be consistent with the language of the conversation.
+
+### Output
+Return only a JSON array, without markdown code fences, for example:
+[{"kind": "fact", "content": "The user's cat is named Mochi.", "importance": 6}]
+`
+
+const MEMORY_EXTRACT_INPUT_JINJA2 = `
+### Conversation
+{{ conversation }}
+`
@@ -0,0 +1,104 @@
+package agentflow
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/llm/application"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/tokens"
+)
+
+// contextWindow 按模型的上下文长度裁剪历史消息
+//
+// 人格、知识库、预调用结果都渲染在系统提示词中，与用户输入、工具定义一起优先保留，
+// 剩余的预算从最近一轮开始向前分配给历史消息，放不下的最早的历史被丢弃
+type contextWindow struct {
+	// inputBudget 可用于输入的 token 数，已扣除为回答预留的部分
+	inputBudget int
+	// toolTokens 工具定义占用的 token 数
+	toolTokens int
+}
+
+// newContextWindow 模型未声明上下文长度时返回 nil，不做裁剪
+func newContextWindow(ctx context.Context, modelInfo *modelmgr.Model, settings *bot_common.ModelInfo, tools []tool.BaseTool) *contextWindow {
+	maxContext := int(application.GetMaxContextTokens(modelInfo))
+	if modelInfo == nil || maxContext <= 0 {
+		return nil
+	}
+
+	// 为回答预留的 token 数依次取智能体配置、模型默认参数、模型声明的输出长度
+	var reserve int
+	if settings != nil && ptr.From(settings.MaxTokens) > 0 {
+		reserve = int(ptr.From(settings.MaxTokens))
+	} else if defaultMaxTokens := modelInfo.GetDefaultMaxTokens(); defaultMaxTokens != nil {
+		reserve = int(*defaultMaxTokens)
+	} else if modelInfo.DisplayInfo != nil {
+		reserve = int(modelInfo.DisplayInfo.OutputTokens)
+	}
+	// 输出与输入共享同一窗口，未声明或预留过大时按上下文的四分之一预留
+	if reserve <= 0 || reserve > maxContext/4 {
+		reserve = maxContext / 4
+	}
+
+	infos := make([]*schema.ToolInfo, 0, len(tools))
+	for _, t := range tools {
+		info, err := t.Info(ctx)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	return &contextWindow{
+		inputBudget: maxContext - reserve,
+		toolTokens:  tokens.EstimateTools(infos),
+	}
+}
+
+// Fit 输入为提示词模板渲染后的消息：系统提示词、历史消息、用户输入
+func (w *contextWindow) Fit(ctx context.Context, msgs []*schema.Message) ([]*schema.Message, error) {
+	if w == nil || len(msgs) == 0 {
+		return msgs, nil
+	}
+
+	var head []*schema.Message
+	if msgs[0].Role == schema.System {
+		head, msgs = msgs[:1], msgs[1:]
+	}
+	if len(msgs) == 0 {
+		return head, nil
+	}
+	history, input := msgs[:len(msgs)-1], msgs[len(msgs)-1:]
+
+	budget := w.inputBudget - w.toolTokens - tokens.EstimateMessages(head) - tokens.EstimateMessages(input)
+	if budget < 0 {
+		logs.WarnX(pkg.ModelName, "[contextWindow] prompt exceeds context window, over by %d tokens", -budget)
+	}
+
+	keep := len(history)
+	for used := 0; keep > 0; keep-- {
+		used += tokens.EstimateMessage(history[keep-1])
+		if used > budget {
+			break
+		}
+	}
+	// 从用户消息开始保留，避免工具调用与返回结果被拆开
+	for keep < len(history) && history[keep].Role != schema.User {
+		keep++
+	}
+	if keep > 0 {
+		logs.InfoX(pkg.ModelName, "[contextWindow] drop %d of %d history messages to fit %d tokens", keep, len(history), w.inputBudget)
+	}
+
+	out := make([]*schema.Message, 0, len(head)+len(history)-keep+len(input))
+	out = append(out, head...)
+	out = append(out, history[keep:]...)
+	out = append(out, input...)
+	return out, nil
+}
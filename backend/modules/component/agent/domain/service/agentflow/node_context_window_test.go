@@ -0,0 +1,63 @@
+package agentflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/pkg/tokens"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWindowFit(t *testing.T) {
+	ctx := context.Background()
+	long := strings.Repeat("word ", 40)
+
+	system := schema.SystemMessage("persona")
+	history := []*schema.Message{
+		schema.UserMessage(long),
+		schema.AssistantMessage(long, nil),
+		schema.UserMessage("weather?"),
+		schema.AssistantMessage("", []schema.ToolCall{{ID: "call_1", Function: schema.FunctionCall{Name: "weather"}}}),
+		schema.ToolMessage("sunny", "call_1"),
+		schema.AssistantMessage("it is sunny", nil),
+	}
+	input := schema.UserMessage("thanks")
+	msgs := append(append([]*schema.Message{system}, history...), input)
+
+	t.Run("nil window keeps all messages", func(t *testing.T) {
+		var w *contextWindow
+		out, err := w.Fit(ctx, msgs)
+		assert.NoError(t, err)
+		assert.Equal(t, msgs, out)
+	})
+
+	t.Run("enough budget keeps all messages", func(t *testing.T) {
+		w := &contextWindow{inputBudget: tokens.EstimateMessages(msgs)}
+		out, err := w.Fit(ctx, msgs)
+		assert.NoError(t, err)
+		assert.Equal(t, msgs, out)
+	})
+
+	t.Run("drop oldest round without splitting tool calls", func(t *testing.T) {
+		// 预算恰好放下最近一轮；少一个 token 时该轮的用户消息放不下，工具调用与回答随之整轮丢弃
+		budget := tokens.EstimateMessage(system) + tokens.EstimateMessage(input) + tokens.EstimateMessages(history[2:])
+		w := &contextWindow{inputBudget: budget}
+		out, err := w.Fit(ctx, msgs)
+		assert.NoError(t, err)
+		assert.Equal(t, append(append([]*schema.Message{system}, history[2:]...), input), out)
+
+		w = &contextWindow{inputBudget: budget - 1}
+		out, err = w.Fit(ctx, msgs)
+		assert.NoError(t, err)
+		assert.Equal(t, []*schema.Message{system, input}, out)
+	})
+
+	t.Run("tool definitions share the budget", func(t *testing.T) {
+		w := &contextWindow{inputBudget: tokens.EstimateMessages(msgs), toolTokens: 1}
+		out, err := w.Fit(ctx, msgs)
+		assert.NoError(t, err)
+		assert.Equal(t, append(append([]*schema.Message{system}, history[2:]...), input), out)
+	})
+}
@@ -0,0 +1,73 @@
+package agentflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg"
+	crossmemory "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory"
+	memorymodel "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+// memoryRecallTopK 每轮对话注入提示词的长期记忆条数
+const memoryRecallTopK = 5
+
+type memoryRetrieverConfig struct {
+	agentID int64
+	userID  string
+}
+
+func newMemoryRetriever(_ context.Context, conf *memoryRetrieverConfig) *memoryRetriever {
+	return &memoryRetriever{
+		agentID: conf.agentID,
+		userID:  conf.userID,
+	}
+}
+
+// memoryRetriever 按当前输入召回智能体对该用户的长期记忆
+type memoryRetriever struct {
+	agentID int64
+	userID  string
+}
+
+func (r *memoryRetriever) Retrieve(ctx context.Context, req *AgentRequest) ([]*memorymodel.Memory, error) {
+	if r.userID == "" || crossmemory.DefaultSVC() == nil {
+		return nil, nil
+	}
+
+	query := retrieveQuery(req.Input)
+	if query == "" {
+		return nil, nil
+	}
+
+	memories, err := crossmemory.DefaultSVC().Recall(ctx, &memorymodel.RecallRequest{
+		AgentID: r.agentID,
+		UserID:  r.userID,
+		Query:   query,
+		TopK:    memoryRecallTopK,
+	})
+	if err != nil {
+		// 召回失败不阻断对话，按没有记忆处理
+		logs.ErrorX(pkg.ModelName, "memory recall failed, err=%v", err)
+		return nil, nil
+	}
+	return memories, nil
+}
+
+func (r *memoryRetriever) PackRecallResult(ctx context.Context, memories []*memorymodel.Memory) (string, error) {
+	packedRes := strings.Builder{}
+	for _, m := range memories {
+		if m == nil {
+			continue
+		}
+		kind := "fact"
+		if m.Kind == memorymodel.MemoryKindEvent {
+			kind = "event"
+		}
+		packedRes.WriteString(fmt.Sprintf("- [%s, %s] %s\n", kind, time.UnixMilli(m.UpdatedAt).Format(time.DateOnly), m.Content))
+	}
+	return packedRes.String(), nil
+}
@@ -3,6 +3,8 @@ package agentflow
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/cloudwego/eino/schema"
@@ -34,14 +36,18 @@ func (p *promptVariables) AssemblePromptVariables(ctx context.Context, req *Agen
 		// Add chat history to variable
 		variables[placeholderOfChatHistory] = req.History
 	}
+	variables[placeholderOfHistorySummary] = req.HistorySummary
+	variables[placeholderOfAvatarCues] = buildAvatarCuePrompt(p.Agent.AvatarInfo)
 
 	if p.avs != nil {
 		var memoryVariablesList []string
 		for k, v := range p.avs {
 			variables[k] = v
-			memoryVariablesList = append(memoryVariablesList, fmt.Sprintf("%s: %s\n", k, v))
+			memoryVariablesList = append(memoryVariablesList, fmt.Sprintf("%s: %s", k, v))
 		}
-		variables[placeholderOfVariables] = memoryVariablesList
+		// 保证每次渲染的提示词顺序一致
+		sort.Strings(memoryVariablesList)
+		variables[placeholderOfVariables] = strings.Join(memoryVariablesList, "\n")
 	}
 
 	return variables, nil
@@ -3,31 +3,119 @@ package agentflow
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cloudwego/eino/schema"
 	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg"
+	crossknowledge "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/knowledge"
+	knowledgemodel "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/knowledge/model"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+const (
+	docMetaKeyKnowledgeID   = "knowledge_id"
+	docMetaKeyKnowledgeName = "knowledge_name"
+	docMetaKeyDocumentID    = "document_id"
+	docMetaKeyDocumentName  = "document_name"
 )
 
 type retrieverConfig struct {
 	knowledgeConfig *bot_common.Knowledge
+	creatorID       int64
 }
 
 func newKnowledgeRetriever(_ context.Context, conf *retrieverConfig) (*knowledgeRetriever, error) {
 	return &knowledgeRetriever{
 		knowledgeConfig: conf.knowledgeConfig,
+		creatorID:       conf.creatorID,
 	}, nil
 }
 
 type knowledgeRetriever struct {
 	knowledgeConfig *bot_common.Knowledge
+	// creatorID Agent 的创建者，只检索其创建的知识库
+	creatorID int64
 }
 
 func (r *knowledgeRetriever) Retrieve(ctx context.Context, req *AgentRequest) ([]*schema.Document, error) {
-	var docs []*schema.Document
+	knowledgeIDs := r.knowledgeIDs()
+	if len(knowledgeIDs) == 0 || crossknowledge.DefaultSVC() == nil {
+		return nil, nil
+	}
+
+	query := retrieveQuery(req.Input)
+	if query == "" {
+		return nil, nil
+	}
+
+	resp, err := crossknowledge.DefaultSVC().Retrieve(ctx, &knowledgemodel.RetrieveRequest{
+		Query:        query,
+		KnowledgeIDs: knowledgeIDs,
+		CreatorID:    r.creatorID,
+		TopK:         r.knowledgeConfig.GetTopK(),
+		MinScore:     r.knowledgeConfig.GetMinScore(),
+		SearchType:   knowledgemodel.SearchType(r.knowledgeConfig.GetSearchStrategy()),
+	})
+	if err != nil {
+		// 检索失败不阻断对话，按未召回处理
+		logs.ErrorX(pkg.ModelName, "knowledge retrieve failed, err=%v", err)
+		return nil, nil
+	}
+
+	docs := make([]*schema.Document, 0, len(resp.Slices))
+	for _, s := range resp.Slices {
+		doc := &schema.Document{
+			ID:      strconv.FormatInt(s.SliceID, 10),
+			Content: s.Content,
+			MetaData: map[string]any{
+				docMetaKeyKnowledgeID:   s.KnowledgeID,
+				docMetaKeyKnowledgeName: s.KnowledgeName,
+				docMetaKeyDocumentID:    s.DocumentID,
+				docMetaKeyDocumentName:  s.DocumentName,
+			},
+		}
+		doc.WithScore(s.Score)
+		docs = append(docs, doc)
+	}
 	return docs, nil
 }
 
+func (r *knowledgeRetriever) knowledgeIDs() []int64 {
+	if r.knowledgeConfig == nil {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(r.knowledgeConfig.KnowledgeInfo))
+	for _, info := range r.knowledgeConfig.KnowledgeInfo {
+		id, err := strconv.ParseInt(info.GetId(), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// retrieveQuery 取用户输入中的文本部分作为检索语句
+func retrieveQuery(input *schema.Message) string {
+	if input == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(input.MultiContent)+1)
+	if input.Content != "" {
+		parts = append(parts, input.Content)
+	}
+	for _, p := range input.MultiContent {
+		if p.Type == schema.ChatMessagePartTypeText && p.Text != "" {
+			parts = append(parts, p.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
 func (r *knowledgeRetriever) PackRetrieveResultInfo(ctx context.Context, docs []*schema.Document) (string, error) {
 	packedRes := strings.Builder{}
 	for idx, doc := range docs {
@@ -36,5 +124,10 @@ func (r *knowledgeRetriever) PackRetrieveResultInfo(ctx context.Context, docs []
 		}
 		packedRes.WriteString(fmt.Sprintf("---\nrecall slice %d: %s\n", idx+1, doc.Content))
 	}
+
+	if packedRes.Len() == 0 && r.knowledgeConfig != nil &&
+		r.knowledgeConfig.GetNoRecallReplyMode() == bot_common.KnowledgeNoRecallReplyMode_CustomizePrompt {
+		return r.knowledgeConfig.GetNoRecallReplyCustomizePrompt(), nil
+	}
 	return packedRes.String(), nil
 }
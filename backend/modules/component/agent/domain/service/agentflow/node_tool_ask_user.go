@@ -0,0 +1,46 @@
+package agentflow
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/compose"
+	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+)
+
+const toolNameAskUser = "ask_user"
+
+// newAskUserTool 内置的提问工具，执行时中断运行并等待用户回答，用户的下一条消息作为工具结果继续执行
+func newAskUserTool() (tool.InvokableTool, error) {
+	desc := `
+## Skills Conditions
+1. When required information is missing and cannot be inferred from the conversation, call the tool to ask the user.
+2. When the user must choose between several options before you can continue, call the tool with the options.
+3. When several pieces of information are needed at once, call the tool with fields to present a form.
+
+## Constraints
+- Ask only one question per call and keep it short.
+- Do not call the tool when the answer can be found in the conversation or by other tools.
+`
+	return utils.InferTool(toolNameAskUser, desc, askUser)
+}
+
+type askUserRequest struct {
+	Question string                          `json:"question" jsonschema:"required,description=the question to ask the user"`
+	Options  []string                        `json:"options,omitempty" jsonschema:"description=candidate answers for the user to choose from"`
+	Fields   []*singleagent.RequireInfoField `json:"fields,omitempty" jsonschema:"description=the information the user should fill in"`
+}
+
+func askUser(ctx context.Context, req *askUserRequest) (string, error) {
+	// 从中断恢复时，用户的回答作为工具结果返回给模型
+	if isResume, _, answer := compose.GetResumeContext[string](ctx); isResume {
+		return answer, nil
+	}
+
+	return "", compose.Interrupt(ctx, &singleagent.QuestionInterrupt{
+		Question: req.Question,
+		Options:  req.Options,
+		Fields:   req.Fields,
+	})
+}
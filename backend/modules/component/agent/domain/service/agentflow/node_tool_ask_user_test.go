@@ -0,0 +1,87 @@
+package agentflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	"github.com/kiosk404/airi-go/backend/pkg/checkpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+// askingModel 首轮调用 ask_user 提问，拿到工具结果后复述回答
+type askingModel struct{}
+
+func (m *askingModel) Generate(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	last := in[len(in)-1]
+	if last.Role == schema.Tool {
+		return schema.AssistantMessage("city: "+last.Content, nil), nil
+	}
+	return schema.AssistantMessage("", []schema.ToolCall{{
+		ID:       "call_1",
+		Type:     "function",
+		Function: schema.FunctionCall{Name: toolNameAskUser, Arguments: `{"question":"which city?","options":["Beijing","Shanghai"]}`},
+	}}), nil
+}
+
+func (m *askingModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := m.Generate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+func (m *askingModel) WithTools(_ []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
+
+func TestAskUserInterruptAndResume(t *testing.T) {
+	ctx := context.Background()
+	askUserTool, err := newAskUserTool()
+	assert.NoError(t, err)
+
+	agent, err := react.NewAgent(ctx, &react.AgentConfig{
+		ToolCallingModel: &askingModel{},
+		ToolsConfig:      compose.ToolsNodeConfig{Tools: []tool.BaseTool{askUserTool}},
+		ModelNodeName:    keyOfReActAgentChatModel,
+		ToolsNodeName:    keyOfReActAgentToolsNode,
+	})
+	assert.NoError(t, err)
+	agentGraph, agentNodeOpts := agent.ExportGraph()
+
+	g := compose.NewGraph[[]*schema.Message, *schema.Message]()
+	_ = g.AddGraphNode(keyOfReActAgent, agentGraph, agentNodeOpts...)
+	_ = g.AddEdge(compose.START, keyOfReActAgent)
+	_ = g.AddEdge(keyOfReActAgent, compose.END)
+	store := checkpoint.NewInMemoryStore(0)
+	runner, err := g.Compile(ctx, compose.WithCheckPointStore(store))
+	assert.NoError(t, err)
+
+	_, err = runner.Invoke(ctx, []*schema.Message{schema.UserMessage("book a flight")}, compose.WithCheckPointID("cp1"))
+	info, ok := compose.ExtractInterruptInfo(err)
+	assert.True(t, ok)
+
+	interrupt := convInterruptInfo(ctx, info)
+	assert.Equal(t, singleagent.InterruptEventType_Question, interrupt.InterruptType)
+	assert.Equal(t, "call_1", interrupt.ToolCallID)
+	assert.Equal(t, "which city?", interrupt.Question.Question)
+	assert.Equal(t, []string{"Beijing", "Shanghai"}, interrupt.Question.Options)
+	assert.NotEmpty(t, interrupt.Question.ResumeID)
+
+	// 恢复时忽略新的输入，回答作为 ask_user 的结果继续执行；与 StreamExecute 一致，从原有现场读取，写入新的 checkpoint
+	resumeCtx := compose.ResumeWithData(ctx, interrupt.Question.ResumeID, "Shanghai")
+	out, err := runner.Invoke(resumeCtx, []*schema.Message{schema.UserMessage("Shanghai")},
+		compose.WithCheckPointID("cp1"), compose.WithWriteToCheckPointID("cp2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "city: Shanghai", out.Content)
+
+	_, ok, err = store.Get(ctx, "cp1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
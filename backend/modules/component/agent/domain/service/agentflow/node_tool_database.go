@@ -5,15 +5,22 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
 	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	crossdatabase "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/database"
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/database/model"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
 )
 
 const (
 	TimeFormat = "2006-01-02 15:04:05"
+
+	// maxResultRows 返回给模型的最大行数，避免结果过长占满上下文
+	maxResultRows = 50
 )
 
 type databaseConfig struct {
@@ -33,6 +40,8 @@ type databaseTool struct {
 
 	name           string
 	promptDisabled bool
+	rwMode         bot_common.BotTableRWMode
+	fieldList      []*bot_common.FieldItem
 }
 
 type ExecuteSQLRequest struct {
@@ -47,7 +56,82 @@ func (d *databaseTool) Invoke(ctx context.Context, req ExecuteSQLRequest) (strin
 		return "the tool to be called is not available", nil
 	}
 
-	panic("implement me")
+	fields := make([]*model.Field, 0, len(d.fieldList))
+	for _, f := range d.fieldList {
+		if f.Name == nil {
+			continue
+		}
+		fields = append(fields, &model.Field{
+			Name:     f.GetName(),
+			Desc:     f.GetDesc(),
+			Type:     model.FieldType(f.GetType()),
+			Required: f.GetMustRequired(),
+		})
+	}
+
+	resp, err := crossdatabase.DefaultSVC().ExecuteSQL(ctx, &model.ExecuteSQLRequest{
+		AgentID:      d.agentIdentity.AgentID,
+		TableID:      d.databaseID,
+		TableName:    d.name,
+		Fields:       fields,
+		RWMode:       model.RWMode(d.rwMode),
+		ConnectorUID: d.connectorUID,
+		SQL:          req.SQL,
+	})
+	if err != nil {
+		// SQL 不合法或执行失败时把原因返回给模型，由模型修正后重试
+		if statusErr, ok := errorx.FromStatusError(err); ok && !statusErr.IsAffectStability() {
+			return statusErr.Msg(), nil
+		}
+		return "", err
+	}
+
+	return formatExecuteSQLResult(resp), nil
+}
+
+// formatExecuteSQLResult 将查询结果格式化为紧凑的表格文本
+func formatExecuteSQLResult(resp *model.ExecuteSQLResponse) string {
+	if len(resp.Columns) == 0 {
+		return fmt.Sprintf("affected rows: %d", resp.AffectedRows)
+	}
+	if len(resp.Rows) == 0 {
+		return "no rows found"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Join(resp.Columns, " | "))
+	sb.WriteString("\n")
+	for i, row := range resp.Rows {
+		if i >= maxResultRows {
+			sb.WriteString(fmt.Sprintf("... %d more rows are omitted, narrow the result with WHERE or LIMIT\n", len(resp.Rows)-maxResultRows))
+			break
+		}
+		values := make([]string, 0, len(resp.Columns))
+		for _, col := range resp.Columns {
+			values = append(values, formatSQLValue(row[col]))
+		}
+		sb.WriteString(strings.Join(values, " | "))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("(%d rows)", len(resp.Rows)))
+
+	return sb.String()
+}
+
+func formatSQLValue(v any) string {
+	var s string
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		s = string(val)
+	case time.Time:
+		s = val.Format(TimeFormat)
+	default:
+		s = fmt.Sprint(val)
+	}
+
+	return strings.NewReplacer("\n", "\\n", "|", "\\|").Replace(s)
 }
 
 func newDatabaseTools(ctx context.Context, conf *databaseConfig) ([]tool.InvokableTool, error) {
@@ -69,6 +153,8 @@ func newDatabaseTools(ctx context.Context, conf *databaseConfig) ([]tool.Invokab
 			promptDisabled: dbInfo.GetPromptDisabled(),
 			name:           dbInfo.GetTableName(),
 			databaseID:     tID,
+			rwMode:         dbInfo.GetRWMode(),
+			fieldList:      dbInfo.FieldList,
 		}
 
 		dbTool, err := utils.InferTool(dbInfo.GetTableName(), buildDatabaseToolDescription(dbInfo), d.Invoke)
@@ -109,8 +195,13 @@ func buildDatabaseToolDescription(tableInfo *bot_common.Database) string {
 
 		sb.WriteString("\n")
 	}
+	sb.WriteString("- id (number): auto-increment row id, generated automatically\n")
+	sb.WriteString("- create_time (date): the time the row was created, generated automatically\n")
 
 	sb.WriteString("\nUse SQL to query this table. You can write SQL statements directly to operate.")
+	if tableInfo.GetRWMode() == bot_common.BotTableRWMode_ReadOnly {
+		sb.WriteString(" This table is read only, only SELECT statements are allowed.")
+	}
 	return sb.String()
 }
 
@@ -2,14 +2,47 @@ package agentflow
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/cloudwego/eino/schema"
+	"github.com/google/uuid"
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg"
+	crossplugin "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/consts"
+	model2 "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/model"
+	crossworkflow "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow"
+	workflowModel "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/model"
+	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/agentrun/model"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/taskgroup"
 )
 
-type toolPreCallConf struct{}
+// maxPreCallConcurrency 预调用工具的最大并发数
+const maxPreCallConcurrency = 5
+
+type toolPreCallConf struct {
+	userID        string
+	agentIdentity *entity.AgentIdentity
+	creatorID     int64
+	pluginConf    []*bot_common.PluginInfo
+	workflowConf  []*bot_common.WorkflowInfo
+
+	conversationID int64
+}
 
 func newPreToolRetriever(conf *toolPreCallConf) *toolPreCallConf {
-	return &toolPreCallConf{}
+	return &toolPreCallConf{
+		userID:         conf.userID,
+		agentIdentity:  conf.agentIdentity,
+		creatorID:      conf.creatorID,
+		pluginConf:     conf.pluginConf,
+		workflowConf:   conf.workflowConf,
+		conversationID: conf.conversationID,
+	}
 }
 
 // 在 Agent 执行主逻辑之前，预先执行一些指定的工具，并将工具的结果作为上下文信息给 Agent，帮助 Agent 做出判断
@@ -22,8 +55,160 @@ func newPreToolRetriever(conf *toolPreCallConf) *toolPreCallConf {
 // 3. 执行工具2（查询库存）
 // 4. 构建消息对（3. Assistant: ToolCall: {"库存还有吗？"}, 4. Tool: Content: "库存充足，可以立即发货"）
 // 返回所有的消息 [消息1，消息2，消息3，消息4]
+//
+// 工具之间互不依赖，因此并发执行；单个工具失败只记录日志并跳过，不影响 Agent 主流程
 func (pr *toolPreCallConf) toolPreRetrieve(ctx context.Context, ar *AgentRequest) ([]*schema.Message, error) {
-	var tms []*schema.Message
+	tools := pr.boundTools(ar.PreCallTools)
+	if len(tools) == 0 {
+		return nil, nil
+	}
+
+	results := make([][]*schema.Message, len(tools))
+	tg := taskgroup.NewUninterruptibleTaskGroup(ctx, maxPreCallConcurrency)
+	for idx, item := range tools {
+		idx, item := idx, item
+		tg.Go(func() error {
+			output, err := pr.execute(ctx, item)
+			if err != nil {
+				logs.WarnX(pkg.ModelName, "pre call tool failed, tool=%s, err=%v", item.ToolName, err)
+				return nil
+			}
+			results[idx] = buildPreCallMessages(item, output)
+			return nil
+		})
+	}
+	_ = tg.Wait()
+
+	tms := make([]*schema.Message, 0, 2*len(tools))
+	for _, msgs := range results {
+		tms = append(tms, msgs...)
+	}
 
 	return tms, nil
 }
+
+// boundTools 预调用工具来自客户端请求，只保留智能体已绑定的插件工具和工作流
+func (pr *toolPreCallConf) boundTools(tools []*agentrun.ToolsRetriever) []*agentrun.ToolsRetriever {
+	bound := make([]*agentrun.ToolsRetriever, 0, len(tools))
+	for _, item := range tools {
+		if item == nil {
+			continue
+		}
+		if !pr.isBound(item) {
+			logs.WarnX(pkg.ModelName, "pre call tool is not bound to agent %d, type=%d, pluginID=%d, toolID=%d",
+				pr.agentIdentity.AgentID, item.Type, item.PluginID, item.ToolID)
+			continue
+		}
+		bound = append(bound, item)
+	}
+	return bound
+}
+
+func (pr *toolPreCallConf) isBound(item *agentrun.ToolsRetriever) bool {
+	switch item.Type {
+	case agentrun.ToolTypePlugin:
+		for _, p := range pr.pluginConf {
+			if p.GetPluginId() == item.PluginID && p.GetApiId() == item.ToolID {
+				return true
+			}
+		}
+	case agentrun.ToolTypeWorkflow:
+		for _, wf := range pr.workflowConf {
+			if wf.GetWorkflowId() == item.PluginID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (pr *toolPreCallConf) execute(ctx context.Context, item *agentrun.ToolsRetriever) (string, error) {
+	switch item.Type {
+	case agentrun.ToolTypePlugin:
+		return pr.executePlugin(ctx, item)
+	case agentrun.ToolTypeWorkflow:
+		return pr.executeWorkflow(ctx, item)
+	default:
+		return "", fmt.Errorf("unsupported pre call tool type: %d", item.Type)
+	}
+}
+
+func (pr *toolPreCallConf) executePlugin(ctx context.Context, item *agentrun.ToolsRetriever) (string, error) {
+	req := &model2.ExecuteToolRequest{
+		UserID:          pr.userID,
+		PluginID:        item.PluginID,
+		ToolID:          item.ToolID,
+		ExecDraftTool:   false,
+		PluginFrom:      item.PluginFrom,
+		ArgumentsInJson: item.Arguments,
+		ExecScene: func() consts.ExecuteScene {
+			if pr.agentIdentity.IsDraft {
+				return consts.ExecSceneOfDraftAgent
+			}
+			return consts.ExecSceneOfOnlineAgent
+		}(),
+	}
+
+	opts := []model2.ExecuteToolOpt{
+		model2.WithInvalidRespProcessStrategy(consts.InvalidResponseProcessStrategyOfReturnDefault),
+		model2.WithProjectInfo(&model2.ProjectInfo{
+			ProjectID:      pr.agentIdentity.AgentID,
+			ProjectType:    consts.ProjectTypeOfAgent,
+			ProjectVersion: ptr.Of(pr.agentIdentity.Version),
+		}),
+		model2.WithPluginHTTPHeader(pr.conversationID),
+	}
+
+	resp, err := crossplugin.DefaultSVC().ExecuteTool(ctx, req, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.TrimmedResp, nil
+}
+
+// executeWorkflow 工作流类型的预调用中 PluginID 为工作流 ID
+func (pr *toolPreCallConf) executeWorkflow(ctx context.Context, item *agentrun.ToolsRetriever) (string, error) {
+	return crossworkflow.DefaultSVC().ExecuteAsTool(ctx, &workflowModel.WorkflowToolPolicy{
+		WorkflowID: item.PluginID,
+		IsDraft:    pr.agentIdentity.IsDraft,
+		CreatorID:  pr.creatorID,
+	}, item.Arguments, &workflowModel.ExecuteConfig{
+		UserID:         pr.userID,
+		AgentID:        pr.agentIdentity.AgentID,
+		ConversationID: pr.conversationID,
+	})
+}
+
+// buildPreCallMessages 将一次工具执行构造成 Assistant ToolCall + Tool 响应的消息对
+func buildPreCallMessages(item *agentrun.ToolsRetriever, output string) []*schema.Message {
+	callID := "pre_call_" + uuid.NewString()
+	return []*schema.Message{
+		{
+			Role: schema.Assistant,
+			ToolCalls: []schema.ToolCall{
+				{
+					ID:   callID,
+					Type: "function",
+					Function: schema.FunctionCall{
+						Name:      item.ToolName,
+						Arguments: item.Arguments,
+					},
+				},
+			},
+		},
+		schema.ToolMessage(output, callID, schema.WithToolName(item.ToolName)),
+	}
+}
+
+// PackPreCallResult 将预调用结果打包为字符串，注入到系统提示词的 tools_pre_retriever 变量中
+func (pr *toolPreCallConf) PackPreCallResult(ctx context.Context, msgs []*schema.Message) (string, error) {
+	packedRes := strings.Builder{}
+	for _, msg := range msgs {
+		if msg == nil || msg.Role != schema.Tool {
+			continue
+		}
+		packedRes.WriteString(fmt.Sprintf("---\ntool %s: %s\n", msg.ToolName, msg.Content))
+	}
+	return packedRes.String(), nil
+}
@@ -0,0 +1,98 @@
+package agentflow
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	crossplugin "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/model"
+	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/agentrun/model"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePluginSVC 记录被执行的工具，toolID 为 failToolID 时返回错误
+type fakePluginSVC struct {
+	crossplugin.PluginService
+
+	mu       sync.Mutex
+	executed []int64
+}
+
+const failToolID = 99
+
+func (f *fakePluginSVC) ExecuteTool(_ context.Context, req *model.ExecuteToolRequest, _ ...model.ExecuteToolOpt) (*model.ExecuteToolResponse, error) {
+	f.mu.Lock()
+	f.executed = append(f.executed, req.ToolID)
+	f.mu.Unlock()
+	if req.ToolID == failToolID {
+		return nil, errors.New("execute failed")
+	}
+	return &model.ExecuteToolResponse{TrimmedResp: "vip"}, nil
+}
+
+func TestToolPreRetrieveOnlyBoundTools(t *testing.T) {
+	svc := &fakePluginSVC{}
+	prev := crossplugin.DefaultSVC()
+	crossplugin.SetDefaultSVC(svc)
+	defer crossplugin.SetDefaultSVC(prev)
+
+	pr := newPreToolRetriever(&toolPreCallConf{
+		userID:        "1",
+		agentIdentity: &entity.AgentIdentity{AgentID: 10},
+		pluginConf: []*bot_common.PluginInfo{
+			{PluginId: ptr.Of(int64(1)), ApiId: ptr.Of(int64(11))},
+			{PluginId: ptr.Of(int64(1)), ApiId: ptr.Of(int64(failToolID))},
+		},
+		workflowConf: []*bot_common.WorkflowInfo{
+			{WorkflowId: ptr.Of(int64(3))},
+		},
+	})
+
+	msgs, err := pr.toolPreRetrieve(context.Background(), &AgentRequest{
+		PreCallTools: []*agentrun.ToolsRetriever{
+			{Type: agentrun.ToolTypePlugin, PluginID: 1, ToolID: 11, ToolName: "get_user"},
+			{Type: agentrun.ToolTypePlugin, PluginID: 1, ToolID: failToolID, ToolName: "broken"},
+			// 未绑定的插件工具和工作流不执行
+			{Type: agentrun.ToolTypePlugin, PluginID: 2, ToolID: 11, ToolName: "other_plugin"},
+			{Type: agentrun.ToolTypePlugin, PluginID: 1, ToolID: 12, ToolName: "other_tool"},
+			{Type: agentrun.ToolTypeWorkflow, PluginID: 4, ToolName: "other_workflow"},
+			nil,
+		},
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{11, failToolID}, svc.executed)
+
+	// 执行失败的工具跳过，不产生消息
+	if assert.Len(t, msgs, 2) {
+		assert.Equal(t, schema.Assistant, msgs[0].Role)
+		assert.Equal(t, "get_user", msgs[0].ToolCalls[0].Function.Name)
+		assert.Equal(t, schema.Tool, msgs[1].Role)
+		assert.Equal(t, "vip", msgs[1].Content)
+	}
+}
+
+func TestToolPreRetrieveNoBinding(t *testing.T) {
+	svc := &fakePluginSVC{}
+	prev := crossplugin.DefaultSVC()
+	crossplugin.SetDefaultSVC(svc)
+	defer crossplugin.SetDefaultSVC(prev)
+
+	pr := newPreToolRetriever(&toolPreCallConf{
+		userID:        "1",
+		agentIdentity: &entity.AgentIdentity{AgentID: 10},
+	})
+	msgs, err := pr.toolPreRetrieve(context.Background(), &AgentRequest{
+		PreCallTools: []*agentrun.ToolsRetriever{
+			{Type: agentrun.ToolTypePlugin, PluginID: 1, ToolID: 11, ToolName: "get_user"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+	assert.Empty(t, svc.executed)
+}
@@ -2,10 +2,15 @@ package agentflow
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	crossvariables "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/variables"
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/variables/model"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
 )
 
 type variableConf struct {
@@ -15,8 +20,25 @@ type variableConf struct {
 
 func loadAgentVariables(ctx context.Context, vc *variableConf) (map[string]string, error) {
 	vbs := make(map[string]string)
+	if len(vc.Agent.Variables) == 0 {
+		return vbs, nil
+	}
+
+	vars, err := crossvariables.DefaultSVC().GetVariables(ctx, &model.GetVariablesRequest{
+		AgentID:      vc.Agent.AgentID,
+		ConnectorUID: vc.UserID,
+		Variables:    vc.Agent.Variables,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// todo:// 暂不支持变量
+	for _, v := range vars {
+		if v.PromptDisabled {
+			continue
+		}
+		vbs[v.Keyword] = v.Value
+	}
 
 	return vbs, nil
 }
@@ -38,6 +60,17 @@ func newAgentVariableTools(ctx context.Context, v *variableConf) ([]tool.Invokab
 - Only make decisions regarding tool invocation based on the user's intention and input related to variable setting.
 - Do not call the tool in any other situation not meeting the above conditions.
 `
+	var sb strings.Builder
+	for _, vb := range v.Agent.Variables {
+		if vb.GetKey() == "" || vb.GetIsDisabled() || vb.GetIsSystem() {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", vb.GetKey(), vb.GetDescription()))
+	}
+	if sb.Len() > 0 {
+		desc += "\n## Keywords\n" + sb.String()
+	}
+
 	at, err := utils.InferTool("setKeywordMemory", desc, a.Invoke)
 	if err != nil {
 		return nil, err
@@ -60,6 +93,31 @@ type KVMemoryVariable struct {
 }
 
 func (a *avTool) Invoke(ctx context.Context, v *KVMemoryVariable) (string, error) {
+	if v == nil || len(v.Data) == 0 {
+		return "no variable to set", nil
+	}
+
+	kvs := make(map[string]string, len(v.Data))
+	for _, kv := range v.Data {
+		if kv == nil || kv.Keyword == "" {
+			continue
+		}
+		kvs[kv.Keyword] = kv.Value
+	}
+
+	err := crossvariables.DefaultSVC().SetVariables(ctx, &model.SetVariablesRequest{
+		AgentID:      a.Agent.AgentID,
+		ConnectorUID: a.UserID,
+		Variables:    a.Agent.Variables,
+		KVs:          kvs,
+	})
+	if err != nil {
+		// 关键字不存在或只读时把原因返回给模型
+		if statusErr, ok := errorx.FromStatusError(err); ok && !statusErr.IsAffectStability() {
+			return statusErr.Msg(), nil
+		}
+		return "", err
+	}
 
 	return "success", nil
 }
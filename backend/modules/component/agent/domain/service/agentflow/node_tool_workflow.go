@@ -0,0 +1,31 @@
+package agentflow
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	crossworkflow "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow"
+	workflowModel "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/model"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+type workflowConfig struct {
+	agentIdentity *entity.AgentIdentity
+	creatorID     int64
+	workflowConf  []*bot_common.WorkflowInfo
+}
+
+// newWorkflowTools 草稿 Agent 使用工作流的草稿画布，线上 Agent 使用工作流最新发布的版本
+func newWorkflowTools(ctx context.Context, conf *workflowConfig) ([]tool.BaseTool, map[string]bool, error) {
+	policies := slices.Transform(conf.workflowConf, func(w *bot_common.WorkflowInfo) *workflowModel.WorkflowToolPolicy {
+		return &workflowModel.WorkflowToolPolicy{
+			WorkflowID: w.GetWorkflowId(),
+			IsDraft:    conf.agentIdentity.IsDraft,
+			CreatorID:  conf.creatorID,
+		}
+	})
+
+	return crossworkflow.DefaultSVC().WorkflowAsModelTool(ctx, policies)
+}
@@ -0,0 +1,27 @@
+package agentflow
+
+const (
+	placeholderOfPrevSummary  = "previous_summary"
+	placeholderOfConversation = "conversation"
+	placeholderOfMaxWords     = "max_words"
+)
+
+const HISTORY_SUMMARY_PROMPT_JINJA2 = `
+You maintain the long-term memory of a role-play conversation between a user and {{ agent_name }}.
+Merge the previous summary and the new conversation given by the user into one updated summary.
+
+### Requirements
+- Keep facts that matter later: names, relationships, preferences, promises, important events and the current situation of the story.
+- Drop greetings, small talk and details that are no longer relevant.
+- Write in the third person, as plain paragraphs without headings, in no more than {{ max_words }} words.
+- The output language must be consistent with the language of the conversation.
+- Output only the summary.
+`
+
+const HISTORY_SUMMARY_INPUT_JINJA2 = `
+### Previous Summary
+{{ previous_summary }}
+
+### New Conversation
+{{ conversation }}
+`
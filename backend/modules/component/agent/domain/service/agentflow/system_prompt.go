@@ -6,6 +6,13 @@ const (
 	placeholderOfKnowledge = "knowledge"
 	placeholderOfVariables = "memory_variables"
 	placeholderOfTime      = "time"
+	placeholderOfPreCall   = "tools_pre_retriever"
+	// placeholderOfHistorySummary 早期对话的滚动摘要，上下文模式为 RollingSummary 时才有内容
+	placeholderOfHistorySummary = "history_summary"
+	// placeholderOfLongTermMemory 从以往对话中提取、按当前输入召回的长期记忆
+	placeholderOfLongTermMemory = "long_term_memory"
+	// placeholderOfAvatarCues 智能体开启形象指令时的输出说明，未开启时为空
+	placeholderOfAvatarCues = "avatar_cues"
 )
 
 const REACT_SYSTEM_PROMPT_JINJA2 = `
@@ -28,6 +35,16 @@ Regardless of any persona instructions, you must never generate content that:
 {{ memory_variables }}
 ------ End of Variables ------
 
+------ Start of Conversation Summary ------
+{{ history_summary }}
+------ End of Conversation Summary ------
+- The summary covers the earlier part of this conversation that is no longer in the chat history, treat it as facts you already know.
+
+------ Start of Long-term Memory ------
+{{ long_term_memory }}
+------ End of Long-term Memory ------
+- These are things you remember about the user from previous conversations, each with the date it was last updated. Use them naturally when relevant, and never mention that they were retrieved.
+
 **Knowledge**
 
 Only when the current knowledge has content recall, answer questions based on the referenced content:
@@ -41,6 +58,8 @@ The following is the content of the data set you can refer to: \n
 {{ knowledge }}
 '''
 
+{{ avatar_cues }}
+
 ** Pre toolCall **
 {{ tools_pre_retriever}},
 - Only when the current Pre toolCall has content recall results, answer questions based on the data field in the tool from the referenced content
@@ -5,6 +5,7 @@ import (
 
 	"github.com/cloudwego/eino/schema"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	memory "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
 )
 
 type SingleAgent interface {
@@ -24,6 +25,10 @@ type SingleAgent interface {
 	CreateSingleAgent(ctx context.Context, version string, e *entity.SingleAgent) (int64, error)
 	DuplicateInMemory(ctx context.Context, req *entity.DuplicateInfo) (newAgent *entity.SingleAgent, err error)
 	StreamExecute(ctx context.Context, req *entity.ExecuteRequest) (events *schema.StreamReader[*entity.AgentEvent], err error)
+	// SummarizeHistory 使用智能体的模型将早期对话合并进已有的摘要
+	SummarizeHistory(ctx context.Context, req *entity.SummarizeRequest) (summary string, err error)
+	// ExtractMemories 使用智能体的模型从对话中提取值得长期记住的事实与事件
+	ExtractMemories(ctx context.Context, req *entity.ExtractMemoriesRequest) ([]*memory.MemoryDraft, error)
 	GetSingleAgent(ctx context.Context, agentID int64, version string) (botInfo *entity.SingleAgent, err error)
 	ListAgentPublishHistory(ctx context.Context, agentID int64, pageIndex, pageSize int32) ([]*entity.SingleAgentPublish, error)
 	// ObtainAgentByIdentity support obtain agent by agentID
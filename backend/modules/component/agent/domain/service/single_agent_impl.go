@@ -13,6 +13,7 @@ import (
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/domain/service/agentflow"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/pkg/errno"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	memory "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
 	"github.com/kiosk404/airi-go/backend/pkg/errorx"
 	"github.com/kiosk404/airi-go/backend/pkg/kvstore"
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
@@ -210,12 +211,42 @@ func (s singleAgentImpl) StreamExecute(ctx context.Context, req *entity.ExecuteR
 		History:  req.History,
 		Identity: req.Identity,
 
+		HistorySummary: req.HistorySummary,
+
 		ResumeInfo:   req.ResumeInfo,
 		PreCallTools: req.PreCallTools,
+
+		ConversationID: req.ConversationID,
 	}
 	return rn.StreamExecute(ctx, rn.PreHandlerReq(ctx, exeReq))
 }
 
+func (s singleAgentImpl) SummarizeHistory(ctx context.Context, req *entity.SummarizeRequest) (string, error) {
+	ae, err := s.ObtainAgentByIdentity(ctx, req.Identity)
+	if err != nil {
+		return "", err
+	}
+
+	return agentflow.SummarizeHistory(ctx, &agentflow.SummaryConfig{
+		Agent:          ae,
+		UserID:         req.UserID,
+		ConversationID: req.ConversationID,
+	}, req.Summary, req.Messages)
+}
+
+func (s singleAgentImpl) ExtractMemories(ctx context.Context, req *entity.ExtractMemoriesRequest) ([]*memory.MemoryDraft, error) {
+	ae, err := s.ObtainAgentByIdentity(ctx, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return agentflow.ExtractMemories(ctx, &agentflow.SummaryConfig{
+		Agent:          ae,
+		UserID:         req.UserID,
+		ConversationID: req.ConversationID,
+	}, req.Messages)
+}
+
 func (s singleAgentImpl) GetSingleAgent(ctx context.Context, agentID int64, version string) (botInfo *entity.SingleAgent, err error) {
 	if len(version) == 0 {
 		return s.GetSingleAgentDraft(ctx, agentID)
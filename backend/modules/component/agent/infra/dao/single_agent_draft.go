@@ -181,6 +181,8 @@ func (sa *SingleAgentDraftDAO) singleAgentDraftPo2Do(po *model.SingleAgentDraft)
 			ShortcutCommand:         po.ShortcutCommand,
 			BotMode:                 bot_common.BotMode(po.BotMode),
 			LayoutInfo:              po.LayoutInfo,
+			AvatarInfo:              po.AvatarInfo,
+			VoicesInfo:              po.VoicesInfo,
 		},
 	}
 }
@@ -209,5 +211,7 @@ func (sa *SingleAgentDraftDAO) singleAgentDraftDo2Po(do *entity.SingleAgent) *mo
 		ShortcutCommand:         do.ShortcutCommand,
 		BotMode:                 int32(do.BotMode),
 		LayoutInfo:              do.LayoutInfo,
+		AvatarInfo:              do.AvatarInfo,
+		VoicesInfo:              do.VoicesInfo,
 	}
 }
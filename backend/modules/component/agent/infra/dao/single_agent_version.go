@@ -86,6 +86,8 @@ func (sa *SingleAgentVersionDAO) singleAgentVersionPo2Do(po *model.SingleAgentVe
 			Database:        po.DatabaseConfig,
 			ShortcutCommand: po.ShortcutCommand,
 			Version:         po.Version,
+			AvatarInfo:      po.AvatarInfo,
+			VoicesInfo:      po.VoicesInfo,
 		},
 	}
 }
@@ -110,5 +112,7 @@ func (sa *SingleAgentVersionDAO) singleAgentVersionDo2Po(do *entity.SingleAgent)
 		Variable:        do.Variables,
 		DatabaseConfig:  do.Database,
 		ShortcutCommand: do.ShortcutCommand,
+		AvatarInfo:      do.AvatarInfo,
+		VoicesInfo:      do.VoicesInfo,
 	}
 }
@@ -36,6 +36,8 @@ type SingleAgentDraft struct {
 	BotMode                 int32                             `gorm:"column:bot_mode;type:tinyint(4);not null;comment:bot mode,0:single mode 2:chatflow mode" json:"bot_mode"`                        // bot mode,0:single mode 2:chatflow mode
 	LayoutInfo              *bot_common.LayoutInfo            `gorm:"column:layout_info;type:text;comment:chatflow layout info;serializer:json" json:"layout_info"`                                   // chatflow layout info
 	ShortcutCommand         []string                          `gorm:"column:shortcut_command;type:json;comment:shortcut command;serializer:json" json:"shortcut_command"`                             // shortcut command
+	AvatarInfo              *bot_common.AvatarInfo            `gorm:"column:avatar_info;type:json;comment:avatar cue configuration;serializer:json" json:"avatar_info"`                               // avatar cue configuration
+	VoicesInfo              *bot_common.VoicesInfo            `gorm:"column:voices_info;type:json;comment:voices configuration;serializer:json" json:"voices_info"`                                   // voices configuration
 }
 
 // TableName SingleAgentDraft's table name
@@ -36,6 +36,8 @@ type SingleAgentVersion struct {
 	BackgroundImageInfoList []*bot_common.BackgroundImageInfo `gorm:"column:background_image_info_list;type:json;comment:Background image;serializer:json" json:"background_image_info_list"`             // Background image
 	DatabaseConfig          []*bot_common.Database            `gorm:"column:database_config;type:json;comment:Agent Database Base Configuration;serializer:json" json:"database_config"`                  // Agent Database Base Configuration
 	ShortcutCommand         []string                          `gorm:"column:shortcut_command;type:json;comment:shortcut command;serializer:json" json:"shortcut_command"`                                 // shortcut command
+	AvatarInfo              *bot_common.AvatarInfo            `gorm:"column:avatar_info;type:json;comment:avatar cue configuration;serializer:json" json:"avatar_info"`                                   // avatar cue configuration
+	VoicesInfo              *bot_common.VoicesInfo            `gorm:"column:voices_info;type:json;comment:voices configuration;serializer:json" json:"voices_info"`                                       // voices configuration
 }
 
 // TableName SingleAgentVersion's table name
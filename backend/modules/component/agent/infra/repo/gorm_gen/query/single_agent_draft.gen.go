@@ -50,6 +50,8 @@ func newSingleAgentDraft(db *gorm.DB, opts ...gen.DOOption) singleAgentDraft {
 	_singleAgentDraft.BotMode = field.NewInt32(tableName, "bot_mode")
 	_singleAgentDraft.LayoutInfo = field.NewField(tableName, "layout_info")
 	_singleAgentDraft.ShortcutCommand = field.NewField(tableName, "shortcut_command")
+	_singleAgentDraft.AvatarInfo = field.NewField(tableName, "avatar_info")
+	_singleAgentDraft.VoicesInfo = field.NewField(tableName, "voices_info")
 
 	_singleAgentDraft.fillFieldMap()
 
@@ -84,6 +86,8 @@ type singleAgentDraft struct {
 	BotMode                 field.Int32  // bot mode,0:single mode 2:chatflow mode
 	LayoutInfo              field.Field  // chatflow layout info
 	ShortcutCommand         field.Field  // shortcut command
+	AvatarInfo              field.Field  // avatar cue configuration
+	VoicesInfo              field.Field  // voices configuration
 
 	fieldMap map[string]field.Expr
 }
@@ -123,6 +127,8 @@ func (s *singleAgentDraft) updateTableName(table string) *singleAgentDraft {
 	s.BotMode = field.NewInt32(table, "bot_mode")
 	s.LayoutInfo = field.NewField(table, "layout_info")
 	s.ShortcutCommand = field.NewField(table, "shortcut_command")
+	s.AvatarInfo = field.NewField(table, "avatar_info")
+	s.VoicesInfo = field.NewField(table, "voices_info")
 
 	s.fillFieldMap()
 
@@ -151,7 +157,7 @@ func (s *singleAgentDraft) GetFieldByName(fieldName string) (field.OrderExpr, bo
 }
 
 func (s *singleAgentDraft) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 23)
+	s.fieldMap = make(map[string]field.Expr, 25)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["agent_id"] = s.AgentID
 	s.fieldMap["creator_id"] = s.CreatorID
@@ -175,6 +181,8 @@ func (s *singleAgentDraft) fillFieldMap() {
 	s.fieldMap["bot_mode"] = s.BotMode
 	s.fieldMap["layout_info"] = s.LayoutInfo
 	s.fieldMap["shortcut_command"] = s.ShortcutCommand
+	s.fieldMap["avatar_info"] = s.AvatarInfo
+	s.fieldMap["voices_info"] = s.VoicesInfo
 }
 
 func (s singleAgentDraft) clone(db *gorm.DB) singleAgentDraft {
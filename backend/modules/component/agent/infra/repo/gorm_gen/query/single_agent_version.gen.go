@@ -50,6 +50,8 @@ func newSingleAgentVersion(db *gorm.DB, opts ...gen.DOOption) singleAgentVersion
 	_singleAgentVersion.BackgroundImageInfoList = field.NewField(tableName, "background_image_info_list")
 	_singleAgentVersion.DatabaseConfig = field.NewField(tableName, "database_config")
 	_singleAgentVersion.ShortcutCommand = field.NewField(tableName, "shortcut_command")
+	_singleAgentVersion.AvatarInfo = field.NewField(tableName, "avatar_info")
+	_singleAgentVersion.VoicesInfo = field.NewField(tableName, "voices_info")
 
 	_singleAgentVersion.fillFieldMap()
 
@@ -84,6 +86,8 @@ type singleAgentVersion struct {
 	BackgroundImageInfoList field.Field  // Background image
 	DatabaseConfig          field.Field  // Agent Database Base Configuration
 	ShortcutCommand         field.Field  // shortcut command
+	AvatarInfo              field.Field  // avatar cue configuration
+	VoicesInfo              field.Field  // voices configuration
 
 	fieldMap map[string]field.Expr
 }
@@ -123,6 +127,8 @@ func (s *singleAgentVersion) updateTableName(table string) *singleAgentVersion {
 	s.BackgroundImageInfoList = field.NewField(table, "background_image_info_list")
 	s.DatabaseConfig = field.NewField(table, "database_config")
 	s.ShortcutCommand = field.NewField(table, "shortcut_command")
+	s.AvatarInfo = field.NewField(table, "avatar_info")
+	s.VoicesInfo = field.NewField(table, "voices_info")
 
 	s.fillFieldMap()
 
@@ -151,7 +157,7 @@ func (s *singleAgentVersion) GetFieldByName(fieldName string) (field.OrderExpr,
 }
 
 func (s *singleAgentVersion) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 23)
+	s.fieldMap = make(map[string]field.Expr, 25)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["agent_id"] = s.AgentID
 	s.fieldMap["name"] = s.Name
@@ -175,6 +181,8 @@ func (s *singleAgentVersion) fillFieldMap() {
 	s.fieldMap["background_image_info_list"] = s.BackgroundImageInfoList
 	s.fieldMap["database_config"] = s.DatabaseConfig
 	s.fieldMap["shortcut_command"] = s.ShortcutCommand
+	s.fieldMap["avatar_info"] = s.AvatarInfo
+	s.fieldMap["voices_info"] = s.VoicesInfo
 }
 
 func (s singleAgentVersion) clone(db *gorm.DB) singleAgentVersion {
@@ -6,6 +6,7 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
 	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/agentrun/model"
+	memory "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
 )
 
 // SingleAgent Requests and responses must not reference domain entities and can only use models under api/model/crossdomain.
@@ -13,6 +14,8 @@ type SingleAgent interface {
 	StreamExecute(ctx context.Context, agentRuntime *AgentRuntime) (*schema.StreamReader[*model.AgentEvent], error)
 	ObtainAgentByIdentity(ctx context.Context, identity *model.AgentIdentity) (*model.SingleAgent, error)
 	GetSingleAgentDraft(ctx context.Context, agentID int64) (agentInfo *model.SingleAgent, err error)
+	SummarizeHistory(ctx context.Context, req *SummarizeRequest) (string, error)
+	ExtractMemories(ctx context.Context, req *ExtractMemoriesRequest) ([]*memory.MemoryDraft, error)
 }
 
 type AgentRuntime struct {
@@ -25,8 +28,31 @@ type AgentRuntime struct {
 	CustomVariables  map[string]string
 
 	HistoryMsg []*schema.Message
-	Input      *schema.Message
-	ResumeInfo *ResumeInfo
+	// HistorySummary 已从 HistoryMsg 中移出的早期对话的摘要
+	HistorySummary string
+	Input          *schema.Message
+	ResumeInfo     *ResumeInfo
+}
+
+type SummarizeRequest struct {
+	AgentVersion   string
+	UserID         string
+	AgentID        int64
+	ConversationId int64
+	IsDraft        bool
+
+	Summary  string
+	Messages []*schema.Message
+}
+
+type ExtractMemoriesRequest struct {
+	AgentVersion   string
+	UserID         string
+	AgentID        int64
+	ConversationId int64
+	IsDraft        bool
+
+	Messages []*schema.Message
 }
 
 type ResumeInfo = model.InterruptInfo
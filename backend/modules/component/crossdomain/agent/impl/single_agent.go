@@ -10,6 +10,7 @@ import (
 	crossagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent"
 	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
 	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/agentrun/model"
+	memory "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
@@ -48,6 +49,7 @@ func (c *impl) buildSingleAgentStreamExecuteReq(ctx context.Context, agentRuntim
 		Identity:        c.buildIdentity(agentRuntime),
 		Input:           agentRuntime.Input,
 		History:         agentRuntime.HistoryMsg,
+		HistorySummary:  agentRuntime.HistorySummary,
 		UserID:          agentRuntime.UserID,
 		CustomVariables: agentRuntime.CustomVariables,
 		PreCallTools: slices.Transform(agentRuntime.PreRetrieveTools, func(tool *agentrun.Tool) *agentrun.ToolsRetriever {
@@ -65,7 +67,7 @@ func (c *impl) buildSingleAgentStreamExecuteReq(ctx context.Context, agentRuntim
 					}
 					return agentrun.ToolTypePlugin
 				}(tool.Type),
-				//PluginFrom: tool.PluginFrom,
+				PluginFrom: tool.PluginFrom,
 			}
 		}),
 		ResumeInfo: agentRuntime.ResumeInfo,
@@ -74,6 +76,33 @@ func (c *impl) buildSingleAgentStreamExecuteReq(ctx context.Context, agentRuntim
 	}
 }
 
+func (c *impl) SummarizeHistory(ctx context.Context, req *crossagent.SummarizeRequest) (string, error) {
+	return c.DomainSVC.SummarizeHistory(ctx, &model.SummarizeRequest{
+		Identity: &model.AgentIdentity{
+			AgentID: req.AgentID,
+			Version: req.AgentVersion,
+			IsDraft: req.IsDraft,
+		},
+		UserID:         req.UserID,
+		Summary:        req.Summary,
+		Messages:       req.Messages,
+		ConversationID: req.ConversationId,
+	})
+}
+
+func (c *impl) ExtractMemories(ctx context.Context, req *crossagent.ExtractMemoriesRequest) ([]*memory.MemoryDraft, error) {
+	return c.DomainSVC.ExtractMemories(ctx, &model.ExtractMemoriesRequest{
+		Identity: &model.AgentIdentity{
+			AgentID: req.AgentID,
+			Version: req.AgentVersion,
+			IsDraft: req.IsDraft,
+		},
+		UserID:         req.UserID,
+		Messages:       req.Messages,
+		ConversationID: req.ConversationId,
+	})
+}
+
 func (c *impl) buildIdentity(agentRuntime *crossagent.AgentRuntime) *model.AgentIdentity {
 	return &model.AgentIdentity{
 		AgentID: agentRuntime.AgentID,
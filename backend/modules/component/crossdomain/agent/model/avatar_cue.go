@@ -0,0 +1,44 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+)
+
+// AvatarCueType 回答中 [type:name] 形式的形象指令类型
+type AvatarCueType string
+
+const (
+	AvatarCueEmotion    AvatarCueType = "emotion"
+	AvatarCueMotion     AvatarCueType = "motion"
+	AvatarCueExpression AvatarCueType = "expression"
+)
+
+var AvatarCueTypes = []AvatarCueType{AvatarCueEmotion, AvatarCueMotion, AvatarCueExpression}
+
+// AllowedAvatarCues 按指令类型返回智能体允许输出的指令名，未开启或没有可用指令时返回 nil
+func AllowedAvatarCues(info *bot_common.AvatarInfo) map[AvatarCueType][]string {
+	if info == nil || !info.GetEnabled() {
+		return nil
+	}
+
+	cues := make(map[AvatarCueType][]string, len(AvatarCueTypes))
+	add := func(cueType AvatarCueType, names []string) {
+		for _, name := range names {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			cues[cueType] = append(cues[cueType], name)
+		}
+	}
+	add(AvatarCueEmotion, info.GetEmotions())
+	add(AvatarCueMotion, info.GetMotions())
+	add(AvatarCueExpression, info.GetExpressions())
+
+	if len(cues) == 0 {
+		return nil
+	}
+	return cues
+}
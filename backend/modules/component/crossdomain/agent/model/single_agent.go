@@ -4,6 +4,7 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
 	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/model"
+	workflowModel "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/model"
 	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/agentrun/model"
 	"gorm.io/gorm"
 )
@@ -19,6 +20,7 @@ const (
 	EventTypeOfSuggest                EventType = "suggest"
 	EventTypeOfKnowledge              EventType = "knowledge"
 	EventTypeOfInterrupt              EventType = "interrupt"
+	EventTypeOfPreToolCall            EventType = "pre_tool_call"
 )
 
 type AgentEvent struct {
@@ -61,6 +63,8 @@ type SingleAgent struct {
 	BotMode                 bot_common.BotMode
 	LayoutInfo              *bot_common.LayoutInfo
 	ShortcutCommand         []string
+	AvatarInfo              *bot_common.AvatarInfo
+	VoicesInfo              *bot_common.VoicesInfo
 }
 
 type InterruptEventType int64
@@ -83,7 +87,25 @@ type InterruptInfo struct {
 	InterruptType InterruptEventType
 	InterruptID   string
 
-	//ChatflowInterrupt *crossworkflow.StateMessage
+	// ChatflowInterrupt 对话流在输入节点中断时的执行现场，用于下一轮对话恢复执行
+	ChatflowInterrupt *workflowModel.ChatflowInterrupt
+	// Question 工具向用户提问或要求补充信息时的中断，用户的下一条消息作为回答恢复执行
+	Question *QuestionInterrupt
+}
+
+// QuestionInterrupt 工具通过 compose.Interrupt 抛出的提问，Fields 不为空时为需要用户补充信息的表单
+type QuestionInterrupt struct {
+	// ResumeID 中断点的地址，恢复时回答投递到该中断点
+	ResumeID string
+	Question string
+	Options  []string
+	Fields   []*RequireInfoField
+}
+
+type RequireInfoField struct {
+	Name        string `json:"name" jsonschema:"required,description=the name of the information to collect"`
+	Description string `json:"description,omitempty" jsonschema:"description=what the user should provide"`
+	Required    bool   `json:"required,omitempty" jsonschema:"description=whether the information is mandatory"`
 }
 
 type ExecuteRequest struct {
@@ -96,6 +118,29 @@ type ExecuteRequest struct {
 	PreCallTools []*agentrun.ToolsRetriever
 
 	CustomVariables map[string]string
+	// HistorySummary 已从 History 中移出的早期对话的摘要
+	HistorySummary string
+
+	ConversationID int64
+}
+
+// SummarizeRequest 将早期对话合并进已有的摘要
+type SummarizeRequest struct {
+	Identity *AgentIdentity
+	UserID   string
+
+	Summary  string
+	Messages []*schema.Message
+
+	ConversationID int64
+}
+
+// ExtractMemoriesRequest 从对话中提取长期记忆
+type ExtractMemoriesRequest struct {
+	Identity *AgentIdentity
+	UserID   string
+
+	Messages []*schema.Message
 
 	ConversationID int64
 }
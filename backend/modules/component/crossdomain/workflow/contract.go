@@ -0,0 +1,35 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/model"
+)
+
+type Workflow interface {
+	// WorkflowAsModelTool 将 Agent 绑定的工作流转换为工具，同时返回需要直接返回结果的工具名
+	WorkflowAsModelTool(ctx context.Context, policies []*model.WorkflowToolPolicy) ([]tool.BaseTool, map[string]bool, error)
+	// CheckWorkflowOwner 校验工作流均由该用户创建，Agent 绑定工作流前调用
+	CheckWorkflowOwner(ctx context.Context, userID int64, workflowIDs []int64) error
+	// ExecuteAsTool 以工具的方式执行一次工作流，返回值与工具输出一致
+	ExecuteAsTool(ctx context.Context, policy *model.WorkflowToolPolicy, argumentsInJSON string, conf *model.ExecuteConfig) (string, error)
+	// WithExecuteConfig 为图中所有工作流工具设置执行上下文
+	WithExecuteConfig(conf *model.ExecuteConfig) compose.Option
+	// WithMessagePipe 为图中所有工作流工具设置中间消息管道，执行结束后需调用返回的 closer
+	WithMessagePipe() (compose.Option, *schema.StreamReader[*model.WorkflowMessage], func())
+	// ExecuteChatflow 以对话流的方式执行一轮对话，节点产生的中间消息写入 sink，执行结束后由调用方关闭
+	ExecuteChatflow(ctx context.Context, req *model.ChatflowRequest, sink *schema.StreamWriter[*model.WorkflowMessage]) (*model.ChatflowResult, error)
+}
+
+var defaultSVC Workflow
+
+func DefaultSVC() Workflow {
+	return defaultSVC
+}
+
+func SetDefaultSVC(svc Workflow) {
+	defaultSVC = svc
+}
@@ -0,0 +1,106 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	crossworkflow "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/model"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+)
+
+// messagePipeCapacity 中间消息管道的缓冲大小
+const messagePipeCapacity = 10
+
+var defaultSVC crossworkflow.Workflow
+
+type impl struct {
+	DomainSVC service.Workflow
+}
+
+func InitDomainService(c service.Workflow) crossworkflow.Workflow {
+	defaultSVC = &impl{
+		DomainSVC: c,
+	}
+
+	return defaultSVC
+}
+
+func (i *impl) WorkflowAsModelTool(ctx context.Context, policies []*model.WorkflowToolPolicy) ([]tool.BaseTool, map[string]bool, error) {
+	return i.DomainSVC.WorkflowAsTools(ctx, policies)
+}
+
+func (i *impl) CheckWorkflowOwner(ctx context.Context, userID int64, workflowIDs []int64) error {
+	for _, id := range workflowIDs {
+		wf, err := i.DomainSVC.GetWorkflow(ctx, id)
+		if err != nil {
+			return err
+		}
+		if wf.CreatorID != userID {
+			return errorx.New(errno.ErrWorkflowPermissionCode, errorx.KVf("msg", "workflow %d is not owned by user %d", id, userID))
+		}
+	}
+	return nil
+}
+
+func (i *impl) ExecuteAsTool(ctx context.Context, policy *model.WorkflowToolPolicy, argumentsInJSON string, conf *model.ExecuteConfig) (string, error) {
+	tools, _, err := i.DomainSVC.WorkflowAsTools(ctx, []*model.WorkflowToolPolicy{policy})
+	if err != nil {
+		return "", err
+	}
+	t, ok := tools[0].(tool.InvokableTool)
+	if !ok {
+		return "", fmt.Errorf("workflow %d is not invokable", policy.WorkflowID)
+	}
+
+	return t.InvokableRun(ctx, argumentsInJSON, service.WithExecuteConfig(conf))
+}
+
+func (i *impl) WithExecuteConfig(conf *model.ExecuteConfig) compose.Option {
+	return compose.WithToolsNodeOption(compose.WithToolOption(service.WithExecuteConfig(conf)))
+}
+
+func (i *impl) WithMessagePipe() (compose.Option, *schema.StreamReader[*model.WorkflowMessage], func()) {
+	sr, sw := schema.Pipe[*model.WorkflowMessage](messagePipeCapacity)
+	opt := compose.WithToolsNodeOption(compose.WithToolOption(service.WithMessageSink(sw)))
+	return opt, sr, sw.Close
+}
+
+func (i *impl) ExecuteChatflow(ctx context.Context, req *model.ChatflowRequest, sink *schema.StreamWriter[*model.WorkflowMessage]) (*model.ChatflowResult, error) {
+	res, err := i.DomainSVC.Chatflow(ctx, &service.ChatflowRequest{
+		WorkflowID: req.WorkflowID,
+		Query:      req.Query,
+		IsDraft:    req.IsDraft,
+		CreatorID:  req.CreatorID,
+		Config:     req.Config,
+		ResumeFrom: req.ResumeFrom,
+	}, sink)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ChatflowResult{
+		ExecuteID: res.ExecuteID,
+		Interrupt: res.Interrupt,
+	}
+	if res.Interrupt != nil {
+		return result, nil
+	}
+	if res.TerminatePlan == entity.TerminatePlanUseAnswerContent {
+		result.Answer = res.Answer
+		return result, nil
+	}
+	if len(res.Output) > 0 {
+		if result.Answer, err = json.MarshalString(res.Output); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package model
+
+// ExecuteConfig 工作流执行上下文
+type ExecuteConfig struct {
+	// UserID 执行者，插件节点以该身份调用工具
+	UserID string
+	// AgentID 以 Agent 工具身份执行时所属的 Agent，为 0 表示独立运行
+	AgentID        int64
+	ConversationID int64
+	// Version 执行的版本，为空时执行草稿
+	Version string
+}
+
+func (c *ExecuteConfig) IsDraft() bool {
+	return c.Version == ""
+}
+
+// WorkflowMessage 工作流执行过程中推送给用户的中间消息，同一节点的多个分片以 Last 标识结束
+type WorkflowMessage struct {
+	ExecuteID string
+	NodeID    string
+	NodeName  string
+	NodeType  string
+	Content   string
+	Last      bool
+}
+
+// WorkflowToolPolicy Agent 绑定工作流的方式
+type WorkflowToolPolicy struct {
+	WorkflowID int64
+	// IsDraft 为 true 时使用草稿画布，否则使用最新发布的版本
+	IsDraft bool
+	// CreatorID Agent 的创建者，只能加载其创建的工作流
+	CreatorID int64
+}
+
+// ChatflowInterrupt 对话流执行到输入节点时中断，用户回复后通过 ExecuteID 与 InterruptID 恢复执行
+type ChatflowInterrupt struct {
+	WorkflowID int64
+	// Version 中断时执行的版本，恢复时需使用同一版本的画布
+	Version     string
+	ExecuteID   string
+	InterruptID string
+	NodeID      string
+	NodeName    string
+	// Prompt 渲染后展示给用户的提示
+	Prompt string
+}
+
+// ChatflowRequest 以对话流的方式执行一轮对话
+type ChatflowRequest struct {
+	WorkflowID int64
+	// Query 用户本轮输入，作为开始节点的 query 入参；恢复执行时作为输入节点的输出
+	Query string
+	// IsDraft 为 true 时执行草稿画布，否则执行最新发布的版本
+	IsDraft bool
+	// CreatorID Agent 的创建者，只能执行其创建的对话流
+	CreatorID int64
+	Config    *ExecuteConfig
+	// ResumeFrom 不为空时从上一轮中断的输入节点恢复执行
+	ResumeFrom *ChatflowInterrupt
+}
+
+// ChatflowResult 一轮对话流的执行结果，Interrupt 不为空时表示等待用户输入
+type ChatflowResult struct {
+	ExecuteID string
+	// Answer 结束节点的回答，TerminatePlan 为 return_variables 时为输出变量的 JSON
+	Answer    string
+	Interrupt *ChatflowInterrupt
+}
@@ -2,10 +2,434 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/bytedance/sonic"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/consts"
 	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/model"
+	"github.com/kiosk404/airi-go/backend/modules/component/plugin/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/plugin/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
 )
 
+const (
+	toolExecTimeout = 60 * time.Second
+	maxToolRespSize = 1 << 20
+)
+
+var toolHTTPClient = &http.Client{Timeout: toolExecTimeout}
+
+// ExecuteTool 按工具的 OpenAPI 定义组装 HTTP 请求调用插件服务，响应按 200 的 JSON schema 裁剪后返回
 func (p *pluginServiceImpl) ExecuteTool(ctx context.Context, req *model.ExecuteToolRequest, opts ...model.ExecuteToolOpt) (resp *model.ExecuteToolResponse, err error) {
-	panic("implement me")
+	opt := &model.ExecuteToolOption{}
+	for _, fn := range opts {
+		fn(opt)
+	}
+
+	pl, tool, err := p.getExecutablePluginAndTool(ctx, req, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	args := map[string]any{}
+	if strings.TrimSpace(req.ArgumentsInJson) != "" {
+		if err = sonic.UnmarshalString(req.ArgumentsInJson, &args); err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrPluginInvalidParamCode, errorx.KV(errno.PluginMsgKey,
+				"invalid tool arguments json"))
+		}
+	}
+
+	httpReq, reqBody, err := buildToolHTTPRequest(ctx, pl, tool, args)
+	if err != nil {
+		return nil, err
+	}
+
+	rawResp, err := doToolHTTPRequest(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedResp, err := trimToolResponse(tool, rawResp, opt.InvalidRespProcessStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ExecuteToolResponse{
+		Tool:        tool,
+		Request:     reqBody,
+		TrimmedResp: trimmedResp,
+		RawResp:     rawResp,
+		RespSchema:  tool.Operation.Responses,
+	}, nil
+}
+
+// getExecutablePluginAndTool 按草稿/指定版本/线上读取插件与工具，WithOpenapiOperation 传入的定义优先于库中的定义
+func (p *pluginServiceImpl) getExecutablePluginAndTool(ctx context.Context, req *model.ExecuteToolRequest,
+	opt *model.ExecuteToolOption) (pl *entity.PluginInfo, tool *entity.ToolInfo, err error) {
+	var pluginExist, toolExist bool
+	switch {
+	case req.ExecDraftTool:
+		pl, pluginExist, err = p.pluginRepo.GetDraftPlugin(ctx, req.PluginID)
+		if err == nil {
+			tool, toolExist, err = p.toolRepo.GetDraftTool(ctx, req.ToolID)
+		}
+	case opt.ToolVersion != "":
+		pl, pluginExist, err = p.pluginRepo.GetVersionPlugin(ctx, model.VersionPlugin{
+			PluginID: req.PluginID,
+			Version:  opt.ToolVersion,
+		})
+		if err == nil {
+			tool, toolExist, err = p.toolRepo.GetVersionTool(ctx, model.VersionTool{
+				ToolID:  req.ToolID,
+				Version: opt.ToolVersion,
+			})
+		}
+	default:
+		pl, pluginExist, err = p.pluginRepo.GetOnlinePlugin(ctx, req.PluginID)
+		if err == nil {
+			tool, toolExist, err = p.toolRepo.GetOnlineTool(ctx, req.ToolID)
+		}
+	}
+	if err != nil {
+		return nil, nil, errorx.Wrapf(err, "get plugin tool failed, pluginID=%d, toolID=%d", req.PluginID, req.ToolID)
+	}
+	if !pluginExist || !toolExist || tool.PluginID != req.PluginID {
+		return nil, nil, errorx.New(errno.ErrPluginRecordNotFound)
+	}
+	if tool.IsDeactivated() {
+		return nil, nil, errorx.New(errno.ErrPluginDeactivatedTool, errorx.KVf(errno.PluginMsgKey,
+			"tool '%s' is deactivated", tool.GetName()))
+	}
+
+	if opt.Operation != nil {
+		t := *tool
+		t.Operation = opt.Operation
+		tool = &t
+	}
+	if tool.Operation == nil || tool.Operation.Operation == nil {
+		return nil, nil, errorx.New(errno.ErrPluginExecuteToolFailed, errorx.KVf(errno.PluginMsgKey,
+			"operation of tool '%d' is required", tool.ID))
+	}
+
+	return pl, tool, nil
+}
+
+// buildToolHTTPRequest 将参数按 Operation.Parameters 放入 path/query/header，其余参数作为请求体，
+// 再补齐 manifest 中的公共参数与鉴权信息，返回的请求体字符串用于回显
+func buildToolHTTPRequest(ctx context.Context, pl *entity.PluginInfo, tool *entity.ToolInfo, args map[string]any) (*http.Request, string, error) {
+	serverURL := strings.TrimRight(pl.GetServerURL(), "/")
+	if serverURL == "" {
+		return nil, "", errorx.New(errno.ErrPluginExecuteToolFailed, errorx.KVf(errno.PluginMsgKey,
+			"server url of plugin '%d' is required", pl.ID))
+	}
+
+	subURL := tool.GetSubURL()
+	header := http.Header{}
+	query := url.Values{}
+
+	for _, ref := range tool.Operation.Parameters {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		param := ref.Value
+
+		val, ok := args[param.Name]
+		delete(args, param.Name)
+		if !ok && param.Schema != nil && param.Schema.Value != nil && param.Schema.Value.Default != nil {
+			val, ok = param.Schema.Value.Default, true
+		}
+		if !ok {
+			if param.Required {
+				return nil, "", errorx.New(errno.ErrPluginInvalidParamCode, errorx.KVf(errno.PluginMsgKey,
+					"parameter '%s' is required", param.Name))
+			}
+			continue
+		}
+
+		str := toolParamString(val)
+		switch param.In {
+		case openapi3.ParameterInPath:
+			subURL = strings.ReplaceAll(subURL, "{"+param.Name+"}", url.PathEscape(str))
+		case openapi3.ParameterInQuery:
+			query.Add(param.Name, str)
+		case openapi3.ParameterInHeader:
+			header.Set(param.Name, str)
+		case openapi3.ParameterInCookie:
+			header.Add("Cookie", (&http.Cookie{Name: param.Name, Value: str}).String())
+		}
+	}
+
+	if pl.Manifest != nil {
+		for loc, params := range pl.Manifest.CommonParams {
+			for _, cp := range params {
+				if cp == nil || cp.Name == "" {
+					continue
+				}
+				switch loc {
+				case consts.ParamInPath:
+					subURL = strings.ReplaceAll(subURL, "{"+cp.Name+"}", url.PathEscape(cp.Value))
+				case consts.ParamInQuery:
+					if !query.Has(cp.Name) {
+						query.Set(cp.Name, cp.Value)
+					}
+				case consts.ParamInHeader:
+					if header.Get(cp.Name) == "" {
+						header.Set(cp.Name, cp.Value)
+					}
+				case consts.ParamInBody:
+					if _, ok := args[cp.Name]; !ok {
+						args[cp.Name] = cp.Value
+					}
+				}
+			}
+		}
+	}
+
+	if err := applyToolAuth(pl, header, query); err != nil {
+		return nil, "", err
+	}
+
+	var (
+		body    io.Reader
+		reqBody string
+	)
+	if rb := tool.Operation.RequestBody; rb != nil && rb.Value != nil && len(rb.Value.Content) > 0 {
+		if rb.Value.Content.Get(consts.MediaTypeJson) == nil && rb.Value.Content.Get(consts.MediaTypeFormURLEncoded) != nil {
+			form := url.Values{}
+			for k, v := range args {
+				form.Set(k, toolParamString(v))
+			}
+			reqBody = form.Encode()
+			header.Set("Content-Type", consts.MediaTypeFormURLEncoded)
+		} else {
+			b, err := sonic.MarshalString(args)
+			if err != nil {
+				return nil, "", errorx.WrapByCode(err, errno.ErrPluginExecuteToolFailed, errorx.KV(errno.PluginMsgKey,
+					"marshal request body failed"))
+			}
+			reqBody = b
+			header.Set("Content-Type", consts.MediaTypeJson)
+		}
+		body = strings.NewReader(reqBody)
+	}
+
+	u, err := url.Parse(serverURL + "/" + strings.TrimLeft(subURL, "/"))
+	if err != nil {
+		return nil, "", errorx.WrapByCode(err, errno.ErrPluginExecuteToolFailed, errorx.KVf(errno.PluginMsgKey,
+			"invalid tool url '%s%s'", serverURL, subURL))
+	}
+	if len(query) > 0 {
+		q := u.Query()
+		for k, vs := range query {
+			for _, v := range vs {
+				q.Add(k, v)
+			}
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	method := tool.GetMethod()
+	if method == "" {
+		method = http.MethodGet
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, "", errorx.WrapByCode(err, errno.ErrPluginExecuteToolFailed, errorx.KV(errno.PluginMsgKey,
+			"build tool request failed"))
+	}
+	httpReq.Header = header
+
+	return httpReq, reqBody, nil
+}
+
+// applyToolAuth 目前只支持无鉴权与 service token 两种方式
+func applyToolAuth(pl *entity.PluginInfo, header http.Header, query url.Values) error {
+	auth := pl.GetAuthInfo()
+	if auth == nil || auth.Type == consts.AuthzTypeOfNone {
+		return nil
+	}
+
+	if auth.Type == consts.AuthzTypeOfService && auth.AuthOfAPIToken != nil {
+		token := auth.AuthOfAPIToken
+		switch consts.HTTPParamLocation(strings.ToLower(string(token.Location))) {
+		case consts.ParamInHeader:
+			header.Set(token.Key, token.ServiceToken)
+		case consts.ParamInQuery:
+			query.Set(token.Key, token.ServiceToken)
+		default:
+			return errorx.New(errno.ErrPluginExecuteToolFailed, errorx.KVf(errno.PluginMsgKey,
+				"invalid service token location '%s'", token.Location))
+		}
+		return nil
+	}
+
+	return errorx.New(errno.ErrPluginExecuteToolFailed, errorx.KVf(errno.PluginMsgKey,
+		"auth type '%s' is not supported yet", auth.Type))
+}
+
+func doToolHTTPRequest(httpReq *http.Request) (string, error) {
+	resp, err := toolHTTPClient.Do(httpReq)
+	if err != nil {
+		return "", errorx.WrapByCode(err, errno.ErrPluginExecuteToolFailed, errorx.KVf(errno.PluginMsgKey,
+			"request plugin server failed, err=%v", err))
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxToolRespSize+1))
+	if err != nil {
+		return "", errorx.WrapByCode(err, errno.ErrPluginExecuteToolFailed, errorx.KV(errno.PluginMsgKey,
+			"read plugin response failed"))
+	}
+	if len(data) > maxToolRespSize {
+		return "", errorx.New(errno.ErrPluginExecuteToolFailed, errorx.KVf(errno.PluginMsgKey,
+			"plugin response exceeds %d bytes", maxToolRespSize))
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", errorx.New(errno.ErrPluginExecuteToolFailed, errorx.KVf(errno.PluginMsgKey,
+			"plugin server returned status %d: %s", resp.StatusCode, truncateToolResp(string(data))))
+	}
+
+	return string(data), nil
+}
+
+// trimToolResponse 只保留 200 响应 schema 中声明的字段，类型不符的字段按 strategy 处理；
+// 工具未声明 JSON 响应 schema 时原样返回
+func trimToolResponse(tool *entity.ToolInfo, rawResp string, strategy consts.InvalidResponseProcessStrategy) (string, error) {
+	respSchema, err := tool.GetResponseOpenapiSchema()
+	if err != nil || len(respSchema.Properties) == 0 {
+		return rawResp, nil
+	}
+
+	var val any
+	if err = sonic.UnmarshalString(rawResp, &val); err != nil {
+		if strategy == consts.InvalidResponseProcessStrategyOfReturnErr {
+			return "", errorx.WrapByCode(err, errno.ErrPluginParseToolRespFailed, errorx.KV(errno.PluginMsgKey,
+				"response is not a valid json"))
+		}
+		return rawResp, nil
+	}
+
+	val, err = trimBySchema("", respSchema, val, strategy)
+	if err != nil {
+		return "", err
+	}
+
+	trimmed, err := sonic.MarshalString(val)
+	if err != nil {
+		return "", errorx.WrapByCode(err, errno.ErrPluginParseToolRespFailed, errorx.KV(errno.PluginMsgKey,
+			"marshal trimmed response failed"))
+	}
+
+	return trimmed, nil
+}
+
+func trimBySchema(path string, sc *openapi3.Schema, val any, strategy consts.InvalidResponseProcessStrategy) (any, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	var err error
+	switch sc.Type {
+	case openapi3.TypeObject:
+		obj, ok := val.(map[string]any)
+		if !ok {
+			break
+		}
+		if len(sc.Properties) == 0 {
+			return obj, nil
+		}
+		res := make(map[string]any, len(sc.Properties))
+		for name, prop := range sc.Properties {
+			v, exist := obj[name]
+			if !exist {
+				continue
+			}
+			if prop != nil && prop.Value != nil {
+				if v, err = trimBySchema(joinRespPath(path, name), prop.Value, v, strategy); err != nil {
+					return nil, err
+				}
+			}
+			res[name] = v
+		}
+		return res, nil
+	case openapi3.TypeArray:
+		arr, ok := val.([]any)
+		if !ok {
+			break
+		}
+		if sc.Items == nil || sc.Items.Value == nil {
+			return arr, nil
+		}
+		for i := range arr {
+			if arr[i], err = trimBySchema(fmt.Sprintf("%s[%d]", path, i), sc.Items.Value, arr[i], strategy); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	case openapi3.TypeString:
+		if _, ok := val.(string); ok {
+			return val, nil
+		}
+	case openapi3.TypeNumber:
+		if _, ok := val.(float64); ok {
+			return val, nil
+		}
+	case openapi3.TypeInteger:
+		if f, ok := val.(float64); ok && f == math.Trunc(f) {
+			return val, nil
+		}
+	case openapi3.TypeBoolean:
+		if _, ok := val.(bool); ok {
+			return val, nil
+		}
+	default:
+		return val, nil
+	}
+
+	switch strategy {
+	case consts.InvalidResponseProcessStrategyOfReturnDefault:
+		return sc.Default, nil
+	case consts.InvalidResponseProcessStrategyOfReturnErr:
+		return nil, errorx.New(errno.ErrPluginParseToolRespFailed, errorx.KVf(errno.PluginMsgKey,
+			"the type of field '%s' should be '%s'", path, sc.Type))
+	default:
+		return val, nil
+	}
+}
+
+func joinRespPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func toolParamString(val any) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case float64, bool, int64, int:
+		return fmt.Sprint(v)
+	default:
+		s, err := sonic.MarshalString(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return s
+	}
+}
+
+func truncateToolResp(s string) string {
+	const maxLen = 256
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen] + "..."
 }
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kiosk404/airi-go/backend/api/model/component/plugin_develop/common"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/consts"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/model"
+	"github.com/kiosk404/airi-go/backend/modules/component/plugin/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/plugin/domain/repo"
+	"github.com/kiosk404/airi-go/backend/modules/component/plugin/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memPluginRepo struct {
+	repo.PluginRepository
+	online map[int64]*entity.PluginInfo
+}
+
+func (m *memPluginRepo) GetOnlinePlugin(_ context.Context, pluginID int64, _ ...repo.PluginSelectedOptions) (*entity.PluginInfo, bool, error) {
+	pl, ok := m.online[pluginID]
+	return pl, ok, nil
+}
+
+type memToolRepo struct {
+	repo.ToolRepository
+	online map[int64]*entity.ToolInfo
+}
+
+func (m *memToolRepo) GetOnlineTool(_ context.Context, toolID int64) (*entity.ToolInfo, bool, error) {
+	tool, ok := m.online[toolID]
+	return tool, ok, nil
+}
+
+func newWeatherTool() *entity.ToolInfo {
+	strSchema := openapi3.NewStringSchema()
+	return &entity.ToolInfo{
+		ID:       2,
+		PluginID: 1,
+		Method:   ptr.Of(http.MethodPost),
+		SubURL:   ptr.Of("/cities/{city}/weather"),
+		Operation: model.NewOpenapi3Operation(&openapi3.Operation{
+			OperationID: "get_weather",
+			Summary:     "get weather",
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewPathParameter("city").WithSchema(strSchema).WithRequired(true)},
+				{Value: openapi3.NewQueryParameter("unit").WithSchema(openapi3.NewStringSchema().WithDefault("c"))},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().WithJSONSchema(openapi3.NewObjectSchema().
+					WithProperty("days", openapi3.NewIntegerSchema())),
+			},
+			Responses: openapi3.Responses{
+				"200": {Value: openapi3.NewResponse().WithJSONSchema(openapi3.NewObjectSchema().
+					WithProperty("temp", openapi3.NewIntegerSchema()).
+					WithProperty("desc", openapi3.NewStringSchema()))},
+			},
+		}),
+	}
+}
+
+func TestExecuteTool(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/cities/beijing/weather" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "c", r.URL.Query().Get("unit"))
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		assert.Equal(t, "airi", r.Header.Get("User-Agent"))
+
+		body, _ := io.ReadAll(r.Body)
+		reqBody := map[string]any{}
+		assert.NoError(t, json.Unmarshal(body, &reqBody))
+		assert.Equal(t, map[string]any{"days": float64(3), "lang": "zh"}, reqBody)
+
+		w.Header().Set("Content-Type", consts.MediaTypeJson)
+		_, _ = w.Write([]byte(`{"temp":"hot","desc":"sunny","debug":"internal"}`))
+	}))
+	defer srv.Close()
+
+	pl := entity.NewPluginInfo(&model.PluginInfo{
+		ID:        1,
+		ServerURL: ptr.Of(srv.URL + "/api/"),
+		Manifest: &model.PluginManifest{
+			Auth: &model.AuthV2{
+				Type:    consts.AuthzTypeOfService,
+				SubType: consts.AuthzSubTypeOfServiceAPIToken,
+				AuthOfAPIToken: &model.AuthOfAPIToken{
+					Location:     consts.ParamInHeader,
+					Key:          "X-Api-Key",
+					ServiceToken: "secret",
+				},
+			},
+			CommonParams: map[consts.HTTPParamLocation][]*common.CommonParamSchema{
+				consts.ParamInHeader: {{Name: "User-Agent", Value: "airi"}},
+				consts.ParamInBody:   {{Name: "lang", Value: "zh"}},
+			},
+		},
+	})
+	p := &pluginServiceImpl{
+		pluginRepo: &memPluginRepo{online: map[int64]*entity.PluginInfo{1: pl}},
+		toolRepo:   &memToolRepo{online: map[int64]*entity.ToolInfo{2: newWeatherTool()}},
+	}
+	ctx := context.Background()
+
+	resp, err := p.ExecuteTool(ctx, &model.ExecuteToolRequest{
+		PluginID:        1,
+		ToolID:          2,
+		ExecScene:       consts.ExecSceneOfWorkflow,
+		ArgumentsInJson: `{"city":"beijing","days":3}`,
+	}, model.WithInvalidRespProcessStrategy(consts.InvalidResponseProcessStrategyOfReturnDefault))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"temp":"hot","desc":"sunny","debug":"internal"}`, resp.RawResp)
+	assert.JSONEq(t, `{"temp":null,"desc":"sunny"}`, resp.TrimmedResp)
+	assert.JSONEq(t, `{"days":3,"lang":"zh"}`, resp.Request)
+
+	var statusErr errorx.StatusError
+
+	_, err = p.ExecuteTool(ctx, &model.ExecuteToolRequest{
+		PluginID:        1,
+		ToolID:          2,
+		ArgumentsInJson: `{"city":"beijing","days":3}`,
+	}, model.WithInvalidRespProcessStrategy(consts.InvalidResponseProcessStrategyOfReturnErr))
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrPluginParseToolRespFailed), statusErr.Code())
+	}
+
+	_, err = p.ExecuteTool(ctx, &model.ExecuteToolRequest{PluginID: 1, ToolID: 2, ArgumentsInJson: `{"days":3}`})
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrPluginInvalidParamCode), statusErr.Code())
+	}
+
+	_, err = p.ExecuteTool(ctx, &model.ExecuteToolRequest{PluginID: 1, ToolID: 2, ArgumentsInJson: `{"city":"shanghai"}`})
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrPluginExecuteToolFailed), statusErr.Code())
+	}
+
+	_, err = p.ExecuteTool(ctx, &model.ExecuteToolRequest{PluginID: 3, ToolID: 2})
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrPluginRecordNotFound), statusErr.Code())
+	}
+}
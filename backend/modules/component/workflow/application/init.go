@@ -0,0 +1,26 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	workflow "github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/service"
+)
+
+type ServiceComponents struct {
+	DB      rdb.Provider
+	IDGen   idgen.IDGenerator
+	CPStore compose.CheckPointStore
+}
+
+func InitService(ctx context.Context, c *ServiceComponents) *WorkflowApplicationService {
+	WorkflowSVC.DomainSVC = workflow.NewWorkflowSVC(&workflow.Components{
+		DB:      c.DB,
+		IDGen:   c.IDGen,
+		CPStore: c.CPStore,
+	})
+
+	return WorkflowSVC
+}
@@ -0,0 +1,258 @@
+package application
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/kiosk404/airi-go/backend/api/model/component/workflow"
+	"github.com/kiosk404/airi-go/backend/application/ctxutil"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	service "github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+type WorkflowApplicationService struct {
+	DomainSVC service.Workflow
+}
+
+var WorkflowSVC = &WorkflowApplicationService{}
+
+func (w *WorkflowApplicationService) CreateWorkflow(ctx context.Context, req *workflow.CreateWorkflowRequest) (*workflow.CreateWorkflowResponse, error) {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return nil, errorx.New(errno.ErrWorkflowPermissionCode, errorx.KV("msg", "session is required"))
+	}
+
+	wf, err := w.DomainSVC.CreateWorkflow(ctx, &service.CreateWorkflowRequest{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		IconURI:     req.GetIconURI(),
+		Mode:        entity.Mode(req.GetMode()),
+		CreatorID:   *uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.CreateWorkflowResponse{
+		Data: workflowDO2DTO(wf, true),
+	}, nil
+}
+
+func (w *WorkflowApplicationService) UpdateWorkflow(ctx context.Context, req *workflow.UpdateWorkflowRequest) (*workflow.UpdateWorkflowResponse, error) {
+	if _, err := w.checkWorkflowPermission(ctx, req.GetWorkflowID()); err != nil {
+		return nil, err
+	}
+
+	err := w.DomainSVC.UpdateWorkflow(ctx, &service.UpdateWorkflowRequest{
+		WorkflowID:  req.GetWorkflowID(),
+		Name:        req.Name,
+		Description: req.Description,
+		IconURI:     req.IconURI,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.UpdateWorkflowResponse{}, nil
+}
+
+func (w *WorkflowApplicationService) SaveCanvas(ctx context.Context, req *workflow.SaveCanvasRequest) (*workflow.SaveCanvasResponse, error) {
+	if _, err := w.checkWorkflowPermission(ctx, req.GetWorkflowID()); err != nil {
+		return nil, err
+	}
+
+	canvas := &entity.Canvas{}
+	if err := json.Unmarshal([]byte(req.GetCanvas()), canvas); err != nil {
+		return nil, errorx.New(errno.ErrWorkflowInvalidParamCode, errorx.KV("msg", "invalid canvas json"))
+	}
+	if err := w.DomainSVC.SaveCanvas(ctx, req.GetWorkflowID(), canvas); err != nil {
+		return nil, err
+	}
+
+	return &workflow.SaveCanvasResponse{}, nil
+}
+
+func (w *WorkflowApplicationService) DeleteWorkflow(ctx context.Context, req *workflow.DeleteWorkflowRequest) (*workflow.DeleteWorkflowResponse, error) {
+	if _, err := w.checkWorkflowPermission(ctx, req.GetWorkflowID()); err != nil {
+		return nil, err
+	}
+
+	if err := w.DomainSVC.DeleteWorkflow(ctx, req.GetWorkflowID()); err != nil {
+		return nil, err
+	}
+
+	return &workflow.DeleteWorkflowResponse{}, nil
+}
+
+func (w *WorkflowApplicationService) GetWorkflow(ctx context.Context, req *workflow.GetWorkflowRequest) (*workflow.GetWorkflowResponse, error) {
+	wf, err := w.checkWorkflowPermission(ctx, req.GetWorkflowID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.GetWorkflowResponse{
+		Data: workflowDO2DTO(wf, true),
+	}, nil
+}
+
+func (w *WorkflowApplicationService) ListWorkflow(ctx context.Context, req *workflow.ListWorkflowRequest) (*workflow.ListWorkflowResponse, error) {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return nil, errorx.New(errno.ErrWorkflowPermissionCode, errorx.KV("msg", "session is required"))
+	}
+
+	res, err := w.DomainSVC.ListWorkflow(ctx, &service.ListWorkflowRequest{
+		CreatorID: *uid,
+		Name:      req.GetName(),
+		Page:      int(req.GetPage()),
+		PageSize:  int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.ListWorkflowResponse{
+		WorkflowList: slices.Transform(res.Workflows, func(wf *entity.Workflow) *workflow.WorkflowInfo {
+			return workflowDO2DTO(wf, false)
+		}),
+		Total: res.Total,
+	}, nil
+}
+
+func (w *WorkflowApplicationService) PublishWorkflow(ctx context.Context, req *workflow.PublishWorkflowRequest) (*workflow.PublishWorkflowResponse, error) {
+	wf, err := w.checkWorkflowPermission(ctx, req.GetWorkflowID())
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := w.DomainSVC.PublishWorkflow(ctx, &service.PublishWorkflowRequest{
+		WorkflowID:         wf.ID,
+		Version:            req.GetVersion(),
+		VersionDescription: req.GetVersionDescription(),
+		CreatorID:          wf.CreatorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.PublishWorkflowResponse{
+		Data: versionDO2DTO(v),
+	}, nil
+}
+
+func (w *WorkflowApplicationService) ListVersion(ctx context.Context, req *workflow.ListVersionRequest) (*workflow.ListVersionResponse, error) {
+	if _, err := w.checkWorkflowPermission(ctx, req.GetWorkflowID()); err != nil {
+		return nil, err
+	}
+
+	res, err := w.DomainSVC.ListVersion(ctx, &service.ListVersionRequest{
+		WorkflowID: req.GetWorkflowID(),
+		Page:       int(req.GetPage()),
+		PageSize:   int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.ListVersionResponse{
+		VersionList: slices.Transform(res.Versions, versionDO2DTO),
+		Total:       res.Total,
+	}, nil
+}
+
+// RunWorkflow 调试运行工作流，不推送中间消息
+func (w *WorkflowApplicationService) RunWorkflow(ctx context.Context, req *workflow.RunWorkflowRequest) (*workflow.RunWorkflowResponse, error) {
+	wf, err := w.checkWorkflowPermission(ctx, req.GetWorkflowID())
+	if err != nil {
+		return nil, err
+	}
+
+	input := make(map[string]any)
+	if req.GetInput() != "" {
+		if err = json.Unmarshal([]byte(req.GetInput()), &input); err != nil {
+			return nil, errorx.New(errno.ErrWorkflowInvalidParamCode, errorx.KV("msg", "input must be a json object"))
+		}
+	}
+
+	res, err := w.DomainSVC.Execute(ctx, &service.ExecuteRequest{
+		WorkflowID: wf.ID,
+		Input:      input,
+		Config: &entity.ExecuteConfig{
+			UserID:  strconv.FormatInt(wf.CreatorID, 10),
+			Version: req.GetVersion(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := json.MarshalString(res.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.RunWorkflowResponse{
+		Data: &workflow.RunWorkflowResult{
+			ExecuteID:     res.ExecuteID,
+			TerminatePlan: terminatePlanDO2DTO(res.TerminatePlan),
+			Output:        output,
+			Answer:        res.Answer,
+		},
+	}, nil
+}
+
+// checkWorkflowPermission 仅允许工作流创建者操作
+func (w *WorkflowApplicationService) checkWorkflowPermission(ctx context.Context, workflowID int64) (*entity.Workflow, error) {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return nil, errorx.New(errno.ErrWorkflowPermissionCode, errorx.KV("msg", "session is required"))
+	}
+
+	wf, err := w.DomainSVC.GetWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if wf.CreatorID != *uid {
+		return nil, errorx.New(errno.ErrWorkflowPermissionCode, errorx.KV("msg", "not the owner of the workflow"))
+	}
+	return wf, nil
+}
+
+func workflowDO2DTO(wf *entity.Workflow, withCanvas bool) *workflow.WorkflowInfo {
+	info := &workflow.WorkflowInfo{
+		WorkflowID:    wf.ID,
+		Name:          wf.Name,
+		Description:   wf.Description,
+		IconURI:       wf.IconURI,
+		Mode:          workflow.WorkflowMode(wf.Mode),
+		LatestVersion: wf.LatestVersion,
+		CreatorID:     wf.CreatorID,
+		CreatedAt:     wf.CreatedAt,
+		UpdatedAt:     wf.UpdatedAt,
+	}
+	if withCanvas && wf.Canvas != nil {
+		info.Canvas, _ = json.MarshalString(wf.Canvas)
+	}
+	return info
+}
+
+func versionDO2DTO(v *entity.WorkflowVersion) *workflow.WorkflowVersionInfo {
+	return &workflow.WorkflowVersionInfo{
+		WorkflowID:         v.WorkflowID,
+		Version:            v.Version,
+		VersionDescription: v.VersionDescription,
+		CreatorID:          v.CreatorID,
+		CreatedAt:          v.CreatedAt,
+	}
+}
+
+func terminatePlanDO2DTO(tp entity.TerminatePlan) workflow.TerminatePlan {
+	if tp == entity.TerminatePlanUseAnswerContent {
+		return workflow.TerminatePlan_UseAnswerContent
+	}
+	return workflow.TerminatePlan_ReturnVariables
+}
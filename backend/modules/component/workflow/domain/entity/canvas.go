@@ -0,0 +1,224 @@
+package entity
+
+// Canvas 工作流画布，由节点和连线组成的有向无环图
+type Canvas struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []*Edge `json:"edges"`
+}
+
+type NodeType string
+
+const (
+	NodeTypeEntry     NodeType = "entry"
+	NodeTypeExit      NodeType = "exit"
+	NodeTypeLLM       NodeType = "llm"
+	NodeTypePlugin    NodeType = "plugin"
+	NodeTypeCode      NodeType = "code"
+	NodeTypeCondition NodeType = "condition"
+	NodeTypeVariable  NodeType = "variable"
+	NodeTypeInput     NodeType = "input"
+)
+
+// Node 画布中的一个节点，仅与 Type 对应的配置生效
+//
+// 节点的输出以 {{ node_id.key }} 的形式被后续节点引用，
+// 变量节点赋值的工作流变量以 {{ variables.key }} 的形式引用
+type Node struct {
+	ID   string   `json:"id"`
+	Type NodeType `json:"type"`
+	Name string   `json:"name"`
+
+	Entry     *EntryConfig     `json:"entry,omitempty"`
+	Exit      *ExitConfig      `json:"exit,omitempty"`
+	LLM       *LLMConfig       `json:"llm,omitempty"`
+	Plugin    *PluginConfig    `json:"plugin,omitempty"`
+	Code      *CodeConfig      `json:"code,omitempty"`
+	Condition *ConditionConfig `json:"condition,omitempty"`
+	Variable  *VariableConfig  `json:"variable,omitempty"`
+	Input     *InputConfig     `json:"input,omitempty"`
+}
+
+// Edge 节点间的连线，SourcePort 仅对条件节点有效，用于指定分支
+type Edge struct {
+	SourceNodeID string `json:"source_node_id"`
+	TargetNodeID string `json:"target_node_id"`
+	SourcePort   string `json:"source_port,omitempty"`
+}
+
+type ParamType string
+
+const (
+	ParamTypeString  ParamType = "string"
+	ParamTypeInteger ParamType = "integer"
+	ParamTypeNumber  ParamType = "number"
+	ParamTypeBoolean ParamType = "boolean"
+	ParamTypeObject  ParamType = "object"
+	ParamTypeArray   ParamType = "array"
+)
+
+// ParamDefine 工作流入参定义
+type ParamDefine struct {
+	Name     string    `json:"name"`
+	Type     ParamType `json:"type"`
+	Desc     string    `json:"desc,omitempty"`
+	Required bool      `json:"required,omitempty"`
+	Default  any       `json:"default,omitempty"`
+}
+
+// Param 节点参数，Value 为模板表达式
+//
+// 当 Value 仅包含一个引用（如 "{{ entry.city }}"）时保留被引用值的原始类型，否则渲染为字符串
+type Param struct {
+	Name  string    `json:"name"`
+	Type  ParamType `json:"type,omitempty"`
+	Value string    `json:"value"`
+}
+
+type EntryConfig struct {
+	Inputs []*ParamDefine `json:"inputs"`
+}
+
+type TerminatePlan string
+
+const (
+	// TerminatePlanReturnVariables 以结构化变量作为工作流输出
+	TerminatePlanReturnVariables TerminatePlan = "return_variables"
+	// TerminatePlanUseAnswerContent 以渲染后的回答文本作为工作流输出，作为 Agent 工具时直接回复用户
+	TerminatePlanUseAnswerContent TerminatePlan = "use_answer_content"
+)
+
+type ExitConfig struct {
+	TerminatePlan TerminatePlan `json:"terminate_plan"`
+	Outputs       []*Param      `json:"outputs,omitempty"`
+	Answer        string        `json:"answer,omitempty"`
+}
+
+type LLMConfig struct {
+	ModelID      int64    `json:"model_id"`
+	Temperature  *float64 `json:"temperature,omitempty"`
+	MaxTokens    *int32   `json:"max_tokens,omitempty"`
+	SystemPrompt string   `json:"system_prompt,omitempty"`
+	UserPrompt   string   `json:"user_prompt"`
+	// StreamOutput 是否将模型输出作为中间消息流式推送给用户
+	StreamOutput bool `json:"stream_output,omitempty"`
+}
+
+type PluginConfig struct {
+	PluginID int64    `json:"plugin_id"`
+	ToolID   int64    `json:"tool_id"`
+	Inputs   []*Param `json:"inputs,omitempty"`
+}
+
+// CodeConfig 代码节点，使用 expr-lang 表达式计算输出，表达式中可直接使用 Inputs 中声明的变量
+type CodeConfig struct {
+	Inputs  []*Param      `json:"inputs,omitempty"`
+	Outputs []*CodeOutput `json:"outputs"`
+}
+
+type CodeOutput struct {
+	Name string `json:"name"`
+	Expr string `json:"expr"`
+}
+
+// 条件节点的分支端口，第 i 个分支为 branch_i，均不满足时走 default
+const (
+	PortDefault      = "default"
+	PortBranchPrefix = "branch_"
+)
+
+type ConditionConfig struct {
+	Branches []*Branch `json:"branches"`
+}
+
+type Logic string
+
+const (
+	LogicAnd Logic = "and"
+	LogicOr  Logic = "or"
+)
+
+type Branch struct {
+	Logic      Logic        `json:"logic,omitempty"`
+	Conditions []*Condition `json:"conditions"`
+}
+
+type Operator string
+
+const (
+	OperatorEqual       Operator = "eq"
+	OperatorNotEqual    Operator = "ne"
+	OperatorGreater     Operator = "gt"
+	OperatorGreaterOrEq Operator = "ge"
+	OperatorLess        Operator = "lt"
+	OperatorLessOrEq    Operator = "le"
+	OperatorContains    Operator = "contains"
+	OperatorNotContains Operator = "not_contains"
+	OperatorEmpty       Operator = "empty"
+	OperatorNotEmpty    Operator = "not_empty"
+)
+
+type Condition struct {
+	Left     string   `json:"left"`
+	Operator Operator `json:"operator"`
+	Right    string   `json:"right,omitempty"`
+}
+
+// VariableConfig 变量节点，为工作流变量赋值
+type VariableConfig struct {
+	Assignments []*Param `json:"assignments"`
+}
+
+// InputConfig 输入节点，仅在对话流中可用
+//
+// 执行到该节点时中断并将 Prompt 渲染后展示给用户，用户的下一条消息作为节点输出 {{ node_id.input }} 恢复执行
+type InputConfig struct {
+	Prompt string `json:"prompt"`
+}
+
+func (c *Canvas) GetNode(id string) *Node {
+	for _, n := range c.Nodes {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+func (c *Canvas) GetNodeByType(t NodeType) *Node {
+	for _, n := range c.Nodes {
+		if n.Type == t {
+			return n
+		}
+	}
+	return nil
+}
+
+// InputParams 工作流的入参定义
+func (c *Canvas) InputParams() []*ParamDefine {
+	entry := c.GetNodeByType(NodeTypeEntry)
+	if entry == nil || entry.Entry == nil {
+		return nil
+	}
+	return entry.Entry.Inputs
+}
+
+func (c *Canvas) TerminatePlan() TerminatePlan {
+	exit := c.GetNodeByType(NodeTypeExit)
+	if exit == nil || exit.Exit == nil {
+		return TerminatePlanReturnVariables
+	}
+	return exit.Exit.TerminatePlan
+}
+
+// DefaultCanvas 新建工作流时的初始画布，开始节点直连结束节点
+func DefaultCanvas() *Canvas {
+	return &Canvas{
+		Nodes: []*Node{
+			{ID: "entry", Type: NodeTypeEntry, Name: "开始", Entry: &EntryConfig{}},
+			{ID: "exit", Type: NodeTypeExit, Name: "结束", Exit: &ExitConfig{TerminatePlan: TerminatePlanReturnVariables}},
+		},
+		Edges: []*Edge{
+			{SourceNodeID: "entry", TargetNodeID: "exit"},
+		},
+	}
+}
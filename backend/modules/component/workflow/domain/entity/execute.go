@@ -0,0 +1,28 @@
+package entity
+
+import (
+	model "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/model"
+)
+
+type ExecuteConfig = model.ExecuteConfig
+
+// ExecuteResult 工作流执行结果
+type ExecuteResult struct {
+	ExecuteID     string
+	TerminatePlan TerminatePlan
+	// Output 结束节点输出的变量
+	Output map[string]any
+	// Answer TerminatePlan 为 use_answer_content 时渲染出的回答
+	Answer string
+	// Interrupt 执行在输入节点中断时不为空，此时 Output 与 Answer 均为空
+	Interrupt *Interrupt
+}
+
+type Interrupt = model.ChatflowInterrupt
+
+// ChatflowInputQuery 对话流开始节点接收用户输入的入参名
+const ChatflowInputQuery = "query"
+
+type Message = model.WorkflowMessage
+
+type WorkflowToolPolicy = model.WorkflowToolPolicy
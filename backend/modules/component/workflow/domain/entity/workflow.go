@@ -0,0 +1,39 @@
+package entity
+
+// Mode 取值与 bot_common.WorkflowMode 保持一致
+type Mode int32
+
+const (
+	ModeWorkflow Mode = 0
+	ModeChatFlow Mode = 3
+)
+
+// Workflow 工作流元信息及草稿画布
+type Workflow struct {
+	ID          int64
+	Name        string
+	Description string
+	IconURI     string
+	Mode        Mode
+	Canvas      *Canvas
+	// LatestVersion 最新发布的版本，为空表示从未发布
+	LatestVersion string
+	CreatorID     int64
+	CreatedAt     int64
+	UpdatedAt     int64
+}
+
+func (w *Workflow) IsPublished() bool {
+	return w.LatestVersion != ""
+}
+
+// WorkflowVersion 发布时的画布快照，发布后不可修改
+type WorkflowVersion struct {
+	ID                 int64
+	WorkflowID         int64
+	Version            string
+	VersionDescription string
+	Canvas             *Canvas
+	CreatorID          int64
+	CreatedAt          int64
+}
@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/infra/dao"
+	"gorm.io/gorm"
+)
+
+func NewWorkflowRepo(db *gorm.DB) WorkflowRepo {
+	return dao.NewWorkflowDAO(db)
+}
+
+func NewWorkflowVersionRepo(db *gorm.DB) WorkflowVersionRepo {
+	return dao.NewWorkflowVersionDAO(db)
+}
+
+type WorkflowRepo interface {
+	Create(ctx context.Context, wf *entity.Workflow) error
+	UpdateMeta(ctx context.Context, id int64, name, description, iconURI *string) error
+	UpdateCanvas(ctx context.Context, id int64, canvas *entity.Canvas) error
+	UpdateLatestVersion(ctx context.Context, id int64, version string) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*entity.Workflow, error)
+	MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Workflow, error)
+	List(ctx context.Context, creatorID int64, name string, offset, limit int) ([]*entity.Workflow, int64, error)
+}
+
+type WorkflowVersionRepo interface {
+	Create(ctx context.Context, v *entity.WorkflowVersion) error
+	Get(ctx context.Context, workflowID int64, version string) (*entity.WorkflowVersion, error)
+	List(ctx context.Context, workflowID int64, offset, limit int) ([]*entity.WorkflowVersion, int64, error)
+	DeleteByWorkflowID(ctx context.Context, workflowID int64) error
+}
@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+)
+
+// nodeFunc 节点的执行逻辑
+//
+// scope 为执行到该节点时可被引用的所有值，input 仅开始节点使用，为工作流入参
+type nodeFunc func(ctx context.Context, node *entity.Node, scope map[string]any, input map[string]any) (map[string]any, error)
+
+var nodeFuncs = map[entity.NodeType]nodeFunc{
+	entity.NodeTypeEntry:     runEntry,
+	entity.NodeTypeExit:      runExit,
+	entity.NodeTypeLLM:       runLLM,
+	entity.NodeTypePlugin:    runPlugin,
+	entity.NodeTypeCode:      runCode,
+	entity.NodeTypeCondition: runCondition,
+	entity.NodeTypeVariable:  runVariable,
+	entity.NodeTypeInput:     runInput,
+}
+
+// Runner 编译后的工作流，可并发执行
+type Runner struct {
+	canvas   *entity.Canvas
+	runnable compose.Runnable[map[string]any, map[string]any]
+	// resumable 是否设置了 checkpoint store，未设置时在输入节点中断后无法恢复
+	resumable bool
+}
+
+// Build 校验画布并编译为 eino graph
+//
+// 画布中的每个节点对应 graph 中的一个 lambda 节点，节点输出以 {节点 ID: 输出} 的形式在边上传递，
+// 汇聚时各前驱的输出按 key 合并；节点实际读取的值来自共享的 State。
+// 条件节点的出边编译为 multi branch，图以 AllPredecessor 模式运行，未命中的分支会被整体跳过。
+// store 用于保存输入节点中断时的执行现场，为空时画布中的输入节点无法恢复执行
+func Build(ctx context.Context, canvas *entity.Canvas, store compose.CheckPointStore) (*Runner, error) {
+	if err := Validate(canvas); err != nil {
+		return nil, err
+	}
+
+	g := compose.NewGraph[map[string]any, map[string]any](compose.WithGenLocalState(newState))
+	for _, n := range canvas.Nodes {
+		if err := g.AddLambdaNode(n.ID, compose.InvokableLambda(wrapNode(n)), compose.WithNodeName(n.Name)); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := canvas.GetNodeByType(entity.NodeTypeEntry)
+	exit := canvas.GetNodeByType(entity.NodeTypeExit)
+	if err := g.AddEdge(compose.START, entry.ID); err != nil {
+		return nil, err
+	}
+	if err := g.AddEdge(exit.ID, compose.END); err != nil {
+		return nil, err
+	}
+
+	portTargets := make(map[string]map[string][]string)
+	for _, e := range canvas.Edges {
+		if canvas.GetNode(e.SourceNodeID).Type != entity.NodeTypeCondition {
+			if err := g.AddEdge(e.SourceNodeID, e.TargetNodeID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if portTargets[e.SourceNodeID] == nil {
+			portTargets[e.SourceNodeID] = make(map[string][]string)
+		}
+		portTargets[e.SourceNodeID][e.SourcePort] = append(portTargets[e.SourceNodeID][e.SourcePort], e.TargetNodeID)
+	}
+	for nodeID, targets := range portTargets {
+		if err := g.AddBranch(nodeID, newConditionBranch(nodeID, targets)); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []compose.GraphCompileOption{
+		compose.WithGraphName("workflow"),
+		compose.WithNodeTriggerMode(compose.AllPredecessor),
+	}
+	if store != nil {
+		opts = append(opts, compose.WithCheckPointStore(store))
+	}
+	runnable, err := g.Compile(ctx, opts...)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowCanvasInvalidCode, errorx.KV("msg", err.Error()))
+	}
+
+	return &Runner{canvas: canvas, runnable: runnable, resumable: store != nil}, nil
+}
+
+func wrapNode(n *entity.Node) func(ctx context.Context, in map[string]any) (map[string]any, error) {
+	run := nodeFuncs[n.Type]
+	return func(ctx context.Context, in map[string]any) (map[string]any, error) {
+		scope, err := snapshotScope(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := run(ctx, n, scope, in)
+		if _, ok := compose.IsInterruptRerunError(err); ok {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("node '%s' failed: %w", n.ID, err)
+		}
+		if err = saveOutput(ctx, n.ID, out); err != nil {
+			return nil, err
+		}
+
+		if n.Type == entity.NodeTypeExit {
+			return out, nil
+		}
+		return map[string]any{n.ID: out}, nil
+	}
+}
+
+func newConditionBranch(nodeID string, portTargets map[string][]string) *compose.GraphBranch {
+	endNodes := make(map[string]bool)
+	for _, targets := range portTargets {
+		for _, t := range targets {
+			endNodes[t] = true
+		}
+	}
+
+	return compose.NewGraphMultiBranch(func(_ context.Context, in map[string]any) (map[string]bool, error) {
+		out, _ := in[nodeID].(map[string]any)
+		port, _ := out[conditionKeyPort].(string)
+		targets := portTargets[port]
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("condition node '%s' port '%s' has no successor", nodeID, port)
+		}
+
+		res := make(map[string]bool, len(targets))
+		for _, t := range targets {
+			res[t] = true
+		}
+		return res, nil
+	}, endNodes)
+}
+
+// Run 执行工作流，sink 不为空时推送节点产生的中间消息
+//
+// 执行到输入节点时返回的结果中 Interrupt 不为空，之后可通过 Resume 携带用户输入继续执行
+func (r *Runner) Run(ctx context.Context, executeID string, input map[string]any, conf *entity.ExecuteConfig,
+	sink *schema.StreamWriter[*entity.Message],
+) (*entity.ExecuteResult, error) {
+	if input == nil {
+		input = make(map[string]any)
+	}
+	return r.invoke(ctx, executeID, input, conf, sink)
+}
+
+// Resume 将 data 作为中断的输入节点的输出，从中断处继续执行
+func (r *Runner) Resume(ctx context.Context, interrupt *entity.Interrupt, data string, conf *entity.ExecuteConfig,
+	sink *schema.StreamWriter[*entity.Message],
+) (*entity.ExecuteResult, error) {
+	if !r.resumable {
+		return nil, errorx.New(errno.ErrWorkflowExecuteCode, errorx.KV("msg", "workflow is not resumable"))
+	}
+
+	ctx = compose.ResumeWithData(ctx, interrupt.InterruptID, data)
+	return r.invoke(ctx, interrupt.ExecuteID, make(map[string]any), conf, sink)
+}
+
+func (r *Runner) invoke(ctx context.Context, executeID string, input map[string]any, conf *entity.ExecuteConfig,
+	sink *schema.StreamWriter[*entity.Message],
+) (*entity.ExecuteResult, error) {
+	if conf == nil {
+		conf = &entity.ExecuteConfig{}
+	}
+
+	var opts []compose.Option
+	if r.resumable {
+		opts = append(opts, compose.WithCheckPointID(executeID))
+	}
+
+	ctx = withExecContext(ctx, &execContext{executeID: executeID, conf: conf, sink: sink})
+	out, err := r.runnable.Invoke(ctx, input, opts...)
+	if info, ok := compose.ExtractInterruptInfo(err); ok {
+		return r.interrupted(executeID, info)
+	}
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowExecuteCode, errorx.KV("msg", err.Error()))
+	}
+
+	res := &entity.ExecuteResult{
+		ExecuteID:     executeID,
+		TerminatePlan: r.canvas.TerminatePlan(),
+	}
+	res.Output, _ = out[exitKeyOutput].(map[string]any)
+	res.Answer, _ = out[exitKeyAnswer].(string)
+	return res, nil
+}
+
+// interrupted 从中断信息中取出首个中断的输入节点
+func (r *Runner) interrupted(executeID string, info *compose.InterruptInfo) (*entity.ExecuteResult, error) {
+	for _, ic := range info.InterruptContexts {
+		interrupt, ok := ic.Info.(*entity.Interrupt)
+		if !ok || !ic.IsRootCause {
+			continue
+		}
+		interrupt.ExecuteID = executeID
+		interrupt.InterruptID = ic.ID
+		return &entity.ExecuteResult{
+			ExecuteID:     executeID,
+			TerminatePlan: r.canvas.TerminatePlan(),
+			Interrupt:     interrupt,
+		}, nil
+	}
+	return nil, errorx.New(errno.ErrWorkflowExecuteCode, errorx.KV("msg", "workflow interrupted without input node"))
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/pkg/checkpoint"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/stretchr/testify/assert"
+)
+
+const gradeCanvas = `{
+  "nodes": [
+    {"id": "entry", "type": "entry", "entry": {"inputs": [{"name": "score", "type": "integer", "required": true}]}},
+    {"id": "calc", "type": "code", "code": {
+      "inputs": [{"name": "score", "value": "{{ entry.score }}"}],
+      "outputs": [{"name": "doubled", "expr": "score * 2"}, {"name": "passed", "expr": "doubled >= 120"}]
+    }},
+    {"id": "check", "type": "condition", "condition": {"branches": [
+      {"conditions": [{"left": "{{ calc.passed }}", "operator": "eq", "right": "true"}]}
+    ]}},
+    {"id": "pass", "type": "variable", "variable": {"assignments": [{"name": "grade", "value": "pass"}]}},
+    {"id": "fail", "type": "variable", "variable": {"assignments": [{"name": "grade", "value": "fail"}]}},
+    {"id": "exit", "type": "exit", "exit": {
+      "terminate_plan": "use_answer_content",
+      "outputs": [{"name": "doubled", "value": "{{ calc.doubled }}"}, {"name": "grade", "value": "{{ variables.grade }}"}],
+      "answer": "score {{ entry.score }} is {{ exit.grade }}"
+    }}
+  ],
+  "edges": [
+    {"source_node_id": "entry", "target_node_id": "calc"},
+    {"source_node_id": "calc", "target_node_id": "check"},
+    {"source_node_id": "check", "target_node_id": "pass", "source_port": "branch_0"},
+    {"source_node_id": "check", "target_node_id": "fail", "source_port": "default"},
+    {"source_node_id": "pass", "target_node_id": "exit"},
+    {"source_node_id": "fail", "target_node_id": "exit"}
+  ]
+}`
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+	canvas := &entity.Canvas{}
+	assert.NoError(t, json.Unmarshal([]byte(gradeCanvas), canvas))
+
+	r, err := Build(ctx, canvas, nil)
+	assert.NoError(t, err)
+
+	res, err := r.Run(ctx, "1", map[string]any{"score": "70"}, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "pass", res.Output["grade"])
+	assert.EqualValues(t, 140, res.Output["doubled"])
+	assert.Equal(t, "score 70 is pass", res.Answer)
+
+	res, err = r.Run(ctx, "2", map[string]any{"score": 30}, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "fail", res.Output["grade"])
+	assert.Equal(t, "score 30 is fail", res.Answer)
+
+	_, err = r.Run(ctx, "3", map[string]any{}, nil, nil)
+	assert.Error(t, err)
+}
+
+const askCanvas = `{
+  "nodes": [
+    {"id": "entry", "type": "entry"},
+    {"id": "greet", "type": "variable", "variable": {"assignments": [{"name": "greeting", "value": "hi {{ entry.query }}"}]}},
+    {"id": "ask", "type": "input", "input": {"prompt": "{{ variables.greeting }}, where are you from?"}},
+    {"id": "exit", "type": "exit", "exit": {
+      "terminate_plan": "use_answer_content",
+      "answer": "{{ entry.query }} from {{ ask.input }}"
+    }}
+  ],
+  "edges": [
+    {"source_node_id": "entry", "target_node_id": "greet"},
+    {"source_node_id": "greet", "target_node_id": "ask"},
+    {"source_node_id": "ask", "target_node_id": "exit"}
+  ]
+}`
+
+func TestRunnerResume(t *testing.T) {
+	ctx := context.Background()
+	canvas := &entity.Canvas{}
+	assert.NoError(t, json.Unmarshal([]byte(askCanvas), canvas))
+
+	r, err := Build(ctx, canvas, checkpoint.NewInMemoryStore(0))
+	assert.NoError(t, err)
+
+	res, err := r.Run(ctx, "1", map[string]any{"query": "tom"}, nil, nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, res.Interrupt) {
+		assert.Equal(t, "ask", res.Interrupt.NodeID)
+		assert.Equal(t, "hi tom, where are you from?", res.Interrupt.Prompt)
+		assert.NotEmpty(t, res.Interrupt.InterruptID)
+	}
+
+	res, err = r.Resume(ctx, res.Interrupt, "paris", nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, res.Interrupt)
+	assert.Equal(t, "tom from paris", res.Answer)
+}
+
+func TestValidate(t *testing.T) {
+	base := func() *entity.Canvas {
+		c := &entity.Canvas{}
+		assert.NoError(t, json.Unmarshal([]byte(gradeCanvas), c))
+		return c
+	}
+
+	assert.NoError(t, Validate(base()))
+
+	c := base()
+	c.Edges = append(c.Edges, &entity.Edge{SourceNodeID: "pass", TargetNodeID: "calc"})
+	assert.Error(t, Validate(c), "cycle")
+
+	c = base()
+	c.Edges = c.Edges[:3]
+	assert.Error(t, Validate(c), "default port is not connected")
+
+	c = base()
+	c.Nodes[1].Code.Inputs[0].Value = "{{ unknown.score }}"
+	assert.Error(t, Validate(c), "unknown reference")
+
+	c = base()
+	c.Edges[0].SourcePort = "branch_0"
+	assert.Error(t, Validate(c), "port on non-condition node")
+}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+)
+
+// scopeVariables 工作流变量在引用中的根名称
+const scopeVariables = "variables"
+
+// refPattern 匹配 {{ node_id.key.sub_key }} 形式的引用
+var refPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+(?:\.[A-Za-z0-9_]+)*)\s*\}\}`)
+
+// extractRefs 提取模板中引用的根名称（节点 ID 或 variables）
+func extractRefs(tpl string) []string {
+	matches := refPattern.FindAllStringSubmatch(tpl, -1)
+	roots := make([]string, 0, len(matches))
+	for _, m := range matches {
+		roots = append(roots, strings.SplitN(m[1], ".", 2)[0])
+	}
+	return roots
+}
+
+// resolve 计算模板表达式的值
+//
+// 当模板仅由一个引用构成时返回被引用值本身，保留原始类型；否则将所有引用替换为字符串后返回
+func resolve(scope map[string]any, tpl string) any {
+	trimmed := strings.TrimSpace(tpl)
+	if loc := refPattern.FindStringSubmatchIndex(trimmed); loc != nil && loc[0] == 0 && loc[1] == len(trimmed) {
+		return lookup(scope, trimmed[loc[2]:loc[3]])
+	}
+	return render(scope, tpl)
+}
+
+// render 将模板中的所有引用替换为字符串，引用不存在时替换为空串
+func render(scope map[string]any, tpl string) string {
+	return refPattern.ReplaceAllStringFunc(tpl, func(s string) string {
+		path := refPattern.FindStringSubmatch(s)[1]
+		return stringify(lookup(scope, path))
+	})
+}
+
+func lookup(scope map[string]any, path string) any {
+	var cur any = scope
+	for _, key := range strings.Split(path, ".") {
+		switch v := cur.(type) {
+		case map[string]any:
+			cur = v[key]
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil
+			}
+			cur = v[idx]
+		default:
+			return nil
+		}
+	}
+	return cur
+}
+
+func stringify(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case fmt.Stringer:
+		return t.String()
+	case bool, int, int32, int64, float32, float64:
+		return fmt.Sprint(t)
+	default:
+		s, err := json.MarshalString(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return s
+	}
+}
+
+// resolveParams 计算一组参数的值并按声明的类型转换
+func resolveParams(scope map[string]any, params []*entity.Param) (map[string]any, error) {
+	res := make(map[string]any, len(params))
+	for _, p := range params {
+		v, err := convert(resolve(scope, p.Value), p.Type)
+		if err != nil {
+			return nil, fmt.Errorf("param '%s': %w", p.Name, err)
+		}
+		res[p.Name] = v
+	}
+	return res, nil
+}
+
+// convert 将值转换为声明的类型，未声明类型时原样返回
+func convert(v any, t entity.ParamType) (any, error) {
+	if v == nil || t == "" {
+		return v, nil
+	}
+
+	switch t {
+	case entity.ParamTypeString:
+		return stringify(v), nil
+	case entity.ParamTypeInteger:
+		switch n := v.(type) {
+		case int:
+			return int64(n), nil
+		case int64:
+			return n, nil
+		case float64:
+			return int64(n), nil
+		case string:
+			return strconv.ParseInt(strings.TrimSpace(n), 10, 64)
+		}
+	case entity.ParamTypeNumber:
+		switch n := v.(type) {
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		case string:
+			return strconv.ParseFloat(strings.TrimSpace(n), 64)
+		}
+	case entity.ParamTypeBoolean:
+		switch b := v.(type) {
+		case bool:
+			return b, nil
+		case string:
+			return strconv.ParseBool(strings.TrimSpace(b))
+		}
+	case entity.ParamTypeObject:
+		switch o := v.(type) {
+		case map[string]any:
+			return o, nil
+		case string:
+			var m map[string]any
+			if err := json.Unmarshal([]byte(o), &m); err != nil {
+				return nil, err
+			}
+			return m, nil
+		}
+	case entity.ParamTypeArray:
+		switch a := v.(type) {
+		case []any:
+			return a, nil
+		case string:
+			var l []any
+			if err := json.Unmarshal([]byte(a), &l); err != nil {
+				return nil, err
+			}
+			return l, nil
+		}
+	default:
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+}
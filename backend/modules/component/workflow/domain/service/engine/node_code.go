@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+)
+
+// runCode 以声明的入参为环境，逐个计算 expr-lang 表达式得到输出
+//
+// 后声明的输出可以引用先声明的输出
+func runCode(_ context.Context, node *entity.Node, scope map[string]any, _ map[string]any) (map[string]any, error) {
+	env, err := resolveParams(scope, node.Code.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]any, len(node.Code.Outputs))
+	for _, o := range node.Code.Outputs {
+		program, err := expr.Compile(o.Expr, expr.Env(env))
+		if err != nil {
+			return nil, fmt.Errorf("compile output '%s': %w", o.Name, err)
+		}
+		v, err := expr.Run(program, env)
+		if err != nil {
+			return nil, fmt.Errorf("run output '%s': %w", o.Name, err)
+		}
+		out[o.Name] = v
+		env[o.Name] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+)
+
+// conditionKeyPort 条件节点输出中命中的分支端口
+const conditionKeyPort = "port"
+
+// runCondition 按顺序匹配分支，命中第一个满足的分支，均不满足时走 default
+func runCondition(_ context.Context, node *entity.Node, scope map[string]any, _ map[string]any) (map[string]any, error) {
+	for idx, b := range node.Condition.Branches {
+		hit, err := matchBranch(scope, b)
+		if err != nil {
+			return nil, err
+		}
+		if hit {
+			return map[string]any{conditionKeyPort: branchPort(idx)}, nil
+		}
+	}
+	return map[string]any{conditionKeyPort: entity.PortDefault}, nil
+}
+
+func matchBranch(scope map[string]any, b *entity.Branch) (bool, error) {
+	isOr := b.Logic == entity.LogicOr
+	for _, c := range b.Conditions {
+		ok, err := compare(resolve(scope, c.Left), c.Operator, resolve(scope, c.Right))
+		if err != nil {
+			return false, err
+		}
+		if isOr && ok {
+			return true, nil
+		}
+		if !isOr && !ok {
+			return false, nil
+		}
+	}
+	return !isOr, nil
+}
+
+func isValidOperator(op entity.Operator) bool {
+	switch op {
+	case entity.OperatorEqual, entity.OperatorNotEqual,
+		entity.OperatorGreater, entity.OperatorGreaterOrEq, entity.OperatorLess, entity.OperatorLessOrEq,
+		entity.OperatorContains, entity.OperatorNotContains,
+		entity.OperatorEmpty, entity.OperatorNotEmpty:
+		return true
+	}
+	return false
+}
+
+func compare(left any, op entity.Operator, right any) (bool, error) {
+	switch op {
+	case entity.OperatorEmpty:
+		return isEmpty(left), nil
+	case entity.OperatorNotEmpty:
+		return !isEmpty(left), nil
+	case entity.OperatorEqual:
+		return equal(left, right), nil
+	case entity.OperatorNotEqual:
+		return !equal(left, right), nil
+	case entity.OperatorContains:
+		return contains(left, right), nil
+	case entity.OperatorNotContains:
+		return !contains(left, right), nil
+	}
+
+	l, lok := toFloat(left)
+	r, rok := toFloat(right)
+	if !lok || !rok {
+		return false, fmt.Errorf("operator '%s' requires numbers, got %v and %v", op, left, right)
+	}
+	switch op {
+	case entity.OperatorGreater:
+		return l > r, nil
+	case entity.OperatorGreaterOrEq:
+		return l >= r, nil
+	case entity.OperatorLess:
+		return l < r, nil
+	case entity.OperatorLessOrEq:
+		return l <= r, nil
+	}
+	return false, fmt.Errorf("unknown operator '%s'", op)
+}
+
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+// equal 数值按数值比较，其余按字符串形式比较，使 "1" 与 1 相等
+func equal(left, right any) bool {
+	if l, ok := toFloat(left); ok {
+		if r, ok := toFloat(right); ok {
+			return l == r
+		}
+	}
+	return stringify(left) == stringify(right)
+}
+
+func contains(container, item any) bool {
+	switch c := container.(type) {
+	case string:
+		return strings.Contains(c, stringify(item))
+	case []any:
+		for _, e := range c {
+			if equal(e, item) {
+				return true
+			}
+		}
+	case map[string]any:
+		_, ok := c[stringify(item)]
+		return ok
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+)
+
+// 结束节点输出给执行器的字段
+const (
+	exitKeyOutput = "output"
+	exitKeyAnswer = "answer"
+)
+
+// runEntry 校验工作流入参，填充默认值并转换为声明的类型，未声明的入参原样透传
+func runEntry(_ context.Context, node *entity.Node, _ map[string]any, input map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(input))
+	for k, v := range input {
+		out[k] = v
+	}
+	if node.Entry == nil {
+		return out, nil
+	}
+
+	for _, p := range node.Entry.Inputs {
+		v, ok := out[p.Name]
+		if !ok || v == nil {
+			if p.Required && p.Default == nil {
+				return nil, fmt.Errorf("input '%s' is required", p.Name)
+			}
+			v = p.Default
+		}
+		cv, err := convert(v, p.Type)
+		if err != nil {
+			return nil, fmt.Errorf("input '%s': %w", p.Name, err)
+		}
+		out[p.Name] = cv
+	}
+	return out, nil
+}
+
+// runExit 计算工作流的输出，TerminatePlan 为 use_answer_content 时同时渲染回答
+func runExit(_ context.Context, node *entity.Node, scope map[string]any, _ map[string]any) (map[string]any, error) {
+	output, err := resolveParams(scope, node.Exit.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]any{exitKeyOutput: output}
+	if node.Exit.TerminatePlan == entity.TerminatePlanUseAnswerContent {
+		// 回答模板中可直接引用结束节点自身的输出
+		scope[node.ID] = output
+		res[exitKeyAnswer] = render(scope, node.Exit.Answer)
+	}
+	return res, nil
+}
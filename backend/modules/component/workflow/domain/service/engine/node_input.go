@@ -0,0 +1,27 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+)
+
+// inputKeyInput 输入节点输出用户回复的字段
+const inputKeyInput = "input"
+
+// runInput 首次执行时中断并等待用户输入，恢复执行时将用户的回复作为输出
+//
+// 同一轮中断了多个输入节点时每次只恢复其中一个，其余节点再次中断，等待下一轮输入
+func runInput(ctx context.Context, node *entity.Node, scope map[string]any, _ map[string]any) (map[string]any, error) {
+	isResumeFlow, hasData, data := compose.GetResumeContext[string](ctx)
+	if isResumeFlow && hasData {
+		return map[string]any{inputKeyInput: data}, nil
+	}
+
+	return nil, compose.Interrupt(ctx, &entity.Interrupt{
+		NodeID:   node.ID,
+		NodeName: node.Name,
+		Prompt:   render(scope, node.Input.Prompt),
+	})
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	llmapp "github.com/kiosk404/airi-go/backend/modules/llm/application"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+)
+
+const llmKeyOutput = "output"
+
+// runLLM 渲染提示词并调用模型，StreamOutput 开启时将增量内容作为中间消息推送
+func runLLM(ctx context.Context, node *entity.Node, scope map[string]any, _ map[string]any) (map[string]any, error) {
+	cfg := node.LLM
+	params := &modelmgr.LLMParams{}
+	if cfg.Temperature != nil {
+		params.Temperature = ptr.Of(float32(*cfg.Temperature))
+	}
+	if cfg.MaxTokens != nil {
+		params.MaxTokens = int(*cfg.MaxTokens)
+	}
+
+	ec := getExecContext(ctx)
+	scopeCtx := modelmgr.WithCallScope(ctx, &modelmgr.CallScope{
+		UserID:         ec.conf.UserID,
+		AgentID:        ec.conf.AgentID,
+		ConversationID: ec.conf.ConversationID,
+	})
+	cm, _, err := llmapp.BuildModelByID(scopeCtx, cfg.ModelID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*schema.Message, 0, 2)
+	if sp := render(scope, cfg.SystemPrompt); strings.TrimSpace(sp) != "" {
+		msgs = append(msgs, schema.SystemMessage(sp))
+	}
+	msgs = append(msgs, schema.UserMessage(render(scope, cfg.UserPrompt)))
+
+	if !cfg.StreamOutput || ec.sink == nil {
+		resp, err := cm.Generate(ctx, msgs)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{llmKeyOutput: resp.Content}, nil
+	}
+
+	sr, err := cm.Stream(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	defer sr.Close()
+
+	var sb strings.Builder
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// 结束已推送的中间消息，避免下游一直等待
+			ec.emit(node, "", true)
+			return nil, err
+		}
+		if chunk.Content == "" {
+			continue
+		}
+		sb.WriteString(chunk.Content)
+		ec.emit(node, chunk.Content, false)
+	}
+	ec.emit(node, "", true)
+
+	return map[string]any{llmKeyOutput: sb.String()}, nil
+}
@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"context"
+	"strings"
+
+	crossplugin "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin"
+	"github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/consts"
+	pluginmodel "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/model"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+)
+
+const pluginKeyOutput = "output"
+
+// runPlugin 调用插件工具，响应为 JSON 对象时直接作为节点输出，否则放在 output 字段中
+func runPlugin(ctx context.Context, node *entity.Node, scope map[string]any, _ map[string]any) (map[string]any, error) {
+	args, err := resolveParams(scope, node.Plugin.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	argsInJSON, err := json.MarshalString(args)
+	if err != nil {
+		return nil, err
+	}
+
+	ec := getExecContext(ctx)
+	resp, err := crossplugin.DefaultSVC().ExecuteTool(ctx, &pluginmodel.ExecuteToolRequest{
+		UserID:          ec.conf.UserID,
+		PluginID:        node.Plugin.PluginID,
+		ToolID:          node.Plugin.ToolID,
+		ExecScene:       consts.ExecSceneOfWorkflow,
+		ExecDraftTool:   false,
+		ArgumentsInJson: argsInJSON,
+	}, pluginmodel.WithInvalidRespProcessStrategy(consts.InvalidResponseProcessStrategyOfReturnDefault))
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(resp.TrimmedResp), "{") {
+		out := make(map[string]any)
+		if err = json.Unmarshal([]byte(resp.TrimmedResp), &out); err == nil {
+			return out, nil
+		}
+	}
+	return map[string]any{pluginKeyOutput: resp.TrimmedResp}, nil
+}
@@ -0,0 +1,19 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+)
+
+// runVariable 为工作流变量赋值，后续节点通过 {{ variables.key }} 引用
+func runVariable(ctx context.Context, node *entity.Node, scope map[string]any, _ map[string]any) (map[string]any, error) {
+	vars, err := resolveParams(scope, node.Variable.Assignments)
+	if err != nil {
+		return nil, err
+	}
+	if err = saveVariables(ctx, vars); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
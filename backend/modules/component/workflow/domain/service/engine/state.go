@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+)
+
+// State 一次执行中所有节点共享的状态
+type State struct {
+	// Outputs 各节点的输出，key 为节点 ID
+	Outputs map[string]map[string]any
+	// Variables 变量节点赋值的工作流变量
+	Variables map[string]any
+}
+
+func init() {
+	_ = compose.RegisterSerializableType[*State]("workflow_state")
+}
+
+func newState(_ context.Context) *State {
+	return &State{
+		Outputs:   make(map[string]map[string]any),
+		Variables: make(map[string]any),
+	}
+}
+
+// snapshotScope 获取当前可被引用的值
+func snapshotScope(ctx context.Context) (map[string]any, error) {
+	scope := make(map[string]any)
+	err := compose.ProcessState[*State](ctx, func(_ context.Context, s *State) error {
+		for id, out := range s.Outputs {
+			scope[id] = out
+		}
+		vars := make(map[string]any, len(s.Variables))
+		for k, v := range s.Variables {
+			vars[k] = v
+		}
+		scope[scopeVariables] = vars
+		return nil
+	})
+	return scope, err
+}
+
+func saveOutput(ctx context.Context, nodeID string, output map[string]any) error {
+	return compose.ProcessState[*State](ctx, func(_ context.Context, s *State) error {
+		s.Outputs[nodeID] = output
+		return nil
+	})
+}
+
+func saveVariables(ctx context.Context, vars map[string]any) error {
+	return compose.ProcessState[*State](ctx, func(_ context.Context, s *State) error {
+		for k, v := range vars {
+			s.Variables[k] = v
+		}
+		return nil
+	})
+}
+
+type execCtxKey struct{}
+
+// execContext 单次执行的上下文，随 ctx 传递给各节点
+type execContext struct {
+	executeID string
+	conf      *entity.ExecuteConfig
+	sink      *schema.StreamWriter[*entity.Message]
+}
+
+func withExecContext(ctx context.Context, ec *execContext) context.Context {
+	return context.WithValue(ctx, execCtxKey{}, ec)
+}
+
+func getExecContext(ctx context.Context) *execContext {
+	if ec, ok := ctx.Value(execCtxKey{}).(*execContext); ok {
+		return ec
+	}
+	return &execContext{conf: &entity.ExecuteConfig{}}
+}
+
+// emit 推送中间消息，未设置消息管道时忽略
+func (ec *execContext) emit(node *entity.Node, content string, last bool) {
+	if ec.sink == nil {
+		return
+	}
+	ec.sink.Send(&entity.Message{
+		ExecuteID: ec.executeID,
+		NodeID:    node.ID,
+		NodeName:  node.Name,
+		NodeType:  string(node.Type),
+		Content:   content,
+		Last:      last,
+	}, nil)
+}
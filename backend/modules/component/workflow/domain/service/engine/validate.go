@@ -0,0 +1,308 @@
+package engine
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+)
+
+var nodeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,64}$`)
+
+// Validate 校验画布是否可以执行
+//
+//   - 有且仅有一个开始节点和一个结束节点，节点 ID 唯一
+//   - 各节点的配置与类型匹配
+//   - 连线合法，条件节点的连线必须指定分支端口
+//   - 画布无环，所有节点均可从开始节点到达，且结束节点可达
+//   - 模板中引用的节点均存在
+func Validate(canvas *entity.Canvas) error {
+	if canvas == nil || len(canvas.Nodes) == 0 {
+		return invalid("canvas is empty")
+	}
+
+	nodes := make(map[string]*entity.Node, len(canvas.Nodes))
+	count := make(map[entity.NodeType]int)
+	for _, n := range canvas.Nodes {
+		if n == nil {
+			return invalid("node is nil")
+		}
+		if !nodeIDPattern.MatchString(n.ID) || n.ID == scopeVariables {
+			return invalid("invalid node id '%s'", n.ID)
+		}
+		if _, ok := nodes[n.ID]; ok {
+			return invalid("duplicate node id '%s'", n.ID)
+		}
+		if err := validateNodeConfig(n); err != nil {
+			return err
+		}
+		nodes[n.ID] = n
+		count[n.Type]++
+	}
+	if count[entity.NodeTypeEntry] != 1 {
+		return invalid("canvas must have exactly one entry node")
+	}
+	if count[entity.NodeTypeExit] != 1 {
+		return invalid("canvas must have exactly one exit node")
+	}
+
+	successors := make(map[string][]string, len(nodes))
+	seenEdges := make(map[string]bool, len(canvas.Edges))
+	connectedPorts := make(map[string]bool)
+	for _, e := range canvas.Edges {
+		src, dst := nodes[e.SourceNodeID], nodes[e.TargetNodeID]
+		if src == nil || dst == nil {
+			return invalid("edge %s -> %s references unknown node", e.SourceNodeID, e.TargetNodeID)
+		}
+		if dst.Type == entity.NodeTypeEntry {
+			return invalid("entry node cannot have incoming edges")
+		}
+		if src.Type == entity.NodeTypeExit {
+			return invalid("exit node cannot have outgoing edges")
+		}
+		if err := validatePort(src, e.SourcePort); err != nil {
+			return err
+		}
+		key := e.SourceNodeID + "|" + e.SourcePort + "|" + e.TargetNodeID
+		if seenEdges[key] {
+			return invalid("duplicate edge %s -> %s", e.SourceNodeID, e.TargetNodeID)
+		}
+		seenEdges[key] = true
+		connectedPorts[e.SourceNodeID+"|"+e.SourcePort] = true
+		successors[e.SourceNodeID] = append(successors[e.SourceNodeID], e.TargetNodeID)
+	}
+
+	// 条件节点的每个分支都必须有后继节点，否则命中该分支时执行无法到达结束节点
+	for _, n := range canvas.Nodes {
+		if n.Type != entity.NodeTypeCondition {
+			continue
+		}
+		ports := []string{entity.PortDefault}
+		for idx := range n.Condition.Branches {
+			ports = append(ports, branchPort(idx))
+		}
+		for _, port := range ports {
+			if !connectedPorts[n.ID+"|"+port] {
+				return invalid("condition node '%s' port '%s' is not connected", n.ID, port)
+			}
+		}
+	}
+
+	entry := canvas.GetNodeByType(entity.NodeTypeEntry)
+	exit := canvas.GetNodeByType(entity.NodeTypeExit)
+	if err := checkAcyclic(nodes, successors); err != nil {
+		return err
+	}
+	reachable := reachableFrom(entry.ID, successors)
+	for id := range nodes {
+		if !reachable[id] {
+			return invalid("node '%s' is not reachable from entry", id)
+		}
+	}
+	if !reachable[exit.ID] {
+		return invalid("exit node is not reachable from entry")
+	}
+
+	for _, n := range canvas.Nodes {
+		for _, tpl := range nodeTemplates(n) {
+			for _, ref := range extractRefs(tpl) {
+				if ref != scopeVariables && nodes[ref] == nil {
+					return invalid("node '%s' references unknown node '%s'", n.ID, ref)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateNodeConfig(n *entity.Node) error {
+	switch n.Type {
+	case entity.NodeTypeEntry:
+		if n.Entry == nil {
+			return nil
+		}
+		for _, p := range n.Entry.Inputs {
+			if p.Name == "" {
+				return invalid("entry input name is empty")
+			}
+		}
+	case entity.NodeTypeExit:
+		if n.Exit == nil {
+			return invalid("exit node '%s' has no config", n.ID)
+		}
+		switch n.Exit.TerminatePlan {
+		case entity.TerminatePlanReturnVariables:
+		case entity.TerminatePlanUseAnswerContent:
+			if strings.TrimSpace(n.Exit.Answer) == "" {
+				return invalid("exit node '%s' answer is empty", n.ID)
+			}
+		default:
+			return invalid("exit node '%s' has unknown terminate plan '%s'", n.ID, n.Exit.TerminatePlan)
+		}
+	case entity.NodeTypeLLM:
+		if n.LLM == nil || n.LLM.ModelID <= 0 || strings.TrimSpace(n.LLM.UserPrompt) == "" {
+			return invalid("llm node '%s' requires model_id and user_prompt", n.ID)
+		}
+	case entity.NodeTypePlugin:
+		if n.Plugin == nil || n.Plugin.PluginID <= 0 || n.Plugin.ToolID <= 0 {
+			return invalid("plugin node '%s' requires plugin_id and tool_id", n.ID)
+		}
+	case entity.NodeTypeCode:
+		if n.Code == nil || len(n.Code.Outputs) == 0 {
+			return invalid("code node '%s' has no outputs", n.ID)
+		}
+		for _, o := range n.Code.Outputs {
+			if o.Name == "" || strings.TrimSpace(o.Expr) == "" {
+				return invalid("code node '%s' output requires name and expr", n.ID)
+			}
+		}
+	case entity.NodeTypeCondition:
+		if n.Condition == nil || len(n.Condition.Branches) == 0 {
+			return invalid("condition node '%s' has no branches", n.ID)
+		}
+		for _, b := range n.Condition.Branches {
+			if len(b.Conditions) == 0 {
+				return invalid("condition node '%s' has an empty branch", n.ID)
+			}
+			for _, c := range b.Conditions {
+				if !isValidOperator(c.Operator) {
+					return invalid("condition node '%s' has unknown operator '%s'", n.ID, c.Operator)
+				}
+			}
+		}
+	case entity.NodeTypeVariable:
+		if n.Variable == nil || len(n.Variable.Assignments) == 0 {
+			return invalid("variable node '%s' has no assignments", n.ID)
+		}
+	case entity.NodeTypeInput:
+		if n.Input == nil || strings.TrimSpace(n.Input.Prompt) == "" {
+			return invalid("input node '%s' requires prompt", n.ID)
+		}
+	default:
+		return invalid("node '%s' has unknown type '%s'", n.ID, n.Type)
+	}
+	return nil
+}
+
+func validatePort(src *entity.Node, port string) error {
+	if src.Type != entity.NodeTypeCondition {
+		if port != "" {
+			return invalid("only condition node can have source port, node '%s'", src.ID)
+		}
+		return nil
+	}
+
+	if port == entity.PortDefault {
+		return nil
+	}
+	if idx, ok := branchIndex(port); ok && idx < len(src.Condition.Branches) {
+		return nil
+	}
+	return invalid("condition node '%s' has invalid port '%s'", src.ID, port)
+}
+
+func branchPort(idx int) string {
+	return entity.PortBranchPrefix + strconv.Itoa(idx)
+}
+
+func branchIndex(port string) (int, bool) {
+	if !strings.HasPrefix(port, entity.PortBranchPrefix) {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(strings.TrimPrefix(port, entity.PortBranchPrefix))
+	if err != nil || idx < 0 {
+		return 0, false
+	}
+	return idx, true
+}
+
+func checkAcyclic(nodes map[string]*entity.Node, successors map[string][]string) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(nodes))
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return invalid("canvas contains a cycle at node '%s'", id)
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		for _, next := range successors[id] {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		return nil
+	}
+
+	for id := range nodes {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func reachableFrom(start string, successors map[string][]string) map[string]bool {
+	reached := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range successors[cur] {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return reached
+}
+
+// nodeTemplates 节点配置中所有可能包含引用的模板
+func nodeTemplates(n *entity.Node) []string {
+	var tpls []string
+	addParams := func(params []*entity.Param) {
+		for _, p := range params {
+			tpls = append(tpls, p.Value)
+		}
+	}
+
+	switch n.Type {
+	case entity.NodeTypeExit:
+		addParams(n.Exit.Outputs)
+		tpls = append(tpls, n.Exit.Answer)
+	case entity.NodeTypeLLM:
+		tpls = append(tpls, n.LLM.SystemPrompt, n.LLM.UserPrompt)
+	case entity.NodeTypePlugin:
+		addParams(n.Plugin.Inputs)
+	case entity.NodeTypeCode:
+		addParams(n.Code.Inputs)
+	case entity.NodeTypeCondition:
+		for _, b := range n.Condition.Branches {
+			for _, c := range b.Conditions {
+				tpls = append(tpls, c.Left, c.Right)
+			}
+		}
+	case entity.NodeTypeVariable:
+		addParams(n.Variable.Assignments)
+	case entity.NodeTypeInput:
+		tpls = append(tpls, n.Input.Prompt)
+	}
+	return tpls
+}
+
+func invalid(format string, args ...any) error {
+	return errorx.New(errno.ErrWorkflowCanvasInvalidCode, errorx.KVf("msg", format, args...))
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+)
+
+type Workflow interface {
+	CreateWorkflow(ctx context.Context, req *CreateWorkflowRequest) (*entity.Workflow, error)
+	UpdateWorkflow(ctx context.Context, req *UpdateWorkflowRequest) error
+	SaveCanvas(ctx context.Context, workflowID int64, canvas *entity.Canvas) error
+	DeleteWorkflow(ctx context.Context, workflowID int64) error
+	GetWorkflow(ctx context.Context, workflowID int64) (*entity.Workflow, error)
+	ListWorkflow(ctx context.Context, req *ListWorkflowRequest) (*ListWorkflowResponse, error)
+
+	PublishWorkflow(ctx context.Context, req *PublishWorkflowRequest) (*entity.WorkflowVersion, error)
+	ListVersion(ctx context.Context, req *ListVersionRequest) (*ListVersionResponse, error)
+
+	Execute(ctx context.Context, req *ExecuteRequest) (*entity.ExecuteResult, error)
+	// Chatflow 以对话流的方式执行一轮对话，sink 不为空时推送节点产生的中间消息，
+	// 执行到输入节点时返回的结果中 Interrupt 不为空
+	Chatflow(ctx context.Context, req *ChatflowRequest, sink *schema.StreamWriter[*entity.Message]) (*entity.ExecuteResult, error)
+	// WorkflowAsTools 将工作流转换为 Agent 可调用的工具，同时返回需要直接返回结果的工具名
+	WorkflowAsTools(ctx context.Context, policies []*entity.WorkflowToolPolicy) ([]tool.BaseTool, map[string]bool, error)
+}
+
+// CreateWorkflowRequest Name 同时作为工具名提供给模型，只能包含字母、数字和下划线
+type CreateWorkflowRequest struct {
+	Name        string
+	Description string
+	IconURI     string
+	Mode        entity.Mode
+	CreatorID   int64
+}
+
+type UpdateWorkflowRequest struct {
+	WorkflowID  int64
+	Name        *string
+	Description *string
+	IconURI     *string
+}
+
+type ListWorkflowRequest struct {
+	CreatorID int64
+	Name      string
+	Page      int
+	PageSize  int
+}
+
+type ListWorkflowResponse struct {
+	Workflows []*entity.Workflow
+	Total     int64
+}
+
+// PublishWorkflowRequest Version 为空时按发布次数自动生成
+type PublishWorkflowRequest struct {
+	WorkflowID         int64
+	Version            string
+	VersionDescription string
+	CreatorID          int64
+}
+
+type ListVersionRequest struct {
+	WorkflowID int64
+	Page       int
+	PageSize   int
+}
+
+type ListVersionResponse struct {
+	Versions []*entity.WorkflowVersion
+	Total    int64
+}
+
+type ExecuteRequest struct {
+	WorkflowID int64
+	Input      map[string]any
+	Config     *entity.ExecuteConfig
+}
+
+// ChatflowRequest IsDraft 为 true 时执行草稿画布，否则执行最新发布的版本；
+// ResumeFrom 不为空时从中断的输入节点恢复执行，Query 作为该节点的输出
+type ChatflowRequest struct {
+	WorkflowID int64
+	Query      string
+	IsDraft    bool
+	CreatorID  int64
+	Config     *entity.ExecuteConfig
+	ResumeFrom *entity.Interrupt
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/google/uuid"
+	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/repo"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/service/engine"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// namePattern 工作流名称会作为工具名提供给模型
+var namePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+
+type Components struct {
+	DB    rdb.Provider
+	IDGen idgen.IDGenerator
+	// CPStore 保存对话流在输入节点中断时的执行现场
+	CPStore compose.CheckPointStore
+}
+
+type workflowSVC struct {
+	workflowRepo repo.WorkflowRepo
+	versionRepo  repo.WorkflowVersionRepo
+
+	idgen   idgen.IDGenerator
+	cpStore compose.CheckPointStore
+}
+
+func NewWorkflowSVC(c *Components) Workflow {
+	db := c.DB.NewSession(context.Background()).DB()
+	return &workflowSVC{
+		workflowRepo: repo.NewWorkflowRepo(db),
+		versionRepo:  repo.NewWorkflowVersionRepo(db),
+		idgen:        c.IDGen,
+		cpStore:      c.CPStore,
+	}
+}
+
+func (w *workflowSVC) CreateWorkflow(ctx context.Context, req *CreateWorkflowRequest) (*entity.Workflow, error) {
+	if !namePattern.MatchString(req.Name) {
+		return nil, errorx.New(errno.ErrWorkflowInvalidParamCode,
+			errorx.KV("msg", "name must start with a letter and contain only letters, digits and underscores"))
+	}
+
+	id, err := w.idgen.GenID(ctx)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowIDGenCode, errorx.KV("msg", "CreateWorkflow"))
+	}
+
+	now := time.Now().UnixMilli()
+	wf := &entity.Workflow{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		IconURI:     req.IconURI,
+		Mode:        req.Mode,
+		Canvas:      entity.DefaultCanvas(),
+		CreatorID:   req.CreatorID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err = w.workflowRepo.Create(ctx, wf); err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "CreateWorkflow"))
+	}
+
+	return wf, nil
+}
+
+func (w *workflowSVC) UpdateWorkflow(ctx context.Context, req *UpdateWorkflowRequest) error {
+	if req.Name != nil && !namePattern.MatchString(*req.Name) {
+		return errorx.New(errno.ErrWorkflowInvalidParamCode,
+			errorx.KV("msg", "name must start with a letter and contain only letters, digits and underscores"))
+	}
+	if err := w.workflowRepo.UpdateMeta(ctx, req.WorkflowID, req.Name, req.Description, req.IconURI); err != nil {
+		return errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "UpdateWorkflow"))
+	}
+	return nil
+}
+
+// SaveCanvas 保存草稿画布，草稿允许处于未完成状态，仅在执行和发布时校验
+func (w *workflowSVC) SaveCanvas(ctx context.Context, workflowID int64, canvas *entity.Canvas) error {
+	if canvas == nil {
+		return errorx.New(errno.ErrWorkflowInvalidParamCode, errorx.KV("msg", "canvas is nil"))
+	}
+	if err := w.workflowRepo.UpdateCanvas(ctx, workflowID, canvas); err != nil {
+		return errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "SaveCanvas"))
+	}
+	return nil
+}
+
+func (w *workflowSVC) DeleteWorkflow(ctx context.Context, workflowID int64) error {
+	if err := w.versionRepo.DeleteByWorkflowID(ctx, workflowID); err != nil {
+		return errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "DeleteWorkflow"))
+	}
+	if err := w.workflowRepo.Delete(ctx, workflowID); err != nil {
+		return errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "DeleteWorkflow"))
+	}
+	return nil
+}
+
+func (w *workflowSVC) GetWorkflow(ctx context.Context, workflowID int64) (*entity.Workflow, error) {
+	wf, err := w.workflowRepo.GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "GetWorkflow"))
+	}
+	if wf == nil {
+		return nil, errorx.New(errno.ErrWorkflowNotExistCode, errorx.KV("msg", strconv.FormatInt(workflowID, 10)))
+	}
+	return wf, nil
+}
+
+func (w *workflowSVC) ListWorkflow(ctx context.Context, req *ListWorkflowRequest) (*ListWorkflowResponse, error) {
+	offset, limit := pageToOffset(req.Page, req.PageSize)
+	list, total, err := w.workflowRepo.List(ctx, req.CreatorID, req.Name, offset, limit)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "ListWorkflow"))
+	}
+	return &ListWorkflowResponse{Workflows: list, Total: total}, nil
+}
+
+// PublishWorkflow 校验草稿画布并保存为新版本
+func (w *workflowSVC) PublishWorkflow(ctx context.Context, req *PublishWorkflowRequest) (*entity.WorkflowVersion, error) {
+	wf, err := w.GetWorkflow(ctx, req.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	if err = engine.Validate(wf.Canvas); err != nil {
+		return nil, err
+	}
+
+	version := req.Version
+	if version == "" {
+		_, total, err := w.versionRepo.List(ctx, wf.ID, 0, 1)
+		if err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "PublishWorkflow"))
+		}
+		version = "v" + strconv.FormatInt(total+1, 10)
+	}
+	existed, err := w.versionRepo.Get(ctx, wf.ID, version)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "PublishWorkflow"))
+	}
+	if existed != nil {
+		return nil, errorx.New(errno.ErrWorkflowInvalidParamCode, errorx.KVf("msg", "version %s already exists", version))
+	}
+
+	id, err := w.idgen.GenID(ctx)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowIDGenCode, errorx.KV("msg", "PublishWorkflow"))
+	}
+	v := &entity.WorkflowVersion{
+		ID:                 id,
+		WorkflowID:         wf.ID,
+		Version:            version,
+		VersionDescription: req.VersionDescription,
+		Canvas:             wf.Canvas,
+		CreatorID:          req.CreatorID,
+		CreatedAt:          time.Now().UnixMilli(),
+	}
+	if err = w.versionRepo.Create(ctx, v); err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "PublishWorkflow"))
+	}
+	if err = w.workflowRepo.UpdateLatestVersion(ctx, wf.ID, version); err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "PublishWorkflow"))
+	}
+
+	return v, nil
+}
+
+func (w *workflowSVC) ListVersion(ctx context.Context, req *ListVersionRequest) (*ListVersionResponse, error) {
+	offset, limit := pageToOffset(req.Page, req.PageSize)
+	list, total, err := w.versionRepo.List(ctx, req.WorkflowID, offset, limit)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "ListVersion"))
+	}
+	return &ListVersionResponse{Versions: list, Total: total}, nil
+}
+
+func (w *workflowSVC) Execute(ctx context.Context, req *ExecuteRequest) (*entity.ExecuteResult, error) {
+	conf := req.Config
+	if conf == nil {
+		conf = &entity.ExecuteConfig{}
+	}
+
+	canvas, err := w.loadCanvas(ctx, req.WorkflowID, conf.Version)
+	if err != nil {
+		return nil, err
+	}
+	runner, err := engine.Build(ctx, canvas, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := runner.Run(ctx, uuid.NewString(), req.Input, conf, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.Interrupt != nil {
+		return nil, errInputNodeUnsupported(res.Interrupt)
+	}
+	return res, nil
+}
+
+func (w *workflowSVC) Chatflow(ctx context.Context, req *ChatflowRequest, sink *schema.StreamWriter[*entity.Message]) (*entity.ExecuteResult, error) {
+	wf, err := w.GetWorkflow(ctx, req.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	if wf.CreatorID != req.CreatorID {
+		return nil, errorx.New(errno.ErrWorkflowPermissionCode, errorx.KVf("msg", "workflow %d is not owned by user %d", wf.ID, req.CreatorID))
+	}
+	if wf.Mode != entity.ModeChatFlow {
+		return nil, errorx.New(errno.ErrWorkflowInvalidParamCode, errorx.KVf("msg", "workflow %d is not a chatflow", wf.ID))
+	}
+
+	conf := &entity.ExecuteConfig{}
+	if req.Config != nil {
+		*conf = *req.Config
+	}
+	// 恢复执行时沿用中断时的版本，保证画布与执行现场一致
+	switch {
+	case req.ResumeFrom != nil:
+		conf.Version = req.ResumeFrom.Version
+	case !req.IsDraft:
+		if !wf.IsPublished() {
+			return nil, errorx.New(errno.ErrWorkflowNotPublishedCode, errorx.KV("msg", strconv.FormatInt(wf.ID, 10)))
+		}
+		conf.Version = wf.LatestVersion
+	default:
+		conf.Version = ""
+	}
+
+	canvas, err := w.loadCanvas(ctx, wf.ID, conf.Version)
+	if err != nil {
+		return nil, err
+	}
+	runner, err := engine.Build(ctx, canvas, w.cpStore)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *entity.ExecuteResult
+	if req.ResumeFrom != nil {
+		logs.InfoX(pkg.ModelName, "resume chatflow %d, execute_id: %s, node: %s", wf.ID, req.ResumeFrom.ExecuteID, req.ResumeFrom.NodeID)
+		res, err = runner.Resume(ctx, req.ResumeFrom, req.Query, conf, sink)
+	} else {
+		input := map[string]any{entity.ChatflowInputQuery: req.Query}
+		res, err = runner.Run(ctx, uuid.NewString(), input, conf, sink)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Interrupt != nil {
+		res.Interrupt.WorkflowID = wf.ID
+		res.Interrupt.Version = conf.Version
+	}
+	return res, nil
+}
+
+// loadCanvas 获取指定版本的画布，version 为空时返回草稿
+func (w *workflowSVC) loadCanvas(ctx context.Context, workflowID int64, version string) (*entity.Canvas, error) {
+	if version == "" {
+		wf, err := w.GetWorkflow(ctx, workflowID)
+		if err != nil {
+			return nil, err
+		}
+		return wf.Canvas, nil
+	}
+
+	v, err := w.versionRepo.Get(ctx, workflowID, version)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrWorkflowDBCode, errorx.KV("msg", "GetVersion"))
+	}
+	if v == nil {
+		return nil, errorx.New(errno.ErrWorkflowNotExistCode, errorx.KVf("msg", "%d@%s", workflowID, version))
+	}
+	return v.Canvas, nil
+}
+
+// errInputNodeUnsupported 输入节点需要等待用户的下一轮输入，只能在对话流中使用
+func errInputNodeUnsupported(interrupt *entity.Interrupt) error {
+	return errorx.New(errno.ErrWorkflowExecuteCode,
+		errorx.KVf("msg", "input node '%s' is only supported in chatflow", interrupt.NodeID))
+}
+
+func pageToOffset(page, pageSize int) (offset, limit int) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return (page - 1) * pageSize, pageSize
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatflowOwner(t *testing.T) {
+	ctx := context.Background()
+	w := newTestWorkflowSVC(t)
+	var statusErr errorx.StatusError
+
+	// 其他用户的 Agent 不能执行该工作流
+	_, err := w.Chatflow(ctx, &ChatflowRequest{WorkflowID: 1, IsDraft: true, CreatorID: 200}, nil)
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrWorkflowPermissionCode), statusErr.Code())
+	}
+
+	// 创建者通过权限校验，工作流 1 不是对话流
+	_, err = w.Chatflow(ctx, &ChatflowRequest{WorkflowID: 1, IsDraft: true, CreatorID: 100}, nil)
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrWorkflowInvalidParamCode), statusErr.Code())
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/google/uuid"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/service/engine"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+var paramType2DataType = map[entity.ParamType]schema.DataType{
+	entity.ParamTypeString:  schema.String,
+	entity.ParamTypeInteger: schema.Integer,
+	entity.ParamTypeNumber:  schema.Number,
+	entity.ParamTypeBoolean: schema.Boolean,
+	entity.ParamTypeObject:  schema.Object,
+	entity.ParamTypeArray:   schema.Array,
+}
+
+type toolOptions struct {
+	execConf *entity.ExecuteConfig
+	sink     *schema.StreamWriter[*entity.Message]
+}
+
+// WithExecuteConfig 设置以工具身份执行时的上下文
+func WithExecuteConfig(conf *entity.ExecuteConfig) tool.Option {
+	return tool.WrapImplSpecificOptFn(func(o *toolOptions) {
+		o.execConf = conf
+	})
+}
+
+// WithMessageSink 设置接收节点中间消息的管道
+func WithMessageSink(sink *schema.StreamWriter[*entity.Message]) tool.Option {
+	return tool.WrapImplSpecificOptFn(func(o *toolOptions) {
+		o.sink = sink
+	})
+}
+
+func (w *workflowSVC) WorkflowAsTools(ctx context.Context, policies []*entity.WorkflowToolPolicy) ([]tool.BaseTool, map[string]bool, error) {
+	tools := make([]tool.BaseTool, 0, len(policies))
+	returnDirectly := make(map[string]bool)
+	for _, p := range policies {
+		wf, err := w.GetWorkflow(ctx, p.WorkflowID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if wf.CreatorID != p.CreatorID {
+			return nil, nil, errorx.New(errno.ErrWorkflowPermissionCode, errorx.KVf("msg", "workflow %d is not owned by user %d", wf.ID, p.CreatorID))
+		}
+
+		canvas, version := wf.Canvas, ""
+		if !p.IsDraft {
+			if !wf.IsPublished() {
+				return nil, nil, errorx.New(errno.ErrWorkflowNotPublishedCode, errorx.KV("msg", strconv.FormatInt(wf.ID, 10)))
+			}
+			version = wf.LatestVersion
+			if canvas, err = w.loadCanvas(ctx, wf.ID, version); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		runner, err := engine.Build(ctx, canvas, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tools = append(tools, &workflowTool{
+			workflow: wf,
+			canvas:   canvas,
+			version:  version,
+			runner:   runner,
+		})
+		if canvas.TerminatePlan() == entity.TerminatePlanUseAnswerContent {
+			returnDirectly[wf.Name] = true
+		}
+	}
+
+	return tools, returnDirectly, nil
+}
+
+type workflowTool struct {
+	workflow *entity.Workflow
+	canvas   *entity.Canvas
+	version  string
+	runner   *engine.Runner
+}
+
+func (t *workflowTool) Info(_ context.Context) (*schema.ToolInfo, error) {
+	params := make(map[string]*schema.ParameterInfo)
+	for _, p := range t.canvas.InputParams() {
+		dataType, ok := paramType2DataType[p.Type]
+		if !ok {
+			dataType = schema.String
+		}
+		params[p.Name] = &schema.ParameterInfo{
+			Type:     dataType,
+			Desc:     p.Desc,
+			Required: p.Required,
+		}
+	}
+
+	return &schema.ToolInfo{
+		Name:        t.workflow.Name,
+		Desc:        t.workflow.Description,
+		ParamsOneOf: schema.NewParamsOneOfByParams(params),
+	}, nil
+}
+
+// InvokableRun 执行工作流，TerminatePlan 为 use_answer_content 时返回渲染的回答，否则返回输出变量的 JSON
+func (t *workflowTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	o := tool.GetImplSpecificOptions(&toolOptions{}, opts...)
+	conf := &entity.ExecuteConfig{}
+	if o.execConf != nil {
+		*conf = *o.execConf
+	}
+	conf.Version = t.version
+
+	input := make(map[string]any)
+	if argumentsInJSON != "" {
+		if err := json.Unmarshal([]byte(argumentsInJSON), &input); err != nil {
+			return "invalid arguments: " + err.Error(), nil
+		}
+	}
+
+	executeID := uuid.NewString()
+	logs.InfoX(pkg.ModelName, "execute workflow %d as tool, execute_id: %s, version: %q", t.workflow.ID, executeID, t.version)
+	res, err := t.runner.Run(ctx, executeID, input, conf, o.sink)
+	if err == nil && res.Interrupt != nil {
+		err = errInputNodeUnsupported(res.Interrupt)
+	}
+	if err != nil {
+		// 入参错误或节点执行失败时把原因返回给模型，由模型决定是否修正后重试
+		if statusErr, ok := errorx.FromStatusError(err); ok && !statusErr.IsAffectStability() {
+			return statusErr.Msg(), nil
+		}
+		return "", err
+	}
+
+	if res.TerminatePlan == entity.TerminatePlanUseAnswerContent {
+		return res.Answer, nil
+	}
+	return json.MarshalString(res.Output)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/repo"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/stretchr/testify/assert"
+)
+
+const echoCanvas = `{
+  "nodes": [
+    {"id": "entry", "type": "entry", "entry": {"inputs": [{"name": "text", "type": "string", "required": true}]}},
+    {"id": "exit", "type": "exit", "exit": {"terminate_plan": "return_variables", "outputs": [{"name": "text", "value": "{{ entry.text }}"}]}}
+  ],
+  "edges": [
+    {"source_node_id": "entry", "target_node_id": "exit"}
+  ]
+}`
+
+type memWorkflowRepo struct {
+	repo.WorkflowRepo
+	workflows map[int64]*entity.Workflow
+}
+
+func (r *memWorkflowRepo) GetByID(_ context.Context, id int64) (*entity.Workflow, error) {
+	return r.workflows[id], nil
+}
+
+func newTestWorkflowSVC(t *testing.T) *workflowSVC {
+	canvas := &entity.Canvas{}
+	assert.NoError(t, json.Unmarshal([]byte(echoCanvas), canvas))
+	return &workflowSVC{
+		workflowRepo: &memWorkflowRepo{workflows: map[int64]*entity.Workflow{
+			1: {ID: 1, Name: "echo", Canvas: canvas, CreatorID: 100},
+		}},
+	}
+}
+
+func TestWorkflowAsToolsOwner(t *testing.T) {
+	ctx := context.Background()
+	w := newTestWorkflowSVC(t)
+
+	tools, _, err := w.WorkflowAsTools(ctx, []*entity.WorkflowToolPolicy{{WorkflowID: 1, IsDraft: true, CreatorID: 100}})
+	assert.NoError(t, err)
+	assert.Len(t, tools, 1)
+
+	// 其他用户的 Agent 不能加载该工作流
+	_, _, err = w.WorkflowAsTools(ctx, []*entity.WorkflowToolPolicy{{WorkflowID: 1, IsDraft: true, CreatorID: 200}})
+	var statusErr errorx.StatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrWorkflowPermissionCode), statusErr.Code())
+	}
+
+	_, _, err = w.WorkflowAsTools(ctx, []*entity.WorkflowToolPolicy{{WorkflowID: 1, IsDraft: true}})
+	assert.Error(t, err)
+}
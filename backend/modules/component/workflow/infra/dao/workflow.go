@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/infra/repo/gorm_gen/model"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/infra/repo/gorm_gen/query"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"gorm.io/gorm"
+)
+
+type WorkflowDAO struct {
+	DB    *gorm.DB
+	Query *query.Query
+}
+
+func NewWorkflowDAO(db *gorm.DB) *WorkflowDAO {
+	return &WorkflowDAO{
+		DB:    db,
+		Query: query.Use(db),
+	}
+}
+
+func (dao *WorkflowDAO) Create(ctx context.Context, wf *entity.Workflow) error {
+	return dao.Query.Workflow.WithContext(ctx).Create(dao.fromEntityToModel(wf))
+}
+
+func (dao *WorkflowDAO) UpdateMeta(ctx context.Context, id int64, name, description, iconURI *string) error {
+	updateMap := make(map[string]any, 3)
+	if name != nil {
+		updateMap["name"] = *name
+	}
+	if description != nil {
+		updateMap["description"] = *description
+	}
+	if iconURI != nil {
+		updateMap["icon_uri"] = *iconURI
+	}
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	w := dao.Query.Workflow
+	_, err := w.WithContext(ctx).Where(w.ID.Eq(id)).Updates(updateMap)
+	return err
+}
+
+func (dao *WorkflowDAO) UpdateCanvas(ctx context.Context, id int64, canvas *entity.Canvas) error {
+	w := dao.Query.Workflow
+	_, err := w.WithContext(ctx).Where(w.ID.Eq(id)).Updates(&model.Workflow{Canvas: canvas})
+	return err
+}
+
+func (dao *WorkflowDAO) UpdateLatestVersion(ctx context.Context, id int64, version string) error {
+	w := dao.Query.Workflow
+	_, err := w.WithContext(ctx).Where(w.ID.Eq(id)).UpdateSimple(w.LatestVersion.Value(version))
+	return err
+}
+
+func (dao *WorkflowDAO) Delete(ctx context.Context, id int64) error {
+	w := dao.Query.Workflow
+	_, err := w.WithContext(ctx).Where(w.ID.Eq(id)).Delete()
+	return err
+}
+
+func (dao *WorkflowDAO) GetByID(ctx context.Context, id int64) (*entity.Workflow, error) {
+	w := dao.Query.Workflow
+	po, err := w.WithContext(ctx).Where(w.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dao.fromModelToEntity(po), nil
+}
+
+func (dao *WorkflowDAO) MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Workflow, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	w := dao.Query.Workflow
+	pos, err := w.WithContext(ctx).Where(w.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), nil
+}
+
+func (dao *WorkflowDAO) List(ctx context.Context, creatorID int64, name string, offset, limit int) ([]*entity.Workflow, int64, error) {
+	w := dao.Query.Workflow
+	do := w.WithContext(ctx).Where(w.CreatorID.Eq(creatorID))
+	if name != "" {
+		do = do.Where(w.Name.Like("%" + name + "%"))
+	}
+
+	pos, total, err := do.Order(w.UpdatedAt.Desc()).FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), total, nil
+}
+
+func (dao *WorkflowDAO) fromModelToEntity(po *model.Workflow) *entity.Workflow {
+	if po == nil {
+		return nil
+	}
+	return &entity.Workflow{
+		ID:            po.ID,
+		Name:          po.Name,
+		Description:   ptr.From(po.Description),
+		IconURI:       po.IconURI,
+		Mode:          entity.Mode(po.Mode),
+		Canvas:        po.Canvas,
+		LatestVersion: po.LatestVersion,
+		CreatorID:     po.CreatorID,
+		CreatedAt:     po.CreatedAt,
+		UpdatedAt:     po.UpdatedAt,
+	}
+}
+
+func (dao *WorkflowDAO) fromEntityToModel(do *entity.Workflow) *model.Workflow {
+	return &model.Workflow{
+		ID:            do.ID,
+		Name:          do.Name,
+		Description:   ptr.Of(do.Description),
+		IconURI:       do.IconURI,
+		Mode:          int32(do.Mode),
+		Canvas:        do.Canvas,
+		LatestVersion: do.LatestVersion,
+		CreatorID:     do.CreatorID,
+		CreatedAt:     do.CreatedAt,
+		UpdatedAt:     do.UpdatedAt,
+	}
+}
@@ -0,0 +1,87 @@
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/infra/repo/gorm_gen/model"
+	"github.com/kiosk404/airi-go/backend/modules/component/workflow/infra/repo/gorm_gen/query"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"gorm.io/gorm"
+)
+
+type WorkflowVersionDAO struct {
+	DB    *gorm.DB
+	Query *query.Query
+}
+
+func NewWorkflowVersionDAO(db *gorm.DB) *WorkflowVersionDAO {
+	return &WorkflowVersionDAO{
+		DB:    db,
+		Query: query.Use(db),
+	}
+}
+
+func (dao *WorkflowVersionDAO) Create(ctx context.Context, v *entity.WorkflowVersion) error {
+	return dao.Query.WorkflowVersion.WithContext(ctx).Create(dao.fromEntityToModel(v))
+}
+
+func (dao *WorkflowVersionDAO) Get(ctx context.Context, workflowID int64, version string) (*entity.WorkflowVersion, error) {
+	v := dao.Query.WorkflowVersion
+	po, err := v.WithContext(ctx).Where(v.WorkflowID.Eq(workflowID), v.Version.Eq(version)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dao.fromModelToEntity(po), nil
+}
+
+func (dao *WorkflowVersionDAO) List(ctx context.Context, workflowID int64, offset, limit int) ([]*entity.WorkflowVersion, int64, error) {
+	v := dao.Query.WorkflowVersion
+	pos, total, err := v.WithContext(ctx).
+		Omit(v.Canvas).
+		Where(v.WorkflowID.Eq(workflowID)).
+		Order(v.CreatedAt.Desc()).
+		FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), total, nil
+}
+
+func (dao *WorkflowVersionDAO) DeleteByWorkflowID(ctx context.Context, workflowID int64) error {
+	v := dao.Query.WorkflowVersion
+	_, err := v.WithContext(ctx).Where(v.WorkflowID.Eq(workflowID)).Delete()
+	return err
+}
+
+func (dao *WorkflowVersionDAO) fromModelToEntity(po *model.WorkflowVersion) *entity.WorkflowVersion {
+	if po == nil {
+		return nil
+	}
+	return &entity.WorkflowVersion{
+		ID:                 po.ID,
+		WorkflowID:         po.WorkflowID,
+		Version:            po.Version,
+		VersionDescription: ptr.From(po.VersionDescription),
+		Canvas:             po.Canvas,
+		CreatorID:          po.CreatorID,
+		CreatedAt:          po.CreatedAt,
+	}
+}
+
+func (dao *WorkflowVersionDAO) fromEntityToModel(do *entity.WorkflowVersion) *model.WorkflowVersion {
+	return &model.WorkflowVersion{
+		ID:                 do.ID,
+		WorkflowID:         do.WorkflowID,
+		Version:            do.Version,
+		VersionDescription: ptr.Of(do.VersionDescription),
+		Canvas:             do.Canvas,
+		CreatorID:          do.CreatorID,
+		CreatedAt:          do.CreatedAt,
+	}
+}
@@ -0,0 +1,67 @@
+package errno
+
+import (
+	"github.com/kiosk404/airi-go/backend/pkg/errorx/code"
+)
+
+// Workflow: 112 000 000 ~ 112 999 999
+const (
+	ErrWorkflowInvalidParamCode  = 112000000
+	ErrWorkflowPermissionCode    = 112000001
+	ErrWorkflowNotExistCode      = 112000002
+	ErrWorkflowCanvasInvalidCode = 112000003
+	ErrWorkflowNotPublishedCode  = 112000004
+	ErrWorkflowExecuteCode       = 112000005
+	ErrWorkflowDBCode            = 112000006
+	ErrWorkflowIDGenCode         = 112000007
+)
+
+func init() {
+	code.Register(
+		ErrWorkflowInvalidParamCode,
+		"invalid parameter : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrWorkflowPermissionCode,
+		"unauthorized access : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrWorkflowNotExistCode,
+		"workflow not exist : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrWorkflowCanvasInvalidCode,
+		"invalid workflow canvas : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrWorkflowNotPublishedCode,
+		"workflow not published : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrWorkflowExecuteCode,
+		"workflow execute failed : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrWorkflowDBCode,
+		"workflow db error : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrWorkflowIDGenCode,
+		"id gen error : {msg}",
+		code.WithAffectStability(true),
+	)
+}
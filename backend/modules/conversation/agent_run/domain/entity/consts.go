@@ -28,10 +28,16 @@ const (
 
 	RunEventMessageDelta     RunEvent = "conversation.message.delta"
 	RunEventMessageCompleted RunEvent = "conversation.message.completed"
+	// RunEventMessageDirective 从回答中剥离出的形象指令
+	RunEventMessageDirective RunEvent = "conversation.message.directive"
+	// RunEventMessageAudio 回答中一句话合成的语音
+	RunEventMessageAudio RunEvent = "conversation.message.audio"
 
 	RunEventAck                 = "conversation.ack"
 	RunEventError      RunEvent = "conversation.error"
 	RunEventStreamDone RunEvent = "conversation.stream.done"
+	// RunEventStreamEnd 只写入事件缓存，标记运行的事件已全部写入，重新接入的客户端读到后结束
+	RunEventStreamEnd RunEvent = "conversation.stream.end"
 )
 
 type ReplyType int64
@@ -66,4 +72,5 @@ const (
 	MessageSubTypeKnowledgeCall  MessageSubType = "knowledge_recall"
 	MessageSubTypeGenerateFinish MessageSubType = "generate_answer_finish"
 	MessageSubTypeInterrupt      MessageSubType = "interrupt"
+	MessageSubTypePreToolCall    MessageSubType = "pre_tool_call"
 )
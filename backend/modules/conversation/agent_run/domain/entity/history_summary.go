@@ -0,0 +1,10 @@
+package entity
+
+// HistorySummary 会话 section 的历史对话滚动摘要，LastRunID 及之前的运行已压缩进摘要
+type HistorySummary struct {
+	ConversationID int64  `json:"conversation_id"`
+	SectionID      int64  `json:"section_id"`
+	Summary        string `json:"summary"`
+	LastRunID      int64  `json:"last_run_id"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
@@ -24,6 +24,24 @@ type RunRecordMeta struct {
 	CompletedAt    int64           `json:"completed_at"`
 	FailedAt       int64           `json:"failed_at"`
 	CreatorID      int64           `json:"creator_id"`
+	// CheckpointID 运行中断时保存执行现场的 checkpoint，取消或删除运行时一并清理
+	CheckpointID string `json:"checkpoint_id"`
+	// ParentID 所在分支上的前一个运行，0 表示会话的第一个运行或分支功能上线前的运行
+	ParentID int64 `json:"parent_id"`
+	// BranchID 重新生成或编辑产生的运行与原运行属于同一分支组，0 表示分支组为运行自身
+	BranchID int64 `json:"branch_id"`
+	// BranchSelected 是否为分支组中选中的分支
+	BranchSelected bool `json:"branch_selected"`
+	// BranchActive 是否在当前展示的分支路径上，历史与消息列表只包含路径上的运行
+	BranchActive bool `json:"branch_active"`
+}
+
+// GetBranchID 分支功能上线前的运行没有分支组，以自身 ID 作为分支组
+func (r *RunRecordMeta) GetBranchID() int64 {
+	if r.BranchID > 0 {
+		return r.BranchID
+	}
+	return r.ID
 }
 
 type ChunkRunItem = RunRecordMeta
@@ -53,6 +71,8 @@ type ChunkMessageItem struct {
 type RunError struct {
 	Code int64  `json:"code"`
 	Msg  string `json:"msg"`
+	// RetryAfterMs 模型配额超限时需要等待的毫秒数
+	RetryAfterMs *int64 `json:"retry_after_ms,omitempty"`
 }
 
 type CustomerConfig struct {
@@ -101,6 +121,8 @@ type AgentRunMeta struct {
 	CustomVariables  map[string]string        `json:"custom_variables"`
 	Version          string                   `json:"version"`
 	Ext              map[string]string        `json:"ext"`
+	// BranchID 重新生成或编辑时为原运行的分支组，为 0 时新建分支组
+	BranchID int64 `json:"branch_id"`
 }
 
 type UpdateMeta struct {
@@ -110,6 +132,8 @@ type UpdateMeta struct {
 	UpdatedAt   int64
 	CompletedAt int64
 	FailedAt    int64
+	// CheckpointID 为空时不更新
+	CheckpointID string
 }
 
 type AgentRunResponse struct {
@@ -117,6 +141,36 @@ type AgentRunResponse struct {
 	ChunkRunItem     *ChunkRunItem     `json:"run_record_item"`
 	ChunkMessageItem *ChunkMessageItem `json:"message_item"`
 	Error            *RunError         `json:"error"`
+	// ChunkDirective 仅 RunEventMessageDirective 事件携带
+	ChunkDirective *ChunkDirective `json:"directive"`
+	// ChunkAudio 仅 RunEventMessageAudio 事件携带
+	ChunkAudio *ChunkAudio `json:"audio"`
+	// Seq 运行内从 1 开始递增的事件序号，客户端断线后据此续传
+	Seq int64 `json:"seq"`
+}
+
+// ChunkDirective 回答中的形象指令，Offset 为指令在去除指令后的回答正文中的字符位置
+type ChunkDirective struct {
+	ConversationID int64  `json:"conversation_id"`
+	MessageID      int64  `json:"message_id"`
+	Type           string `json:"type"`
+	Name           string `json:"name"`
+	Offset         int    `json:"offset"`
+	// ElapsedMs 距运行开始的毫秒数
+	ElapsedMs int64 `json:"elapsed_ms"`
+}
+
+// ChunkAudio 回答中一句话合成的语音，Offset 为该句在回答正文中的起始字符位置
+type ChunkAudio struct {
+	ConversationID int64  `json:"conversation_id"`
+	MessageID      int64  `json:"message_id"`
+	Index          int    `json:"index"`
+	Text           string `json:"text"`
+	Offset         int    `json:"offset"`
+	URL            string `json:"url"`
+	ContentType    string `json:"content_type"`
+	// Autoplay 智能体配置为自动播放
+	Autoplay bool `json:"autoplay"`
 }
 
 type AgentRespEvent struct {
@@ -148,6 +202,29 @@ type ListRunRecordMeta struct {
 	AfterID        int64  `json:"after_id"`
 }
 
+// ForkRunMeta 从指定运行处分叉，原运行及之后的运行从当前分支路径上移除
+type ForkRunMeta struct {
+	ConversationID int64 `json:"conversation_id"`
+	RunID          int64 `json:"run_id"`
+}
+
+type ForkRunResult struct {
+	// BranchID 新分支上的运行使用的分支组
+	BranchID int64 `json:"branch_id"`
+	// Deactivated 从当前分支路径上移除的运行
+	Deactivated []int64 `json:"deactivated"`
+}
+
+type SwitchBranchMeta struct {
+	ConversationID int64 `json:"conversation_id"`
+	RunID          int64 `json:"run_id"`
+}
+
+type SwitchBranchResult struct {
+	Deactivated []int64 `json:"deactivated"`
+	Activated   []int64 `json:"activated"`
+}
+
 type CancelRunMeta struct {
 	ConversationID int64 `json:"conversation_id"`
 	RunID          int64 `json:"run_id"`
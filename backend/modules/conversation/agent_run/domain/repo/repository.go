@@ -2,7 +2,9 @@ package repo
 
 import (
 	"context"
+	"time"
 
+	"github.com/kiosk404/airi-go/backend/infra/contract/cache"
 	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
 	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
@@ -16,8 +18,37 @@ func NewRunRecordRepo(rdb rdb.Provider, idGen idgen.IDGenerator) RunRecordRepo {
 type RunRecordRepo interface {
 	Create(ctx context.Context, runMeta *entity.AgentRunMeta) (*entity.RunRecordMeta, error)
 	GetByID(ctx context.Context, id int64) (*entity.RunRecordMeta, error)
+	MGetByID(ctx context.Context, ids []int64) ([]*entity.RunRecordMeta, error)
 	Cancel(ctx context.Context, req *entity.CancelRunMeta) (*entity.RunRecordMeta, error)
 	Delete(ctx context.Context, id []int64) error
 	UpdateByID(ctx context.Context, id int64, update *entity.UpdateMeta) error
+	// List 只返回当前分支路径上的运行
 	List(ctx context.Context, meta *entity.ListRunRecordMeta) ([]*entity.RunRecordMeta, error)
+	// ListBranch 返回分支组中的运行，按创建时间升序
+	ListBranch(ctx context.Context, branchIDs []int64) ([]*entity.RunRecordMeta, error)
+	// DeactivateFrom 将运行及分支路径上在它之后的运行移出路径，返回移出的运行
+	DeactivateFrom(ctx context.Context, runRecord *entity.RunRecordMeta) ([]int64, error)
+	// ActivateFrom 选中运行并沿之后选中的分支恢复路径，返回恢复的运行
+	ActivateFrom(ctx context.Context, runRecord *entity.RunRecordMeta) ([]int64, error)
+}
+
+func NewHistorySummaryRepo(rdb rdb.Provider) HistorySummaryRepo {
+	return dao.NewHistorySummaryDAO(rdb.NewSession(context.Background()).DB())
+}
+
+// HistorySummaryRepo 按会话 section 保存历史对话的滚动摘要
+type HistorySummaryRepo interface {
+	Get(ctx context.Context, sectionID int64) (*entity.HistorySummary, error)
+	Save(ctx context.Context, summary *entity.HistorySummary) error
+}
+
+func NewRunEventRepo(cli cache.Cmdable, ttl time.Duration) RunEventRepo {
+	return dao.NewRunEventDAO(cli, ttl)
+}
+
+// RunEventRepo 缓存运行推送过的事件，客户端断线后从缓存重放，过期后不可续传
+type RunEventRepo interface {
+	Append(ctx context.Context, runID int64, events ...*entity.AgentRunResponse) error
+	// ListAfter 返回序号大于 afterSeq 的事件
+	ListAfter(ctx context.Context, runID int64, afterSeq int64) ([]*entity.AgentRunResponse, error)
 }
@@ -9,9 +9,15 @@ import (
 
 type Run interface {
 	AgentRun(ctx context.Context, req *entity.AgentRunMeta) (*schema.StreamReader[*entity.AgentRunResponse], error)
+	AsyncRun(ctx context.Context, req *entity.AgentRunMeta) (*entity.RunRecordMeta, error)
+	Reattach(ctx context.Context, runID int64, afterSeq int64) (*schema.StreamReader[*entity.AgentRunResponse], error)
 	Delete(ctx context.Context, runID []int64) error
 	Create(ctx context.Context, runRecord *entity.AgentRunMeta) (*entity.RunRecordMeta, error)
 	List(ctx context.Context, ListMeta *entity.ListRunRecordMeta) ([]*entity.RunRecordMeta, error)
 	GetByID(ctx context.Context, runID int64) (*entity.RunRecordMeta, error)
 	Cancel(ctx context.Context, req *entity.CancelRunMeta) (*entity.RunRecordMeta, error)
+	Fork(ctx context.Context, req *entity.ForkRunMeta) (*entity.ForkRunResult, error)
+	SwitchBranch(ctx context.Context, req *entity.SwitchBranchMeta) (*entity.SwitchBranchResult, error)
+	// ListBranches 返回存在多个分支的运行所在分支组的全部运行 ID，按创建时间升序
+	ListBranches(ctx context.Context, runIDs []int64) (map[int64][]int64, error)
 }
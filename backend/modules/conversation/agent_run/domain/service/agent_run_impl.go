@@ -6,24 +6,104 @@ import (
 	"time"
 
 	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/infra/contract/eventbus"
 	"github.com/kiosk404/airi-go/backend/infra/contract/imagex"
+	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	"github.com/kiosk404/airi-go/backend/infra/contract/stt"
+	"github.com/kiosk404/airi-go/backend/infra/contract/tts"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/repo"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/service/runtime"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/checkpoint"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
 	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
 )
 
-type runImpl struct {
+// DefaultMemoryExtractInterval 未配置时每累计多少个运行提取一次长期记忆
+const DefaultMemoryExtractInterval = 4
+
+type Components struct {
 	RunRecordRepo repo.RunRecordRepo
-	ImagexSVC     imagex.ImageX
+	// RunEventRepo 为空时不缓存运行事件，客户端断线后无法续传
+	RunEventRepo repo.RunEventRepo
+	// SummaryRepo 为空时滚动摘要模式退化为截断最早的历史
+	SummaryRepo repo.HistorySummaryRepo
+	ImagexSVC   imagex.ImageX
+	CPStore     checkpoint.Store
+	Registry    *runtime.Registry
+	// CancelProducer 为空时只能取消本实例上执行中的运行
+	CancelProducer eventbus.Producer
+	// AsyncRunProducer 为空时异步运行直接提交到本实例的工作池
+	AsyncRunProducer eventbus.Producer
+	AsyncRunPool     *RunPool
+	// MemoryExtractInterval 每累计多少个运行提取一次长期记忆，不大于 0 时不提取
+	MemoryExtractInterval int
+	// TosClient 保存合成的语音，读取待识别的语音
+	TosClient storage.Storage
+	// TTS 为空时不合成语音
+	TTS tts.Synthesizer
+	// STT 为空时不识别语音
+	STT stt.Transcriber
+}
+
+type runImpl struct {
+	RunRecordRepo         repo.RunRecordRepo
+	RunEventRepo          repo.RunEventRepo
+	SummaryRepo           repo.HistorySummaryRepo
+	ImagexSVC             imagex.ImageX
+	CPStore               checkpoint.Store
+	TosClient             storage.Storage
+	TTS                   tts.Synthesizer
+	STT                   stt.Transcriber
+	Registry              *runtime.Registry
+	CancelProducer        eventbus.Producer
+	AsyncRunProducer      eventbus.Producer
+	AsyncRunPool          *RunPool
+	MemoryExtractInterval int
+}
+
+func NewService(c *Components) Run {
+	impl := &runImpl{
+		RunRecordRepo:         c.RunRecordRepo,
+		RunEventRepo:          c.RunEventRepo,
+		SummaryRepo:           c.SummaryRepo,
+		ImagexSVC:             c.ImagexSVC,
+		CPStore:               c.CPStore,
+		TosClient:             c.TosClient,
+		TTS:                   c.TTS,
+		STT:                   c.STT,
+		Registry:              c.Registry,
+		CancelProducer:        c.CancelProducer,
+		AsyncRunProducer:      c.AsyncRunProducer,
+		AsyncRunPool:          c.AsyncRunPool,
+		MemoryExtractInterval: c.MemoryExtractInterval,
+	}
+	if impl.AsyncRunPool != nil {
+		impl.AsyncRunPool.start(impl.execAsyncRun)
+	}
+	return impl
 }
 
-func NewService(repo repo.RunRecordRepo, imagexSVC imagex.ImageX) Run {
-	return &runImpl{
-		RunRecordRepo: repo,
-		ImagexSVC:     imagexSVC,
+func (c *runImpl) newRuntime(arm *entity.AgentRunMeta, sw *schema.StreamWriter[*entity.AgentRunResponse]) *runtime.AgentRuntime {
+	return &runtime.AgentRuntime{
+		StartTime:             time.Now(),
+		RunMeta:               arm,
+		SW:                    sw,
+		MessageEvent:          runtime.NewMessageEvent(),
+		RunProcess:            runtime.NewRunProcess(c.RunRecordRepo),
+		RunRecordRepo:         c.RunRecordRepo,
+		SummaryRepo:           c.SummaryRepo,
+		MemoryExtractInterval: c.MemoryExtractInterval,
+		ImagexClient:          c.ImagexSVC,
+		CPStore:               c.CPStore,
+		TosClient:             c.TosClient,
+		TTS:                   c.TTS,
+		STT:                   c.STT,
+		Registry:              c.Registry,
 	}
 }
 
@@ -37,25 +117,28 @@ func (c *runImpl) AgentRun(ctx context.Context, arm *entity.AgentRunMeta) (*sche
 		}
 	}()
 
-	art := &runtime.AgentRuntime{
-		StartTime:     time.Now(),
-		RunMeta:       arm,
-		SW:            sw,
-		MessageEvent:  runtime.NewMessageEvent(),
-		RunProcess:    runtime.NewRunProcess(c.RunRecordRepo),
-		RunRecordRepo: c.RunRecordRepo,
-		ImagexClient:  c.ImagexSVC,
-	}
-	safego.Go(ctx, func() {
+	// 客户端断开后运行继续执行，重新接入时从事件缓存续传
+	runCtx := context.WithoutCancel(ctx)
+	art := c.newRuntime(arm, sw)
+	safego.Go(runCtx, func() {
 		defer sw.Close()
-		_ = art.Run(ctx)
+		_ = art.Run(runCtx)
 	})
 
-	return sr, nil
+	return c.bufferStream(runCtx, sr), nil
 }
 
 func (c *runImpl) Delete(ctx context.Context, runID []int64) error {
-	return c.RunRecordRepo.Delete(ctx, runID)
+	runRecords, err := c.RunRecordRepo.MGetByID(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if err = c.RunRecordRepo.Delete(ctx, runID); err != nil {
+		return err
+	}
+
+	c.purgeCheckpoint(ctx, runRecords...)
+	return nil
 }
 
 func (c *runImpl) List(ctx context.Context, meta *entity.ListRunRecordMeta) ([]*entity.RunRecordMeta, error) {
@@ -65,8 +148,68 @@ func (c *runImpl) List(ctx context.Context, meta *entity.ListRunRecordMeta) ([]*
 func (c *runImpl) Create(ctx context.Context, runRecord *entity.AgentRunMeta) (*entity.RunRecordMeta, error) {
 	return c.RunRecordRepo.Create(ctx, runRecord)
 }
+
+// Cancel 只有未结束或等待用户操作的运行可以取消，执行中的运行通过 context 通知执行链路退出
 func (c *runImpl) Cancel(ctx context.Context, req *entity.CancelRunMeta) (*entity.RunRecordMeta, error) {
-	return c.RunRecordRepo.Cancel(ctx, req)
+	origin, err := c.RunRecordRepo.GetByID(ctx, req.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if origin == nil || origin.ConversationID != req.ConversationID {
+		return nil, errorx.New(errno.ErrRecordNotFound)
+	}
+
+	// 状态由存储层在更新时判断，查询到更新之间运行可能已经结束
+	runRecord, err := c.RunRecordRepo.Cancel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if origin.Status != entity.RunStatusRequiredAction {
+		c.stopRun(ctx, req.RunID)
+	}
+	c.purgeCheckpoint(ctx, runRecord)
+	return runRecord, nil
+}
+
+// stopRun 运行不在本实例执行时广播取消事件，由执行该运行的实例取消
+func (c *runImpl) stopRun(ctx context.Context, runID int64) {
+	if c.Registry != nil && c.Registry.Cancel(runID) {
+		return
+	}
+	if c.CancelProducer == nil {
+		logs.WarnX(pkg.ModelName, "run %d is not running on this instance and cancel event bus is not configured", runID)
+		return
+	}
+
+	body, err := json.Marshal(&cancelEvent{RunID: runID})
+	if err != nil {
+		logs.WarnX(pkg.ModelName, "marshal cancel event failed, run_id: %d, err: %v", runID, err)
+		return
+	}
+	if err = c.CancelProducer.Send(ctx, body); err != nil {
+		logs.WarnX(pkg.ModelName, "send cancel event failed, run_id: %d, err: %v", runID, err)
+	}
+}
+
+// purgeCheckpoint 运行被取消或删除后不会再恢复，清理失败时由过期清理兜底
+func (c *runImpl) purgeCheckpoint(ctx context.Context, runRecords ...*entity.RunRecordMeta) {
+	if c.CPStore == nil {
+		return
+	}
+
+	ids := make([]string, 0, len(runRecords))
+	for _, r := range runRecords {
+		if r != nil && r.CheckpointID != "" {
+			ids = append(ids, r.CheckpointID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if err := c.CPStore.Delete(ctx, ids...); err != nil {
+		logs.WarnX(pkg.ModelName, "purge checkpoint failed, ids: %v, err: %v", ids, err)
+	}
 }
 
 func (c *runImpl) GetByID(ctx context.Context, runID int64) (*entity.RunRecordMeta, error) {
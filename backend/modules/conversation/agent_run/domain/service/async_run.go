@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/infra/contract/eventbus"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+)
+
+// DefaultAsyncRunWorkerNum 未配置时本实例同时执行的异步运行数量
+const DefaultAsyncRunWorkerNum = 8
+
+// asyncRunEvent 异步运行入队事件，执行时从运行记录中恢复请求
+type asyncRunEvent struct {
+	RunID int64 `json:"run_id"`
+}
+
+// RunPool 异步运行的工作池，限制本实例同时执行的运行数量
+type RunPool struct {
+	workerNum int
+	jobs      chan int64
+	once      sync.Once
+}
+
+func NewRunPool(workerNum int) *RunPool {
+	if workerNum <= 0 {
+		workerNum = DefaultAsyncRunWorkerNum
+	}
+	return &RunPool{
+		workerNum: workerNum,
+		jobs:      make(chan int64, workerNum*4),
+	}
+}
+
+func (p *RunPool) start(exec func(ctx context.Context, runID int64)) {
+	p.once.Do(func() {
+		ctx := context.Background()
+		for i := 0; i < p.workerNum; i++ {
+			safego.Go(ctx, func() {
+				for runID := range p.jobs {
+					exec(ctx, runID)
+				}
+			})
+		}
+	})
+}
+
+// Submit 队列已满时阻塞，消费事件时借此把积压留在消息队列中
+func (p *RunPool) Submit(ctx context.Context, runID int64) error {
+	select {
+	case p.jobs <- runID:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TrySubmit 队列已满时返回 false
+func (p *RunPool) TrySubmit(runID int64) bool {
+	select {
+	case p.jobs <- runID:
+		return true
+	default:
+		return false
+	}
+}
+
+type asyncRunEventHandler struct {
+	pool *RunPool
+}
+
+// NewAsyncRunEventHandler 消费异步运行事件并提交到本实例的工作池
+func NewAsyncRunEventHandler(pool *RunPool) eventbus.ConsumerHandler {
+	return &asyncRunEventHandler{pool: pool}
+}
+
+func (h *asyncRunEventHandler) HandleMessage(ctx context.Context, msg *eventbus.Message) error {
+	event := &asyncRunEvent{}
+	if err := json.Unmarshal(msg.Body, event); err != nil {
+		logs.WarnX(pkg.ModelName, "unmarshal async run event failed, body: %s, err: %v", string(msg.Body), err)
+		return nil
+	}
+	return h.pool.Submit(ctx, event.RunID)
+}
+
+// AsyncRun 创建运行记录后入队，由工作池执行，调用方通过运行记录查询进度
+func (c *runImpl) AsyncRun(ctx context.Context, arm *entity.AgentRunMeta) (*entity.RunRecordMeta, error) {
+	runRecord, err := c.RunRecordRepo.Create(ctx, arm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.enqueueAsyncRun(ctx, runRecord.ID); err != nil {
+		c.failAsyncRun(ctx, runRecord, err)
+		return nil, err
+	}
+	return runRecord, nil
+}
+
+func (c *runImpl) enqueueAsyncRun(ctx context.Context, runID int64) error {
+	if c.AsyncRunProducer != nil {
+		body, err := json.Marshal(&asyncRunEvent{RunID: runID})
+		if err != nil {
+			return err
+		}
+		return c.AsyncRunProducer.Send(ctx, body)
+	}
+
+	if c.AsyncRunPool == nil || !c.AsyncRunPool.TrySubmit(runID) {
+		return errorx.New(errno.ErrAsyncRunQueueFull)
+	}
+	return nil
+}
+
+// execAsyncRun 运行在入队后被取消或已被执行时跳过
+func (c *runImpl) execAsyncRun(ctx context.Context, runID int64) {
+	defer func() {
+		if pe := recover(); pe != nil {
+			logs.ErrorX(pkg.ModelName, "panic recover: %v\n, [stack]:%v", pe, string(debug.Stack()))
+		}
+	}()
+
+	runRecord, err := c.RunRecordRepo.GetByID(ctx, runID)
+	if err != nil {
+		logs.WarnX(pkg.ModelName, "get async run record failed, run_id: %d, err: %v", runID, err)
+		return
+	}
+	if runRecord == nil || runRecord.Status != entity.RunStatusCreated {
+		return
+	}
+
+	arm := &entity.AgentRunMeta{}
+	if runRecord.ChatRequest == nil {
+		c.failAsyncRun(ctx, runRecord, fmt.Errorf("chat request of run %d is empty", runID))
+		return
+	}
+	if err = json.Unmarshal([]byte(*runRecord.ChatRequest), arm); err != nil {
+		c.failAsyncRun(ctx, runRecord, err)
+		return
+	}
+
+	sr, sw := schema.Pipe[*entity.AgentRunResponse](20)
+	art := c.newRuntime(arm, sw)
+	art.SetRunRecord(runRecord)
+
+	var runErr error
+	safego.Go(ctx, func() {
+		defer sw.Close()
+		runErr = art.Run(ctx)
+	})
+
+	// 异步运行没有订阅方，事件写入缓存后可通过重新接入订阅，结果也可通过运行记录与消息查询
+	sr = c.bufferStream(ctx, sr)
+	for {
+		if _, err = sr.Recv(); errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	sr.Close()
+
+	if runErr == nil {
+		return
+	}
+	// 运行记录启动前的失败不会更新状态，避免查询方一直等待
+	latest, err := c.RunRecordRepo.GetByID(ctx, runID)
+	if err == nil && latest != nil && latest.Status == entity.RunStatusCreated {
+		c.failAsyncRun(ctx, latest, runErr)
+	}
+}
+
+func (c *runImpl) failAsyncRun(ctx context.Context, runRecord *entity.RunRecordMeta, cause error) {
+	nowTime := time.Now().UnixMilli()
+	err := c.RunRecordRepo.UpdateByID(ctx, runRecord.ID, &entity.UpdateMeta{
+		Status:    entity.RunStatusFailed,
+		UpdatedAt: nowTime,
+		FailedAt:  nowTime,
+		LastError: &entity.RunError{
+			Code: errno.ErrConversationAgentRunError,
+			Msg:  cause.Error(),
+		},
+	})
+	if err != nil {
+		logs.WarnX(pkg.ModelName, "mark async run %d failed error: %v", runRecord.ID, err)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+)
+
+// Fork 重新生成或编辑时从原运行处分叉，原运行及之后的运行保留为未选中的分支
+func (c *runImpl) Fork(ctx context.Context, req *entity.ForkRunMeta) (*entity.ForkRunResult, error) {
+	runRecord, err := c.getConversationRun(ctx, req.ConversationID, req.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if !runRecord.BranchActive {
+		return nil, errorx.New(errno.ErrRunNotOnBranch)
+	}
+
+	deactivated, err := c.RunRecordRepo.DeactivateFrom(ctx, runRecord)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.ForkRunResult{
+		BranchID:    runRecord.GetBranchID(),
+		Deactivated: deactivated,
+	}, nil
+}
+
+// SwitchBranch 只能切换当前分支路径上的分支组，切换后沿目标分支之后选中的分支恢复路径
+func (c *runImpl) SwitchBranch(ctx context.Context, req *entity.SwitchBranchMeta) (*entity.SwitchBranchResult, error) {
+	target, err := c.getConversationRun(ctx, req.ConversationID, req.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if target.BranchActive {
+		return &entity.SwitchBranchResult{}, nil
+	}
+
+	siblings, err := c.RunRecordRepo.ListBranch(ctx, []int64{target.GetBranchID()})
+	if err != nil {
+		return nil, err
+	}
+	var current *entity.RunRecordMeta
+	for _, s := range siblings {
+		if s.BranchActive {
+			current = s
+			break
+		}
+	}
+	if current == nil {
+		return nil, errorx.New(errno.ErrRunNotOnBranch)
+	}
+
+	deactivated, err := c.RunRecordRepo.DeactivateFrom(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+	activated, err := c.RunRecordRepo.ActivateFrom(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.SwitchBranchResult{
+		Deactivated: deactivated,
+		Activated:   activated,
+	}, nil
+}
+
+func (c *runImpl) ListBranches(ctx context.Context, runIDs []int64) (map[int64][]int64, error) {
+	if len(runIDs) == 0 {
+		return nil, nil
+	}
+	runRecords, err := c.RunRecordRepo.MGetByID(ctx, runIDs)
+	if err != nil {
+		return nil, err
+	}
+	branchIDs := make([]int64, 0, len(runRecords))
+	for _, r := range runRecords {
+		branchIDs = append(branchIDs, r.GetBranchID())
+	}
+	branchRuns, err := c.RunRecordRepo.ListBranch(ctx, branchIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make(map[int64][]int64, len(branchIDs))
+	for _, r := range branchRuns {
+		branches[r.GetBranchID()] = append(branches[r.GetBranchID()], r.ID)
+	}
+	result := make(map[int64][]int64, len(runRecords))
+	for _, r := range runRecords {
+		if siblings := branches[r.GetBranchID()]; len(siblings) > 1 {
+			result[r.ID] = siblings
+		}
+	}
+	return result, nil
+}
+
+func (c *runImpl) getConversationRun(ctx context.Context, conversationID, runID int64) (*entity.RunRecordMeta, error) {
+	runRecord, err := c.RunRecordRepo.GetByID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if runRecord == nil || runRecord.ConversationID != conversationID || runRecord.Status == entity.RunStatusDeleted {
+		return nil, errorx.New(errno.ErrRecordNotFound)
+	}
+	return runRecord, nil
+}
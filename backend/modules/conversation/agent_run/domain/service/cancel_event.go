@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/eventbus"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/service/runtime"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+// cancelEvent 多实例部署时广播的取消事件
+type cancelEvent struct {
+	RunID int64 `json:"run_id"`
+}
+
+type cancelEventHandler struct {
+	registry *runtime.Registry
+}
+
+// NewCancelEventHandler 消费广播的取消事件，运行不在本实例执行时忽略
+func NewCancelEventHandler(registry *runtime.Registry) eventbus.ConsumerHandler {
+	return &cancelEventHandler{registry: registry}
+}
+
+func (h *cancelEventHandler) HandleMessage(_ context.Context, msg *eventbus.Message) error {
+	event := &cancelEvent{}
+	if err := json.Unmarshal(msg.Body, event); err != nil {
+		logs.WarnX(pkg.ModelName, "unmarshal cancel event failed, body: %s, err: %v", string(msg.Body), err)
+		return nil
+	}
+	if h.registry.Cancel(event.RunID) {
+		logs.InfoX(pkg.ModelName, "run %d cancelled by event", event.RunID)
+	}
+	return nil
+}
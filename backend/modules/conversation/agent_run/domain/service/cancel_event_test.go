@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/eventbus"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/service/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// broadcastProducer 将事件同步投递给其他实例的消费者
+type broadcastProducer struct {
+	handlers []eventbus.ConsumerHandler
+}
+
+func (p *broadcastProducer) Send(ctx context.Context, body []byte, _ ...eventbus.ProduceOpt) error {
+	for _, h := range p.handlers {
+		if err := h.HandleMessage(ctx, &eventbus.Message{Body: body}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *broadcastProducer) BatchSend(ctx context.Context, bodyArr [][]byte, opts ...eventbus.ProduceOpt) error {
+	for _, body := range bodyArr {
+		if err := p.Send(ctx, body, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStopRunAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	local, remote := runtime.NewRegistry(), runtime.NewRegistry()
+	producer := &broadcastProducer{}
+	producer.handlers = []eventbus.ConsumerHandler{NewCancelEventHandler(local), NewCancelEventHandler(remote)}
+	impl := &runImpl{Registry: local, CancelProducer: producer}
+
+	localCtx, localCancel := context.WithCancelCause(ctx)
+	defer localCancel(nil)
+	local.Register(1, localCancel)
+	remoteCtx, remoteCancel := context.WithCancelCause(ctx)
+	defer remoteCancel(nil)
+	remote.Register(2, remoteCancel)
+
+	// 本实例上的运行直接取消，不广播
+	impl.stopRun(ctx, 1)
+	assert.True(t, runtime.IsCancelled(localCtx))
+	assert.NoError(t, remoteCtx.Err())
+
+	// 其他实例上的运行由广播的取消事件取消
+	impl.stopRun(ctx, 2)
+	assert.True(t, runtime.IsCancelled(remoteCtx))
+
+	// 没有实例执行该运行时忽略
+	impl.stopRun(ctx, 3)
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+)
+
+const (
+	// DefaultRunEventTTL 未配置时运行事件的缓存时长
+	DefaultRunEventTTL = 10 * time.Minute
+
+	reattachPollInterval = 200 * time.Millisecond
+	// reattachIdleCheckInterval 长时间没有新事件时检查运行是否已结束，执行实例异常退出时不会写入结束标记
+	reattachIdleCheckInterval = 5 * time.Second
+
+	// runEventBatchSize 消息增量事件攒够该数量或距上次写入超过 runEventFlushInterval 时批量写入缓存，其余事件立即写入
+	runEventBatchSize     = 16
+	runEventFlushInterval = reattachPollInterval
+)
+
+// bufferStream 为运行事件分配序号并写入缓存，客户端断开后继续读完事件，运行不受影响
+func (c *runImpl) bufferStream(ctx context.Context, sr *schema.StreamReader[*entity.AgentRunResponse]) *schema.StreamReader[*entity.AgentRunResponse] {
+	if c.RunEventRepo == nil {
+		return sr
+	}
+
+	out, sw := schema.Pipe[*entity.AgentRunResponse](20)
+	safego.Go(ctx, func() {
+		defer sw.Close()
+		defer sr.Close()
+
+		var (
+			seq       int64
+			runID     int64
+			pending   []*entity.AgentRunResponse
+			lastFlush = time.Now()
+			detached  bool
+		)
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				if !detached {
+					detached = sw.Send(nil, err)
+				}
+				continue
+			}
+
+			seq++
+			chunk.Seq = seq
+			if chunk.ChunkMessageItem != nil {
+				chunk.ChunkMessageItem.SeqID = seq
+			}
+			// 运行记录创建后才能确定缓存的 key，之前的事件先暂存
+			if runID == 0 {
+				runID = eventRunID(chunk)
+			}
+			pending = append(pending, chunk)
+			if runID != 0 && (chunk.Event != entity.RunEventMessageDelta ||
+				len(pending) >= runEventBatchSize || time.Since(lastFlush) >= runEventFlushInterval) {
+				c.appendRunEvents(ctx, runID, pending...)
+				pending = nil
+				lastFlush = time.Now()
+			}
+
+			if !detached {
+				detached = sw.Send(chunk, nil)
+			}
+		}
+
+		if runID != 0 {
+			c.appendRunEvents(ctx, runID, append(pending, &entity.AgentRunResponse{
+				Event: entity.RunEventStreamEnd,
+				Seq:   seq + 1,
+			})...)
+		}
+	})
+	return out
+}
+
+func eventRunID(chunk *entity.AgentRunResponse) int64 {
+	if chunk.ChunkRunItem != nil && chunk.ChunkRunItem.ID != 0 {
+		return chunk.ChunkRunItem.ID
+	}
+	if chunk.ChunkMessageItem != nil {
+		return chunk.ChunkMessageItem.RunID
+	}
+	return 0
+}
+
+func (c *runImpl) appendRunEvents(ctx context.Context, runID int64, events ...*entity.AgentRunResponse) {
+	if err := c.RunEventRepo.Append(ctx, runID, events...); err != nil {
+		logs.WarnX(pkg.ModelName, "buffer run events failed, run_id: %d, err: %v", runID, err)
+	}
+}
+
+// Reattach 从缓存重放序号大于 afterSeq 的事件，运行未结束时继续推送新的事件
+func (c *runImpl) Reattach(ctx context.Context, runID int64, afterSeq int64) (*schema.StreamReader[*entity.AgentRunResponse], error) {
+	if c.RunEventRepo == nil {
+		return nil, errorx.New(errno.ErrRunEventsExpired)
+	}
+
+	events, err := c.RunEventRepo.ListAfter(ctx, runID, afterSeq)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		finished, err := c.runFinished(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+		if finished {
+			return nil, errorx.New(errno.ErrRunEventsExpired)
+		}
+	}
+
+	sr, sw := schema.Pipe[*entity.AgentRunResponse](20)
+	safego.Go(ctx, func() {
+		defer sw.Close()
+		c.followRunEvents(ctx, runID, afterSeq, events, sw)
+	})
+	return sr, nil
+}
+
+// followRunEvents 读到结束标记、运行已结束或客户端断开时返回
+func (c *runImpl) followRunEvents(ctx context.Context, runID int64, seq int64, events []*entity.AgentRunResponse,
+	sw *schema.StreamWriter[*entity.AgentRunResponse]) {
+	ticker := time.NewTicker(reattachPollInterval)
+	defer ticker.Stop()
+
+	lastActive := time.Now()
+	for {
+		for _, e := range events {
+			if e.Event == entity.RunEventStreamEnd {
+				return
+			}
+			if closed := sw.Send(e, nil); closed {
+				return
+			}
+			seq = e.Seq
+		}
+
+		if len(events) > 0 {
+			lastActive = time.Now()
+		} else if time.Since(lastActive) > reattachIdleCheckInterval {
+			if finished, err := c.runFinished(ctx, runID); err != nil || finished {
+				return
+			}
+			lastActive = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var err error
+		if events, err = c.RunEventRepo.ListAfter(ctx, runID, seq); err != nil {
+			logs.WarnX(pkg.ModelName, "list run events failed, run_id: %d, err: %v", runID, err)
+			return
+		}
+	}
+}
+
+func (c *runImpl) runFinished(ctx context.Context, runID int64) (bool, error) {
+	runRecord, err := c.RunRecordRepo.GetByID(ctx, runID)
+	if err != nil {
+		return false, err
+	}
+	if runRecord == nil {
+		return true, nil
+	}
+	return runRecord.Status != entity.RunStatusCreated && runRecord.Status != entity.RunStatusInProgress, nil
+}
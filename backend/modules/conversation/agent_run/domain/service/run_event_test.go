@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/infra/impl/cache/local"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/repo"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/stretchr/testify/assert"
+)
+
+// statusRunRecordRepo 只实现 GetByID，用于判断运行是否结束
+type statusRunRecordRepo struct {
+	repo.RunRecordRepo
+	status entity.RunStatus
+}
+
+func (r *statusRunRecordRepo) GetByID(_ context.Context, id int64) (*entity.RunRecordMeta, error) {
+	return &entity.RunRecordMeta{ID: id, Status: r.status}, nil
+}
+
+// countingRunEventRepo 记录写入缓存的次数
+type countingRunEventRepo struct {
+	repo.RunEventRepo
+	appends int
+}
+
+func (r *countingRunEventRepo) Append(ctx context.Context, runID int64, events ...*entity.AgentRunResponse) error {
+	r.appends++
+	return r.RunEventRepo.Append(ctx, runID, events...)
+}
+
+func newTestRunEventImpl(t *testing.T, status entity.RunStatus) *runImpl {
+	cli, err := local.New()
+	assert.NoError(t, err)
+	return &runImpl{
+		RunEventRepo:  repo.NewRunEventRepo(cli, time.Minute),
+		RunRecordRepo: &statusRunRecordRepo{status: status},
+	}
+}
+
+func runEvents(runID int64, deltas int) []*entity.AgentRunResponse {
+	events := []*entity.AgentRunResponse{
+		{Event: entity.RunEventCreated, ChunkRunItem: &entity.ChunkRunItem{ID: runID}},
+	}
+	for i := 0; i < deltas; i++ {
+		events = append(events, &entity.AgentRunResponse{
+			Event:            entity.RunEventMessageDelta,
+			ChunkMessageItem: &entity.ChunkMessageItem{RunID: runID},
+		})
+	}
+	return append(events, &entity.AgentRunResponse{
+		Event:        entity.RunEventCompleted,
+		ChunkRunItem: &entity.ChunkRunItem{ID: runID},
+	})
+}
+
+func recvAll(t *testing.T, sr *schema.StreamReader[*entity.AgentRunResponse]) []*entity.AgentRunResponse {
+	defer sr.Close()
+	var events []*entity.AgentRunResponse
+	for {
+		e, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return events
+		}
+		assert.NoError(t, err)
+		events = append(events, e)
+	}
+}
+
+func seqs(events []*entity.AgentRunResponse) []int64 {
+	out := make([]int64, 0, len(events))
+	for _, e := range events {
+		out = append(out, e.Seq)
+	}
+	return out
+}
+
+func TestBufferStreamAndReplay(t *testing.T) {
+	ctx := context.Background()
+	impl := newTestRunEventImpl(t, entity.RunStatusCompleted)
+	counter := &countingRunEventRepo{RunEventRepo: impl.RunEventRepo}
+	impl.RunEventRepo = counter
+
+	// 增量事件跨越一个批次，剩余部分随完成事件写入
+	events := runEvents(1, runEventBatchSize+3)
+	out := recvAll(t, impl.bufferStream(ctx, schema.StreamReaderFromArray(events)))
+	assert.LessOrEqual(t, counter.appends, 4)
+	assert.Len(t, out, len(events))
+	for i, e := range out {
+		assert.Equal(t, int64(i+1), e.Seq)
+	}
+	assert.Equal(t, out[1].Seq, out[1].ChunkMessageItem.SeqID)
+
+	// 缓存写入在输出关闭前完成，结束标记位于最后
+	buffered, err := impl.RunEventRepo.ListAfter(ctx, 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, append(seqs(out), int64(len(out)+1)), seqs(buffered))
+	assert.Equal(t, entity.RunEventStreamEnd, buffered[len(buffered)-1].Event)
+
+	replayed, err := impl.Reattach(ctx, 1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, seqs(out[5:]), seqs(recvAll(t, replayed)))
+}
+
+func TestReattachFollowsRunningRun(t *testing.T) {
+	ctx := context.Background()
+	impl := newTestRunEventImpl(t, entity.RunStatusInProgress)
+	events := runEvents(2, 2)
+	for i, e := range events {
+		e.Seq = int64(i + 1)
+	}
+	assert.NoError(t, impl.RunEventRepo.Append(ctx, 2, events[:2]...))
+
+	sr, err := impl.Reattach(ctx, 2, 1)
+	assert.NoError(t, err)
+
+	// 重新接入后写入的事件继续推送，读到结束标记后结束
+	assert.NoError(t, impl.RunEventRepo.Append(ctx, 2, events[2:]...))
+	assert.NoError(t, impl.RunEventRepo.Append(ctx, 2, &entity.AgentRunResponse{
+		Event: entity.RunEventStreamEnd,
+		Seq:   int64(len(events) + 1),
+	}))
+	assert.Equal(t, []int64{2, 3, 4}, seqs(recvAll(t, sr)))
+}
+
+func TestReattachExpired(t *testing.T) {
+	ctx := context.Background()
+	impl := newTestRunEventImpl(t, entity.RunStatusCompleted)
+
+	_, err := impl.Reattach(ctx, 3, 0)
+	var statusErr errorx.StatusError
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, int32(errno.ErrRunEventsExpired), statusErr.Code())
+}
@@ -0,0 +1,141 @@
+package runtime
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+)
+
+// avatarCueMaxLen 未闭合的 [ 之后超过该长度仍未出现 ] 时按普通文本输出，避免整段回答被暂存
+const avatarCueMaxLen = 64
+
+type avatarCue struct {
+	Type   singleagent.AvatarCueType
+	Name   string
+	Offset int
+}
+
+// avatarCueParser 从流式回答中剥离 [type:name] 形式的形象指令。
+//
+// 指令可能被拆在多个分片中，未闭合的部分暂存到下一个分片再解析；
+// 已知类型但不在允许列表中的指令直接丢弃，未知类型按普通文本保留。
+type avatarCueParser struct {
+	// allowed 按类型记录允许的指令，key 为小写的指令名，value 为配置中的指令名
+	allowed map[singleagent.AvatarCueType]map[string]string
+	pending string
+	// offset 已输出的正文字符数
+	offset int
+}
+
+// newAvatarCueParser 智能体未开启形象指令时返回 nil
+func newAvatarCueParser(agentInfo *singleagent.SingleAgent) *avatarCueParser {
+	if agentInfo == nil {
+		return nil
+	}
+	cues := singleagent.AllowedAvatarCues(agentInfo.AvatarInfo)
+	if len(cues) == 0 {
+		return nil
+	}
+
+	allowed := make(map[singleagent.AvatarCueType]map[string]string, len(singleagent.AvatarCueTypes))
+	for _, cueType := range singleagent.AvatarCueTypes {
+		allowed[cueType] = make(map[string]string)
+		for _, name := range cues[cueType] {
+			allowed[cueType][strings.ToLower(name)] = name
+		}
+	}
+	return &avatarCueParser{allowed: allowed}
+}
+
+// Feed 解析一个分片，返回去除指令后的正文和其中的指令
+func (p *avatarCueParser) Feed(chunk string) (string, []*avatarCue) {
+	s := p.pending + chunk
+	p.pending = ""
+
+	var (
+		text strings.Builder
+		cues []*avatarCue
+	)
+	for len(s) > 0 {
+		start := strings.IndexByte(s, '[')
+		if start < 0 {
+			p.write(&text, s)
+			break
+		}
+		p.write(&text, s[:start])
+		s = s[start:]
+
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			if utf8.RuneCountInString(s) <= avatarCueMaxLen && !strings.ContainsAny(s, "\n") {
+				p.pending = s
+				break
+			}
+			p.write(&text, s[:1])
+			s = s[1:]
+			continue
+		}
+
+		cueType, name, known := p.parse(s[1:end])
+		if !known {
+			p.write(&text, s[:1])
+			s = s[1:]
+			continue
+		}
+		if allowedName, ok := p.allowed[cueType][strings.ToLower(name)]; ok {
+			cues = append(cues, &avatarCue{
+				Type:   cueType,
+				Name:   allowedName,
+				Offset: p.offset,
+			})
+		}
+		s = s[end+1:]
+	}
+
+	return text.String(), cues
+}
+
+// Flush 回答结束时输出暂存的未闭合内容
+func (p *avatarCueParser) Flush() string {
+	s := p.pending
+	p.pending = ""
+	p.offset += utf8.RuneCountInString(s)
+	return s
+}
+
+func (p *avatarCueParser) write(sb *strings.Builder, s string) {
+	sb.WriteString(s)
+	p.offset += utf8.RuneCountInString(s)
+}
+
+// parse 解析 [] 内的内容，known 为 false 表示不是形象指令
+func (p *avatarCueParser) parse(inner string) (cueType singleagent.AvatarCueType, name string, known bool) {
+	t, n, ok := strings.Cut(inner, ":")
+	if !ok {
+		return "", "", false
+	}
+	cueType = singleagent.AvatarCueType(strings.ToLower(strings.TrimSpace(t)))
+	name = strings.TrimSpace(n)
+	if _, ok = p.allowed[cueType]; !ok || name == "" {
+		return "", "", false
+	}
+	return cueType, name, true
+}
+
+// sendAvatarCues 推送回答消息中解析出的形象指令
+func (art *AgentRuntime) sendAvatarCues(answerMsgID int64, cues []*avatarCue) {
+	elapsed := time.Since(art.GetStartTime()).Milliseconds()
+	for _, cue := range cues {
+		art.MessageEvent.SendDirectiveEvent(&entity.ChunkDirective{
+			ConversationID: art.GetRunMeta().ConversationID,
+			MessageID:      answerMsgID,
+			Type:           string(cue.Type),
+			Name:           cue.Name,
+			Offset:         cue.Offset,
+			ElapsedMs:      elapsed,
+		}, art.SW)
+	}
+}
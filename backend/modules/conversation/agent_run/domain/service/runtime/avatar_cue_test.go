@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAvatarCueParser() *avatarCueParser {
+	return newAvatarCueParser(&singleagent.SingleAgent{
+		AvatarInfo: &bot_common.AvatarInfo{
+			Enabled:  ptr.Of(true),
+			Emotions: []string{"happy", "sad"},
+			Motions:  []string{"Wave"},
+		},
+	})
+}
+
+func feedAll(p *avatarCueParser, chunks ...string) (string, []*avatarCue) {
+	var (
+		text strings.Builder
+		cues []*avatarCue
+	)
+	for _, chunk := range chunks {
+		t, c := p.Feed(chunk)
+		text.WriteString(t)
+		cues = append(cues, c...)
+	}
+	text.WriteString(p.Flush())
+	return text.String(), cues
+}
+
+func TestAvatarCueParser(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, newAvatarCueParser(nil))
+		assert.Nil(t, newAvatarCueParser(&singleagent.SingleAgent{}))
+		assert.Nil(t, newAvatarCueParser(&singleagent.SingleAgent{
+			AvatarInfo: &bot_common.AvatarInfo{Emotions: []string{"happy"}},
+		}))
+		assert.Nil(t, newAvatarCueParser(&singleagent.SingleAgent{
+			AvatarInfo: &bot_common.AvatarInfo{Enabled: ptr.Of(true), Emotions: []string{" "}},
+		}))
+	})
+
+	t.Run("cues split across chunks", func(t *testing.T) {
+		text, cues := feedAll(newTestAvatarCueParser(), "[emo", "tion:happy] 你好！", "[motion: wave]Nice to [emotion:sad]meet you.")
+		assert.Equal(t, " 你好！Nice to meet you.", text)
+		assert.Equal(t, []*avatarCue{
+			{Type: singleagent.AvatarCueEmotion, Name: "happy", Offset: 0},
+			{Type: singleagent.AvatarCueMotion, Name: "Wave", Offset: 4},
+			{Type: singleagent.AvatarCueEmotion, Name: "sad", Offset: 12},
+		}, cues)
+	})
+
+	t.Run("disallowed and unknown cues", func(t *testing.T) {
+		text, cues := feedAll(newTestAvatarCueParser(), "[emotion:angry]See [the docs](https://a.b) [note: x] [expression:wink]ok")
+		assert.Equal(t, "See [the docs](https://a.b) [note: x] ok", text)
+		assert.Empty(t, cues)
+	})
+
+	t.Run("unclosed bracket", func(t *testing.T) {
+		p := newTestAvatarCueParser()
+		text, cues := p.Feed("array[0")
+		assert.Equal(t, "array", text)
+		assert.Empty(t, cues)
+		assert.Equal(t, "[0", p.Flush())
+
+		text, _ = feedAll(newTestAvatarCueParser(), "a [b\nc] [emotion:happy]d")
+		assert.Equal(t, "a [b\nc] d", text)
+
+		long := "[" + strings.Repeat("x", avatarCueMaxLen)
+		text, _ = newTestAvatarCueParser().Feed(long)
+		assert.Equal(t, long, text)
+	})
+}
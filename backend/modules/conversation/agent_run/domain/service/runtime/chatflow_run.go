@@ -1,18 +1,216 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"strconv"
 	"strings"
 
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
 	"github.com/kiosk404/airi-go/backend/infra/contract/imagex"
+	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	crossworkflow "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow"
+	workflowModel "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/workflow/model"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
 	message "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message/model"
+	msgEntity "github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/entity"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
 )
 
+// chatflowMessageCapacity 对话流节点中间消息管道的缓冲大小
+const chatflowMessageCapacity = 10
+
+// ChatflowRun 由 Agent 绑定的对话流驱动本轮对话，替代 ReAct Agent。
+//
+// 执行流程：
+//  1. 从历史消息中解析上一轮在输入节点中断的现场，存在时以用户本轮输入恢复执行，否则以用户输入作为 query 开始新的执行
+//  2. 节点流式输出的中间消息按节点转为回答消息推送
+//  3. 执行结束时推送结束节点的回答；在输入节点中断时推送输入节点的提示，并记录中断现场供下一轮恢复
 func (art *AgentRuntime) ChatflowRun(ctx context.Context, imagex imagex.ImageX) (err error) {
+	mh := &MesssageEventHanlder{
+		sw:           art.SW,
+		messageEvent: art.MessageEvent,
+	}
+
+	workflowID, err := getChatflowID(art.GetAgentInfo())
+	if err != nil {
+		return err
+	}
+
+	arm := art.GetRunMeta()
+	req := &workflowModel.ChatflowRequest{
+		WorkflowID: workflowID,
+		Query:      concatWfInput(art),
+		IsDraft:    arm.IsDraft,
+		CreatorID:  art.GetAgentInfo().CreatorID,
+		Config: &workflowModel.ExecuteConfig{
+			UserID:         arm.UserID,
+			AgentID:        arm.AgentID,
+			ConversationID: arm.ConversationID,
+		},
+	}
+	resumeInfo := parseResumeInfo(ctx, art.GetHistory())
+	if resumeInfo != nil && resumeInfo.InterruptType == singleagent.InterruptEventType_InputNode &&
+		resumeInfo.ChatflowInterrupt != nil && resumeInfo.ChatflowInterrupt.WorkflowID == workflowID {
+		req.ResumeFrom = resumeInfo.ChatflowInterrupt
+	}
+
+	sr, sw := schema.Pipe[*workflowModel.WorkflowMessage](chatflowMessageCapacity)
+	var result *workflowModel.ChatflowResult
+	var execErr error
+	done := make(chan struct{})
+	safego.Go(ctx, func() {
+		defer close(done)
+		defer sw.Close()
+		result, execErr = crossworkflow.DefaultSVC().ExecuteChatflow(ctx, req, sw)
+	})
+
+	lastStreamed, err := art.pushChatflowMessages(ctx, mh, sr)
+	sr.Close()
+	<-done
+	if err != nil {
+		return err
+	}
+	if execErr != nil {
+		return errors.New(errorx.ErrorWithoutStack(execErr))
+	}
+
+	if result.Interrupt != nil {
+		err = art.handlerChatflowInterrupt(ctx, mh, result.Interrupt)
+		if err != nil {
+			return err
+		}
+		return mh.handlerFinalAnswerFinish(ctx, art)
+	}
+
+	// 结束节点直接引用流式输出节点的结果时，回答已推送过，无需重复推送
+	if len(result.Answer) > 0 && result.Answer != lastStreamed {
+		err = art.sendChatflowAnswer(ctx, mh, result.Answer, message.ContentTypeText)
+		if err != nil {
+			return err
+		}
+	}
+	return mh.handlerFinalAnswerFinish(ctx, art)
+}
+
+// pushChatflowMessages 将节点的中间消息转为回答消息推送，每个节点的输出对应一条回答，返回最后一条完成的回答内容
+func (art *AgentRuntime) pushChatflowMessages(ctx context.Context, mh *MesssageEventHanlder, sr *schema.StreamReader[*workflowModel.WorkflowMessage]) (string, error) {
+	type nodeAnswer struct {
+		msg     *msgEntity.Message
+		content *bytes.Buffer
+	}
+	answers := make(map[string]*nodeAnswer)
+	var lastStreamed string
+
+	for {
+		chunk, err := sr.Recv()
+		if err != nil {
+			// 取消时各节点已输出的部分回答保存为被打断的消息
+			if IsCancelled(ctx) {
+				for _, answer := range answers {
+					sendMsg := buildSendMsg(ctx, answer.msg, false, art)
+					sendMsg.Content = answer.content.String()
+					if bErr := mh.handlerBrokenAnswer(ctx, sendMsg, nil, art, answer.msg); bErr != nil {
+						return "", bErr
+					}
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return lastStreamed, nil
+			}
+			return "", err
+		}
+
+		key := chunk.ExecuteID + ":" + chunk.NodeID
+		answer, ok := answers[key]
+		if !ok {
+			msg, cErr := preCreateAnswer(ctx, art)
+			if cErr != nil {
+				return "", cErr
+			}
+			answer = &nodeAnswer{msg: msg, content: bytes.NewBuffer([]byte{})}
+			answers[key] = answer
+		}
+
+		sendMsg := buildSendMsg(ctx, answer.msg, false, art)
+		sendMsg.Ext["message_title"] = chunk.NodeName
+		if len(chunk.Content) > 0 {
+			answer.content.WriteString(chunk.Content)
+			sendMsg.Content = chunk.Content
+			art.MessageEvent.SendMsgEvent(entity.RunEventMessageDelta, sendMsg, art.SW)
+		}
 
-	// todo： 暂不支持 LangChain 中的对话流
+		if chunk.Last {
+			delete(answers, key)
+			sendMsg.Content = answer.content.String()
+			if err = mh.handlerAnswer(ctx, sendMsg, nil, art, answer.msg); err != nil {
+				return "", err
+			}
+			lastStreamed = sendMsg.Content
+		}
+	}
+}
+
+// handlerChatflowInterrupt 推送输入节点的提示，并以 verbose 消息记录中断现场
+func (art *AgentRuntime) handlerChatflowInterrupt(ctx context.Context, mh *MesssageEventHanlder, interrupt *workflowModel.ChatflowInterrupt) error {
+	interruptInfo := &singleagent.InterruptInfo{
+		InterruptType:     singleagent.InterruptEventType_InputNode,
+		InterruptID:       interrupt.InterruptID,
+		ChatflowInterrupt: interrupt,
+	}
+	logs.InfoX(pkg.ModelName, "chatflow %d interrupted at input node %s, execute_id: %s",
+		interrupt.WorkflowID, interrupt.NodeID, interrupt.ExecuteID)
+
+	content, contentType, err := parseInterruptData(ctx, interruptInfo)
+	if err != nil {
+		return err
+	}
+	if err = art.sendChatflowAnswer(ctx, mh, content, contentType); err != nil {
+		return err
+	}
 
-	return err
+	return mh.handlerInterruptVerbose(ctx, &entity.AgentRespEvent{
+		EventType: message.MessageTypeInterrupt,
+		Interrupt: interruptInfo,
+	}, art)
+}
+
+func (art *AgentRuntime) sendChatflowAnswer(ctx context.Context, mh *MesssageEventHanlder, content string, contentType message.ContentType) error {
+	answerMsg, err := preCreateAnswer(ctx, art)
+	if err != nil {
+		return err
+	}
+
+	sendMsg := buildSendMsg(ctx, answerMsg, false, art)
+	sendMsg.Content = content
+	sendMsg.ContentType = contentType
+	art.MessageEvent.SendMsgEvent(entity.RunEventMessageDelta, sendMsg, art.SW)
+
+	return mh.handlerAnswer(ctx, sendMsg, nil, art, answerMsg)
+}
+
+// getChatflowID 优先使用编排布局中指定的对话流，否则使用绑定的第一个对话流
+func getChatflowID(agentInfo *singleagent.SingleAgent) (int64, error) {
+	if agentInfo.LayoutInfo != nil && agentInfo.LayoutInfo.WorkflowId != "" {
+		id, err := strconv.ParseInt(agentInfo.LayoutInfo.WorkflowId, 10, 64)
+		if err == nil && id > 0 {
+			return id, nil
+		}
+	}
+
+	for _, w := range agentInfo.Workflow {
+		if w.GetFlowMode() == bot_common.WorkflowMode_ChatFlow && w.GetWorkflowId() > 0 {
+			return w.GetWorkflowId(), nil
+		}
+	}
+	return 0, errorx.New(errno.ErrAgentRunWorkflowNotFound)
 }
 
 func concatWfInput(rtDependence *AgentRuntime) string {
@@ -0,0 +1,130 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	crossagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent"
+	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	agentEntity "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	crossmessage "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+)
+
+const (
+	// summaryPendingFactor 未摘要的运行达到历史轮数的该倍数时刷新摘要，避免每轮对话都调用模型
+	summaryPendingFactor = 2
+	// summaryMaxFoldRuns 单次刷新最多合并进摘要的运行数
+	summaryMaxFoldRuns = 20
+)
+
+// summaryRefreshing 正在刷新摘要的 section，同一 section 同时只有一个刷新任务
+var summaryRefreshing sync.Map
+
+// isRollingSummary 单智能体模式且上下文模式为滚动摘要时，早期历史压缩为摘要注入提示词
+func isRollingSummary(agentInfo *singleagent.SingleAgent) bool {
+	if agentInfo == nil || agentInfo.BotMode == bot_common.BotMode_WorkflowMode || agentInfo.ModelInfo == nil {
+		return false
+	}
+	policy := agentInfo.ModelInfo.ShortMemoryPolicy
+	return policy != nil && ptr.From(policy.ContextMode) == bot_common.ContextMode_RollingSummary
+}
+
+// loadHistorySummary 摘要覆盖的最后一个运行已不在当前分支路径上时摘要失效，等待重新生成
+func (art *AgentRuntime) loadHistorySummary(ctx context.Context) (*agentEntity.HistorySummary, error) {
+	if art.SummaryRepo == nil || !isRollingSummary(art.GetAgentInfo()) {
+		return nil, nil
+	}
+
+	summary, err := art.SummaryRepo.Get(ctx, art.GetRunMeta().SectionID)
+	if err != nil || summary == nil {
+		return nil, err
+	}
+
+	lastRun, err := art.RunRecordRepo.GetByID(ctx, summary.LastRunID)
+	if err != nil {
+		logs.WarnX(pkg.ModelName, "get summarized run %d failed, ignore summary, err: %v", summary.LastRunID, err)
+		return nil, nil
+	}
+	if !lastRun.BranchActive || lastRun.Status == agentEntity.RunStatusDeleted {
+		return nil, nil
+	}
+	return summary, nil
+}
+
+// refreshHistorySummary 运行完成后在后台将较早的运行合并进摘要，最近的 HistoryRound 轮始终保留原文
+func (art *AgentRuntime) refreshHistorySummary(ctx context.Context) {
+	if art.SummaryRepo == nil || !isRollingSummary(art.GetAgentInfo()) {
+		return
+	}
+
+	sectionID := art.GetRunMeta().SectionID
+	if _, loaded := summaryRefreshing.LoadOrStore(sectionID, struct{}{}); loaded {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	safego.Go(ctx, func() {
+		defer summaryRefreshing.Delete(sectionID)
+
+		if err := art.foldHistorySummary(ctx); err != nil {
+			logs.WarnX(pkg.ModelName, "refresh history summary of section %d failed, err: %v", sectionID, err)
+		}
+	})
+}
+
+func (art *AgentRuntime) foldHistorySummary(ctx context.Context) error {
+	turns := int(getAgentHistoryRounds(art.GetAgentInfo()))
+	summary, err := art.loadHistorySummary(ctx)
+	if err != nil {
+		return err
+	}
+	if summary == nil {
+		summary = &agentEntity.HistorySummary{
+			ConversationID: art.GetRunMeta().ConversationID,
+			SectionID:      art.GetRunMeta().SectionID,
+		}
+	}
+
+	runs, err := art.RunRecordRepo.List(ctx, &agentEntity.ListRunRecordMeta{
+		ConversationID: summary.ConversationID,
+		SectionID:      summary.SectionID,
+		AfterID:        summary.LastRunID,
+		Limit:          int32(turns + summaryMaxFoldRuns),
+		OrderBy:        "asc",
+	})
+	if err != nil {
+		return err
+	}
+	if len(runs) < turns*summaryPendingFactor {
+		return nil
+	}
+
+	fold := runs[:len(runs)-turns]
+	msgs, err := crossmessage.DefaultSVC().GetByRunIDs(ctx, summary.ConversationID, concactRunID(fold))
+	if err != nil {
+		return err
+	}
+
+	text, err := crossagent.DefaultSVC().SummarizeHistory(ctx, &crossagent.SummarizeRequest{
+		AgentVersion:   art.GetRunMeta().Version,
+		UserID:         art.GetRunMeta().UserID,
+		AgentID:        art.GetRunMeta().AgentID,
+		ConversationId: summary.ConversationID,
+		IsDraft:        art.GetRunMeta().IsDraft,
+		Summary:        summary.Summary,
+		Messages:       transMessageToSchemaMessage(ctx, historyPairs(msgs), art.ImagexClient),
+	})
+	if err != nil {
+		return err
+	}
+
+	summary.Summary = text
+	summary.LastRunID = fold[len(fold)-1].ID
+	logs.InfoX(pkg.ModelName, "fold %d runs into history summary of section %d", len(fold), summary.SectionID)
+	return art.SummaryRepo.Save(ctx, summary)
+}
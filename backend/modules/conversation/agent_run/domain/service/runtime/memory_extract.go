@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+	crossagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent"
+	agentEntity "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	crossmessage "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message"
+	crossmemory "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory"
+	memorymodel "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+)
+
+// memoryMaxExtractRuns 单次提取最多处理的运行数，积压过多时分批提取
+const memoryMaxExtractRuns = 20
+
+// memoryExtracting 正在提取记忆的 section，同一 section 同时只有一个提取任务
+var memoryExtracting sync.Map
+
+// extractMemories 运行完成后在后台从尚未提取过的运行中提取长期记忆，每累计 MemoryExtractInterval 个运行提取一次
+func (art *AgentRuntime) extractMemories(ctx context.Context) {
+	agentInfo := art.GetAgentInfo()
+	if art.MemoryExtractInterval <= 0 || crossmemory.DefaultSVC() == nil ||
+		agentInfo == nil || agentInfo.BotMode == bot_common.BotMode_WorkflowMode {
+		return
+	}
+
+	sectionID := art.GetRunMeta().SectionID
+	if _, loaded := memoryExtracting.LoadOrStore(sectionID, struct{}{}); loaded {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	safego.Go(ctx, func() {
+		defer memoryExtracting.Delete(sectionID)
+
+		if err := art.extractPendingMemories(ctx); err != nil {
+			logs.WarnX(pkg.ModelName, "extract memories of section %d failed, err: %v", sectionID, err)
+		}
+	})
+}
+
+func (art *AgentRuntime) extractPendingMemories(ctx context.Context) error {
+	runMeta := art.GetRunMeta()
+	cursor, err := crossmemory.DefaultSVC().GetExtractCursor(ctx, runMeta.SectionID)
+	if err != nil {
+		return err
+	}
+
+	runs, err := art.RunRecordRepo.List(ctx, &agentEntity.ListRunRecordMeta{
+		ConversationID: runMeta.ConversationID,
+		SectionID:      runMeta.SectionID,
+		AfterID:        cursor,
+		Limit:          memoryMaxExtractRuns,
+		OrderBy:        "asc",
+	})
+	if err != nil {
+		return err
+	}
+	if len(runs) < art.MemoryExtractInterval {
+		return nil
+	}
+
+	msgs, err := crossmessage.DefaultSVC().GetByRunIDs(ctx, runMeta.ConversationID, concactRunID(runs))
+	if err != nil {
+		return err
+	}
+
+	drafts, err := crossagent.DefaultSVC().ExtractMemories(ctx, &crossagent.ExtractMemoriesRequest{
+		AgentVersion:   runMeta.Version,
+		UserID:         runMeta.UserID,
+		AgentID:        runMeta.AgentID,
+		ConversationId: runMeta.ConversationID,
+		IsDraft:        runMeta.IsDraft,
+		Messages:       transMessageToSchemaMessage(ctx, historyPairs(msgs), art.ImagexClient),
+	})
+	if err != nil {
+		return err
+	}
+
+	lastRunID := runs[len(runs)-1].ID
+	if len(drafts) > 0 {
+		err = crossmemory.DefaultSVC().AddMemories(ctx, &memorymodel.AddMemoriesRequest{
+			AgentID:        runMeta.AgentID,
+			UserID:         runMeta.UserID,
+			ConversationID: runMeta.ConversationID,
+			SourceRunID:    lastRunID,
+			Memories:       drafts,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	logs.InfoX(pkg.ModelName, "extract %d memories from %d runs of section %d", len(drafts), len(runs), runMeta.SectionID)
+	return crossmemory.DefaultSVC().SetExtractCursor(ctx, runMeta.SectionID, lastRunID)
+}
@@ -43,6 +43,7 @@ func buildSendMsg(_ context.Context, msg *msgEntity.Message, isFinish bool, rtDe
 		UpdatedAt:        msg.UpdatedAt,
 		RunID:            rtDependence.GetRunRecord().ID,
 		Ext:              copyMap,
+		RequiredAction:   msg.RequiredAction,
 		IsFinish:         isFinish,
 		ReasoningContent: ptr.Of(msg.ReasoningContent),
 	}
@@ -83,6 +84,27 @@ func buildKnowledge(_ context.Context, chunk *entity.AgentRespEvent) *msgEntity.
 	return knowledgeInfo
 }
 
+func buildPreToolCall(_ context.Context, chunk *entity.AgentRespEvent) *msgEntity.VerboseInfo {
+	if chunk.FuncCall == nil || len(chunk.FuncCall.ToolCalls) == 0 || len(chunk.ToolsMessage) == 0 {
+		return nil
+	}
+	toolCall := chunk.FuncCall.ToolCalls[0]
+	preToolCallData := &msgEntity.PreToolCallData{
+		ToolCallID: toolCall.ID,
+		ToolName:   toolCall.Function.Name,
+		Arguments:  toolCall.Function.Arguments,
+		Output:     chunk.ToolsMessage[0].Content,
+	}
+	data, err := json.Marshal(preToolCallData)
+	if err != nil {
+		return nil
+	}
+	return &msgEntity.VerboseInfo{
+		MessageType: string(entity.MessageSubTypePreToolCall),
+		Data:        string(data),
+	}
+}
+
 func buildBotStateExt(arm *entity.AgentRunMeta) *msgEntity.BotStateExt {
 	agentID := conv.Int64ToStr(arm.AgentID)
 	botStateExt := &msgEntity.BotStateExt{
@@ -109,12 +131,13 @@ func parseInterruptData(_ context.Context, interruptData *singleagent.InterruptI
 	case singleagent.InterruptEventType_OauthPlugin:
 		data = interruptData.AllToolInterruptData[interruptData.ToolCallID].ToolNeedOAuth.Message
 		return data, defaultContentType, nil
-	case singleagent.InterruptEventType_Question:
-		//data = interruptData.AllWfInterruptData[interruptData.ToolCallID].InterruptData
-		return processQuestionInterruptData(data)
+	case singleagent.InterruptEventType_Question, singleagent.InterruptEventType_RequireInfos:
+		return processQuestionInterrupt(interruptData.Question)
 	case singleagent.InterruptEventType_InputNode:
-		//data = interruptData.AllWfInterruptData[interruptData.ToolCallID].InterruptData
-		return processInputNodeInterruptData(data)
+		if interruptData.ChatflowInterrupt == nil {
+			return "", defaultContentType, errorx.New(errno.ErrInterruptDataEmpty)
+		}
+		return interruptData.ChatflowInterrupt.Prompt, defaultContentType, nil
 	case singleagent.InterruptEventType_WorkflowLLM:
 		//toolInterruptEvent := interruptData.AllWfInterruptData[interruptData.ToolCallID].ToolInterruptEvent
 		//data := toolInterruptEvent.InterruptData
@@ -130,32 +153,60 @@ func parseInterruptData(_ context.Context, interruptData *singleagent.InterruptI
 	return "", defaultContentType, errorx.New(errno.ErrUnknowInterruptType)
 }
 
-func processQuestionInterruptData(data string) (string, message.ContentType, error) {
+type optionContent struct {
+	Question string          `json:"question"`
+	Options  []*optionOption `json:"options"`
+}
+
+type optionOption struct {
+	Name string `json:"name"`
+}
+
+type formSchemaContent struct {
+	Question string                          `json:"question"`
+	Fields   []*singleagent.RequireInfoField `json:"fields"`
+}
+
+// processQuestionInterrupt 无选项的提问以文本推送，带选项或表单的提问以卡片推送
+func processQuestionInterrupt(q *singleagent.QuestionInterrupt) (string, message.ContentType, error) {
 	defaultContentType := message.ContentTypeText
-	var iData map[string][]*irMsg
-	err := json.Unmarshal([]byte(data), &iData)
-	if err != nil {
-		return "", defaultContentType, err
-	}
-	if len(iData["messages"]) == 0 {
+	if q == nil {
 		return "", defaultContentType, errorx.New(errno.ErrInterruptDataEmpty)
 	}
-	interruptMsg := iData["messages"][0]
 
-	if interruptMsg.ContentType == "text" {
-		return interruptMsg.Content.(string), defaultContentType, nil
-	} else if interruptMsg.ContentType == "option" || interruptMsg.ContentType == "form_schema" {
-		iMarshalData, err := json.Marshal(interruptMsg)
-		if err != nil {
-			return "", defaultContentType, err
+	interruptMsg := &irMsg{
+		Type: "question",
+		ID:   q.ResumeID,
+	}
+	switch {
+	case len(q.Fields) > 0:
+		interruptMsg.ContentType = "form_schema"
+		interruptMsg.Content = &formSchemaContent{
+			Question: q.Question,
+			Fields:   q.Fields,
+		}
+	case len(q.Options) > 0:
+		options := make([]*optionOption, 0, len(q.Options))
+		for _, o := range q.Options {
+			options = append(options, &optionOption{Name: o})
+		}
+		interruptMsg.ContentType = "option"
+		interruptMsg.Content = &optionContent{
+			Question: q.Question,
+			Options:  options,
 		}
-		return string(iMarshalData), message.ContentTypeCard, nil
+	default:
+		if len(q.Question) == 0 {
+			return "", defaultContentType, errorx.New(errno.ErrInterruptDataEmpty)
+		}
+		return q.Question, defaultContentType, nil
 	}
-	return "", defaultContentType, errorx.New(errno.ErrUnknowInterruptType)
-}
 
-func processInputNodeInterruptData(data string) (string, message.ContentType, error) {
-	return data, message.ContentTypeCard, nil
+	iMarshalData, err := json.Marshal(interruptMsg)
+	if err != nil {
+		return "", defaultContentType, err
+	}
+	return string(iMarshalData), message.ContentTypeCard, nil
 }
 
 func handlerUsage(meta *schema.ResponseMeta) *msgEntity.UsageExt {
@@ -295,6 +346,19 @@ func buildAgentMessage2Create(ctx context.Context, chunk *entity.AgentRespEvent,
 		msg.Role = schema.Assistant
 		msg.ContentType = message.ContentTypeText
 
+		if chunk != nil && chunk.EventType == message.MessageTypeVerbose {
+			preToolCall := buildPreToolCall(ctx, chunk)
+			if preToolCall != nil {
+				pcInfo, err := json.Marshal(preToolCall)
+				if err == nil {
+					msg.Content = string(pcInfo)
+				}
+				buildExt[string(msgEntity.MessageExtKeyToolName)] = chunk.FuncCall.ToolCalls[0].Function.Name
+			}
+			buildExt[string(msgEntity.MessageExtKeyTimeCost)] = timeCost
+			break
+		}
+
 		d := &entity.Data{
 			FinishReason: 0,
 			FinData:      "",
@@ -328,6 +392,12 @@ func buildAgentMessage2Create(ctx context.Context, chunk *entity.AgentRespEvent,
 			Type:              "submit_tool_outputs",
 			SubmitToolOutputs: &messageModel.SubmitToolOutputs{},
 		}
+		if chunk.Interrupt.ToolCallID != "" {
+			rc.SubmitToolOutputs.ToolCalls = []*messageModel.InterruptPlugin{{
+				ID:   chunk.Interrupt.ToolCallID,
+				Type: requiredActionToolType(chunk.Interrupt.InterruptType),
+			}}
+		}
 		msg.RequiredAction = rc
 		rcExtByte, err := json.Marshal(rc)
 		if err == nil {
@@ -466,3 +536,13 @@ func buildSendRunRecord(_ context.Context, runRecord *entity.RunRecordMeta, runS
 		CreatedAt:      runRecord.CreatedAt,
 	}
 }
+
+// requiredActionToolType 提问类中断需要用户补充信息，插件类中断需要提交工具的执行结果
+func requiredActionToolType(interruptType singleagent.InterruptEventType) string {
+	switch interruptType {
+	case singleagent.InterruptEventType_Question, singleagent.InterruptEventType_RequireInfos:
+		return "require_info"
+	default:
+		return "function"
+	}
+}
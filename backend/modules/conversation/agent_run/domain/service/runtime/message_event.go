@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/cloudwego/eino/schema"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
@@ -15,6 +16,7 @@ import (
 	crossmessage "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message"
 	message "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message/model"
 	msgEntity "github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/entity"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
 	"github.com/kiosk404/airi-go/backend/pkg/errorx"
 	"github.com/kiosk404/airi-go/backend/pkg/json"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
@@ -67,6 +69,22 @@ func (e *Event) SendMsgEvent(runEvent entity.RunEvent, messageItem *entity.Chunk
 	sw.Send(resp, nil)
 }
 
+func (e *Event) SendDirectiveEvent(directive *entity.ChunkDirective, sw *schema.StreamWriter[*entity.AgentRunResponse]) {
+	resp := &entity.AgentRunResponse{
+		Event:          entity.RunEventMessageDirective,
+		ChunkDirective: directive,
+	}
+	sw.Send(resp, nil)
+}
+
+func (e *Event) SendAudioEvent(audio *entity.ChunkAudio, sw *schema.StreamWriter[*entity.AgentRunResponse]) {
+	resp := &entity.AgentRunResponse{
+		Event:      entity.RunEventMessageAudio,
+		ChunkAudio: audio,
+	}
+	sw.Send(resp, nil)
+}
+
 func (e *Event) SendErrEvent(runEvent entity.RunEvent, sw *schema.StreamWriter[*entity.AgentRunResponse], err *entity.RunError) {
 	resp := e.buildErrEvent(runEvent, err)
 	sw.Send(resp, nil)
@@ -96,10 +114,16 @@ func (mh *MesssageEventHanlder) handlerErr(_ context.Context, err error) {
 		}
 	}
 
-	mh.messageEvent.SendErrEvent(entity.RunEventError, mh.sw, &entity.RunError{
+	runErr := &entity.RunError{
 		Code: errno.ErrAgentRun,
 		Msg:  errMsg,
-	})
+	}
+	if retryAfter, ok := modelmgr.QuotaExceeded(err); ok {
+		runErr.Code = int64(statusErr.Code())
+		runErr.RetryAfterMs = ptr.Of(retryAfter.Milliseconds())
+	}
+
+	mh.messageEvent.SendErrEvent(entity.RunEventError, mh.sw, runErr)
 }
 
 func (mh *MesssageEventHanlder) handlerAckMessage(_ context.Context, input *msgEntity.Message) error {
@@ -191,6 +215,19 @@ func (mh *MesssageEventHanlder) handlerKnowledge(ctx context.Context, chunk *ent
 	return nil
 }
 
+func (mh *MesssageEventHanlder) handlerPreToolCall(ctx context.Context, chunk *entity.AgentRespEvent, rtDependence *AgentRuntime) error {
+	cm := buildAgentMessage2Create(ctx, chunk, message.MessageTypeVerbose, rtDependence)
+	cmData, err := crossmessage.DefaultSVC().Create(ctx, cm)
+	if err != nil {
+		return err
+	}
+
+	sendMsg := buildSendMsg(ctx, cmData, true, rtDependence)
+
+	mh.messageEvent.SendMsgEvent(entity.RunEventMessageCompleted, sendMsg, mh.sw)
+	return nil
+}
+
 func (mh *MesssageEventHanlder) handlerAnswer(ctx context.Context, msg *entity.ChunkMessageItem, usage *msgEntity.UsageExt, rtDependence *AgentRuntime, preAnswerMsg *msgEntity.Message) error {
 
 	if len(msg.Content) == 0 && len(ptr.From(msg.ReasoningContent)) == 0 {
@@ -207,11 +244,11 @@ func (mh *MesssageEventHanlder) handlerAnswer(ctx context.Context, msg *entity.C
 		msg.Ext[string(msgEntity.MessageExtKeyInputTokens)] = conv.Int64ToStr(usage.InputTokens)
 		msg.Ext[string(msgEntity.MessageExtKeyOutputTokens)] = conv.Int64ToStr(usage.OutputTokens)
 
-		rtDependence.Usage = &agentrun.Usage{
+		rtDependence.SetUsage(&agentrun.Usage{
 			LlmPromptTokens:     usage.InputTokens,
 			LlmCompletionTokens: usage.OutputTokens,
 			LlmTotalTokens:      usage.TotalCount,
-		}
+		})
 	}
 
 	if _, ok := msg.Ext[string(msgEntity.MessageExtKeyTimeCost)]; !ok {
@@ -244,6 +281,25 @@ func (mh *MesssageEventHanlder) handlerAnswer(ctx context.Context, msg *entity.C
 	return nil
 }
 
+// handlerBrokenAnswer 运行被取消时保存已输出的部分回答，并标记为被打断的消息
+func (mh *MesssageEventHanlder) handlerBrokenAnswer(ctx context.Context, msg *entity.ChunkMessageItem, usage *msgEntity.UsageExt, rtDependence *AgentRuntime, preAnswerMsg *msgEntity.Message) error {
+	if len(msg.Content) == 0 && len(ptr.From(msg.ReasoningContent)) == 0 {
+		return nil
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	if err := mh.handlerAnswer(ctx, msg, usage, rtDependence, preAnswerMsg); err != nil {
+		return err
+	}
+
+	_, err := crossmessage.DefaultSVC().Edit(ctx, &msgEntity.Message{
+		ID:       preAnswerMsg.ID,
+		Status:   message.MessageStatusBroken,
+		Position: int32(utf8.RuneCountInString(msg.Content)),
+	})
+	return err
+}
+
 func (mh *MesssageEventHanlder) handlerFinalAnswerFinish(ctx context.Context, rtDependence *AgentRuntime) error {
 	cm := buildAgentMessage2Create(ctx, nil, message.MessageTypeVerbose, rtDependence)
 	cmData, err := crossmessage.DefaultSVC().Create(ctx, cm)
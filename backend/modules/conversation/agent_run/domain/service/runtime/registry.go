@@ -0,0 +1,51 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrRunCancelled 运行被用户取消时作为 context 的 cause，用于区分取消与其他错误
+var ErrRunCancelled = errors.New("agent run cancelled")
+
+// IsCancelled 运行的 context 是否因用户取消而结束
+func IsCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrRunCancelled)
+}
+
+// Registry 记录本实例上执行中的运行，取消时通过 context 通知 Agent 执行链路退出
+type Registry struct {
+	mu   sync.Mutex
+	runs map[int64]context.CancelCauseFunc
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		runs: make(map[int64]context.CancelCauseFunc),
+	}
+}
+
+func (r *Registry) Register(runID int64, cancel context.CancelCauseFunc) {
+	r.mu.Lock()
+	r.runs[runID] = cancel
+	r.mu.Unlock()
+}
+
+func (r *Registry) Unregister(runID int64) {
+	r.mu.Lock()
+	delete(r.runs, runID)
+	r.mu.Unlock()
+}
+
+// Cancel 取消本实例上的运行，运行不在本实例执行时返回 false
+func (r *Registry) Cancel(runID int64) bool {
+	r.mu.Lock()
+	cancel, ok := r.runs[runID]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+	cancel(ErrRunCancelled)
+	return true
+}
@@ -0,0 +1,31 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryCancel(t *testing.T) {
+	r := NewRegistry()
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	assert.False(t, r.Cancel(1))
+
+	r.Register(1, cancel)
+	assert.True(t, r.Cancel(1))
+	<-ctx.Done()
+	assert.True(t, IsCancelled(ctx))
+
+	r.Unregister(1)
+	assert.False(t, r.Cancel(1))
+}
+
+func TestIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(nil)
+	assert.False(t, IsCancelled(ctx))
+	assert.False(t, IsCancelled(context.Background()))
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
 	"github.com/kiosk404/airi-go/backend/infra/contract/imagex"
+	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	"github.com/kiosk404/airi-go/backend/infra/contract/stt"
+	"github.com/kiosk404/airi-go/backend/infra/contract/tts"
 	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
 	agentEntity "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/repo"
@@ -15,6 +18,8 @@ import (
 	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/agentrun/model"
 	crossmessage "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message"
 	msgEntity "github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/entity"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/pkg/checkpoint"
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
 )
 
@@ -31,8 +36,26 @@ type AgentRuntime struct {
 
 	RunProcess    *RunProcess
 	RunRecordRepo repo.RunRecordRepo
-	ImagexClient  imagex.ImageX
-	MessageEvent  *Event
+	SummaryRepo   repo.HistorySummaryRepo
+	// MemoryExtractInterval 每累计多少个运行提取一次长期记忆，不大于 0 时不提取
+	MemoryExtractInterval int
+	ImagexClient          imagex.ImageX
+	CPStore               checkpoint.Store
+	Registry              *Registry
+	MessageEvent          *Event
+	// TosClient 保存合成的语音，读取待识别的语音
+	TosClient storage.Storage
+	// TTS 为空时不合成语音
+	TTS tts.Synthesizer
+	// STT 为空时不支持音频的模型只能收到语音地址
+	STT stt.Transcriber
+
+	answerModelID   int64
+	answerModelName string
+
+	checkpointID   string
+	pushFailed     bool
+	historySummary string
 }
 
 func (art *AgentRuntime) SetRunRecord(runRecord *agentEntity.RunRecordMeta) {
@@ -44,12 +67,51 @@ func (art *AgentRuntime) GetRunRecord() *agentEntity.RunRecordMeta {
 }
 
 func (art *AgentRuntime) SetUsage(usage *agentrun.Usage) {
+	if usage != nil && art.answerModelID != 0 {
+		usage.LlmModelID = art.answerModelID
+		usage.LlmModelName = art.answerModelName
+	}
 	art.Usage = usage
 }
 func (art *AgentRuntime) GetUsage() *agentrun.Usage {
 	return art.Usage
 }
 
+// SetAnswerModel 记录模型降级链写在消息中的应答模型，随 usage 一起落到运行记录
+func (art *AgentRuntime) SetAnswerModel(msg *schema.Message) {
+	id, name, ok := modelmgr.GetAnswerModel(msg)
+	if !ok {
+		return
+	}
+	art.answerModelID = id
+	art.answerModelName = name
+	if art.Usage != nil {
+		art.Usage.LlmModelID = id
+		art.Usage.LlmModelName = name
+	}
+}
+
+// BindCheckpoint 记录中断时保存执行现场的 checkpoint，运行被取消或删除时据此清理
+func (art *AgentRuntime) BindCheckpoint(ctx context.Context, checkpointID string) error {
+	if checkpointID == "" {
+		return nil
+	}
+	art.checkpointID = checkpointID
+	return art.RunRecordRepo.UpdateByID(ctx, art.GetRunRecord().ID, &agentEntity.UpdateMeta{
+		CheckpointID: checkpointID,
+	})
+}
+
+// releaseCheckpoint 从中断恢复的运行结束后，原有的执行现场不再需要，再次中断时现场已写入本次运行的 checkpoint
+func (art *AgentRuntime) releaseCheckpoint(ctx context.Context, resumeInfo *singleagent.InterruptInfo) {
+	if art.CPStore == nil || resumeInfo == nil || resumeInfo.InterruptID == "" || art.pushFailed {
+		return
+	}
+	if err := art.CPStore.Delete(ctx, resumeInfo.InterruptID); err != nil {
+		logs.WarnX(pkg.ModelName, "release checkpoint %s failed, err: %v", resumeInfo.InterruptID, err)
+	}
+}
+
 func (art *AgentRuntime) SetRunMeta(arm *agentEntity.AgentRunMeta) {
 	art.RunMeta = arm
 }
@@ -103,8 +165,13 @@ func (art *AgentRuntime) Run(ctx context.Context) (err error) {
 		return
 	}
 
-	runRecord, err := art.createRunRecord(ctx)
-
+	// 异步运行在入队时已创建运行记录
+	runRecord := art.GetRunRecord()
+	if runRecord == nil {
+		runRecord, err = art.createRunRecord(ctx)
+	} else {
+		err = art.startRunRecord(ctx, runRecord)
+	}
 	if err != nil {
 		return
 	}
@@ -112,8 +179,20 @@ func (art *AgentRuntime) Run(ctx context.Context) (err error) {
 	art.SetRunRecord(runRecord)
 	art.SetHistoryMsg(history)
 
+	// 取消运行时中止 Agent 执行链路，收尾阶段的落库与事件推送不受取消影响
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	if art.Registry != nil {
+		art.Registry.Register(runRecord.ID, cancel)
+		defer art.Registry.Unregister(runRecord.ID)
+	}
+
 	defer func() {
 		srRecord := buildSendRunRecord(ctx, runRecord, agentEntity.RunStatusCompleted)
+		if IsCancelled(ctx) {
+			art.RunProcess.StepToCancelled(context.WithoutCancel(ctx), srRecord, art.SW)
+			return
+		}
 		if err != nil {
 			srRecord.Error = &agentEntity.RunError{
 				Code: errno.ErrConversationAgentRunError,
@@ -122,7 +201,13 @@ func (art *AgentRuntime) Run(ctx context.Context) (err error) {
 			art.RunProcess.StepToFailed(ctx, srRecord, art.SW)
 			return
 		}
+		if art.checkpointID != "" {
+			art.RunProcess.StepToRequiredAction(ctx, srRecord, art.SW, art.GetUsage())
+			return
+		}
 		art.RunProcess.StepToComplete(ctx, srRecord, art.SW, art.GetUsage())
+		art.refreshHistorySummary(ctx)
+		art.extractMemories(ctx)
 	}()
 	mh := &MesssageEventHanlder{
 		messageEvent: art.MessageEvent,
@@ -139,6 +224,7 @@ func (art *AgentRuntime) Run(ctx context.Context) (err error) {
 	if art.GetAgentInfo().BotMode == bot_common.BotMode_WorkflowMode {
 		err = art.ChatflowRun(ctx, art.ImagexClient)
 	} else {
+		art.transcribeInput(ctx, input)
 		err = art.AgentStreamExecute(ctx, art.ImagexClient)
 	}
 	return
@@ -148,11 +234,28 @@ func (art *AgentRuntime) getHistory(ctx context.Context) ([]*msgEntity.Message,
 
 	conversationTurns := getAgentHistoryRounds(art.GetAgentInfo())
 
-	runRecordList, err := art.RunRecordRepo.List(ctx, &agentEntity.ListRunRecordMeta{
+	listMeta := &agentEntity.ListRunRecordMeta{
 		ConversationID: art.GetRunMeta().ConversationID,
 		SectionID:      art.GetRunMeta().SectionID,
 		Limit:          conversationTurns,
-	})
+	}
+	// 滚动摘要模式下取摘要之后所有未摘要的运行，超出上下文预算的部分由 Agent 裁剪
+	if isRollingSummary(art.GetAgentInfo()) {
+		summary, err := art.loadHistorySummary(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if summary != nil {
+			listMeta.AfterID = summary.LastRunID
+			art.historySummary = summary.Summary
+		}
+		listMeta.Limit = conversationTurns * summaryPendingFactor
+	}
+	// 异步运行的记录在入队时已创建，只取在它之前的运行
+	if art.GetRunRecord() != nil {
+		listMeta.BeforeID = art.GetRunRecord().ID
+	}
+	runRecordList, err := art.RunRecordRepo.List(ctx, listMeta)
 	if err != nil {
 		return nil, err
 	}
@@ -185,14 +288,21 @@ func (art *AgentRuntime) createRunRecord(ctx context.Context) (*agentEntity.RunR
 		return nil, err
 	}
 
-	srRecord := buildSendRunRecord(ctx, runPoData, agentEntity.RunStatusCreated)
+	if err = art.startRunRecord(ctx, runPoData); err != nil {
+		return nil, err
+	}
+	return runPoData, nil
+}
+
+func (art *AgentRuntime) startRunRecord(ctx context.Context, runRecord *agentEntity.RunRecordMeta) error {
+	srRecord := buildSendRunRecord(ctx, runRecord, agentEntity.RunStatusCreated)
 
 	art.RunProcess.StepToCreate(ctx, srRecord, art.SW)
 
-	err = art.RunProcess.StepToInProgress(ctx, srRecord, art.SW)
+	err := art.RunProcess.StepToInProgress(ctx, srRecord, art.SW)
 	if err != nil {
 		logs.ErrorX(pkg.ModelName, "runProcess.StepToInProgress error: %v", err)
-		return nil, err
+		return err
 	}
-	return runPoData, nil
+	return nil
 }
@@ -73,6 +73,39 @@ func (r *RunProcess) StepToComplete(ctx context.Context, srRecord *entity.ChunkR
 
 	r.event.SendStreamDoneEvent(sw)
 }
+
+// StepToRequiredAction 运行因工具中断而暂停，等待用户回答后由下一轮对话恢复
+func (r *RunProcess) StepToRequiredAction(ctx context.Context, srRecord *entity.ChunkRunItem, sw *schema.StreamWriter[*entity.AgentRunResponse], usage *agentrun.Usage) {
+	nowTime := time.Now().UnixMilli()
+	updateMeta := &entity.UpdateMeta{
+		Status:    entity.RunStatusRequiredAction,
+		Usage:     usage,
+		UpdatedAt: nowTime,
+	}
+	err := r.RunRecordRepo.UpdateByID(ctx, srRecord.ID, updateMeta)
+	if err != nil {
+		logs.ErrorX(pkg.ModelName, "RunRecordRepo.UpdateByID error: %v", err)
+		r.event.SendErrEvent(entity.RunEventError, sw, &entity.RunError{
+			Code: errno.ErrConversationAgentRunError,
+			Msg:  err.Error(),
+		})
+		return
+	}
+
+	srRecord.Status = entity.RunStatusRequiredAction
+	r.event.SendRunEvent(entity.RunEventRequiredAction, srRecord, sw)
+
+	r.event.SendStreamDoneEvent(sw)
+}
+
+// StepToCancelled 运行状态在取消时已更新，这里只通知客户端并结束流
+func (r *RunProcess) StepToCancelled(ctx context.Context, srRecord *entity.ChunkRunItem, sw *schema.StreamWriter[*entity.AgentRunResponse]) {
+	srRecord.Status = entity.RunStatusCancelled
+	r.event.SendRunEvent(entity.RunEventCancelled, srRecord, sw)
+
+	r.event.SendStreamDoneEvent(sw)
+}
+
 func (r *RunProcess) StepToFailed(ctx context.Context, srRecord *entity.ChunkRunItem, sw *schema.StreamWriter[*entity.AgentRunResponse]) {
 
 	nowTime := time.Now().UnixMilli()
@@ -55,6 +55,7 @@ import (
 //   - err: 执行过程中的错误
 func (art *AgentRuntime) AgentStreamExecute(ctx context.Context, imagex imagex.ImageX) (err error) {
 	mainChan := make(chan *entity.AgentRespEvent, 100)
+	resumeInfo := parseResumeInfo(ctx, art.GetHistory())
 
 	ar := &crossagent.AgentRuntime{
 		AgentVersion:     art.GetRunMeta().Version,
@@ -66,8 +67,10 @@ func (art *AgentRuntime) AgentStreamExecute(ctx context.Context, imagex imagex.I
 		Input: transMessageToSchemaMessage(ctx, []*msgEntity.Message{art.GetInput()}, imagex)[0],
 		// 将历史消息转为 schema.Message 类型 格式
 		HistoryMsg: transMessageToSchemaMessage(ctx, historyPairs(art.GetHistory()), imagex),
+		// 滚动摘要模式下早期对话的摘要
+		HistorySummary: art.historySummary,
 		// 解析恢复信息（用于断点续传场景）
-		ResumeInfo: parseResumeInfo(ctx, art.GetHistory()),
+		ResumeInfo: resumeInfo,
 	}
 
 	streamer, err := crossagent.DefaultSVC().StreamExecute(ctx, ar)
@@ -90,6 +93,7 @@ func (art *AgentRuntime) AgentStreamExecute(ctx context.Context, imagex imagex.I
 	})
 
 	wg.Wait()
+	art.releaseCheckpoint(ctx, resumeInfo)
 
 	return err
 }
@@ -100,11 +104,12 @@ func (art *AgentRuntime) AgentStreamExecute(ctx context.Context, imagex imagex.I
 //   - MessageTypeFunctionCall: 处理工具/函数调用事件
 //   - MessageTypeToolResponse: 处理工具执行结果
 //   - MessageTypeKnowledge: 处理知识库检索结果
+//   - MessageTypeVerbose: 处理预调用工具的执行记录
 //   - MessageTypeToolMidAnswer: 处理工具执行过程中的中间回答
 //   - MessageTypeToolAsAnswer: 处理工具直接作为最终回答的情况
 //   - MessageTypeAnswer: 处理模型的正常回答（包含推理内容和最终内容）
 //   - MessageTypeFlowUp: 处理后续问题建议
-//   - MessageTypeInterrupt: 处理用户打断（待实现）
+//   - MessageTypeInterrupt: 处理工具中断，等待用户回答后从 checkpoint 恢复
 //
 // 状态管理：
 //   - reasoningContent: 累积模型的推理过程内容（思维链）
@@ -123,8 +128,10 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 
 	var err error
 	defer func() {
-		if err != nil {
+		// 取消导致的错误不再推送，由运行收尾时发送取消事件
+		if err != nil && !IsCancelled(ctx) {
 			logs.ErrorX(pkg.ModelName, "run.push error: %v", err)
+			art.pushFailed = true
 			mh.handlerErr(ctx, err)
 		}
 	}()
@@ -142,7 +149,13 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 	var preToolResponseMsg *msgEntity.Message           // 用于在工具执行完成后关联结果
 	toolResponseMsgContent := bytes.NewBuffer([]byte{}) // 累计工具的执行结果
 	for {
-		chunk, ok := <-mainChan
+		var chunk *entity.AgentRespEvent
+		var ok bool
+		select {
+		case chunk, ok = <-mainChan:
+		case <-ctx.Done():
+			return
+		}
 		if !ok || chunk == nil {
 			return
 		}
@@ -169,6 +182,10 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 				}
 				return
 			}
+			// 运行被取消时执行链路返回的错误无需推送
+			if IsCancelled(ctx) {
+				return
+			}
 			// 其他错误，记录错误信息
 			mh.handlerErr(ctx, chunk.Err)
 			return
@@ -177,6 +194,7 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 		switch chunk.EventType {
 		// 当模型决定调用工具时触发
 		case message.MessageTypeFunctionCall:
+			art.SetAnswerModel(chunk.FuncCall)
 			if chunk.FuncCall != nil && chunk.FuncCall.ResponseMeta != nil {
 				if usage := handlerUsage(chunk.FuncCall.ResponseMeta); usage != nil {
 					art.SetUsage(&agentrun.Usage{
@@ -206,6 +224,12 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 				return
 			}
 			preToolResponseMsg = nil // reset
+		// 预调用工具执行完成，记录为 verbose 消息
+		case message.MessageTypeVerbose:
+			err = mh.handlerPreToolCall(ctx, chunk, art)
+			if err != nil {
+				return
+			}
 		// 当 Agent 决定调用知识库时触发
 		case message.MessageTypeKnowledge:
 			err = mh.handlerKnowledge(ctx, chunk, art)
@@ -230,6 +254,12 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 					if errors.Is(receErr, io.EOF) {
 						break
 					}
+					if IsCancelled(ctx) && !preMsgIsFinish {
+						sendMidAnswerMsg := buildSendMsg(ctx, toolMidAnswerMsg, false, art)
+						sendMidAnswerMsg.Content = fullMidAnswerContent.String()
+						err = mh.handlerBrokenAnswer(ctx, sendMidAnswerMsg, usage, art, toolMidAnswerMsg)
+						return
+					}
 					err = receErr
 					return
 				}
@@ -307,6 +337,12 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 						}
 						break
 					}
+					if IsCancelled(ctx) {
+						answer := buildSendMsg(ctx, toolAsAnswerMsg, false, art)
+						answer.Content = fullContent.String()
+						err = mh.handlerBrokenAnswer(ctx, answer, usage, art, toolAsAnswerMsg)
+						return
+					}
 					err = receErr
 					return
 				}
@@ -325,26 +361,52 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 			var usage *msgEntity.UsageExt
 			var isToolCalls = false
 			var modelAnswerMsg *msgEntity.Message
+			// 智能体开启形象指令时，指令从正文中剥离后单独推送
+			cueParser := newAvatarCueParser(art.GetAgentInfo())
+			// 智能体开启声音时边生成边逐句合成语音，离开前需要 Close 等待合成完成
+			var speech *speechPipeline
 			for {
 				streamMsg, receErr := chunk.ModelAnswer.Recv()
 				if receErr != nil {
 					if errors.Is(receErr, io.EOF) {
 
 						if isToolCalls {
+							speech.Close()
 							break
 						}
 						if modelAnswerMsg == nil {
 							break
 						}
+						if cueParser != nil {
+							if tail := cueParser.Flush(); len(tail) > 0 {
+								fullContent.WriteString(tail)
+								sendAnswerMsg := buildSendMsg(ctx, modelAnswerMsg, false, art)
+								sendAnswerMsg.Content = tail
+								art.MessageEvent.SendMsgEvent(entity.RunEventMessageDelta, sendAnswerMsg, art.SW)
+								speech.Feed(tail)
+							}
+						}
 						answer := buildSendMsg(ctx, modelAnswerMsg, false, art)
 						answer.Content = fullContent.String()
 						hfErr := mh.handlerAnswer(ctx, answer, usage, art, modelAnswerMsg)
+						speech.Close()
 						if hfErr != nil {
 							err = hfErr
 							return
 						}
 						break
 					}
+					// 取消时已输出的部分回答保存为被打断的消息
+					speech.Close()
+					if IsCancelled(ctx) && modelAnswerMsg != nil {
+						if cueParser != nil {
+							fullContent.WriteString(cueParser.Flush())
+						}
+						answer := buildSendMsg(ctx, modelAnswerMsg, false, art)
+						answer.Content = fullContent.String()
+						err = mh.handlerBrokenAnswer(ctx, answer, usage, art, modelAnswerMsg)
+						return
+					}
 					err = receErr
 					return
 				}
@@ -353,6 +415,8 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 					isToolCalls = true
 				}
 
+				art.SetAnswerModel(streamMsg)
+
 				if streamMsg != nil && streamMsg.ResponseMeta != nil {
 					usage = handlerUsage(streamMsg.ResponseMeta)
 				}
@@ -386,12 +450,22 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 							}
 							firstAnswerMsg = modelAnswerMsg
 						}
+						speech = art.newSpeechPipeline(ctx, modelAnswerMsg.ID)
 					}
 
-					sendAnswerMsg := buildSendMsg(ctx, modelAnswerMsg, false, art)
-					fullContent.WriteString(streamMsg.Content)
-					sendAnswerMsg.Content = streamMsg.Content
-					art.MessageEvent.SendMsgEvent(entity.RunEventMessageDelta, sendAnswerMsg, art.SW)
+					content := streamMsg.Content
+					var cues []*avatarCue
+					if cueParser != nil {
+						content, cues = cueParser.Feed(content)
+					}
+					if len(content) > 0 {
+						sendAnswerMsg := buildSendMsg(ctx, modelAnswerMsg, false, art)
+						fullContent.WriteString(content)
+						sendAnswerMsg.Content = content
+						art.MessageEvent.SendMsgEvent(entity.RunEventMessageDelta, sendAnswerMsg, art.SW)
+						speech.Feed(content)
+					}
+					art.sendAvatarCues(modelAnswerMsg.ID, cues)
 				}
 			}
 		// Agent 完成回答后，生成后续问题建议
@@ -414,9 +488,12 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 			if err != nil {
 				return
 			}
-		// 用户打断 Agent 输出
+		// 工具中断运行等待用户操作，提问作为回答推送，中断现场记录在 verbose 消息中供下一轮恢复
 		case message.MessageTypeInterrupt:
-			// ToDo: 支持打断
+			if chunk.Interrupt == nil {
+				continue
+			}
+			err = art.handlerInterrupt(ctx, mh, chunk)
 			if err != nil {
 				return
 			}
@@ -424,6 +501,31 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 	}
 }
 
+// handlerInterrupt 绑定中断现场并推送提问，运行以 required_action 状态结束
+func (art *AgentRuntime) handlerInterrupt(ctx context.Context, mh *MesssageEventHanlder, chunk *entity.AgentRespEvent) error {
+	if err := art.BindCheckpoint(ctx, chunk.Interrupt.InterruptID); err != nil {
+		return err
+	}
+
+	content, contentType, err := parseInterruptData(ctx, chunk.Interrupt)
+	if err != nil {
+		return err
+	}
+	answerMsg, err := preCreateAnswer(ctx, art)
+	if err != nil {
+		return err
+	}
+	sendMsg := buildSendMsg(ctx, answerMsg, false, art)
+	sendMsg.Content = content
+	sendMsg.ContentType = contentType
+	art.MessageEvent.SendMsgEvent(entity.RunEventMessageDelta, sendMsg, art.SW)
+	if err = mh.handlerAnswer(ctx, sendMsg, nil, art, answerMsg); err != nil {
+		return err
+	}
+
+	return mh.handlerInterruptVerbose(ctx, chunk, art)
+}
+
 // pull 是生产者方法，负责从 Agent 执行引擎拉取事件并写入 mainChan。
 //
 // 该方法持续从 StreamReader 读取 Agent 事件，将其转换为内部事件格式后
@@ -436,21 +538,32 @@ func (art *AgentRuntime) push(ctx context.Context, mainChan chan *entity.AgentRe
 //	      (外部格式)                                         (内部格式)
 //
 // 参数：
-//   - ctx: 上下文（当前未使用，预留扩展）
+//   - ctx: 上下文，运行被取消时停止拉取
 //   - mainChan: 事件写入通道
 //   - events: Agent 事件流读取器
-func (art *AgentRuntime) pull(_ context.Context, mainChan chan *entity.AgentRespEvent, events *schema.StreamReader[*crossagent.AgentEvent]) {
+func (art *AgentRuntime) pull(ctx context.Context, mainChan chan *entity.AgentRespEvent, events *schema.StreamReader[*crossagent.AgentEvent]) {
 	defer func() {
+		events.Close()
 		close(mainChan)
 	}()
 
+	// push 因取消提前退出后不再消费，发送时需要感知取消避免阻塞
+	send := func(chunk *entity.AgentRespEvent) bool {
+		select {
+		case mainChan <- chunk:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
 	for {
 		rm, re := events.Recv()
 		if re != nil {
 			errChunk := &entity.AgentRespEvent{
 				Err: re,
 			}
-			mainChan <- errChunk
+			send(errChunk)
 			return
 		}
 
@@ -462,7 +575,7 @@ func (art *AgentRuntime) pull(_ context.Context, mainChan chan *entity.AgentResp
 			errChunk := &entity.AgentRespEvent{
 				Err: tErr,
 			}
-			mainChan <- errChunk
+			send(errChunk)
 			return
 		}
 
@@ -480,7 +593,9 @@ func (art *AgentRuntime) pull(_ context.Context, mainChan chan *entity.AgentResp
 			ToolAsAnswer:  rm.ToolAsChatModelAnswer,
 		}
 
-		mainChan <- respChunk
+		if !send(respChunk) {
+			return
+		}
 	}
 }
 
@@ -503,6 +618,8 @@ func transformEventMap(eventType singleagent.EventType) (message.MessageType, er
 		return message.MessageTypeFlowUp, nil
 	case singleagent.EventTypeOfInterrupt:
 		return message.MessageTypeInterrupt, nil
+	case singleagent.EventTypeOfPreToolCall:
+		return message.MessageTypeVerbose, nil
 	}
 	return eType, errorx.New(errno.ErrReplyUnknowEventType)
 }
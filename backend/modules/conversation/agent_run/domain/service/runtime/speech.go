@@ -0,0 +1,279 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	"github.com/kiosk404/airi-go/backend/infra/contract/tts"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	"github.com/kiosk404/airi-go/backend/pkg/i18n"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+)
+
+const (
+	// speechMinRunes 字数过少的句子并入下一句，减少零碎的合成请求
+	speechMinRunes = 6
+	// speechMaxRunes 长句超过该长度仍没有句末标点时在逗号等处断开，避免首句等待过久
+	speechMaxRunes = 120
+	// speechQueueSize 等待合成的句子数，合成跟不上生成速度时阻塞回答的推送
+	speechQueueSize = 64
+)
+
+const (
+	// sentenceEnds 出现即断句的标点
+	sentenceEnds = "。！？；…\n"
+	// sentenceEndsASCII 后面跟空白时才断句，避免拆开小数、缩写和网址
+	sentenceEndsASCII = ".!?;"
+	// sentenceTrailers 句末标点之后仍归入本句的字符
+	sentenceTrailers = "。！？!?…”’\"'）)」』"
+	// softBreaks 长句强制断开的位置
+	softBreaks = "，,、：:；; "
+	// unspeakable 合成前去掉的 Markdown 标记
+	unspeakable = "*#`~>|"
+)
+
+type sentence struct {
+	Text string
+	// Offset 句子在回答正文中的起始字符位置
+	Offset int
+}
+
+// sentenceSplitter 将流式输出的回答切分为适合逐句合成的句子
+type sentenceSplitter struct {
+	buf []rune
+	// start buf[0] 在回答正文中的字符位置
+	start int
+}
+
+func (s *sentenceSplitter) Feed(text string) []*sentence {
+	s.buf = append(s.buf, []rune(text)...)
+
+	var sentences []*sentence
+	for {
+		end := s.boundary()
+		if end <= 0 {
+			break
+		}
+		sentences = appendSentence(sentences, s.cut(end))
+	}
+	return sentences
+}
+
+// Flush 回答结束时剩余的内容作为最后一句
+func (s *sentenceSplitter) Flush() []*sentence {
+	if len(s.buf) == 0 {
+		return nil
+	}
+	return appendSentence(nil, s.cut(len(s.buf)))
+}
+
+// boundary 返回 buf 中第一句的结束位置，还不能断句时返回 -1
+func (s *sentenceSplitter) boundary() int {
+	for i := 0; i < len(s.buf); i++ {
+		r := s.buf[i]
+		switch {
+		case strings.ContainsRune(sentenceEnds, r):
+		case strings.ContainsRune(sentenceEndsASCII, r):
+			// 需要看到下一个字符才能确定是否断句
+			if i+1 == len(s.buf) {
+				return -1
+			}
+			if !unicode.IsSpace(s.buf[i+1]) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		end := i + 1
+		for end < len(s.buf) && strings.ContainsRune(sentenceTrailers, s.buf[end]) {
+			end++
+		}
+		if countWordRunes(s.buf[:end]) >= speechMinRunes {
+			return end
+		}
+		i = end - 1
+	}
+
+	if len(s.buf) < speechMaxRunes {
+		return -1
+	}
+	for i := speechMaxRunes - 1; i > speechMinRunes; i-- {
+		if strings.ContainsRune(softBreaks, s.buf[i]) {
+			return i + 1
+		}
+	}
+	return speechMaxRunes
+}
+
+func (s *sentenceSplitter) cut(end int) *sentence {
+	text := s.buf[:end]
+	offset := s.start
+	for len(text) > 0 && unicode.IsSpace(text[0]) {
+		text = text[1:]
+		offset++
+	}
+
+	s.buf = s.buf[end:]
+	s.start += end
+	return &sentence{
+		Text:   strings.TrimRightFunc(string(text), unicode.IsSpace),
+		Offset: offset,
+	}
+}
+
+// appendSentence 跳过没有可朗读内容的句子，如单独的表情或分隔线
+func appendSentence(sentences []*sentence, st *sentence) []*sentence {
+	if countWordRunes([]rune(st.Text)) == 0 {
+		return sentences
+	}
+	return append(sentences, st)
+}
+
+func countWordRunes(runes []rune) int {
+	n := 0
+	for _, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			n++
+		}
+	}
+	return n
+}
+
+func speakable(text string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(unspeakable, r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// speechPipeline 边生成边合成一条回答的语音，按句子顺序推送语音地址
+type speechPipeline struct {
+	art      *AgentRuntime
+	msgID    int64
+	voice    string
+	language string
+	autoplay bool
+
+	splitter sentenceSplitter
+	queue    chan *sentence
+	done     chan struct{}
+	once     sync.Once
+}
+
+// newSpeechPipeline 未配置语音合成或智能体未开启声音时返回 nil
+func (art *AgentRuntime) newSpeechPipeline(ctx context.Context, msgID int64) *speechPipeline {
+	if art.TTS == nil || art.TosClient == nil {
+		return nil
+	}
+	agentInfo := art.GetAgentInfo()
+	if agentInfo == nil || agentInfo.VoicesInfo == nil || agentInfo.VoicesInfo.GetMuted() {
+		return nil
+	}
+
+	language := string(i18n.GetLocale(ctx))
+	sp := &speechPipeline{
+		art:      art,
+		msgID:    msgID,
+		voice:    pickVoice(agentInfo.VoicesInfo.GetI18nLangVoiceStr(), language),
+		language: language,
+		autoplay: agentInfo.VoicesInfo.GetAutoplay(),
+		queue:    make(chan *sentence, speechQueueSize),
+		done:     make(chan struct{}),
+	}
+	safego.Go(ctx, func() {
+		defer close(sp.done)
+		sp.run(ctx)
+	})
+	return sp
+}
+
+// pickVoice 按语言选择智能体配置的音色，先精确匹配 zh-CN，再匹配 zh
+func pickVoice(voices map[string]string, language string) string {
+	if voice := voices[language]; voice != "" {
+		return voice
+	}
+	lang, _, _ := strings.Cut(language, "-")
+	return voices[lang]
+}
+
+func (sp *speechPipeline) Feed(text string) {
+	if sp == nil {
+		return
+	}
+	for _, st := range sp.splitter.Feed(text) {
+		sp.queue <- st
+	}
+}
+
+// Close 合成剩余的内容并等待所有语音推送完成，可重复调用
+func (sp *speechPipeline) Close() {
+	if sp == nil {
+		return
+	}
+	sp.once.Do(func() {
+		for _, st := range sp.splitter.Flush() {
+			sp.queue <- st
+		}
+		close(sp.queue)
+	})
+	<-sp.done
+}
+
+func (sp *speechPipeline) run(ctx context.Context) {
+	index := 0
+	for st := range sp.queue {
+		// 运行被取消后不再合成，只消费完队列
+		if ctx.Err() != nil {
+			continue
+		}
+
+		audio, err := sp.synthesize(ctx, st, index)
+		if err != nil {
+			logs.WarnX(pkg.ModelName, "synthesize speech of message %d failed, err: %v", sp.msgID, err)
+		} else {
+			sp.art.MessageEvent.SendAudioEvent(audio, sp.art.SW)
+		}
+		index++
+	}
+}
+
+func (sp *speechPipeline) synthesize(ctx context.Context, st *sentence, index int) (*entity.ChunkAudio, error) {
+	audio, err := sp.art.TTS.Synthesize(ctx, &tts.SynthesizeRequest{
+		Text:     speakable(st.Text),
+		Voice:    sp.voice,
+		Language: sp.language,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conversationID := sp.art.GetRunMeta().ConversationID
+	key := fmt.Sprintf("tts/%d/%d/%d.%s", conversationID, sp.msgID, index, audio.Format)
+	if err = sp.art.TosClient.PutObject(ctx, key, audio.Data, storage.WithContentType(audio.ContentType)); err != nil {
+		return nil, err
+	}
+	url, err := sp.art.TosClient.GetObjectUrl(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ChunkAudio{
+		ConversationID: conversationID,
+		MessageID:      sp.msgID,
+		Index:          index,
+		Text:           st.Text,
+		Offset:         st.Offset,
+		URL:            url,
+		ContentType:    audio.ContentType,
+		Autoplay:       sp.autoplay,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func splitAll(chunks ...string) []*sentence {
+	var (
+		s         sentenceSplitter
+		sentences []*sentence
+	)
+	for _, chunk := range chunks {
+		sentences = append(sentences, s.Feed(chunk)...)
+	}
+	return append(sentences, s.Flush()...)
+}
+
+func TestSentenceSplitter(t *testing.T) {
+	t.Run("cjk punctuation", func(t *testing.T) {
+		assert.Equal(t, []*sentence{
+			{Text: "你好，很高兴认识你！", Offset: 0},
+			{Text: "今天想聊点什么呢？”", Offset: 10},
+		}, splitAll("你好，很高兴", "认识你！今天想聊点什么", "呢？”"))
+	})
+
+	t.Run("ascii punctuation needs whitespace", func(t *testing.T) {
+		assert.Equal(t, []*sentence{
+			{Text: "Pi is about 3.14 today.", Offset: 0},
+			{Text: "See example.com for more", Offset: 24},
+		}, splitAll("Pi is about 3.", "14 today. See example.com for more"))
+	})
+
+	t.Run("short sentences merged", func(t *testing.T) {
+		assert.Equal(t, []*sentence{
+			{Text: "嗯。好的。我们开始吧。", Offset: 0},
+		}, splitAll("嗯。好的。我们开始吧。"))
+	})
+
+	t.Run("long text breaks softly", func(t *testing.T) {
+		long := strings.Repeat("字", 100) + "，" + strings.Repeat("字", 50)
+		sentences := splitAll(long)
+		assert.Len(t, sentences, 2)
+		assert.Equal(t, 101, len([]rune(sentences[0].Text)))
+		assert.Equal(t, 101, sentences[1].Offset)
+	})
+
+	t.Run("unspeakable skipped", func(t *testing.T) {
+		assert.Empty(t, splitAll("---\n", "😊"))
+		assert.Equal(t, "重点内容", speakable("**重点**内容"))
+	})
+}
@@ -0,0 +1,287 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/infra/contract/stt"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	crossmessage "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message"
+	msgEntity "github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/entity"
+	uploadpkg "github.com/kiosk404/airi-go/backend/modules/data/upload/pkg"
+	crossmodelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr"
+	"github.com/kiosk404/airi-go/backend/pkg/i18n"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+// maxTranscribeAudioSize 超过该大小的语音不识别，与 OpenAI /audio/transcriptions 的限制一致
+const maxTranscribeAudioSize = 25 << 20
+
+// transcribeInput 模型不支持音频时，在运行前将用户语音识别为文本。
+//
+// 识别结果写入消息 Ext，原始语音保留在消息内容中供展示；
+// 模型输入中已识别的语音替换为文本，历史对话中同样生效。识别失败的语音保持原样，由模型侧降级为语音地址。
+func (art *AgentRuntime) transcribeInput(ctx context.Context, input *msgEntity.Message) {
+	if art.STT == nil || input == nil || input.ModelContent == "" {
+		return
+	}
+
+	var mc *schema.Message
+	if err := json.Unmarshal([]byte(input.ModelContent), &mc); err != nil || mc == nil {
+		return
+	}
+	if !hasAudioPart(mc) || art.supportAudio(ctx) {
+		return
+	}
+
+	var (
+		transcripts []*msgEntity.AudioTranscriptExt
+		texts       []string
+		parts       = make([]schema.ChatMessagePart, 0, len(mc.MultiContent))
+	)
+	for _, part := range mc.MultiContent {
+		if part.Type != schema.ChatMessagePartTypeAudioURL || part.AudioURL == nil {
+			parts = append(parts, part)
+			continue
+		}
+		transcript, err := art.transcribeAudio(ctx, part.AudioURL)
+		if err != nil || transcript.Text == "" {
+			logs.WarnX(pkg.ModelName, "transcribe audio of message %d failed, err: %v", input.ID, err)
+			parts = append(parts, part)
+			continue
+		}
+		transcripts = append(transcripts, &msgEntity.AudioTranscriptExt{
+			URI:      part.AudioURL.URI,
+			URL:      part.AudioURL.URL,
+			Text:     transcript.Text,
+			Language: transcript.Language,
+		})
+		texts = append(texts, transcript.Text)
+	}
+	if len(transcripts) == 0 {
+		return
+	}
+
+	mc.MultiContent = withTranscriptText(parts, texts)
+	modelContent, err := json.Marshal(mc)
+	if err != nil {
+		return
+	}
+	transcriptExt, err := json.Marshal(transcripts)
+	if err != nil {
+		return
+	}
+
+	ext := map[string]string{
+		string(msgEntity.MessageExtKeyAudioTranscript): string(transcriptExt),
+	}
+	_, err = crossmessage.DefaultSVC().Edit(ctx, &msgEntity.Message{
+		ID:             input.ID,
+		ConversationID: input.ConversationID,
+		ModelContent:   string(modelContent),
+		Ext:            ext,
+	})
+	if err != nil {
+		logs.WarnX(pkg.ModelName, "save audio transcript of message %d failed, err: %v", input.ID, err)
+		return
+	}
+
+	input.ModelContent = string(modelContent)
+	if input.Ext == nil {
+		input.Ext = map[string]string{}
+	}
+	input.Ext[string(msgEntity.MessageExtKeyAudioTranscript)] = string(transcriptExt)
+}
+
+func hasAudioPart(mc *schema.Message) bool {
+	for _, part := range mc.MultiContent {
+		if part.Type == schema.ChatMessagePartTypeAudioURL {
+			return true
+		}
+	}
+	return false
+}
+
+// withTranscriptText 识别出的文本拼接到用户输入的文本之后，保持只有一个文本片段
+func withTranscriptText(parts []schema.ChatMessagePart, texts []string) []schema.ChatMessagePart {
+	transcript := strings.Join(texts, "\n")
+	for i := range parts {
+		if parts[i].Type != schema.ChatMessagePartTypeText {
+			continue
+		}
+		if parts[i].Text != "" {
+			transcript = parts[i].Text + "\n" + transcript
+		}
+		parts[i].Text = transcript
+		return parts
+	}
+	return append(parts, schema.ChatMessagePart{
+		Type: schema.ChatMessagePartTypeText,
+		Text: transcript,
+	})
+}
+
+// supportAudio 智能体使用的模型能否直接理解音频
+func (art *AgentRuntime) supportAudio(ctx context.Context) bool {
+	agentInfo := art.GetAgentInfo()
+	if agentInfo == nil || agentInfo.ModelInfo == nil || agentInfo.ModelInfo.ModelId == nil {
+		return false
+	}
+	model, err := crossmodelmgr.DefaultSVC().GetModelByID(ctx, *agentInfo.ModelInfo.ModelId)
+	if err != nil || model == nil {
+		logs.WarnX(pkg.ModelName, "get model %d failed, err: %v", *agentInfo.ModelInfo.ModelId, err)
+		return false
+	}
+	return model.Capability.GetAudioUnderstanding()
+}
+
+func (art *AgentRuntime) transcribeAudio(ctx context.Context, audio *schema.ChatMessageAudioURL) (*stt.Transcript, error) {
+	data, err := art.readAudio(ctx, audio)
+	if err != nil {
+		return nil, err
+	}
+
+	language, _, _ := strings.Cut(string(i18n.GetLocale(ctx)), "-")
+	return art.STT.Transcribe(ctx, &stt.TranscribeRequest{
+		Data:     data,
+		FileName: audioFileName(audio),
+		Language: language,
+	})
+}
+
+// readAudio 优先从存储读取上传的语音，没有 URI 或读取失败时下载 URL
+func (art *AgentRuntime) readAudio(ctx context.Context, audio *schema.ChatMessageAudioURL) ([]byte, error) {
+	if audio.URI != "" {
+		// URI 由客户端传入，只读取当前用户上传的对象
+		if !uploadpkg.IsUserFileObjectKey(audio.URI, conv.StrToInt64D(art.GetRunMeta().UserID, 0)) {
+			return nil, fmt.Errorf("audio uri %s is not uploaded by current user", audio.URI)
+		}
+		if art.TosClient != nil {
+			data, err := art.TosClient.GetObject(ctx, audio.URI)
+			if err == nil {
+				if len(data) > maxTranscribeAudioSize {
+					return nil, fmt.Errorf("audio exceeds %d bytes", maxTranscribeAudioSize)
+				}
+				return data, nil
+			}
+			logs.WarnX(pkg.ModelName, "get audio %s from storage failed, err: %v", audio.URI, err)
+		}
+	}
+
+	audioURL := audio.URL
+	if audioURL == "" && audio.URI != "" && art.ImagexClient != nil {
+		resourceURL, err := art.ImagexClient.GetResourceURL(ctx, audio.URI)
+		if err != nil {
+			return nil, err
+		}
+		audioURL = resourceURL.URL
+	}
+	if audioURL == "" {
+		return nil, fmt.Errorf("audio url is empty")
+	}
+	return downloadAudio(ctx, audioHTTPClient, audioURL)
+}
+
+const (
+	audioDownloadTimeout   = 30 * time.Second
+	audioDownloadRedirects = 3
+)
+
+// audioHTTPClient 下载客户端传入的语音 URL，只允许 http/https，连接时拒绝内网地址
+var audioHTTPClient = &http.Client{
+	Timeout: audioDownloadTimeout,
+	Transport: &http.Transport{
+		// 不走代理，保证连接的地址经过检查
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: denyPrivateAddr,
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= audioDownloadRedirects {
+			return fmt.Errorf("stopped after %d redirects", audioDownloadRedirects)
+		}
+		return checkAudioScheme(req.URL)
+	},
+}
+
+func checkAudioScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported audio url scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+// denyPrivateAddr 在 DNS 解析后检查实际连接的地址，重定向和 DNS 重绑定同样生效
+func denyPrivateAddr(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("audio url resolves to disallowed address %s", host)
+	}
+	return nil
+}
+
+func downloadAudio(ctx context.Context, client *http.Client, audioURL string) ([]byte, error) {
+	u, err := url.Parse(audioURL)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkAudioScheme(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download audio failed, status=%d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTranscribeAudioSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxTranscribeAudioSize {
+		return nil, fmt.Errorf("audio exceeds %d bytes", maxTranscribeAudioSize)
+	}
+	return data, nil
+}
+
+// audioFileName 识别服务依据扩展名判断音频格式
+func audioFileName(audio *schema.ChatMessageAudioURL) string {
+	name := path.Base(audio.URI)
+	if audio.URI == "" {
+		if u, err := url.Parse(audio.URL); err == nil {
+			name = path.Base(u.Path)
+		}
+	}
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	agentEntity "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTranscriptText(t *testing.T) {
+	image := schema.ChatMessagePart{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "https://a.b/c.png"}}
+
+	t.Run("append to user text", func(t *testing.T) {
+		parts := withTranscriptText([]schema.ChatMessagePart{
+			image,
+			{Type: schema.ChatMessagePartTypeText, Text: "看看这张图"},
+		}, []string{"这是哪里", "帮我翻译一下"})
+		assert.Equal(t, []schema.ChatMessagePart{
+			image,
+			{Type: schema.ChatMessagePartTypeText, Text: "看看这张图\n这是哪里\n帮我翻译一下"},
+		}, parts)
+	})
+
+	t.Run("audio only", func(t *testing.T) {
+		parts := withTranscriptText(nil, []string{"你好"})
+		assert.Equal(t, []schema.ChatMessagePart{
+			{Type: schema.ChatMessagePartTypeText, Text: "你好"},
+		}, parts)
+	})
+}
+
+func TestAudioFileName(t *testing.T) {
+	assert.Equal(t, "voice.webm", audioFileName(&schema.ChatMessageAudioURL{URI: "tos/123/voice.webm", URL: "https://a.b/x.mp3"}))
+	assert.Equal(t, "x.mp3", audioFileName(&schema.ChatMessageAudioURL{URL: "https://a.b/x.mp3?sign=1"}))
+	assert.Equal(t, "", audioFileName(&schema.ChatMessageAudioURL{}))
+}
+
+// fakeStorage 只实现 GetObject
+type fakeStorage struct {
+	storage.Storage
+	objects map[string][]byte
+	gets    []string
+}
+
+func (s *fakeStorage) GetObject(_ context.Context, key string) ([]byte, error) {
+	s.gets = append(s.gets, key)
+	return s.objects[key], nil
+}
+
+func TestReadAudioURI(t *testing.T) {
+	ctx := context.Background()
+	tos := &fakeStorage{objects: map[string][]byte{
+		"BIZ_BOT_DATASET/7_1_a.mp3": []byte("mine"),
+		"BIZ_BOT_DATASET/8_1_b.mp3": []byte("other"),
+	}}
+	art := &AgentRuntime{TosClient: tos, RunMeta: &agentEntity.AgentRunMeta{UserID: "7"}}
+
+	data, err := art.readAudio(ctx, &schema.ChatMessageAudioURL{URI: "BIZ_BOT_DATASET/7_1_a.mp3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("mine"), data)
+
+	// 其他用户上传的对象不读取
+	_, err = art.readAudio(ctx, &schema.ChatMessageAudioURL{URI: "BIZ_BOT_DATASET/8_1_b.mp3", URL: "https://a.b/x.mp3"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"BIZ_BOT_DATASET/7_1_a.mp3"}, tos.gets)
+}
+
+func TestDownloadAudio(t *testing.T) {
+	ctx := context.Background()
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/voice.mp3":
+			_, _ = w.Write([]byte("audio"))
+		case "/large.mp3":
+			_, _ = io.CopyN(w, zeroReader{}, maxTranscribeAudioSize+1)
+		case "/redirect":
+			http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("deny private address", func(t *testing.T) {
+		_, err := downloadAudio(ctx, audioHTTPClient, srv.URL+"/voice.mp3")
+		assert.ErrorContains(t, err, "disallowed address")
+		assert.Equal(t, 0, hits)
+	})
+
+	t.Run("deny scheme", func(t *testing.T) {
+		_, err := downloadAudio(ctx, audioHTTPClient, "file:///etc/passwd")
+		assert.ErrorContains(t, err, "unsupported audio url scheme")
+	})
+
+	// 以下用例连接本地服务，跳过地址检查
+	client := srv.Client()
+	client.CheckRedirect = audioHTTPClient.CheckRedirect
+
+	t.Run("download", func(t *testing.T) {
+		data, err := downloadAudio(ctx, client, srv.URL+"/voice.mp3")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("audio"), data)
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		_, err := downloadAudio(ctx, client, srv.URL+"/large.mp3")
+		assert.ErrorContains(t, err, "audio exceeds")
+	})
+
+	t.Run("deny redirect scheme", func(t *testing.T) {
+		_, err := downloadAudio(ctx, client, srv.URL+"/redirect")
+		assert.ErrorContains(t, err, "unsupported audio url scheme")
+	})
+}
+
+func TestDenyPrivateAddr(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:80", "[::1]:80", "10.0.0.1:80", "192.168.1.1:80", "169.254.169.254:80", "0.0.0.0:80", "[fe80::1]:80", "[::ffff:127.0.0.1]:80"} {
+		assert.Error(t, denyPrivateAddr("tcp", addr, nil), addr)
+	}
+	assert.NoError(t, denyPrivateAddr("tcp", "93.184.216.34:443", nil))
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
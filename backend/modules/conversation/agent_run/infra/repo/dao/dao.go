@@ -7,11 +7,14 @@ import (
 	"time"
 
 	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/infra/repo/gorm_gen/model"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/infra/repo/gorm_gen/query"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
 	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/agentrun/model"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
 	"github.com/kiosk404/airi-go/backend/pkg/json"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
@@ -33,6 +36,14 @@ func NewRunRecordDAO(db *gorm.DB, idGen idgen.IDGenerator) *RunRecordDAO {
 	}
 }
 
+// queryCtx context 中绑定了事务时在该事务中读写，与其他仓储的写入一起提交或回滚
+func (dao *RunRecordDAO) queryCtx(ctx context.Context) *query.Query {
+	if tx, ok := rdb.TxFromCtx(ctx); ok {
+		return query.Use(tx)
+	}
+	return dao.query
+}
+
 func (dao *RunRecordDAO) Create(ctx context.Context, runMeta *entity.AgentRunMeta) (*entity.RunRecordMeta, error) {
 
 	createPO, err := dao.buildCreatePO(ctx, runMeta)
@@ -49,7 +60,8 @@ func (dao *RunRecordDAO) Create(ctx context.Context, runMeta *entity.AgentRunMet
 }
 
 func (dao *RunRecordDAO) GetByID(ctx context.Context, id int64) (*entity.RunRecordMeta, error) {
-	po, err := dao.query.RunRecord.WithContext(ctx).Where(dao.query.RunRecord.ID.Eq(id)).First()
+	m := dao.queryCtx(ctx).RunRecord
+	po, err := m.WithContext(ctx).Where(m.ID.Eq(id)).First()
 	if err != nil {
 		return nil, err
 	}
@@ -82,12 +94,25 @@ func (dao *RunRecordDAO) UpdateByID(ctx context.Context, id int64, updateMeta *e
 	if updateMeta.Usage != nil {
 		po.Usage = ptr.PtrConvert[agentrun.Usage, entity.Usage](updateMeta.Usage)
 	}
+	if updateMeta.CheckpointID != "" {
+		po.CheckpointID = updateMeta.CheckpointID
+	}
 	po.UpdatedAt = time.Now().UnixMilli()
 
 	_, err := dao.query.RunRecord.WithContext(ctx).Where(dao.query.RunRecord.ID.Eq(id)).Updates(po)
 	return err
 }
 
+func (dao *RunRecordDAO) MGetByID(ctx context.Context, ids []int64) ([]*entity.RunRecordMeta, error) {
+	pos, err := dao.query.RunRecord.WithContext(ctx).Where(dao.query.RunRecord.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Transform(pos, func(item *model.RunRecord) *entity.RunRecordMeta {
+		return dao.buildPo2Do(item)
+	}), nil
+}
+
 func (dao *RunRecordDAO) Delete(ctx context.Context, id []int64) error {
 
 	_, err := dao.query.RunRecord.WithContext(ctx).Where(dao.query.RunRecord.ID.In(id...)).UpdateColumns(map[string]interface{}{
@@ -102,7 +127,7 @@ func (dao *RunRecordDAO) List(ctx context.Context, meta *entity.ListRunRecordMet
 	logs.InfoX(pkg.ModelName, "list run record req:%v, sectionID:%v, limit:%v", meta.ConversationID, meta.SectionID, meta.Limit)
 	m := dao.query.RunRecord
 	mq := m.WithContext(ctx)
-	do := m.WithContext(ctx).Where(m.ConversationID.Eq(meta.ConversationID)).Debug().Where(m.Status.NotIn(string(entity.RunStatusDeleted)))
+	do := m.WithContext(ctx).Where(m.ConversationID.Eq(meta.ConversationID)).Debug().Where(m.Status.NotIn(string(entity.RunStatusDeleted)), m.BranchActive.Is(true))
 	if meta.BeforeID > 0 {
 		runRecord, err := mq.Where(m.ID.Eq(meta.BeforeID)).First()
 		if err != nil {
@@ -152,6 +177,15 @@ func (dao *RunRecordDAO) buildCreatePO(ctx context.Context, runMeta *entity.Agen
 	if err != nil {
 		return nil, err
 	}
+	// 新运行接在当前分支路径的最后一个运行之后，分叉时原运行已移出路径，同样适用
+	parentID, err := dao.lastActiveRunID(ctx, runMeta.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	branchID := runMeta.BranchID
+	if branchID == 0 {
+		branchID = runID
+	}
 	return &model.RunRecord{
 		ID:             runID,
 		ConversationID: runMeta.ConversationID,
@@ -162,9 +196,29 @@ func (dao *RunRecordDAO) buildCreatePO(ctx context.Context, runMeta *entity.Agen
 		UserID:         runMeta.UserID,
 		CreatedAt:      timeNow,
 		CreatorID:      creatorID,
+		ParentID:       parentID,
+		BranchID:       branchID,
+		BranchSelected: true,
+		BranchActive:   true,
 	}, nil
 }
 
+func (dao *RunRecordDAO) lastActiveRunID(ctx context.Context, conversationID int64) (int64, error) {
+	m := dao.query.RunRecord
+	pos, err := m.WithContext(ctx).Where(
+		m.ConversationID.Eq(conversationID),
+		m.Status.Neq(string(entity.RunStatusDeleted)),
+		m.BranchActive.Is(true),
+	).Order(m.CreatedAt.Desc()).Limit(1).Find()
+	if err != nil {
+		return 0, err
+	}
+	if len(pos) == 0 {
+		return 0, nil
+	}
+	return pos[0].ID, nil
+}
+
 func (dao *RunRecordDAO) buildPo2Do(po *model.RunRecord) *entity.RunRecordMeta {
 	runMeta := &entity.RunRecordMeta{
 		ID:             po.ID,
@@ -179,20 +233,132 @@ func (dao *RunRecordDAO) buildPo2Do(po *model.RunRecord) *entity.RunRecordMeta {
 		FailedAt:       po.FailedAt,
 		Usage:          ptr.PtrConvert[entity.Usage, agentrun.Usage](po.Usage),
 		CreatorID:      po.CreatorID,
+		CheckpointID:   po.CheckpointID,
+		ChatRequest:    po.ChatRequest,
+		ParentID:       po.ParentID,
+		BranchID:       po.BranchID,
+		BranchSelected: po.BranchSelected,
+		BranchActive:   po.BranchActive,
+	}
+	if po.LastError != nil && len(*po.LastError) > 0 {
+		runError := &entity.RunError{}
+		if err := json.Unmarshal([]byte(*po.LastError), runError); err == nil {
+			runMeta.Error = runError
+		}
 	}
 
 	return runMeta
 }
 
+// Cancel 状态判断与更新在同一条语句中完成，运行在此期间结束时不会被改回已取消
 func (dao *RunRecordDAO) Cancel(ctx context.Context, meta *entity.CancelRunMeta) (*entity.RunRecordMeta, error) {
-
 	m := dao.query.RunRecord
-	_, err := m.WithContext(ctx).Where(m.ID.Eq(meta.RunID)).UpdateColumns(map[string]interface{}{
+	do, err := m.WithContext(ctx).Where(
+		m.ID.Eq(meta.RunID),
+		m.Status.In(string(entity.RunStatusCreated), string(entity.RunStatusInProgress), string(entity.RunStatusRequiredAction)),
+	).UpdateColumns(map[string]interface{}{
 		"updated_at": time.Now().UnixMilli(),
-		"status":     entity.RunEventCancelled,
+		"status":     entity.RunStatusCancelled,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return dao.GetByID(ctx, meta.RunID)
+
+	runRecord, err := dao.GetByID(ctx, meta.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if do.RowsAffected == 0 {
+		return nil, errorx.New(errno.ErrRunCanNotCancel, errorx.KV("status", string(runRecord.Status)))
+	}
+	return runRecord, nil
+}
+
+func (dao *RunRecordDAO) ListBranch(ctx context.Context, branchIDs []int64) ([]*entity.RunRecordMeta, error) {
+	m := dao.queryCtx(ctx).RunRecord
+	pos, err := m.WithContext(ctx).
+		Where(m.Status.Neq(string(entity.RunStatusDeleted))).
+		Where(m.WithContext(ctx).Where(m.BranchID.In(branchIDs...)).Or(m.ID.In(branchIDs...))).
+		Order(m.CreatedAt.Asc()).Find()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Transform(pos, func(item *model.RunRecord) *entity.RunRecordMeta {
+		return dao.buildPo2Do(item)
+	}), nil
+}
+
+func (dao *RunRecordDAO) DeactivateFrom(ctx context.Context, runRecord *entity.RunRecordMeta) ([]int64, error) {
+	var runIDs []int64
+	err := dao.queryCtx(ctx).Transaction(func(tx *query.Query) error {
+		m := tx.RunRecord
+		tail, err := m.WithContext(ctx).Where(
+			m.ConversationID.Eq(runRecord.ConversationID),
+			m.Status.Neq(string(entity.RunStatusDeleted)),
+			m.BranchActive.Is(true),
+			m.CreatedAt.Gte(runRecord.CreatedAt),
+		).Order(m.CreatedAt.Asc()).Find()
+		if err != nil {
+			return err
+		}
+
+		runIDs = make([]int64, 0, len(tail))
+		for i, po := range tail {
+			runIDs = append(runIDs, po.ID)
+			// 分支功能上线前的运行没有记录前一个运行，移出路径前补齐，切换回来时才能沿路径恢复
+			if i == 0 || po.ParentID > 0 {
+				continue
+			}
+			if _, err = m.WithContext(ctx).Where(m.ID.Eq(po.ID)).UpdateColumn(m.ParentID, tail[i-1].ID); err != nil {
+				return err
+			}
+		}
+		if len(runIDs) == 0 {
+			return nil
+		}
+
+		if _, err = m.WithContext(ctx).Where(m.ID.In(runIDs...)).UpdateColumn(m.BranchActive, false); err != nil {
+			return err
+		}
+		_, err = m.WithContext(ctx).Where(m.ID.Eq(runRecord.ID)).UpdateColumn(m.BranchSelected, false)
+		return err
+	})
+	return runIDs, err
+}
+
+func (dao *RunRecordDAO) ActivateFrom(ctx context.Context, runRecord *entity.RunRecordMeta) ([]int64, error) {
+	var runIDs []int64
+	err := dao.queryCtx(ctx).Transaction(func(tx *query.Query) error {
+		m := tx.RunRecord
+		_, err := m.WithContext(ctx).Where(m.ID.Eq(runRecord.ID)).UpdateColumns(map[string]interface{}{
+			m.BranchSelected.ColumnName().String(): true,
+			m.BranchActive.ColumnName().String():   true,
+		})
+		if err != nil {
+			return err
+		}
+		runIDs = append(runIDs, runRecord.ID)
+
+		// 每个运行之后的各分支中只有一个被选中，沿选中的分支恢复到路径末尾
+		for parentID := runRecord.ID; ; {
+			children, err := m.WithContext(ctx).Where(
+				m.ConversationID.Eq(runRecord.ConversationID),
+				m.Status.Neq(string(entity.RunStatusDeleted)),
+				m.ParentID.Eq(parentID),
+				m.BranchSelected.Is(true),
+			).Order(m.CreatedAt.Desc()).Limit(1).Find()
+			if err != nil {
+				return err
+			}
+			if len(children) == 0 {
+				return nil
+			}
+			if _, err = m.WithContext(ctx).Where(m.ID.Eq(children[0].ID)).UpdateColumn(m.BranchActive, true); err != nil {
+				return err
+			}
+			runIDs = append(runIDs, children[0].ID)
+			parentID = children[0].ID
+		}
+	})
+	return runIDs, err
 }
@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/infra/repo/gorm_gen/model"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const runRecordDDL = `CREATE TABLE run_record (
+	id INTEGER PRIMARY KEY,
+	conversation_id INTEGER NOT NULL DEFAULT 0,
+	section_id INTEGER NOT NULL DEFAULT 0,
+	agent_id INTEGER NOT NULL DEFAULT 0,
+	user_id TEXT NOT NULL DEFAULT '',
+	source INTEGER NOT NULL DEFAULT 0,
+	token_count INTEGER NOT NULL DEFAULT 0,
+	usage TEXT,
+	output_tokens INTEGER NOT NULL DEFAULT 0,
+	input_tokens INTEGER NOT NULL DEFAULT 0,
+	status TEXT NOT NULL DEFAULT '',
+	creator_id INTEGER NOT NULL DEFAULT 0,
+	created_at INTEGER NOT NULL DEFAULT 0,
+	updated_at INTEGER NOT NULL DEFAULT 0,
+	failed_at INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT,
+	completed_at INTEGER NOT NULL DEFAULT 0,
+	chat_request TEXT,
+	ext TEXT,
+	checkpoint_id TEXT NOT NULL DEFAULT '',
+	parent_id INTEGER NOT NULL DEFAULT 0,
+	branch_id INTEGER NOT NULL DEFAULT 0,
+	branch_selected INTEGER NOT NULL DEFAULT 1,
+	branch_active INTEGER NOT NULL DEFAULT 1
+)`
+
+func newTestRunRecordDAO(t *testing.T, records ...*model.RunRecord) *RunRecordDAO {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Exec(runRecordDDL).Error)
+	for _, r := range records {
+		assert.NoError(t, db.Create(r).Error)
+	}
+	return NewRunRecordDAO(db, nil)
+}
+
+func TestCancel(t *testing.T) {
+	ctx := context.Background()
+	dao := newTestRunRecordDAO(t,
+		&model.RunRecord{ID: 1, ConversationID: 10, Status: string(entity.RunStatusInProgress)},
+		&model.RunRecord{ID: 2, ConversationID: 10, Status: string(entity.RunStatusRequiredAction), CheckpointID: "cp2"},
+		&model.RunRecord{ID: 3, ConversationID: 10, Status: string(entity.RunStatusCompleted)},
+	)
+
+	runRecord, err := dao.Cancel(ctx, &entity.CancelRunMeta{ConversationID: 10, RunID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RunStatusCancelled, runRecord.Status)
+
+	runRecord, err = dao.Cancel(ctx, &entity.CancelRunMeta{ConversationID: 10, RunID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RunStatusCancelled, runRecord.Status)
+	assert.Equal(t, "cp2", runRecord.CheckpointID)
+
+	// 已结束的运行不会被改为已取消
+	_, err = dao.Cancel(ctx, &entity.CancelRunMeta{ConversationID: 10, RunID: 3})
+	var statusErr errorx.StatusError
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, int32(errno.ErrRunCanNotCancel), statusErr.Code())
+	runRecord, err = dao.GetByID(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RunStatusCompleted, runRecord.Status)
+
+	// 重复取消同样被拒绝
+	_, err = dao.Cancel(ctx, &entity.CancelRunMeta{ConversationID: 10, RunID: 1})
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, int32(errno.ErrRunCanNotCancel), statusErr.Code())
+}
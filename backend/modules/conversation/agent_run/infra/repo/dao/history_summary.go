@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/infra/repo/gorm_gen/model"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/infra/repo/gorm_gen/query"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HistorySummaryDAO struct {
+	db    *gorm.DB
+	query *query.Query
+}
+
+func NewHistorySummaryDAO(db *gorm.DB) *HistorySummaryDAO {
+	return &HistorySummaryDAO{
+		db:    db,
+		query: query.Use(db),
+	}
+}
+
+// Get section 还没有摘要时返回 nil
+func (dao *HistorySummaryDAO) Get(ctx context.Context, sectionID int64) (*entity.HistorySummary, error) {
+	m := dao.query.HistorySummary
+	po, err := m.WithContext(ctx).Where(m.SectionID.Eq(sectionID)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entity.HistorySummary{
+		ConversationID: po.ConversationID,
+		SectionID:      po.SectionID,
+		Summary:        ptr.From(po.Summary),
+		LastRunID:      po.LastRunID,
+		UpdatedAt:      po.UpdatedAt,
+	}, nil
+}
+
+// Save 每个 section 只保留一份摘要，已存在时覆盖
+func (dao *HistorySummaryDAO) Save(ctx context.Context, summary *entity.HistorySummary) error {
+	m := dao.query.HistorySummary
+	return m.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: m.SectionID.ColumnName().String()}},
+		DoUpdates: clause.AssignmentColumns([]string{"summary", "last_run_id", "updated_at"}),
+	}).Create(&model.HistorySummary{
+		SectionID:      summary.SectionID,
+		ConversationID: summary.ConversationID,
+		Summary:        ptr.Of(summary.Summary),
+		LastRunID:      summary.LastRunID,
+	})
+}
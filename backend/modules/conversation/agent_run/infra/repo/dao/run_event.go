@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/cache"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+)
+
+type RunEventDAO struct {
+	cacheClient cache.Cmdable
+	ttl         time.Duration
+}
+
+func NewRunEventDAO(cli cache.Cmdable, ttl time.Duration) *RunEventDAO {
+	return &RunEventDAO{
+		cacheClient: cli,
+		ttl:         ttl,
+	}
+}
+
+// makeRunEventKey 事件按序号顺序写入列表，序号为 n 的事件位于下标 n-1
+func makeRunEventKey(runID int64) string {
+	return fmt.Sprintf("run_events:%d", runID)
+}
+
+func (dao *RunEventDAO) Append(ctx context.Context, runID int64, events ...*entity.AgentRunResponse) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, 0, len(events))
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		values = append(values, string(data))
+	}
+
+	// 写入与续期在一次往返中完成，运行时间超过 TTL 时缓存也不会在运行中途过期
+	key := makeRunEventKey(runID)
+	pipe := dao.cacheClient.Pipeline()
+	pipe.RPush(ctx, key, values...)
+	pipe.Expire(ctx, key, dao.ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (dao *RunEventDAO) ListAfter(ctx context.Context, runID int64, afterSeq int64) ([]*entity.AgentRunResponse, error) {
+	if afterSeq < 0 {
+		afterSeq = 0
+	}
+
+	values, err := dao.cacheClient.LRange(ctx, makeRunEventKey(runID), afterSeq, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*entity.AgentRunResponse, 0, len(values))
+	for _, v := range values {
+		e := &entity.AgentRunResponse{}
+		if err = json.Unmarshal([]byte(v), e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
@@ -0,0 +1,22 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+const TableNameHistorySummary = "history_summary"
+
+// HistorySummary 历史对话滚动摘要表
+type HistorySummary struct {
+	SectionID      int64   `gorm:"column:section_id;type:bigint(20) unsigned;primaryKey;comment:section ID" json:"section_id"`                                         // section ID
+	ConversationID int64   `gorm:"column:conversation_id;type:bigint(20) unsigned;not null;index:idx_conversation_id,priority:1;comment:会话 ID" json:"conversation_id"` // 会话 ID
+	Summary        *string `gorm:"column:summary;type:mediumtext;comment:历史对话摘要" json:"summary"`                                                                       // 历史对话摘要
+	LastRunID      int64   `gorm:"column:last_run_id;type:bigint(20) unsigned;not null;comment:摘要覆盖到的最后一个运行 ID" json:"last_run_id"`                                    // 摘要覆盖到的最后一个运行 ID
+	CreatedAt      int64   `gorm:"column:created_at;type:bigint(20) unsigned;not null;autoCreateTime:milli;comment:创建时间" json:"created_at"`                            // 创建时间
+	UpdatedAt      int64   `gorm:"column:updated_at;type:bigint(20) unsigned;not null;autoUpdateTime:milli;comment:更新时间" json:"updated_at"`                            // 更新时间
+}
+
+// TableName HistorySummary's table name
+func (*HistorySummary) TableName() string {
+	return TableNameHistorySummary
+}
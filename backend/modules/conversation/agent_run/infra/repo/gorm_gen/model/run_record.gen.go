@@ -29,6 +29,11 @@ type RunRecord struct {
 	CompletedAt    int64         `gorm:"column:completed_at;type:bigint(20) unsigned;not null;comment:结束时间" json:"completed_at"`                                                                          // 结束时间
 	ChatRequest    *string       `gorm:"column:chat_request;type:text;comment:保存原始请求的部分字段" json:"chat_request"`                                                                                           // 保存原始请求的部分字段
 	Ext            *string       `gorm:"column:ext;type:text;comment:扩展字段" json:"ext"`                                                                                                                    // 扩展字段
+	CheckpointID   string        `gorm:"column:checkpoint_id;type:varchar(128);not null;comment:中断时保存执行现场的 checkpoint ID" json:"checkpoint_id"`                                                           // 中断时保存执行现场的 checkpoint ID
+	ParentID       int64         `gorm:"column:parent_id;type:bigint(20) unsigned;not null;comment:所在分支上的前一个运行 ID" json:"parent_id"`                                                                      // 所在分支上的前一个运行 ID
+	BranchID       int64         `gorm:"column:branch_id;type:bigint(20) unsigned;not null;index:idx_branch_id,priority:1;comment:分支组 ID，同一位置的各分支相同" json:"branch_id"`                                    // 分支组 ID，同一位置的各分支相同
+	BranchSelected bool          `gorm:"column:branch_selected;type:tinyint(1);not null;default:1;comment:是否为分支组中选中的分支" json:"branch_selected"`                                                           // 是否为分支组中选中的分支
+	BranchActive   bool          `gorm:"column:branch_active;type:tinyint(1);not null;default:1;comment:是否在当前展示的分支路径上" json:"branch_active"`                                                              // 是否在当前展示的分支路径上
 }
 
 // TableName RunRecord's table name
@@ -17,23 +17,26 @@ import (
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:        db,
-		RunRecord: newRunRecord(db, opts...),
+		db:             db,
+		HistorySummary: newHistorySummary(db, opts...),
+		RunRecord:      newRunRecord(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	RunRecord runRecord
+	HistorySummary historySummary
+	RunRecord      runRecord
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:        db,
-		RunRecord: q.RunRecord.clone(db),
+		db:             db,
+		HistorySummary: q.HistorySummary.clone(db),
+		RunRecord:      q.RunRecord.clone(db),
 	}
 }
 
@@ -47,18 +50,21 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:        db,
-		RunRecord: q.RunRecord.replaceDB(db),
+		db:             db,
+		HistorySummary: q.HistorySummary.replaceDB(db),
+		RunRecord:      q.RunRecord.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	RunRecord *runRecordDo
+	HistorySummary *historySummaryDo
+	RunRecord      *runRecordDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		RunRecord: q.RunRecord.WithContext(ctx),
+		HistorySummary: q.HistorySummary.WithContext(ctx),
+		RunRecord:      q.RunRecord.WithContext(ctx),
 	}
 }
 
@@ -0,0 +1,352 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/infra/repo/gorm_gen/model"
+)
+
+func newHistorySummary(db *gorm.DB, opts ...gen.DOOption) historySummary {
+	_historySummary := historySummary{}
+
+	_historySummary.historySummaryDo.UseDB(db, opts...)
+	_historySummary.historySummaryDo.UseModel(&model.HistorySummary{})
+
+	tableName := _historySummary.historySummaryDo.TableName()
+	_historySummary.ALL = field.NewAsterisk(tableName)
+	_historySummary.SectionID = field.NewInt64(tableName, "section_id")
+	_historySummary.ConversationID = field.NewInt64(tableName, "conversation_id")
+	_historySummary.Summary = field.NewString(tableName, "summary")
+	_historySummary.LastRunID = field.NewInt64(tableName, "last_run_id")
+	_historySummary.CreatedAt = field.NewInt64(tableName, "created_at")
+	_historySummary.UpdatedAt = field.NewInt64(tableName, "updated_at")
+
+	_historySummary.fillFieldMap()
+
+	return _historySummary
+}
+
+// historySummary 历史对话滚动摘要表
+type historySummary struct {
+	historySummaryDo historySummaryDo
+
+	ALL            field.Asterisk
+	SectionID      field.Int64  // section ID
+	ConversationID field.Int64  // 会话 ID
+	Summary        field.String // 历史对话摘要
+	LastRunID      field.Int64  // 摘要覆盖到的最后一个运行 ID
+	CreatedAt      field.Int64  // 创建时间
+	UpdatedAt      field.Int64  // 更新时间
+
+	fieldMap map[string]field.Expr
+}
+
+func (h historySummary) Table(newTableName string) *historySummary {
+	h.historySummaryDo.UseTable(newTableName)
+	return h.updateTableName(newTableName)
+}
+
+func (h historySummary) As(alias string) *historySummary {
+	h.historySummaryDo.DO = *(h.historySummaryDo.As(alias).(*gen.DO))
+	return h.updateTableName(alias)
+}
+
+func (h *historySummary) updateTableName(table string) *historySummary {
+	h.ALL = field.NewAsterisk(table)
+	h.SectionID = field.NewInt64(table, "section_id")
+	h.ConversationID = field.NewInt64(table, "conversation_id")
+	h.Summary = field.NewString(table, "summary")
+	h.LastRunID = field.NewInt64(table, "last_run_id")
+	h.CreatedAt = field.NewInt64(table, "created_at")
+	h.UpdatedAt = field.NewInt64(table, "updated_at")
+
+	h.fillFieldMap()
+
+	return h
+}
+
+func (h *historySummary) WithContext(ctx context.Context) *historySummaryDo {
+	return h.historySummaryDo.WithContext(ctx)
+}
+
+func (h historySummary) TableName() string { return h.historySummaryDo.TableName() }
+
+func (h historySummary) Alias() string { return h.historySummaryDo.Alias() }
+
+func (h historySummary) Columns(cols ...field.Expr) gen.Columns {
+	return h.historySummaryDo.Columns(cols...)
+}
+
+func (h *historySummary) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := h.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (h *historySummary) fillFieldMap() {
+	h.fieldMap = make(map[string]field.Expr, 6)
+	h.fieldMap["section_id"] = h.SectionID
+	h.fieldMap["conversation_id"] = h.ConversationID
+	h.fieldMap["summary"] = h.Summary
+	h.fieldMap["last_run_id"] = h.LastRunID
+	h.fieldMap["created_at"] = h.CreatedAt
+	h.fieldMap["updated_at"] = h.UpdatedAt
+}
+
+func (h historySummary) clone(db *gorm.DB) historySummary {
+	h.historySummaryDo.ReplaceConnPool(db.Statement.ConnPool)
+	return h
+}
+
+func (h historySummary) replaceDB(db *gorm.DB) historySummary {
+	h.historySummaryDo.ReplaceDB(db)
+	return h
+}
+
+type historySummaryDo struct{ gen.DO }
+
+func (h historySummaryDo) Debug() *historySummaryDo {
+	return h.withDO(h.DO.Debug())
+}
+
+func (h historySummaryDo) WithContext(ctx context.Context) *historySummaryDo {
+	return h.withDO(h.DO.WithContext(ctx))
+}
+
+func (h historySummaryDo) ReadDB() *historySummaryDo {
+	return h.Clauses(dbresolver.Read)
+}
+
+func (h historySummaryDo) WriteDB() *historySummaryDo {
+	return h.Clauses(dbresolver.Write)
+}
+
+func (h historySummaryDo) Session(config *gorm.Session) *historySummaryDo {
+	return h.withDO(h.DO.Session(config))
+}
+
+func (h historySummaryDo) Clauses(conds ...clause.Expression) *historySummaryDo {
+	return h.withDO(h.DO.Clauses(conds...))
+}
+
+func (h historySummaryDo) Returning(value interface{}, columns ...string) *historySummaryDo {
+	return h.withDO(h.DO.Returning(value, columns...))
+}
+
+func (h historySummaryDo) Not(conds ...gen.Condition) *historySummaryDo {
+	return h.withDO(h.DO.Not(conds...))
+}
+
+func (h historySummaryDo) Or(conds ...gen.Condition) *historySummaryDo {
+	return h.withDO(h.DO.Or(conds...))
+}
+
+func (h historySummaryDo) Select(conds ...field.Expr) *historySummaryDo {
+	return h.withDO(h.DO.Select(conds...))
+}
+
+func (h historySummaryDo) Where(conds ...gen.Condition) *historySummaryDo {
+	return h.withDO(h.DO.Where(conds...))
+}
+
+func (h historySummaryDo) Order(conds ...field.Expr) *historySummaryDo {
+	return h.withDO(h.DO.Order(conds...))
+}
+
+func (h historySummaryDo) Distinct(cols ...field.Expr) *historySummaryDo {
+	return h.withDO(h.DO.Distinct(cols...))
+}
+
+func (h historySummaryDo) Omit(cols ...field.Expr) *historySummaryDo {
+	return h.withDO(h.DO.Omit(cols...))
+}
+
+func (h historySummaryDo) Join(table schema.Tabler, on ...field.Expr) *historySummaryDo {
+	return h.withDO(h.DO.Join(table, on...))
+}
+
+func (h historySummaryDo) LeftJoin(table schema.Tabler, on ...field.Expr) *historySummaryDo {
+	return h.withDO(h.DO.LeftJoin(table, on...))
+}
+
+func (h historySummaryDo) RightJoin(table schema.Tabler, on ...field.Expr) *historySummaryDo {
+	return h.withDO(h.DO.RightJoin(table, on...))
+}
+
+func (h historySummaryDo) Group(cols ...field.Expr) *historySummaryDo {
+	return h.withDO(h.DO.Group(cols...))
+}
+
+func (h historySummaryDo) Having(conds ...gen.Condition) *historySummaryDo {
+	return h.withDO(h.DO.Having(conds...))
+}
+
+func (h historySummaryDo) Limit(limit int) *historySummaryDo {
+	return h.withDO(h.DO.Limit(limit))
+}
+
+func (h historySummaryDo) Offset(offset int) *historySummaryDo {
+	return h.withDO(h.DO.Offset(offset))
+}
+
+func (h historySummaryDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *historySummaryDo {
+	return h.withDO(h.DO.Scopes(funcs...))
+}
+
+func (h historySummaryDo) Unscoped() *historySummaryDo {
+	return h.withDO(h.DO.Unscoped())
+}
+
+func (h historySummaryDo) Create(values ...*model.HistorySummary) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return h.DO.Create(values)
+}
+
+func (h historySummaryDo) CreateInBatches(values []*model.HistorySummary, batchSize int) error {
+	return h.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (h historySummaryDo) Save(values ...*model.HistorySummary) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return h.DO.Save(values)
+}
+
+func (h historySummaryDo) First() (*model.HistorySummary, error) {
+	if result, err := h.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.HistorySummary), nil
+	}
+}
+
+func (h historySummaryDo) Take() (*model.HistorySummary, error) {
+	if result, err := h.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.HistorySummary), nil
+	}
+}
+
+func (h historySummaryDo) Last() (*model.HistorySummary, error) {
+	if result, err := h.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.HistorySummary), nil
+	}
+}
+
+func (h historySummaryDo) Find() ([]*model.HistorySummary, error) {
+	result, err := h.DO.Find()
+	return result.([]*model.HistorySummary), err
+}
+
+func (h historySummaryDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.HistorySummary, err error) {
+	buf := make([]*model.HistorySummary, 0, batchSize)
+	err = h.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (h historySummaryDo) FindInBatches(result *[]*model.HistorySummary, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return h.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (h historySummaryDo) Attrs(attrs ...field.AssignExpr) *historySummaryDo {
+	return h.withDO(h.DO.Attrs(attrs...))
+}
+
+func (h historySummaryDo) Assign(attrs ...field.AssignExpr) *historySummaryDo {
+	return h.withDO(h.DO.Assign(attrs...))
+}
+
+func (h historySummaryDo) Joins(fields ...field.RelationField) *historySummaryDo {
+	for _, _f := range fields {
+		h = *h.withDO(h.DO.Joins(_f))
+	}
+	return &h
+}
+
+func (h historySummaryDo) Preload(fields ...field.RelationField) *historySummaryDo {
+	for _, _f := range fields {
+		h = *h.withDO(h.DO.Preload(_f))
+	}
+	return &h
+}
+
+func (h historySummaryDo) FirstOrInit() (*model.HistorySummary, error) {
+	if result, err := h.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.HistorySummary), nil
+	}
+}
+
+func (h historySummaryDo) FirstOrCreate() (*model.HistorySummary, error) {
+	if result, err := h.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*model.HistorySummary), nil
+	}
+}
+
+func (h historySummaryDo) FindByPage(offset int, limit int) (result []*model.HistorySummary, count int64, err error) {
+	result, err = h.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = h.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (h historySummaryDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = h.Count()
+	if err != nil {
+		return
+	}
+
+	err = h.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (h historySummaryDo) Scan(result interface{}) (err error) {
+	return h.DO.Scan(result)
+}
+
+func (h historySummaryDo) Delete(models ...*model.HistorySummary) (result gen.ResultInfo, err error) {
+	return h.DO.Delete(models)
+}
+
+func (h *historySummaryDo) withDO(do gen.Dao) *historySummaryDo {
+	h.DO = *do.(*gen.DO)
+	return h
+}
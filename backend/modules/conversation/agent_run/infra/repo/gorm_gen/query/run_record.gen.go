@@ -46,6 +46,11 @@ func newRunRecord(db *gorm.DB, opts ...gen.DOOption) runRecord {
 	_runRecord.CompletedAt = field.NewInt64(tableName, "completed_at")
 	_runRecord.ChatRequest = field.NewString(tableName, "chat_request")
 	_runRecord.Ext = field.NewString(tableName, "ext")
+	_runRecord.CheckpointID = field.NewString(tableName, "checkpoint_id")
+	_runRecord.ParentID = field.NewInt64(tableName, "parent_id")
+	_runRecord.BranchID = field.NewInt64(tableName, "branch_id")
+	_runRecord.BranchSelected = field.NewBool(tableName, "branch_selected")
+	_runRecord.BranchActive = field.NewBool(tableName, "branch_active")
 
 	_runRecord.fillFieldMap()
 
@@ -76,6 +81,11 @@ type runRecord struct {
 	CompletedAt    field.Int64  // 结束时间
 	ChatRequest    field.String // 保存原始请求的部分字段
 	Ext            field.String // 扩展字段
+	CheckpointID   field.String // 中断时保存执行现场的 checkpoint ID
+	ParentID       field.Int64  // 所在分支上的前一个运行 ID
+	BranchID       field.Int64  // 分支组 ID，同一位置的各分支相同
+	BranchSelected field.Bool   // 是否为分支组中选中的分支
+	BranchActive   field.Bool   // 是否在当前展示的分支路径上
 
 	fieldMap map[string]field.Expr
 }
@@ -111,6 +121,11 @@ func (r *runRecord) updateTableName(table string) *runRecord {
 	r.CompletedAt = field.NewInt64(table, "completed_at")
 	r.ChatRequest = field.NewString(table, "chat_request")
 	r.Ext = field.NewString(table, "ext")
+	r.CheckpointID = field.NewString(table, "checkpoint_id")
+	r.ParentID = field.NewInt64(table, "parent_id")
+	r.BranchID = field.NewInt64(table, "branch_id")
+	r.BranchSelected = field.NewBool(table, "branch_selected")
+	r.BranchActive = field.NewBool(table, "branch_active")
 
 	r.fillFieldMap()
 
@@ -137,7 +152,7 @@ func (r *runRecord) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (r *runRecord) fillFieldMap() {
-	r.fieldMap = make(map[string]field.Expr, 19)
+	r.fieldMap = make(map[string]field.Expr, 24)
 	r.fieldMap["id"] = r.ID
 	r.fieldMap["conversation_id"] = r.ConversationID
 	r.fieldMap["section_id"] = r.SectionID
@@ -157,6 +172,11 @@ func (r *runRecord) fillFieldMap() {
 	r.fieldMap["completed_at"] = r.CompletedAt
 	r.fieldMap["chat_request"] = r.ChatRequest
 	r.fieldMap["ext"] = r.Ext
+	r.fieldMap["checkpoint_id"] = r.CheckpointID
+	r.fieldMap["parent_id"] = r.ParentID
+	r.fieldMap["branch_id"] = r.BranchID
+	r.fieldMap["branch_selected"] = r.BranchSelected
+	r.fieldMap["branch_active"] = r.BranchActive
 }
 
 func (r runRecord) clone(db *gorm.DB) runRecord {
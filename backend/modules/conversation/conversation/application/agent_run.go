@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strconv"
 
 	"github.com/cloudwego/eino/schema"
+	"github.com/gin-contrib/sse"
 	"github.com/kiosk404/airi-go/backend/api/model/conversation/message"
 	"github.com/kiosk404/airi-go/backend/api/model/conversation/run"
 	"github.com/kiosk404/airi-go/backend/application/ctxutil"
@@ -24,64 +26,139 @@ import (
 	"github.com/kiosk404/airi-go/backend/pkg/logs"
 )
 
-func (c *ConversationApplicationService) Run(ctx context.Context, sseSender *sseImpl.SSenderImpl, ar *run.AgentRunRequest) error {
+func (c *ConversationApplicationService) Run(ctx context.Context, sseSender sseImpl.SSESender, ar *run.AgentRunRequest) error {
+	arr, err := c.prepareRun(ctx, ar)
+	if err != nil {
+		return err
+	}
+	// 启动智能体运行
+	streamer, err := c.AgentRunDomainSVC.AgentRun(ctx, arr)
+	if err != nil {
+		return err
+	}
+	// 处理流式响应
+	c.pullStream(ctx, sseSender, streamer, ar)
+	return nil
+}
+
+// AsyncRun 运行进入队列后立即返回，调用方通过 RetrieveRun 与 ListRunMessages 查询进度与结果
+func (c *ConversationApplicationService) AsyncRun(ctx context.Context, ar *run.AgentRunRequest) (*run.AgentRunResponse, error) {
+	arr, err := c.prepareRun(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+	runRecord, err := c.AgentRunDomainSVC.AsyncRun(ctx, arr)
+	if err != nil {
+		return nil, err
+	}
+	return &run.AgentRunResponse{
+		Data: buildChatDetail(runRecord),
+	}, nil
+}
+
+func (c *ConversationApplicationService) prepareRun(ctx context.Context, ar *run.AgentRunRequest) (*entity.AgentRunMeta, error) {
 	agentInfo, caErr := c.checkAgent(ctx, ar)
 	if caErr != nil {
 		logs.ErrorX(pkg.ModelName, "checkAgent err:%v", caErr)
-		return caErr
+		return nil, caErr
 	}
 	logs.DebugX(pkg.ModelName, "agent run req id:%v, req name:%v", agentInfo.AgentID, agentInfo.Name)
 
-	userID := ctxutil.MustGetUIDFromCtx(ctx)
+	userID, err := requestUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// 验证对话是否存在以及是否拥有权限访问该对话
 	conversationData, ccErr := c.checkConversation(ctx, ar, userID)
 	if ccErr != nil {
 		logs.ErrorX(pkg.ModelName, "checkConversation err:%v", ccErr)
-		return ccErr
+		return nil, ccErr
 	}
 
-	// 处理消息的重生成逻辑
-	if ar.RegenMessageID != nil && ptr.From(ar.RegenMessageID) > 0 {
-		// 获取重生成消息的元数据
-		msgMeta, err := c.MessageDomainSVC.GetByID(ctx, ptr.From(ar.RegenMessageID))
-		if err != nil {
-			return err
-		}
-		// 验证消息存在性并检查用户权限
-		if msgMeta != nil {
-			if msgMeta.UserID != conv.Int64ToStr(userID) {
-				return errorx.New(errno.ErrConversationPermissionCode, errorx.KV("msg", "message not match"))
-			}
-
-			err = c.AgentRunDomainSVC.Delete(ctx, []int64{msgMeta.RunID})
-			if err != nil {
-				return err
-			}
-
-			delErr := c.MessageDomainSVC.Delete(ctx, &msgEntity.DeleteMeta{
-				RunIDs: []int64{msgMeta.RunID},
-			})
-			if delErr != nil {
-				return delErr
-			}
-		}
+	// 重新生成与编辑后重新发送都从原运行处分叉
+	branchID, err := c.forkRun(ctx, ar, userID, conversationData)
+	if err != nil {
+		return nil, err
 	}
 
 	// 构建AgentRunMeta请求
 	arr, err := c.buildAgentRunRequest(ctx, ar, userID, conversationData)
 	if err != nil {
 		logs.ErrorX(pkg.ModelName, "buildAgentRunRequest err:%v", err)
-		return err
+		return nil, err
 	}
-	// 启动智能体运行
-	streamer, err := c.AgentRunDomainSVC.AgentRun(ctx, arr)
+	arr.BranchID = branchID
+	return arr, nil
+}
+
+// forkRun 原运行及之后的运行保留为未选中的分支，其消息不再出现在消息列表与历史中，返回新运行使用的分支组
+func (c *ConversationApplicationService) forkRun(ctx context.Context, ar *run.AgentRunRequest, userID int64, conversationData *convEntity.Conversation) (int64, error) {
+	msgID := ptr.From(ar.EditMessageID)
+	isEdit := msgID > 0
+	if !isEdit {
+		msgID = ptr.From(ar.RegenMessageID)
+	}
+	if msgID <= 0 {
+		return 0, nil
+	}
+
+	msgMeta, err := c.MessageDomainSVC.GetByID(ctx, msgID)
+	if err != nil {
+		return 0, err
+	}
+	if msgMeta == nil {
+		return 0, errorx.New(errno.ErrConversationMessageNotFound)
+	}
+	if msgMeta.UserID != conv.Int64ToStr(userID) || msgMeta.ConversationID != conversationData.ID {
+		return 0, errorx.New(errno.ErrConversationPermissionCode, errorx.KV("msg", "message not match"))
+	}
+	if isEdit && msgMeta.MessageType != crossDomainMessage.MessageTypeQuestion {
+		return 0, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", "only user message can be edited"))
+	}
+
+	// 重新生成时未传入提问则沿用原提问
+	if !isEdit && ar.Query == "" {
+		if err = c.fillRegenQuery(ctx, ar, msgMeta); err != nil {
+			return 0, err
+		}
+	}
+
+	forkResult, err := c.AgentRunDomainSVC.Fork(ctx, &entity.ForkRunMeta{
+		ConversationID: msgMeta.ConversationID,
+		RunID:          msgMeta.RunID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	err = c.MessageDomainSVC.UpdateStatus(ctx, &msgEntity.UpdateStatusMeta{
+		RunIDs: forkResult.Deactivated,
+		From:   msgEntity.MessageStatusAvailable,
+		To:     msgEntity.MessageStatusReplaced,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return forkResult.BranchID, nil
+}
+
+func (c *ConversationApplicationService) fillRegenQuery(ctx context.Context, ar *run.AgentRunRequest, msgMeta *msgEntity.Message) error {
+	msgs, err := c.MessageDomainSVC.GetByRunIDs(ctx, msgMeta.ConversationID, []int64{msgMeta.RunID})
 	if err != nil {
 		return err
 	}
-	// 处理流式响应
-	c.pullStream(ctx, sseSender, streamer, ar)
-	return nil
+	for _, m := range msgs {
+		if m.MessageType != crossDomainMessage.MessageTypeQuestion {
+			continue
+		}
+		if m.ContentType != crossDomainMessage.ContentTypeText {
+			return errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", "query is required to regenerate a multi-content message"))
+		}
+		ar.Query = m.Content
+		ar.ContentType = ptr.Of(run.ContentTypeText)
+		return nil
+	}
+	return errorx.New(errno.ErrConversationMessageNotFound)
 }
 
 func (c *ConversationApplicationService) checkAgent(ctx context.Context, ar *run.AgentRunRequest) (*singleagentEntity.SingleAgent, error) {
@@ -291,40 +368,98 @@ func (c *ConversationApplicationService) getType(fileType string) crossDomainMes
 	}
 }
 
-func (c *ConversationApplicationService) pullStream(ctx context.Context, sseSender *sseImpl.SSenderImpl, arStream *schema.StreamReader[*entity.AgentRunResponse], req *run.AgentRunRequest) {
-	var ackMessageInfo *entity.ChunkMessageItem
+func (c *ConversationApplicationService) pullStream(ctx context.Context, sseSender sseImpl.SSESender, arStream *schema.StreamReader[*entity.AgentRunResponse], req *run.AgentRunRequest) {
+	defer arStream.Close()
+
+	var (
+		ackMessageInfo *entity.ChunkMessageItem
+		lastSeq        int64
+	)
+	// 事件 ID 使用运行内的事件序号，没有序号的事件沿用上一个，客户端重新接入时从该序号之后续传
+	send := func(event *sse.Event) error {
+		if lastSeq > 0 {
+			event.Id = strconv.FormatInt(lastSeq, 10)
+		}
+		return sseSender.Send(ctx, event)
+	}
 	for {
 		chunk, recvErr := arStream.Recv()
 		if recvErr != nil {
 			if errors.Is(recvErr, io.EOF) {
-				sseSender.Send(ctx, buildDoneEvent(run.RunEventDone))
+				_ = send(buildDoneEvent(run.RunEventDone))
 				return
 			}
-			sseSender.Send(ctx, buildErrorEvent(errno.ErrConversationAgentRunError, recvErr.Error()))
+			_ = send(buildErrorEvent(errno.ErrConversationAgentRunError, recvErr.Error()))
 			return
 		}
+		if chunk.Seq > 0 {
+			lastSeq = chunk.Seq
+		}
+
+		var sendErr error
 		switch chunk.Event {
-		case entity.RunEventCreated, entity.RunEventInProgress, entity.RunEventCompleted:
+		case entity.RunEventCreated, entity.RunEventInProgress, entity.RunEventCompleted, entity.RunEventRequiredAction:
 		case entity.RunEventError:
+			// 模型配额超限时直接返回错误事件，由客户端按 retry_after_ms 等待后重试
+			if chunk.Error != nil && chunk.Error.RetryAfterMs != nil {
+				sendErr = send(buildRetryAfterErrorEvent(chunk.Error.Code, chunk.Error.Msg, *chunk.Error.RetryAfterMs))
+				break
+			}
+			// 重新接入时可能没有读到 ack 消息
+			if ackMessageInfo == nil {
+				sendErr = send(buildErrorEvent(chunk.Error.Code, chunk.Error.Msg))
+				break
+			}
 			id, err := c.GenID(ctx)
 			if err != nil {
-				sseSender.Send(ctx, buildErrorEvent(errno.ErrConversationAgentRunError, err.Error()))
+				sendErr = send(buildErrorEvent(errno.ErrConversationAgentRunError, err.Error()))
 			} else {
-				sseSender.Send(ctx, buildMessageChunkEvent(run.RunEventMessage, buildErrMsg(ackMessageInfo, chunk.Error, id)))
+				sendErr = send(buildMessageChunkEvent(run.RunEventMessage, buildErrMsg(ackMessageInfo, chunk.Error, id)))
 			}
+		case entity.RunEventCancelled:
+			sendErr = send(buildRunChunkEvent(string(entity.RunEventCancelled), buildChatDetail(chunk.ChunkRunItem)))
 		case entity.RunEventStreamDone:
-			sseSender.Send(ctx, buildDoneEvent(run.RunEventDone))
+			sendErr = send(buildDoneEvent(run.RunEventDone))
 		case entity.RunEventAck:
 			ackMessageInfo = chunk.ChunkMessageItem
-			sseSender.Send(ctx, buildMessageChunkEvent(run.RunEventMessage, buildARSM2Message(chunk, req)))
+			sendErr = send(buildMessageChunkEvent(run.RunEventMessage, buildARSM2Message(chunk, req)))
 		case entity.RunEventMessageDelta, entity.RunEventMessageCompleted:
-			sseSender.Send(ctx, buildMessageChunkEvent(run.RunEventMessage, buildARSM2Message(chunk, req)))
+			sendErr = send(buildMessageChunkEvent(run.RunEventMessage, buildARSM2Message(chunk, req)))
+		case entity.RunEventMessageDirective:
+			sendErr = send(buildDirectiveEvent(chunk.ChunkDirective))
+		case entity.RunEventMessageAudio:
+			sendErr = send(buildAudioEvent(chunk.ChunkAudio))
 		default:
 			logs.ErrorX(pkg.ModelName, "unknown handler event:%v", chunk.Event)
 		}
+		// 客户端已断开，运行在后台继续执行，可通过重新接入续传
+		if sendErr != nil {
+			return
+		}
 	}
 }
 
+// ReattachRun 客户端断线后重新接入运行，重放 LastEventID 之后的事件，运行未结束时继续推送
+func (c *ConversationApplicationService) ReattachRun(ctx context.Context, sseSender sseImpl.SSESender, req *run.ReattachChatApiRequest) error {
+	userID, err := requestUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	runRecord, err := c.getOwnedRun(ctx, req.ChatID, req.ConversationID, userID)
+	if err != nil {
+		return err
+	}
+
+	streamer, err := c.AgentRunDomainSVC.Reattach(ctx, runRecord.ID, req.GetLastEventID())
+	if err != nil {
+		return err
+	}
+	// 重新接入时没有原始请求，ack 消息使用保存的用户输入
+	c.pullStream(ctx, sseSender, streamer, &run.AgentRunRequest{})
+	return nil
+}
+
 func buildARSM2Message(chunk *entity.AgentRunResponse, req *run.AgentRunRequest) []byte {
 	chunkMessageItem := chunk.ChunkMessageItem
 
@@ -350,8 +485,10 @@ func buildARSM2Message(chunk *entity.AgentRunResponse, req *run.AgentRunRequest)
 		SeqID: int32(chunkMessageItem.SeqID),
 	}
 	if chunkMessageItem.MessageType == crossDomainMessage.MessageTypeAck {
-		chunkMessage.Message.Content = req.GetQuery()
-		chunkMessage.Message.ContentType = req.GetContentType()
+		if req.GetQuery() != "" {
+			chunkMessage.Message.Content = req.GetQuery()
+			chunkMessage.Message.ContentType = req.GetContentType()
+		}
 		chunkMessage.Message.ExtraInfo = &message.ExtraInfo{
 			LocalMessageID: req.GetLocalMessageID(),
 		}
@@ -418,3 +555,156 @@ func buildErrMsg(ackChunk *entity.ChunkMessageItem, err *entity.RunError, id int
 	mCM, _ := json.Marshal(chunkMessage)
 	return mCM
 }
+
+// CancelRun 取消对话中的运行，执行中的运行中止后推送 conversation.chat.cancelled 事件，已输出的回答保存为被打断的消息
+func (c *ConversationApplicationService) CancelRun(ctx context.Context, req *run.CancelChatApiRequest) (*run.CancelChatApiResponse, error) {
+	userID, err := requestUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runRecord, err := c.getOwnedRun(ctx, req.ChatID, req.ConversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	runRecord, err = c.AgentRunDomainSVC.Cancel(ctx, &entity.CancelRunMeta{
+		ConversationID: req.ConversationID,
+		RunID:          req.ChatID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &run.CancelChatApiResponse{
+		ChatV3ChatDetail: buildChatDetail(runRecord),
+	}, nil
+}
+
+// RetrieveRun 查询运行状态，异步运行通过轮询该接口等待结束
+func (c *ConversationApplicationService) RetrieveRun(ctx context.Context, req *run.RetrieveChatApiRequest) (*run.RetrieveChatApiResponse, error) {
+	userID, err := requestUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runRecord, err := c.getOwnedRun(ctx, req.ChatID, req.ConversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &run.RetrieveChatApiResponse{
+		Data: buildChatDetail(runRecord),
+	}, nil
+}
+
+// ListRunMessages 查询运行产生的消息，包括用户输入、回答与中间过程
+func (c *ConversationApplicationService) ListRunMessages(ctx context.Context, req *run.ListChatMessageApiRequest) (*run.ListChatMessageApiResponse, error) {
+	userID, err := requestUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runRecord, err := c.getOwnedRun(ctx, req.ChatID, req.ConversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := c.MessageDomainSVC.GetByRunIDs(ctx, runRecord.ConversationID, []int64{runRecord.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*run.ChatV3MessageDetail, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Status == crossDomainMessage.MessageStatusDeleted {
+			continue
+		}
+		data = append(data, buildMessageDetail(msg))
+	}
+	return &run.ListChatMessageApiResponse{
+		Data: data,
+	}, nil
+}
+
+// requestUserID 网页端使用登录态，OpenAPI 与 WebSocket 客户端也可以使用 API Key
+func requestUserID(ctx context.Context) (int64, error) {
+	if uid := ctxutil.GetUIDFromCtx(ctx); uid != nil {
+		return *uid, nil
+	}
+	if apiKeyInfo := ctxutil.GetApiAuthFromCtx(ctx); apiKeyInfo != nil {
+		return apiKeyInfo.UserID, nil
+	}
+	return 0, errorx.New(errno.ErrConversationPermissionCode, errorx.KV("msg", "missing user session or api key"))
+}
+
+func (c *ConversationApplicationService) getOwnedRun(ctx context.Context, runID, conversationID, userID int64) (*entity.RunRecordMeta, error) {
+	runRecord, err := c.AgentRunDomainSVC.GetByID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if runRecord == nil || runRecord.ConversationID != conversationID {
+		return nil, errorx.New(errno.ErrRecordNotFound)
+	}
+	if runRecord.CreatorID != userID {
+		return nil, errorx.New(errno.ErrConversationPermissionCode, errorx.KV("msg", "chat not match"))
+	}
+	return runRecord, nil
+}
+
+func buildMessageDetail(msg *msgEntity.Message) *run.ChatV3MessageDetail {
+	detail := &run.ChatV3MessageDetail{
+		ID:             conv.Int64ToStr(msg.ID),
+		ConversationID: conv.Int64ToStr(msg.ConversationID),
+		BotID:          conv.Int64ToStr(msg.AgentID),
+		Role:           string(msg.Role),
+		Type:           string(msg.MessageType),
+		Content:        msg.Content,
+		ContentType:    string(msg.ContentType),
+		MetaData:       msg.Ext,
+		ChatID:         conv.Int64ToStr(msg.RunID),
+		SectionID:      ptr.Of(conv.Int64ToStr(msg.SectionID)),
+		CreatedAt:      ptr.Of(msg.CreatedAt / 1000),
+		UpdatedAt:      ptr.Of(msg.UpdatedAt / 1000),
+	}
+	if msg.ReasoningContent != "" {
+		detail.ReasoningContent = ptr.Of(msg.ReasoningContent)
+	}
+	return detail
+}
+
+func buildChatDetail(runRecord *entity.RunRecordMeta) *run.ChatV3ChatDetail {
+	if runRecord == nil {
+		return nil
+	}
+	detail := &run.ChatV3ChatDetail{
+		ID:             runRecord.ID,
+		ConversationID: runRecord.ConversationID,
+		BotID:          runRecord.AgentID,
+		Status:         string(runRecord.Status),
+		SectionID:      ptr.Of(runRecord.SectionID),
+	}
+	if runRecord.CreatedAt > 0 {
+		detail.CreatedAt = ptr.Of(int32(runRecord.CreatedAt / 1000))
+	}
+	if runRecord.CompletedAt > 0 {
+		detail.CompletedAt = ptr.Of(int32(runRecord.CompletedAt / 1000))
+	}
+	if runRecord.FailedAt > 0 {
+		detail.FailedAt = ptr.Of(int32(runRecord.FailedAt / 1000))
+	}
+	if runRecord.Error != nil {
+		detail.LastError = &run.LastError{
+			Code: int32(runRecord.Error.Code),
+			Msg:  runRecord.Error.Msg,
+		}
+	}
+	if runRecord.Usage != nil {
+		detail.Usage = &run.Usage{
+			TokenCount:   ptr.Of(int32(runRecord.Usage.LlmTotalTokens)),
+			OutputTokens: ptr.Of(int32(runRecord.Usage.LlmCompletionTokens)),
+			InputTokens:  ptr.Of(int32(runRecord.Usage.LlmPromptTokens)),
+		}
+	}
+	return detail
+}
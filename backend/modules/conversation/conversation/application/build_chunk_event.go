@@ -3,7 +3,9 @@ package application
 import (
 	"github.com/gin-contrib/sse"
 	"github.com/kiosk404/airi-go/backend/api/model/conversation/run"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
 	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
 )
 
 func buildDoneEvent(event string) *sse.Event {
@@ -26,9 +28,66 @@ func buildErrorEvent(errCode int64, errMsg string) *sse.Event {
 	}
 }
 
+func buildRetryAfterErrorEvent(errCode int64, errMsg string, retryAfterMs int64) *sse.Event {
+	errData := run.ErrorData{
+		Code:         errCode,
+		Msg:          errMsg,
+		RetryAfterMs: &retryAfterMs,
+	}
+	ed, _ := json.Marshal(errData)
+
+	return &sse.Event{
+		Event: run.RunEventError,
+		Data:  ed,
+	}
+}
+
 func buildMessageChunkEvent(event string, chunkMsg []byte) *sse.Event {
 	return &sse.Event{
 		Event: event,
 		Data:  chunkMsg,
 	}
 }
+
+func buildRunChunkEvent(event string, chatDetail *run.ChatV3ChatDetail) *sse.Event {
+	cd, _ := json.Marshal(chatDetail)
+
+	return &sse.Event{
+		Event: event,
+		Data:  cd,
+	}
+}
+
+func buildDirectiveEvent(directive *entity.ChunkDirective) *sse.Event {
+	dd, _ := json.Marshal(&run.DirectiveData{
+		ConversationID: conv.Int64ToStr(directive.ConversationID),
+		MessageID:      conv.Int64ToStr(directive.MessageID),
+		Type:           directive.Type,
+		Name:           directive.Name,
+		Offset:         int32(directive.Offset),
+		ElapsedMs:      directive.ElapsedMs,
+	})
+
+	return &sse.Event{
+		Event: run.RunEventDirective,
+		Data:  dd,
+	}
+}
+
+func buildAudioEvent(audio *entity.ChunkAudio) *sse.Event {
+	ad, _ := json.Marshal(&run.AudioData{
+		ConversationID: conv.Int64ToStr(audio.ConversationID),
+		MessageID:      conv.Int64ToStr(audio.MessageID),
+		Index:          int32(audio.Index),
+		Text:           audio.Text,
+		Offset:         int32(audio.Offset),
+		URL:            audio.URL,
+		ContentType:    audio.ContentType,
+		Autoplay:       audio.Autoplay,
+	})
+
+	return &sse.Event{
+		Event: run.RunEventAudio,
+		Data:  ad,
+	}
+}
@@ -1,31 +1,72 @@
 package application
 
 import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiosk404/airi-go/backend/infra/contract/cache"
+	"github.com/kiosk404/airi-go/backend/infra/contract/eventbus"
 	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
 	"github.com/kiosk404/airi-go/backend/infra/contract/imagex"
 	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
 	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	"github.com/kiosk404/airi-go/backend/infra/contract/stt"
+	"github.com/kiosk404/airi-go/backend/infra/contract/tts"
 	"github.com/kiosk404/airi-go/backend/modules/component/agent/application/singleagent"
 	agentRepo "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/repo"
 	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/service/runtime"
 	convRepo "github.com/kiosk404/airi-go/backend/modules/conversation/conversation/domain/repo"
 	conversationService "github.com/kiosk404/airi-go/backend/modules/conversation/conversation/domain/service"
 	messageRepo "github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/repo"
 	message "github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/service"
+	"github.com/kiosk404/airi-go/backend/pkg/checkpoint"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/types/consts"
 )
 
 type ServiceComponents struct {
 	IDGen     idgen.IDGenerator
 	DB        rdb.Provider
+	Cache     cache.Cmdable
 	TosClient storage.Storage
 	ImageX    imagex.ImageX
+	CPStore   checkpoint.Store
+	// TTS 为空时不合成语音
+	TTS tts.Synthesizer
+	// STT 为空时不识别语音
+	STT stt.Transcriber
+	// CancelProducer 多实例部署时广播取消事件，为空时只能取消本实例上执行中的运行
+	CancelProducer eventbus.Producer
+	// AsyncRunProducer 多实例部署时分发异步运行，为空时由接收请求的实例执行
+	AsyncRunProducer eventbus.Producer
 
 	SingleAgentDomainSVC singleagent.SingleAgent
 }
 
 func InitService(s *ServiceComponents) *ConversationApplicationService {
-	agentRunDomainSVC := agentrun.NewService(agentRepo.NewRunRecordRepo(s.DB, s.IDGen), s.ImageX)        // 运行记录
-	conversationDomainSVC := conversationService.NewService(convRepo.NewConversationRepo(s.DB, s.IDGen)) //
+	registry := runtime.NewRegistry()                           // 本实例上执行中的运行
+	asyncRunPool := agentrun.NewRunPool(getAsyncRunWorkerNum()) // 本实例执行异步运行的工作池
+	agentRunDomainSVC := agentrun.NewService(&agentrun.Components{
+		RunRecordRepo:    agentRepo.NewRunRecordRepo(s.DB, s.IDGen),
+		RunEventRepo:     agentRepo.NewRunEventRepo(s.Cache, getRunEventTTL()),
+		SummaryRepo:      agentRepo.NewHistorySummaryRepo(s.DB),
+		ImagexSVC:        s.ImageX,
+		CPStore:          s.CPStore,
+		TosClient:        s.TosClient,
+		TTS:              s.TTS,
+		STT:              s.STT,
+		Registry:         registry,
+		CancelProducer:   s.CancelProducer,
+		AsyncRunProducer: s.AsyncRunProducer,
+		AsyncRunPool:     asyncRunPool,
+
+		MemoryExtractInterval: getMemoryExtractInterval(),
+	})
+	conversationDomainSVC := conversationService.NewService(convRepo.NewConversationRepo(s.DB, s.IDGen))
 	messageDomainSVC := message.NewService(messageRepo.NewMessageRepo(s.DB, s.IDGen))
 
 	ConversationSVC.AgentRunDomainSVC = agentRunDomainSVC
@@ -33,6 +74,22 @@ func InitService(s *ServiceComponents) *ConversationApplicationService {
 	ConversationSVC.ConversationDomainSVC = conversationDomainSVC
 	ConversationSVC.appContext = s
 
+	// 每个实例使用独立的消费组，保证取消事件广播到所有实例
+	nameServer := os.Getenv(consts.MQServer)
+	cancelGroup := consts.RMQConsumeGroupChatCancel + "_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	err := eventbus.GetDefaultSVC().RegisterConsumer(nameServer, consts.RMQTopicChatCancel, cancelGroup, agentrun.NewCancelEventHandler(registry))
+	if err != nil {
+		logs.Warn("register chat cancel consumer failed, runs can only be cancelled on the instance executing them, err: %v", err)
+	}
+
+	// 所有实例共用一个消费组，每个异步运行只由一个实例执行
+	if s.AsyncRunProducer != nil {
+		err = eventbus.GetDefaultSVC().RegisterConsumer(nameServer, consts.RMQTopicChatAsyncRun, consts.RMQConsumeGroupAsyncRun, agentrun.NewAsyncRunEventHandler(asyncRunPool))
+		if err != nil {
+			logs.Warn("register chat async run consumer failed, err: %v", err)
+		}
+	}
+
 	return &ConversationApplicationService{
 		appContext: s,
 
@@ -41,3 +98,43 @@ func InitService(s *ServiceComponents) *ConversationApplicationService {
 		MessageDomainSVC:      messageDomainSVC,
 	}
 }
+
+func getAsyncRunWorkerNum() int {
+	v := os.Getenv(consts.AsyncRunWorkerNum)
+	if v == "" {
+		return agentrun.DefaultAsyncRunWorkerNum
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		logs.Warn("invalid %s=%s, use default %d", consts.AsyncRunWorkerNum, v, agentrun.DefaultAsyncRunWorkerNum)
+		return agentrun.DefaultAsyncRunWorkerNum
+	}
+	return n
+}
+
+// getMemoryExtractInterval 配置为 0 时不提取长期记忆
+func getMemoryExtractInterval() int {
+	v := os.Getenv(consts.MemoryExtractInterval)
+	if v == "" {
+		return agentrun.DefaultMemoryExtractInterval
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		logs.Warn("invalid %s=%s, use default %d", consts.MemoryExtractInterval, v, agentrun.DefaultMemoryExtractInterval)
+		return agentrun.DefaultMemoryExtractInterval
+	}
+	return n
+}
+
+func getRunEventTTL() time.Duration {
+	v := os.Getenv(consts.RunEventTTL)
+	if v == "" {
+		return agentrun.DefaultRunEventTTL
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		logs.Warn("invalid %s=%s, use default %s", consts.RunEventTTL, v, agentrun.DefaultRunEventTTL)
+		return agentrun.DefaultRunEventTTL
+	}
+	return d
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/kiosk404/airi-go/backend/api/model/conversation/message"
 	"github.com/kiosk404/airi-go/backend/api/model/conversation/run"
 	"github.com/kiosk404/airi-go/backend/application/ctxutil"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
 	singleAgentEntity "github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	agentEntity "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
 	convEntity "github.com/kiosk404/airi-go/backend/modules/conversation/conversation/domain/entity"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
 	model "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message/model"
@@ -76,6 +78,9 @@ func (c *ConversationApplicationService) GetMessageList(ctx context.Context, mr
 	}
 	// 获取历史消息体
 	resp := c.buildMessageListResponse(ctx, mListMessages, currentConversation)
+	if err = c.fillBranchInfo(ctx, resp.MessageList, mListMessages.Messages); err != nil {
+		return nil, err
+	}
 
 	resp.ParticipantInfoMap = map[string]*message.MsgParticipantInfo{}
 	for _, aOne := range agentInfo {
@@ -84,6 +89,91 @@ func (c *ConversationApplicationService) GetMessageList(ctx context.Context, mr
 	return resp, err
 }
 
+// fillBranchInfo 重新生成或编辑产生多个分支时，运行下的每条消息都返回所在分支的位置，供前端切换分支
+func (c *ConversationApplicationService) fillBranchInfo(ctx context.Context, voMessages []*message.ChatMessage, messages []*entity.Message) error {
+	// 按消息 ID 对应运行，不依赖两个列表的顺序与长度一致
+	runIDs := make([]int64, 0, len(messages))
+	msgRunIDs := make(map[string]int64, len(messages))
+	seen := make(map[int64]bool, len(messages))
+	for _, m := range messages {
+		msgRunIDs[conv.Int64ToStr(m.ID)] = m.RunID
+		if !seen[m.RunID] {
+			seen[m.RunID] = true
+			runIDs = append(runIDs, m.RunID)
+		}
+	}
+	branches, err := c.AgentRunDomainSVC.ListBranches(ctx, runIDs)
+	if err != nil {
+		return err
+	}
+	if len(branches) == 0 {
+		return nil
+	}
+
+	for _, vo := range voMessages {
+		msgRunID, ok := msgRunIDs[vo.MessageID]
+		if !ok {
+			continue
+		}
+		siblings, ok := branches[msgRunID]
+		if !ok {
+			continue
+		}
+		branchInfo := &message.BranchInfo{
+			Total:   int32(len(siblings)),
+			ChatIds: slices.Transform(siblings, conv.Int64ToStr),
+		}
+		for idx, runID := range siblings {
+			if runID == msgRunID {
+				branchInfo.Index = int32(idx + 1)
+			}
+		}
+		vo.BranchInfo = branchInfo
+	}
+	return nil
+}
+
+// SwitchBranch 切换到同一位置的另一个分支，消息列表与之后对话的历史随之切换
+func (c *ConversationApplicationService) SwitchBranch(ctx context.Context, req *message.SwitchBranchRequest) (*message.SwitchBranchResponse, error) {
+	userID, err := requestUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.getOwnedRun(ctx, req.ChatID, req.ConversationID, userID); err != nil {
+		return nil, err
+	}
+
+	// 运行路径与消息状态在同一事务中切换，中途失败时不会出现路径与消息不一致
+	err = c.appContext.DB.Transaction(ctx, func(tx rdb.RDB) error {
+		txCtx := rdb.WithTx(ctx, tx.DB())
+		result, err := c.AgentRunDomainSVC.SwitchBranch(txCtx, &agentEntity.SwitchBranchMeta{
+			ConversationID: req.ConversationID,
+			RunID:          req.ChatID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = c.MessageDomainSVC.UpdateStatus(txCtx, &entity.UpdateStatusMeta{
+			RunIDs: result.Deactivated,
+			From:   entity.MessageStatusAvailable,
+			To:     entity.MessageStatusReplaced,
+		})
+		if err != nil {
+			return err
+		}
+		return c.MessageDomainSVC.UpdateStatus(txCtx, &entity.UpdateStatusMeta{
+			RunIDs: result.Activated,
+			From:   entity.MessageStatusReplaced,
+			To:     entity.MessageStatusAvailable,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &message.SwitchBranchResponse{}, nil
+}
+
 func (c *ConversationApplicationService) buildAgentInfo(ctx context.Context, agentIDs []int64) ([]*message.MsgParticipantInfo, error) {
 	var result []*message.MsgParticipantInfo
 	if len(agentIDs) > 0 {
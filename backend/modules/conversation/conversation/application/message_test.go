@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/api/model/conversation/message"
+	"github.com/kiosk404/airi-go/backend/infra/impl/rdb/sqlite"
+	agentRepo "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/repo"
+	agentrun "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/entity"
+	messageRepo "github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/repo"
+	messageService "github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/service"
+	userEntity "github.com/kiosk404/airi-go/backend/modules/foundation/user/domain/entity"
+	"github.com/kiosk404/airi-go/backend/pkg/ctxcache"
+	"github.com/kiosk404/airi-go/backend/types/consts"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const testRunRecordDDL = `CREATE TABLE run_record (
+	id INTEGER PRIMARY KEY,
+	conversation_id INTEGER NOT NULL DEFAULT 0,
+	section_id INTEGER NOT NULL DEFAULT 0,
+	agent_id INTEGER NOT NULL DEFAULT 0,
+	user_id TEXT NOT NULL DEFAULT '',
+	source INTEGER NOT NULL DEFAULT 0,
+	token_count INTEGER NOT NULL DEFAULT 0,
+	usage TEXT,
+	output_tokens INTEGER NOT NULL DEFAULT 0,
+	input_tokens INTEGER NOT NULL DEFAULT 0,
+	status TEXT NOT NULL DEFAULT '',
+	creator_id INTEGER NOT NULL DEFAULT 0,
+	created_at INTEGER NOT NULL DEFAULT 0,
+	updated_at INTEGER NOT NULL DEFAULT 0,
+	failed_at INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT,
+	completed_at INTEGER NOT NULL DEFAULT 0,
+	chat_request TEXT,
+	ext TEXT,
+	checkpoint_id TEXT NOT NULL DEFAULT '',
+	parent_id INTEGER NOT NULL DEFAULT 0,
+	branch_id INTEGER NOT NULL DEFAULT 0,
+	branch_selected INTEGER NOT NULL DEFAULT 1,
+	branch_active INTEGER NOT NULL DEFAULT 1
+)`
+
+const testMessageDDL = `CREATE TABLE message (
+	id INTEGER PRIMARY KEY,
+	run_id INTEGER NOT NULL DEFAULT 0,
+	conversation_id INTEGER NOT NULL DEFAULT 0,
+	user_id TEXT NOT NULL DEFAULT '',
+	agent_id INTEGER NOT NULL DEFAULT 0,
+	role TEXT NOT NULL DEFAULT '',
+	content_type TEXT NOT NULL DEFAULT '',
+	content TEXT,
+	message_type TEXT NOT NULL DEFAULT '',
+	display_content TEXT,
+	ext TEXT,
+	section_id INTEGER,
+	broken_position INTEGER,
+	status INTEGER NOT NULL DEFAULT 0,
+	model_content TEXT,
+	meta_info TEXT,
+	reasoning_content TEXT,
+	created_at INTEGER NOT NULL DEFAULT 0,
+	updated_at INTEGER NOT NULL DEFAULT 0
+)`
+
+const testUserID = 7
+
+// newBranchTestService 会话 10 中运行 1 之后重新生成过一次：原回答运行 2 未选中，新回答运行 3 在当前路径上
+func newBranchTestService(t *testing.T) (*ConversationApplicationService, *gorm.DB) {
+	provider, err := sqlite.NewDB(&sqlite.Config{DBName: filepath.Join(t.TempDir(), "conversation.db")})
+	assert.NoError(t, err)
+	db := provider.NewSession(context.Background()).DB()
+	assert.NoError(t, db.Exec(testRunRecordDDL).Error)
+	assert.NoError(t, db.Exec(testMessageDDL).Error)
+
+	assert.NoError(t, db.Exec(`INSERT INTO run_record (id, conversation_id, creator_id, status, created_at, parent_id, branch_id, branch_selected, branch_active) VALUES
+		(1, 10, ?, 'completed', 1, 0, 0, 1, 1),
+		(2, 10, ?, 'completed', 2, 1, 0, 0, 0),
+		(3, 10, ?, 'completed', 3, 1, 2, 1, 1)`, testUserID, testUserID, testUserID).Error)
+	assert.NoError(t, db.Exec(`INSERT INTO message (id, run_id, conversation_id, status, created_at) VALUES
+		(101, 1, 10, ?, 1), (201, 2, 10, ?, 2), (301, 3, 10, ?, 3)`,
+		entity.MessageStatusAvailable, entity.MessageStatusReplaced, entity.MessageStatusAvailable).Error)
+
+	return &ConversationApplicationService{
+		appContext: &ServiceComponents{DB: provider},
+		AgentRunDomainSVC: agentrun.NewService(&agentrun.Components{
+			RunRecordRepo: agentRepo.NewRunRecordRepo(provider, nil),
+		}),
+		MessageDomainSVC: messageService.NewService(messageRepo.NewMessageRepo(provider, nil)),
+	}, db
+}
+
+func sessionCtx() context.Context {
+	ctx := ctxcache.Init(context.Background())
+	ctxcache.Store(ctx, consts.SessionDataKeyInCtx, &userEntity.Session{UserID: testUserID})
+	return ctx
+}
+
+// branchState 返回运行是否在当前路径上，以及运行下消息的状态
+func branchState(t *testing.T, db *gorm.DB, runID int64) (bool, int32) {
+	var active bool
+	assert.NoError(t, db.Raw("SELECT branch_active FROM run_record WHERE id = ?", runID).Scan(&active).Error)
+	var status int32
+	assert.NoError(t, db.Raw("SELECT status FROM message WHERE run_id = ?", runID).Scan(&status).Error)
+	return active, status
+}
+
+func TestSwitchBranch(t *testing.T) {
+	c, db := newBranchTestService(t)
+
+	_, err := c.SwitchBranch(sessionCtx(), &message.SwitchBranchRequest{ConversationID: 10, ChatID: 2})
+	assert.NoError(t, err)
+
+	active, status := branchState(t, db, 2)
+	assert.True(t, active)
+	assert.Equal(t, int32(entity.MessageStatusAvailable), status)
+	active, status = branchState(t, db, 3)
+	assert.False(t, active)
+	assert.Equal(t, int32(entity.MessageStatusReplaced), status)
+}
+
+func TestSwitchBranchRollback(t *testing.T) {
+	c, db := newBranchTestService(t)
+	// 消息状态更新失败时，运行路径的切换一起回滚
+	assert.NoError(t, db.Exec("ALTER TABLE message RENAME COLUMN status TO status_bak").Error)
+
+	_, err := c.SwitchBranch(sessionCtx(), &message.SwitchBranchRequest{ConversationID: 10, ChatID: 2})
+	assert.Error(t, err)
+
+	for runID, want := range map[int64]bool{1: true, 2: false, 3: true} {
+		var active bool
+		assert.NoError(t, db.Raw("SELECT branch_active FROM run_record WHERE id = ?", runID).Scan(&active).Error)
+		assert.Equal(t, want, active, "run %d", runID)
+	}
+}
+
+func TestFillBranchInfo(t *testing.T) {
+	c, _ := newBranchTestService(t)
+	messages := []*entity.Message{{ID: 101, RunID: 1}, {ID: 301, RunID: 3}}
+	// 展示列表与消息列表的顺序不同，分支信息按消息 ID 对应
+	voMessages := []*message.ChatMessage{{MessageID: "301"}, {MessageID: "101"}}
+
+	assert.NoError(t, c.fillBranchInfo(context.Background(), voMessages, messages))
+	assert.Equal(t, &message.BranchInfo{Index: 2, Total: 2, ChatIds: []string{"2", "3"}}, voMessages[0].BranchInfo)
+	assert.Nil(t, voMessages[1].BranchInfo)
+}
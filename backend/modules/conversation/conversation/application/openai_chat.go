@@ -0,0 +1,426 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/api/model/openai"
+	"github.com/kiosk404/airi-go/backend/application/ctxutil"
+	singleagentEntity "github.com/kiosk404/airi-go/backend/modules/component/agent/domain/entity"
+	crossagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent"
+	singleagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+)
+
+const (
+	// openaiModelPrefix OpenAI 兼容接口中智能体的模型名为 agent:<agent_id>
+	openaiModelPrefix = "agent:"
+	openaiOwnedBy     = "airi-go"
+	maxOpenAIModels   = 100
+)
+
+// ListOpenAIModels 列出 API Key 所属用户已发布的智能体
+func (c *ConversationApplicationService) ListOpenAIModels(ctx context.Context) (*openai.ModelList, error) {
+	userID, err := openaiUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	agents, _, err := c.appContext.SingleAgentDomainSVC.ListAgentDraftByCreator(ctx, userID, 1, maxOpenAIModels)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*openai.Model, 0, len(agents))
+	for _, agent := range agents {
+		publishedAt, err := c.appContext.SingleAgentDomainSVC.GetPublishedTime(ctx, agent.AgentID)
+		if err != nil {
+			return nil, err
+		}
+		if publishedAt == 0 {
+			continue
+		}
+		models = append(models, &openai.Model{
+			ID:          openaiModelPrefix + conv.Int64ToStr(agent.AgentID),
+			Object:      openai.ObjectModel,
+			Created:     publishedAt / 1000,
+			OwnedBy:     openaiOwnedBy,
+			Name:        agent.Name,
+			Description: agent.Desc,
+		})
+	}
+
+	return &openai.ModelList{Object: openai.ObjectList, Data: models}, nil
+}
+
+// ChatCompletion 以非流式的 OpenAI 接口执行智能体，聚合流式分片后返回
+func (c *ConversationApplicationService) ChatCompletion(ctx context.Context, req *openai.ChatCompletionRequest) (*openai.ChatCompletion, error) {
+	sr, err := c.ChatCompletionStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer sr.Close()
+
+	msg := &openai.Message{Role: openai.RoleAssistant}
+	resp := &openai.ChatCompletion{
+		Object: openai.ObjectChatCompletion,
+		Model:  req.Model,
+	}
+	var (
+		content, reasoning strings.Builder
+		finishReason       = openai.FinishReasonStop
+	)
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		resp.ID, resp.Created = chunk.ID, chunk.Created
+		if chunk.Usage != nil {
+			resp.Usage = chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			content.WriteString(choice.Delta.Content)
+			reasoning.WriteString(choice.Delta.ReasoningContent)
+			msg.ToolCalls = append(msg.ToolCalls, choice.Delta.ToolCalls...)
+			if choice.FinishReason != nil {
+				finishReason = *choice.FinishReason
+			}
+		}
+	}
+
+	msg.Content = content.String()
+	msg.ReasoningContent = reasoning.String()
+	resp.Choices = []*openai.Choice{{Message: msg, FinishReason: finishReason}}
+	return resp, nil
+}
+
+// ChatCompletionStream 以 OpenAI 接口执行已发布的智能体
+//
+// 请求中最后一条用户消息作为输入，之前的消息作为历史；智能体的人设、工具与知识库按发布的配置生效，
+// 请求中的 system 消息与工具调用结果被忽略。智能体在服务端执行的工具调用以 tool_calls 分片返回，
+// 仅用于展示，调用方无需执行，完成原因始终为 stop
+func (c *ConversationApplicationService) ChatCompletionStream(ctx context.Context, req *openai.ChatCompletionRequest) (*schema.StreamReader[*openai.ChatCompletionChunk], error) {
+	userID, err := openaiUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	agentID, err := parseOpenAIModel(req.Model)
+	if err != nil {
+		return nil, err
+	}
+	agentInfo, err := c.appContext.SingleAgentDomainSVC.ObtainAgentByIdentity(ctx, &singleagentEntity.AgentIdentity{AgentID: agentID})
+	if err != nil {
+		return nil, err
+	}
+	// 只允许调用自己发布的智能体，不区分不存在与无权限
+	if agentInfo == nil || agentInfo.CreatorID != userID {
+		return nil, errorx.New(errno.ErrAgentNotExists)
+	}
+
+	input, history, err := openaiMessagesToSchema(req.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := crossagent.DefaultSVC().StreamExecute(ctx, &crossagent.AgentRuntime{
+		AgentID:      agentID,
+		AgentVersion: agentInfo.Version,
+		UserID:       conv.Int64ToStr(userID),
+		Input:        input,
+		HistoryMsg:   history,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := c.GenID(ctx)
+	if err != nil {
+		events.Close()
+		return nil, err
+	}
+
+	w := &openaiChunkWriter{
+		id:           fmt.Sprintf("chatcmpl-%d", id),
+		model:        req.Model,
+		created:      time.Now().Unix(),
+		includeUsage: req.StreamOptions != nil && req.StreamOptions.IncludeUsage,
+	}
+	sr, sw := schema.Pipe[*openai.ChatCompletionChunk](10)
+	w.sw = sw
+	safego.Go(ctx, func() {
+		defer func() {
+			events.Close()
+			sw.Close()
+		}()
+		w.pipe(events)
+	})
+
+	return sr, nil
+}
+
+func openaiUserID(ctx context.Context) (int64, error) {
+	apiKeyInfo := ctxutil.GetApiAuthFromCtx(ctx)
+	if apiKeyInfo == nil {
+		return 0, errorx.New(errno.ErrConversationPermissionCode, errorx.KV("msg", "missing api key"))
+	}
+	return apiKeyInfo.UserID, nil
+}
+
+func parseOpenAIModel(model string) (int64, error) {
+	idStr, ok := strings.CutPrefix(model, openaiModelPrefix)
+	if !ok {
+		return 0, errorx.New(errno.ErrConversationInvalidParamCode,
+			errorx.KVf("msg", "model must be in the form %s<agent_id>, got %q", openaiModelPrefix, model))
+	}
+	agentID, err := conv.StrToInt64(idStr)
+	if err != nil || agentID <= 0 {
+		return 0, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KVf("msg", "invalid agent id %q", idStr))
+	}
+	return agentID, nil
+}
+
+// openaiMessagesToSchema 最后一条用户消息作为输入，其后不能再有其他消息
+func openaiMessagesToSchema(msgs []*openai.ChatMessage) (input *schema.Message, history []*schema.Message, err error) {
+	last := len(msgs) - 1
+	if last < 0 || msgs[last] == nil || msgs[last].Role != openai.RoleUser {
+		return nil, nil, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KV("msg", "the last message must be a user message"))
+	}
+
+	for i, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+
+		var m *schema.Message
+		switch msg.Role {
+		case openai.RoleUser:
+			m = &schema.Message{Role: schema.User}
+		case openai.RoleAssistant:
+			// 历史中的工具调用已在服务端执行完毕，只保留回答内容
+			m = &schema.Message{Role: schema.Assistant}
+		case openai.RoleSystem, openai.RoleDeveloper, openai.RoleTool:
+			continue
+		default:
+			return nil, nil, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KVf("msg", "unknown role %q", msg.Role))
+		}
+
+		m.Content = msg.Content.Text
+		for _, part := range msg.Content.Parts {
+			if part == nil {
+				continue
+			}
+			switch part.Type {
+			case openai.ContentPartTypeText:
+				m.MultiContent = append(m.MultiContent, schema.ChatMessagePart{
+					Type: schema.ChatMessagePartTypeText,
+					Text: part.Text,
+				})
+			case openai.ContentPartTypeImageURL:
+				if part.ImageURL == nil || part.ImageURL.URL == "" {
+					return nil, nil, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KVf("msg", "messages[%d]: image_url is required", i))
+				}
+				m.MultiContent = append(m.MultiContent, schema.ChatMessagePart{
+					Type: schema.ChatMessagePartTypeImageURL,
+					ImageURL: &schema.ChatMessageImageURL{
+						URL:    part.ImageURL.URL,
+						Detail: schema.ImageURLDetail(part.ImageURL.Detail),
+					},
+				})
+			default:
+				return nil, nil, errorx.New(errno.ErrConversationInvalidParamCode, errorx.KVf("msg", "messages[%d]: unsupported content type %q", i, part.Type))
+			}
+		}
+		m.Content, m.MultiContent = collapseTextParts(m.Content, m.MultiContent)
+		if m.Role == schema.Assistant && m.Content == "" && len(m.MultiContent) == 0 {
+			continue
+		}
+
+		if i == last {
+			input = m
+		} else {
+			history = append(history, m)
+		}
+	}
+
+	return input, history, nil
+}
+
+// collapseTextParts 只有文本分片时合并为纯文本消息，与对话接口的文本消息保持一致
+func collapseTextParts(content string, parts []schema.ChatMessagePart) (string, []schema.ChatMessagePart) {
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part.Type != schema.ChatMessagePartTypeText {
+			return content, parts
+		}
+		texts = append(texts, part.Text)
+	}
+	if len(texts) == 0 {
+		return content, nil
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// openaiChunkWriter 将智能体事件转换为 OpenAI 的流式分片
+type openaiChunkWriter struct {
+	id           string
+	model        string
+	created      int64
+	includeUsage bool
+
+	sw        *schema.StreamWriter[*openai.ChatCompletionChunk]
+	sentRole  bool
+	toolIndex int
+	usage     openai.Usage
+}
+
+func (w *openaiChunkWriter) pipe(events *schema.StreamReader[*crossagent.AgentEvent]) {
+	for {
+		event, err := events.Recv()
+		if errors.Is(err, io.EOF) {
+			w.finish()
+			return
+		}
+		if err != nil {
+			w.sw.Send(nil, err)
+			return
+		}
+		if event == nil {
+			continue
+		}
+
+		switch event.EventType {
+		case singleagent.EventTypeOfChatModelAnswer:
+			err = w.pipeAnswer(event.ChatModelAnswer, true)
+		case singleagent.EventTypeOfToolsAsChatModelStream:
+			err = w.pipeAnswer(event.ToolAsChatModelAnswer, false)
+		case singleagent.EventTypeOfToolMidAnswer:
+			err = w.pipeAnswer(event.ToolMidAnswer, false)
+		case singleagent.EventTypeOfFuncCall:
+			err = w.sendToolCalls(event.FuncCall)
+		default:
+			// 工具结果、知识库召回、推荐问题等没有对应的 OpenAI 字段
+			logs.DebugX(pkg.ModelName, "[openai] skip agent event %s", event.EventType)
+		}
+		if err != nil {
+			w.sw.Send(nil, err)
+			return
+		}
+	}
+}
+
+// pipeAnswer 模型的工具调用由随后的 func_call 事件统一发送，这里只转发回答与推理内容
+func (w *openaiChunkWriter) pipeAnswer(sr *schema.StreamReader[*schema.Message], countUsage bool) error {
+	if sr == nil {
+		return nil
+	}
+	defer sr.Close()
+
+	var usage *schema.TokenUsage
+	for {
+		msg, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			continue
+		}
+		if msg.ResponseMeta != nil && msg.ResponseMeta.Usage != nil {
+			usage = msg.ResponseMeta.Usage
+		}
+		if msg.Content == "" && msg.ReasoningContent == "" {
+			continue
+		}
+		if w.send(&openai.Delta{Content: msg.Content, ReasoningContent: msg.ReasoningContent}) {
+			return io.ErrClosedPipe
+		}
+	}
+
+	// 一次回答对应一次模型调用，多次调用（如工具调用前后）的用量累加
+	if countUsage && usage != nil {
+		w.usage.PromptTokens += int64(usage.PromptTokens)
+		w.usage.CompletionTokens += int64(usage.CompletionTokens)
+		w.usage.TotalTokens += int64(usage.TotalTokens)
+	}
+	return nil
+}
+
+func (w *openaiChunkWriter) sendToolCalls(msg *schema.Message) error {
+	if msg == nil || len(msg.ToolCalls) == 0 {
+		return nil
+	}
+
+	calls := make([]*openai.ToolCall, 0, len(msg.ToolCalls))
+	for _, tc := range msg.ToolCalls {
+		calls = append(calls, &openai.ToolCall{
+			Index: ptr.Of(w.toolIndex),
+			ID:    tc.ID,
+			Type:  openai.ToolTypeFunction,
+			Function: openai.FunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: tc.Function.Arguments,
+			},
+		})
+		w.toolIndex++
+	}
+	if w.send(&openai.Delta{ToolCalls: calls}) {
+		return io.ErrClosedPipe
+	}
+	return nil
+}
+
+func (w *openaiChunkWriter) finish() {
+	if w.send(&openai.Delta{}, openai.FinishReasonStop) {
+		return
+	}
+	if w.includeUsage {
+		chunk := w.newChunk()
+		chunk.Choices = []*openai.ChunkChoice{}
+		chunk.Usage = ptr.Of(w.usage)
+		w.sw.Send(chunk, nil)
+	}
+}
+
+// send 第一个分片带上 role，返回 true 表示调用方已关闭流
+func (w *openaiChunkWriter) send(delta *openai.Delta, finishReason ...string) (closed bool) {
+	if !w.sentRole {
+		delta.Role = openai.RoleAssistant
+		w.sentRole = true
+	}
+	choice := &openai.ChunkChoice{Delta: delta}
+	if len(finishReason) > 0 {
+		choice.FinishReason = ptr.Of(finishReason[0])
+	}
+
+	chunk := w.newChunk()
+	chunk.Choices = []*openai.ChunkChoice{choice}
+	return w.sw.Send(chunk, nil)
+}
+
+func (w *openaiChunkWriter) newChunk() *openai.ChatCompletionChunk {
+	return &openai.ChatCompletionChunk{
+		ID:      w.id,
+		Object:  openai.ObjectChatCompletionChunk,
+		Created: w.created,
+		Model:   w.model,
+	}
+}
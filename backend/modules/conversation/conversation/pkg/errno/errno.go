@@ -26,9 +26,37 @@ const (
 
 	ErrAgentRunWorkflowNotFound = 103200004
 	ErrInProgressCanNotCancel   = 103200005
+	ErrRunCanNotCancel          = 103200006
+	ErrAsyncRunQueueFull        = 103200007
+	ErrRunEventsExpired         = 103200008
+	ErrRunNotOnBranch           = 103200009
 )
 
 func init() {
+	code.Register(
+		ErrRunNotOnBranch,
+		"chat is not on the current conversation branch",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrRunEventsExpired,
+		"chat events expired, please get the chat messages instead",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrAsyncRunQueueFull,
+		"too many async chats in queue, please retry later",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrRunCanNotCancel,
+		"chat in status {status} can not be cancelled",
+		code.WithAffectStability(false),
+	)
+
 	code.Register(
 		ErrInProgressCanNotCancel,
 		"in progress can not be cancelled",
@@ -35,4 +35,8 @@ type Usage struct {
 	LlmTotalTokens      int64  `json:"llm_total_tokens"`
 	WorkflowTokens      *int64 `json:"workflow_tokens,omitempty"`
 	WorkflowCost        *int64 `json:"workflow_cost,omitempty"`
+
+	// LlmModelID/LlmModelName 实际应答的模型，发生模型降级时与智能体配置的模型不同
+	LlmModelID   int64  `json:"llm_model_id,omitempty"`
+	LlmModelName string `json:"llm_model_name,omitempty"`
 }
@@ -40,7 +40,9 @@ type MessageStatus int32
 const (
 	MessageStatusAvailable MessageStatus = 1
 	MessageStatusDeleted   MessageStatus = 2
-	MessageStatusBroken    MessageStatus = 4
+	// MessageStatusReplaced 未选中分支上的消息，切换回该分支时恢复
+	MessageStatusReplaced MessageStatus = 3
+	MessageStatusBroken   MessageStatus = 4
 )
 
 type InputType string
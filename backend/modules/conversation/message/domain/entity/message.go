@@ -37,6 +37,13 @@ type DeleteMeta struct {
 	RunIDs         []int64 `json:"run_ids"`
 }
 
+// UpdateStatusMeta 只更新状态为 From 的消息
+type UpdateStatusMeta struct {
+	RunIDs []int64       `json:"run_ids"`
+	From   MessageStatus `json:"from"`
+	To     MessageStatus `json:"to"`
+}
+
 type BrokenMeta struct {
 	ID       int64  `json:"id"`
 	Position *int32 `json:"position"`
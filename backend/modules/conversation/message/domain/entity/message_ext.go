@@ -23,6 +23,8 @@ const (
 	ExtKeyBreakPoint                 MessageExtKey = "break_point"
 	ExtKeyToolCallsIDs               MessageExtKey = "tool_calls_ids"
 	ExtKeyRequiresAction             MessageExtKey = "requires_action"
+	// MessageExtKeyAudioTranscript 用户语音的识别结果，值为 AudioTranscriptExt 列表的 JSON
+	MessageExtKeyAudioTranscript MessageExtKey = "audio_transcript"
 )
 
 type BotStateExt struct {
@@ -37,3 +39,10 @@ type UsageExt struct {
 	InputTokens  int64 `json:"input_tokens"`
 	OutputTokens int64 `json:"output_tokens"`
 }
+
+type AudioTranscriptExt struct {
+	URI      string `json:"uri,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Text     string `json:"text"`
+	Language string `json:"language,omitempty"`
+}
@@ -0,0 +1,9 @@
+package entity
+
+// PreToolCallData 预调用工具的执行记录，作为 verbose 消息的 data 字段
+type PreToolCallData struct {
+	ToolCallID string `json:"tool_call_id"`
+	ToolName   string `json:"tool_name"`
+	Arguments  string `json:"arguments"`
+	Output     string `json:"output"`
+}
@@ -23,4 +23,5 @@ type MessageRepo interface {
 	Edit(ctx context.Context, msgID int64, message *message.Message) (int64, error)
 	GetByID(ctx context.Context, msgID int64) (*entity.Message, error)
 	Delete(ctx context.Context, delMeta *entity.DeleteMeta) error
+	UpdateStatus(ctx context.Context, meta *entity.UpdateStatusMeta) error
 }
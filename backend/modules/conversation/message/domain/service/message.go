@@ -17,4 +17,6 @@ type Message interface {
 	Edit(ctx context.Context, req *entity.Message) (*entity.Message, error)
 	Delete(ctx context.Context, req *entity.DeleteMeta) error
 	Broken(ctx context.Context, req *entity.BrokenMeta) error
+	// UpdateStatus 切换分支时隐藏或恢复运行下的消息
+	UpdateStatus(ctx context.Context, req *entity.UpdateStatusMeta) error
 }
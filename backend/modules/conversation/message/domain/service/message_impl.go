@@ -104,6 +104,13 @@ func (m *messageImpl) Delete(ctx context.Context, req *entity.DeleteMeta) error
 	return m.MessageRepo.Delete(ctx, req)
 }
 
+func (m *messageImpl) UpdateStatus(ctx context.Context, req *entity.UpdateStatusMeta) error {
+	if len(req.RunIDs) == 0 {
+		return nil
+	}
+	return m.MessageRepo.UpdateStatus(ctx, req)
+}
+
 func (m *messageImpl) GetByID(ctx context.Context, id int64) (*entity.Message, error) {
 	return m.MessageRepo.GetByID(ctx, id)
 }
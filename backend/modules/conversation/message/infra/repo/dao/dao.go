@@ -8,6 +8,7 @@ import (
 	"github.com/bytedance/sonic"
 	"github.com/cloudwego/eino/schema"
 	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/conversation/pkg/errno"
 	message "github.com/kiosk404/airi-go/backend/modules/conversation/crossdomain/message/model"
 	"github.com/kiosk404/airi-go/backend/modules/conversation/message/domain/entity"
@@ -257,6 +258,19 @@ func (dao *MessageDAO) Delete(ctx context.Context, delMeta *entity.DeleteMeta) e
 	return err
 }
 
+// UpdateStatus context 中绑定了事务时在该事务中更新，切换分支时与运行记录一起提交
+func (dao *MessageDAO) UpdateStatus(ctx context.Context, meta *entity.UpdateStatusMeta) error {
+	m := dao.query.Message
+	if tx, ok := rdb.TxFromCtx(ctx); ok {
+		m = query.Use(tx).Message
+	}
+	_, err := m.WithContext(ctx).Where(m.RunID.In(meta.RunIDs...), m.Status.Eq(int32(meta.From))).UpdateColumns(map[string]interface{}{
+		"status":     int32(meta.To),
+		"updated_at": time.Now().UnixMilli(),
+	})
+	return err
+}
+
 func (dao *MessageDAO) messageDO2PO(ctx context.Context, msgDo *entity.Message) (*model.Message, error) {
 	var id int64
 	if msgDo.ID > 0 {
@@ -0,0 +1,21 @@
+package database
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/database/model"
+)
+
+type Database interface {
+	ExecuteSQL(ctx context.Context, req *model.ExecuteSQLRequest) (*model.ExecuteSQLResponse, error)
+}
+
+var defaultSVC Database
+
+func DefaultSVC() Database {
+	return defaultSVC
+}
+
+func SetDefaultSVC(svc Database) {
+	defaultSVC = svc
+}
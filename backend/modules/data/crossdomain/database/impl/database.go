@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"context"
+
+	crossdatabase "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/database"
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/database/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/database/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/database/domain/service"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+var defaultSVC crossdatabase.Database
+
+type impl struct {
+	DomainSVC service.Database
+}
+
+func InitDomainService(c service.Database) crossdatabase.Database {
+	defaultSVC = &impl{
+		DomainSVC: c,
+	}
+
+	return defaultSVC
+}
+
+func (i *impl) ExecuteSQL(ctx context.Context, req *model.ExecuteSQLRequest) (*model.ExecuteSQLResponse, error) {
+	res, err := i.DomainSVC.ExecuteSQL(ctx, &service.ExecuteSQLRequest{
+		Table: &entity.Table{
+			AgentID: req.AgentID,
+			TableID: req.TableID,
+			Name:    req.TableName,
+			Fields: slices.Transform(req.Fields, func(f *model.Field) *entity.Field {
+				return &entity.Field{
+					Name:     f.Name,
+					Desc:     f.Desc,
+					Type:     entity.FieldType(f.Type),
+					Required: f.Required,
+				}
+			}),
+			RWMode: entity.RWMode(req.RWMode),
+		},
+		ConnectorUID: req.ConnectorUID,
+		SQL:          req.SQL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ExecuteSQLResponse{
+		Columns:      res.Columns,
+		Rows:         res.Rows,
+		AffectedRows: res.AffectedRows,
+	}, nil
+}
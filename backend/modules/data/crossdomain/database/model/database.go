@@ -0,0 +1,44 @@
+package model
+
+type FieldType int64
+
+const (
+	FieldTypeText    FieldType = 1
+	FieldTypeNumber  FieldType = 2
+	FieldTypeDate    FieldType = 3
+	FieldTypeFloat   FieldType = 4
+	FieldTypeBoolean FieldType = 5
+)
+
+type RWMode int64
+
+const (
+	RWModeLimitedReadWrite   RWMode = 1
+	RWModeReadOnly           RWMode = 2
+	RWModeUnlimitedReadWrite RWMode = 3
+)
+
+type Field struct {
+	Name     string
+	Desc     string
+	Type     FieldType
+	Required bool
+}
+
+type ExecuteSQLRequest struct {
+	AgentID   int64
+	TableID   int64
+	TableName string
+	Fields    []*Field
+	RWMode    RWMode
+
+	// ConnectorUID 用于单用户模式下的数据隔离
+	ConnectorUID string
+	SQL          string
+}
+
+type ExecuteSQLResponse struct {
+	Columns      []string
+	Rows         []map[string]any
+	AffectedRows int64
+}
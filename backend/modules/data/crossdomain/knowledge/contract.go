@@ -0,0 +1,23 @@
+package knowledge
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/knowledge/model"
+)
+
+type Knowledge interface {
+	Retrieve(ctx context.Context, req *model.RetrieveRequest) (*model.RetrieveResponse, error)
+	// CheckKnowledgeOwner 校验知识库均由该用户创建，Agent 绑定知识库前调用
+	CheckKnowledgeOwner(ctx context.Context, userID int64, knowledgeIDs []int64) error
+}
+
+var defaultSVC Knowledge
+
+func DefaultSVC() Knowledge {
+	return defaultSVC
+}
+
+func SetDefaultSVC(svc Knowledge) {
+	defaultSVC = svc
+}
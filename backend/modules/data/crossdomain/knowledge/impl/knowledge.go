@@ -0,0 +1,70 @@
+package impl
+
+import (
+	"context"
+
+	crossknowledge "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/knowledge"
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/knowledge/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+var defaultSVC crossknowledge.Knowledge
+
+type impl struct {
+	DomainSVC service.Knowledge
+}
+
+func InitDomainService(c service.Knowledge) crossknowledge.Knowledge {
+	defaultSVC = &impl{
+		DomainSVC: c,
+	}
+
+	return defaultSVC
+}
+
+func (i *impl) CheckKnowledgeOwner(ctx context.Context, userID int64, knowledgeIDs []int64) error {
+	for _, id := range knowledgeIDs {
+		kn, err := i.DomainSVC.GetKnowledge(ctx, id)
+		if err != nil {
+			return err
+		}
+		if kn.CreatorID != userID {
+			return errorx.New(errno.ErrKnowledgePermissionCode, errorx.KVf("msg", "knowledge %d is not owned by user %d", id, userID))
+		}
+	}
+	return nil
+}
+
+func (i *impl) Retrieve(ctx context.Context, req *model.RetrieveRequest) (*model.RetrieveResponse, error) {
+	res, err := i.DomainSVC.Retrieve(ctx, &service.RetrieveRequest{
+		Query:        req.Query,
+		KnowledgeIDs: req.KnowledgeIDs,
+		CreatorID:    req.CreatorID,
+		Strategy: &entity.RetrievalStrategy{
+			TopK:       req.TopK,
+			MinScore:   req.MinScore,
+			SearchType: entity.SearchType(req.SearchType),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RetrieveResponse{
+		Slices: slices.Transform(res, func(s *entity.RetrieveSlice) *model.RetrieveSlice {
+			return &model.RetrieveSlice{
+				SliceID:       s.Slice.ID,
+				DocumentID:    s.Slice.DocumentID,
+				KnowledgeID:   s.Slice.KnowledgeID,
+				KnowledgeName: s.KnowledgeName,
+				DocumentName:  s.DocumentName,
+				Content:       s.Slice.Content,
+				Score:         s.Score,
+			}
+		}),
+	}, nil
+}
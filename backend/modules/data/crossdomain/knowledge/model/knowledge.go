@@ -0,0 +1,35 @@
+package model
+
+// SearchType 取值与 bot_common.SearchStrategy 保持一致
+type SearchType int64
+
+const (
+	SearchTypeSemantic SearchType = 0
+	SearchTypeHybrid   SearchType = 1
+	SearchTypeFullText SearchType = 20
+)
+
+type RetrieveRequest struct {
+	Query        string
+	KnowledgeIDs []int64
+	// CreatorID Agent 的创建者，只能检索其创建的知识库
+	CreatorID int64
+
+	TopK       int64
+	MinScore   float64
+	SearchType SearchType
+}
+
+type RetrieveResponse struct {
+	Slices []*RetrieveSlice
+}
+
+type RetrieveSlice struct {
+	SliceID       int64
+	DocumentID    int64
+	KnowledgeID   int64
+	KnowledgeName string
+	DocumentName  string
+	Content       string
+	Score         float64
+}
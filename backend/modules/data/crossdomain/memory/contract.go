@@ -0,0 +1,24 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
+)
+
+type Memory interface {
+	Recall(ctx context.Context, req *model.RecallRequest) ([]*model.Memory, error)
+	AddMemories(ctx context.Context, req *model.AddMemoriesRequest) error
+	GetExtractCursor(ctx context.Context, sectionID int64) (int64, error)
+	SetExtractCursor(ctx context.Context, sectionID, runID int64) error
+}
+
+var defaultSVC Memory
+
+func DefaultSVC() Memory {
+	return defaultSVC
+}
+
+func SetDefaultSVC(svc Memory) {
+	defaultSVC = svc
+}
@@ -0,0 +1,72 @@
+package impl
+
+import (
+	"context"
+
+	crossmemory "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory"
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/memory/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/service"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+var defaultSVC crossmemory.Memory
+
+type impl struct {
+	DomainSVC service.Memory
+}
+
+func InitDomainService(c service.Memory) crossmemory.Memory {
+	defaultSVC = &impl{
+		DomainSVC: c,
+	}
+
+	return defaultSVC
+}
+
+func (i *impl) Recall(ctx context.Context, req *model.RecallRequest) ([]*model.Memory, error) {
+	res, err := i.DomainSVC.Recall(ctx, &service.RecallRequest{
+		AgentID: req.AgentID,
+		UserID:  req.UserID,
+		Query:   req.Query,
+		TopK:    req.TopK,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Transform(res, func(m *entity.RecallMemory) *model.Memory {
+		return &model.Memory{
+			ID:         m.ID,
+			Kind:       model.MemoryKind(m.Kind),
+			Content:    m.Content,
+			Importance: m.Importance,
+			UpdatedAt:  m.UpdatedAt,
+		}
+	}), nil
+}
+
+func (i *impl) AddMemories(ctx context.Context, req *model.AddMemoriesRequest) error {
+	_, err := i.DomainSVC.AddMemories(ctx, &service.AddMemoriesRequest{
+		AgentID:        req.AgentID,
+		UserID:         req.UserID,
+		ConversationID: req.ConversationID,
+		SourceRunID:    req.SourceRunID,
+		Memories: slices.Transform(req.Memories, func(d *model.MemoryDraft) *service.MemoryDraft {
+			return &service.MemoryDraft{
+				Kind:       entity.MemoryKind(d.Kind),
+				Content:    d.Content,
+				Importance: d.Importance,
+			}
+		}),
+	})
+	return err
+}
+
+func (i *impl) GetExtractCursor(ctx context.Context, sectionID int64) (int64, error) {
+	return i.DomainSVC.GetExtractCursor(ctx, sectionID)
+}
+
+func (i *impl) SetExtractCursor(ctx context.Context, sectionID, runID int64) error {
+	return i.DomainSVC.SetExtractCursor(ctx, sectionID, runID)
+}
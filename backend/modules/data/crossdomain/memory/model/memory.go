@@ -0,0 +1,37 @@
+package model
+
+type MemoryKind int32
+
+const (
+	MemoryKindFact  MemoryKind = 1
+	MemoryKindEvent MemoryKind = 2
+)
+
+type RecallRequest struct {
+	AgentID int64
+	UserID  string
+	Query   string
+	TopK    int
+}
+
+type AddMemoriesRequest struct {
+	AgentID        int64
+	UserID         string
+	ConversationID int64
+	SourceRunID    int64
+	Memories       []*MemoryDraft
+}
+
+type MemoryDraft struct {
+	Kind       MemoryKind
+	Content    string
+	Importance int32
+}
+
+type Memory struct {
+	ID         int64
+	Kind       MemoryKind
+	Content    string
+	Importance int32
+	UpdatedAt  int64
+}
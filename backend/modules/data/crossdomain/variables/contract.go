@@ -0,0 +1,22 @@
+package variables
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/variables/model"
+)
+
+type Variables interface {
+	GetVariables(ctx context.Context, req *model.GetVariablesRequest) ([]*model.Variable, error)
+	SetVariables(ctx context.Context, req *model.SetVariablesRequest) error
+}
+
+var defaultSVC Variables
+
+func DefaultSVC() Variables {
+	return defaultSVC
+}
+
+func SetDefaultSVC(svc Variables) {
+	defaultSVC = svc
+}
@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"context"
+
+	crossvariables "github.com/kiosk404/airi-go/backend/modules/data/crossdomain/variables"
+	"github.com/kiosk404/airi-go/backend/modules/data/crossdomain/variables/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/variables/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/variables/domain/service"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+var defaultSVC crossvariables.Variables
+
+type impl struct {
+	DomainSVC service.Variables
+}
+
+func InitDomainService(c service.Variables) crossvariables.Variables {
+	defaultSVC = &impl{
+		DomainSVC: c,
+	}
+
+	return defaultSVC
+}
+
+func (i *impl) GetVariables(ctx context.Context, req *model.GetVariablesRequest) ([]*model.Variable, error) {
+	res, err := i.DomainSVC.GetVariables(ctx, &service.GetVariablesRequest{
+		AgentID:      req.AgentID,
+		ConnectorUID: req.ConnectorUID,
+		Metas:        entity.MetasFromBotVariables(req.Variables),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Transform(res, func(v *entity.Variable) *model.Variable {
+		return &model.Variable{
+			Keyword:        v.Keyword,
+			Description:    v.Description,
+			Value:          v.Value,
+			PromptDisabled: v.PromptDisabled,
+		}
+	}), nil
+}
+
+func (i *impl) SetVariables(ctx context.Context, req *model.SetVariablesRequest) error {
+	_, err := i.DomainSVC.SetVariables(ctx, &service.SetVariablesRequest{
+		AgentID:      req.AgentID,
+		ConnectorUID: req.ConnectorUID,
+		Metas:        entity.MetasFromBotVariables(req.Variables),
+		KVs:          req.KVs,
+	})
+	return err
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+)
+
+type GetVariablesRequest struct {
+	AgentID      int64
+	ConnectorUID string
+	Variables    []*bot_common.Variable
+}
+
+type SetVariablesRequest struct {
+	AgentID      int64
+	ConnectorUID string
+	Variables    []*bot_common.Variable
+	KVs          map[string]string
+}
+
+type Variable struct {
+	Keyword        string
+	Description    string
+	Value          string
+	PromptDisabled bool
+}
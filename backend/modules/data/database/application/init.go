@@ -0,0 +1,28 @@
+package application
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	"github.com/kiosk404/airi-go/backend/infra/impl/sqlparser"
+	database "github.com/kiosk404/airi-go/backend/modules/data/database/domain/service"
+)
+
+type DatabaseApplicationService struct {
+	DomainSVC database.Database
+}
+
+var DatabaseSVC = &DatabaseApplicationService{}
+
+type ServiceComponents struct {
+	DB rdb.Provider
+}
+
+func InitService(ctx context.Context, c *ServiceComponents) *DatabaseApplicationService {
+	DatabaseSVC.DomainSVC = database.NewDatabaseSVC(&database.Components{
+		DB:        c.DB,
+		SQLParser: sqlparser.NewSQLParser(),
+	})
+
+	return DatabaseSVC
+}
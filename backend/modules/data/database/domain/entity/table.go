@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"fmt"
+)
+
+// FieldType 取值与 bot_common.FieldItemType 保持一致
+type FieldType int64
+
+const (
+	FieldTypeText    FieldType = 1
+	FieldTypeNumber  FieldType = 2
+	FieldTypeDate    FieldType = 3
+	FieldTypeFloat   FieldType = 4
+	FieldTypeBoolean FieldType = 5
+)
+
+// RWMode 取值与 bot_common.BotTableRWMode 保持一致
+type RWMode int64
+
+const (
+	// RWModeLimitedReadWrite 单用户模式，每个用户只能读写自己的数据
+	RWModeLimitedReadWrite RWMode = 1
+	// RWModeReadOnly 只读模式，用户只能查询，数据由开发者维护
+	RWModeReadOnly RWMode = 2
+	// RWModeUnlimitedReadWrite 多用户模式，所有用户共享读写整张表
+	RWModeUnlimitedReadWrite RWMode = 3
+)
+
+// 物理表的系统字段
+const (
+	ColumnID         = "id"
+	ColumnCreateTime = "create_time"
+	ColumnUID        = "sys_uid"
+)
+
+// Field 用户定义的表字段，物理列名与字段名一致
+type Field struct {
+	Name     string
+	Desc     string
+	Type     FieldType
+	Required bool
+}
+
+// Table 挂载在 Agent 上的一张逻辑表
+type Table struct {
+	AgentID int64
+	TableID int64
+	Name    string
+	Fields  []*Field
+	RWMode  RWMode
+}
+
+// PhysicalTableName 每个 Agent 的每张逻辑表对应一张独立的物理表
+func (t *Table) PhysicalTableName() string {
+	return fmt.Sprintf("agent_db_%d_%d", t.AgentID, t.TableID)
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	rdbentity "github.com/kiosk404/airi-go/backend/infra/contract/rdb/entity"
+	"github.com/kiosk404/airi-go/backend/infra/contract/sqlparser"
+	"github.com/kiosk404/airi-go/backend/modules/data/database/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/database/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/data/database/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+var fieldNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,63}$`)
+
+type Components struct {
+	DB        rdb.Provider
+	SQLParser sqlparser.SQLParser
+}
+
+func NewDatabaseSVC(c *Components) Database {
+	return &databaseImpl{
+		db:     c.DB,
+		parser: c.SQLParser,
+	}
+}
+
+type databaseImpl struct {
+	db     rdb.Provider
+	parser sqlparser.SQLParser
+
+	// syncedTables 物理表名 -> 字段签名，避免每次执行都查询 information_schema
+	syncedTables sync.Map
+}
+
+func (d *databaseImpl) ExecuteSQL(ctx context.Context, req *ExecuteSQLRequest) (*ExecuteSQLResponse, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	execSQL, params, err := d.rewriteSQL(req.Table, req.ConnectorUID, req.SQL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.syncTable(ctx, req.Table); err != nil {
+		return nil, err
+	}
+
+	resp, err := d.db.NewSession(ctx).ExecuteSQL(ctx, &rdb.ExecuteSQLRequest{
+		SQL:     execSQL,
+		Params:  params,
+		SQLType: rdbentity.SQLType_Parameterized,
+	})
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrDatabaseExecuteSQLCode, errorx.KV("msg", err.Error()))
+	}
+
+	res := &ExecuteSQLResponse{
+		Columns:      make([]string, 0, len(resp.ResultSet.Columns)),
+		Rows:         resp.ResultSet.Rows,
+		AffectedRows: resp.ResultSet.AffectedRows,
+	}
+	// 用户隔离字段不暴露给模型
+	for _, col := range resp.ResultSet.Columns {
+		if !strings.EqualFold(col, entity.ColumnUID) {
+			res.Columns = append(res.Columns, col)
+		}
+	}
+	for _, row := range res.Rows {
+		delete(row, entity.ColumnUID)
+	}
+
+	return res, nil
+}
+
+func validateRequest(req *ExecuteSQLRequest) error {
+	if req == nil || req.Table == nil {
+		return errorx.New(errno.ErrDatabaseInvalidParamCode, errorx.KV("msg", "table is required"))
+	}
+	if req.Table.Name == "" || req.Table.AgentID <= 0 || req.Table.TableID <= 0 {
+		return errorx.New(errno.ErrDatabaseInvalidParamCode, errorx.KV("msg", "invalid table info"))
+	}
+	if strings.TrimSpace(req.SQL) == "" {
+		return errorx.New(errno.ErrDatabaseInvalidParamCode, errorx.KV("msg", "sql is empty"))
+	}
+	if req.ConnectorUID == "" {
+		return errorx.New(errno.ErrDatabaseInvalidParamCode, errorx.KV("msg", "connector uid is required"))
+	}
+
+	for _, f := range req.Table.Fields {
+		if !fieldNameRegexp.MatchString(f.Name) {
+			return errorx.New(errno.ErrDatabaseInvalidParamCode, errorx.KVf("msg", "invalid field name '%s'", f.Name))
+		}
+		if isSystemColumn(f.Name) {
+			return errorx.New(errno.ErrDatabaseInvalidParamCode, errorx.KVf("msg", "field name '%s' is reserved", f.Name))
+		}
+	}
+
+	return nil
+}
+
+// rewriteSQL 校验 SQL 并改写为可在物理表上执行的语句：
+//  1. 只允许 SELECT / INSERT / UPDATE / DELETE，只读表只允许 SELECT
+//  2. 只允许访问当前逻辑表，并将其替换为物理表名
+//  3. 单用户模式下所有读写都追加 sys_uid 过滤，INSERT 自动写入 sys_uid
+//
+// sys_uid 以参数绑定，不拼接到 SQL 中，返回的 params 与 SQL 中的 ? 一一对应
+func (d *databaseImpl) rewriteSQL(table *entity.Table, uid, origSQL string) (string, []any, error) {
+	op, err := d.parser.GetSQLOperation(origSQL)
+	if err != nil {
+		return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+	}
+
+	switch op {
+	case sqlparser.OperationTypeSelect:
+	case sqlparser.OperationTypeInsert, sqlparser.OperationTypeUpdate, sqlparser.OperationTypeDelete:
+		if table.RWMode == entity.RWModeReadOnly {
+			return "", nil, errorx.New(errno.ErrDatabaseOperationDeniedCode,
+				errorx.KVf("msg", "table '%s' is read only, %s is not allowed", table.Name, op))
+		}
+	default:
+		return "", nil, errorx.New(errno.ErrDatabaseOperationDeniedCode,
+			errorx.KVf("msg", "%s is not allowed, only SELECT, INSERT, UPDATE and DELETE are supported", op))
+	}
+
+	// 用户 SQL 中的 ? 会占用 sys_uid 的绑定参数
+	markers, err := d.parser.GetParamMarkerNums(origSQL)
+	if err != nil {
+		return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+	}
+	if markers > 0 {
+		return "", nil, errorx.New(errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", "parameter markers '?' are not supported"))
+	}
+
+	// SELECT ... INTO 会把结果写入服务器文件或会话变量
+	intos, err := d.parser.GetSelectIntoNums(origSQL)
+	if err != nil {
+		return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+	}
+	if intos > 0 {
+		return "", nil, errorx.New(errno.ErrDatabaseOperationDeniedCode, errorx.KV("msg", "SELECT ... INTO is not supported"))
+	}
+
+	if op == sqlparser.OperationTypeInsert {
+		source, err := d.parser.GetInsertSource(origSQL)
+		if err != nil {
+			return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+		}
+		if source == sqlparser.InsertSourceSelect {
+			return "", nil, errorx.New(errno.ErrDatabaseOperationDeniedCode,
+				errorx.KV("msg", "INSERT ... SELECT and INSERT ... TABLE are not supported, use INSERT ... VALUES or INSERT ... SET"))
+		}
+	}
+
+	tables, err := d.parser.GetTableNames(origSQL)
+	if err != nil {
+		return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+	}
+	if len(tables) == 0 {
+		return "", nil, errorx.New(errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", "no table found in sql"))
+	}
+	for _, t := range tables {
+		if !strings.EqualFold(t, table.Name) {
+			return "", nil, errorx.New(errno.ErrDatabaseOperationDeniedCode,
+				errorx.KVf("msg", "only table '%s' can be accessed, got '%s'", table.Name, t))
+		}
+	}
+
+	scoped := isUserScoped(table.RWMode)
+	// 子查询或自连接会绕过外层的 sys_uid 过滤
+	if scoped && len(tables) > 1 {
+		return "", nil, errorx.New(errno.ErrDatabaseOperationDeniedCode,
+			errorx.KVf("msg", "table '%s' can only be referenced once, subqueries and self joins are not supported", table.Name))
+	}
+
+	columns, err := d.parser.GetColumnNames(origSQL)
+	if err != nil {
+		return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+	}
+	for _, col := range columns {
+		if strings.EqualFold(col, entity.ColumnUID) {
+			return "", nil, errorx.New(errno.ErrDatabaseOperationDeniedCode, errorx.KVf("msg", "column '%s' does not exist", col))
+		}
+		// 插入时不允许指定 id，避免通过主键冲突 (REPLACE / ON DUPLICATE KEY UPDATE) 覆盖其他用户的数据
+		if scoped && op == sqlparser.OperationTypeInsert && strings.EqualFold(col, entity.ColumnID) {
+			return "", nil, errorx.New(errno.ErrDatabaseOperationDeniedCode,
+				errorx.KVf("msg", "column '%s' is generated automatically and can not be inserted", col))
+		}
+	}
+
+	var insertNums int
+	if op == sqlparser.OperationTypeInsert {
+		insertNums, err = d.parser.GetInsertDataNums(origSQL)
+		if err != nil {
+			return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+		}
+		if insertNums == 0 {
+			return "", nil, errorx.New(errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", "no rows to insert"))
+		}
+	}
+
+	execSQL, err := d.parser.ParseAndModifySQL(origSQL, map[string]sqlparser.TableColumn{
+		strings.ToLower(table.Name): {NewTableName: ptr.Of(table.PhysicalTableName())},
+	})
+	if err != nil {
+		return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+	}
+
+	var params []any
+	switch op {
+	case sqlparser.OperationTypeInsert:
+		execSQL, _, err = d.parser.AddColumnsToInsertSQL(execSQL, []sqlparser.ColumnValue{
+			{ColName: entity.ColumnUID},
+		}, nil, true)
+		for i := 0; i < insertNums; i++ {
+			params = append(params, uid)
+		}
+	default:
+		if scoped {
+			execSQL, err = d.parser.AppendSQLFilter(execSQL, sqlparser.SQLFilterOpAnd, entity.ColumnUID+" = ?")
+			params = append(params, uid)
+		}
+	}
+	if err != nil {
+		return "", nil, errorx.WrapByCode(err, errno.ErrDatabaseInvalidSQLCode, errorx.KV("msg", err.Error()))
+	}
+
+	return execSQL, params, nil
+}
+
+// syncTable 按字段定义创建物理表，字段新增时补齐缺失的列。
+// 已存在的列不会被修改或删除，避免丢失用户数据。
+func (d *databaseImpl) syncTable(ctx context.Context, table *entity.Table) error {
+	tableName := table.PhysicalTableName()
+	signature := fieldsSignature(table.Fields)
+	if v, ok := d.syncedTables.Load(tableName); ok && v.(string) == signature {
+		return nil
+	}
+
+	session := d.db.NewSession(ctx)
+	resp, err := session.GetTable(ctx, &rdb.GetTableRequest{TableName: tableName})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errorx.WrapByCode(err, errno.ErrDatabaseSyncTableCode, errorx.KV("msg", err.Error()))
+	}
+
+	if errors.Is(err, sql.ErrNoRows) || resp == nil || resp.Table == nil {
+		logs.InfoX(pkg.ModelName, "create physical table %s for agent %d", tableName, table.AgentID)
+		if _, err = session.CreateTable(ctx, &rdb.CreateTableRequest{Table: buildPhysicalTable(table)}); err != nil {
+			return errorx.WrapByCode(err, errno.ErrDatabaseSyncTableCode, errorx.KV("msg", err.Error()))
+		}
+	} else {
+		existing := make(map[string]bool, len(resp.Table.Columns))
+		for _, col := range resp.Table.Columns {
+			existing[strings.ToLower(col.Name)] = true
+		}
+
+		ops := make([]*rdb.AlterTableOperation, 0)
+		for _, f := range table.Fields {
+			if existing[strings.ToLower(f.Name)] {
+				continue
+			}
+			ops = append(ops, &rdb.AlterTableOperation{
+				Action: rdbentity.AddColumn,
+				Column: buildColumn(f),
+			})
+		}
+
+		if len(ops) > 0 {
+			logs.InfoX(pkg.ModelName, "add %d columns to physical table %s", len(ops), tableName)
+			if _, err = session.AlterTable(ctx, &rdb.AlterTableRequest{TableName: tableName, Operations: ops}); err != nil {
+				return errorx.WrapByCode(err, errno.ErrDatabaseSyncTableCode, errorx.KV("msg", err.Error()))
+			}
+		}
+	}
+
+	d.syncedTables.Store(tableName, signature)
+	return nil
+}
+
+func buildPhysicalTable(table *entity.Table) *rdbentity.Table {
+	columns := []*rdbentity.Column{
+		{Name: entity.ColumnID, DataType: rdbentity.TypeBigInt, NotNull: true, AutoIncrement: true},
+		{Name: entity.ColumnUID, DataType: rdbentity.TypeVarchar, Length: ptr.Of(255), NotNull: true},
+		{Name: entity.ColumnCreateTime, DataType: rdbentity.TypeTimestamp, NotNull: true, DefaultValue: ptr.Of("CURRENT_TIMESTAMP")},
+	}
+	for _, f := range table.Fields {
+		columns = append(columns, buildColumn(f))
+	}
+
+	return &rdbentity.Table{
+		Name:    table.PhysicalTableName(),
+		Columns: columns,
+		Indexes: []*rdbentity.Index{
+			{Type: rdbentity.PrimaryKey, Columns: []string{entity.ColumnID}},
+			{Name: "idx_" + entity.ColumnUID, Type: rdbentity.NormalKey, Columns: []string{entity.ColumnUID}},
+		},
+		Options: &rdbentity.TableOption{
+			Comment: ptr.Of(fmt.Sprintf("agent_id=%d, table_id=%d", table.AgentID, table.TableID)),
+		},
+	}
+}
+
+func buildColumn(f *entity.Field) *rdbentity.Column {
+	return &rdbentity.Column{
+		Name:     f.Name,
+		DataType: toDataType(f.Type),
+		NotNull:  f.Required,
+	}
+}
+
+func toDataType(t entity.FieldType) rdbentity.DataType {
+	switch t {
+	case entity.FieldTypeNumber:
+		return rdbentity.TypeBigInt
+	case entity.FieldTypeDate:
+		return rdbentity.TypeTimestamp
+	case entity.FieldTypeFloat:
+		return rdbentity.TypeDouble
+	case entity.FieldTypeBoolean:
+		return rdbentity.TypeBoolean
+	default:
+		return rdbentity.TypeText
+	}
+}
+
+func fieldsSignature(fields []*entity.Field) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts = append(parts, fmt.Sprintf("%s:%d:%t", strings.ToLower(f.Name), f.Type, f.Required))
+	}
+	return strings.Join(parts, ",")
+}
+
+// isUserScoped 单用户模式下每个用户只能读写自己的数据，未指定时按单用户模式处理
+func isUserScoped(mode entity.RWMode) bool {
+	return mode != entity.RWModeReadOnly && mode != entity.RWModeUnlimitedReadWrite
+}
+
+func isSystemColumn(name string) bool {
+	return strings.EqualFold(name, entity.ColumnID) ||
+		strings.EqualFold(name, entity.ColumnUID) ||
+		strings.EqualFold(name, entity.ColumnCreateTime)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sqlparserimpl "github.com/kiosk404/airi-go/backend/infra/impl/sqlparser"
+	"github.com/kiosk404/airi-go/backend/modules/data/database/domain/entity"
+)
+
+func TestRewriteSQL(t *testing.T) {
+	d := &databaseImpl{parser: sqlparserimpl.NewSQLParser()}
+	table := &entity.Table{
+		AgentID: 1,
+		TableID: 2,
+		Name:    "diary",
+		Fields: []*entity.Field{
+			{Name: "content", Type: entity.FieldTypeText},
+			{Name: "mood", Type: entity.FieldTypeText},
+		},
+		RWMode: entity.RWModeLimitedReadWrite,
+	}
+
+	scopedCases := []struct {
+		name   string
+		sql    string
+		want   string
+		params []any
+	}{
+		{
+			name:   "select is scoped to the connector user",
+			sql:    "SELECT content FROM diary WHERE mood = 'happy'",
+			want:   "SELECT `content` FROM `agent_db_1_2` WHERE (`mood`='happy') AND (`sys_uid`=?)",
+			params: []any{"10086"},
+		},
+		{
+			name:   "or keeps the filter outside",
+			sql:    "SELECT content FROM diary WHERE mood='x' OR 1=1",
+			want:   "SELECT `content` FROM `agent_db_1_2` WHERE (`mood`='x' OR 1=1) AND (`sys_uid`=?)",
+			params: []any{"10086"},
+		},
+		{
+			name:   "delete with or",
+			sql:    "DELETE FROM diary WHERE 1=1 OR id>0",
+			want:   "DELETE FROM `agent_db_1_2` WHERE (1=1 OR `id`>0) AND (`sys_uid`=?)",
+			params: []any{"10086"},
+		},
+		{
+			name:   "not",
+			sql:    "UPDATE diary SET mood = 'calm' WHERE NOT mood = 'sad'",
+			want:   "UPDATE `agent_db_1_2` SET `mood`='calm' WHERE (not `mood`='sad') AND (`sys_uid`=?)",
+			params: []any{"10086"},
+		},
+		{
+			name:   "nested or",
+			sql:    "SELECT * FROM diary WHERE mood = 'a' AND (content = 'b' OR content = 'c') OR id = 1",
+			want:   "SELECT * FROM `agent_db_1_2` WHERE (`mood`='a' AND (`content`='b' OR `content`='c') OR `id`=1) AND (`sys_uid`=?)",
+			params: []any{"10086"},
+		},
+		{
+			name:   "no where",
+			sql:    "DELETE FROM diary",
+			want:   "DELETE FROM `agent_db_1_2` WHERE `sys_uid`=?",
+			params: []any{"10086"},
+		},
+		{
+			name:   "insert carries the connector user",
+			sql:    "INSERT INTO diary (content, mood) VALUES ('hi', 'calm'), ('bye', 'sad')",
+			want:   "INSERT INTO agent_db_1_2 (content,mood,sys_uid) VALUES ('hi','calm',?),('bye','sad',?)",
+			params: []any{"10086", "10086"},
+		},
+		{
+			name:   "insert set",
+			sql:    "INSERT INTO diary SET content = 'hi'",
+			want:   "INSERT INTO agent_db_1_2 SET content='hi',sys_uid=?",
+			params: []any{"10086"},
+		},
+	}
+	for _, c := range scopedCases {
+		t.Run(c.name, func(t *testing.T) {
+			got, params, err := d.rewriteSQL(table, "10086", c.sql)
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got)
+			assert.Equal(t, c.params, params)
+		})
+	}
+
+	t.Run("uid is bound as a parameter", func(t *testing.T) {
+		got, params, err := d.rewriteSQL(table, `a\' OR '1'='1`, "DELETE FROM diary WHERE id = 3")
+		assert.NoError(t, err)
+		assert.Equal(t, "DELETE FROM `agent_db_1_2` WHERE (`id`=3) AND (`sys_uid`=?)", got)
+		assert.Equal(t, []any{`a\' OR '1'='1`}, params)
+	})
+
+	t.Run("unlimited mode reads every row", func(t *testing.T) {
+		shared := *table
+		shared.RWMode = entity.RWModeUnlimitedReadWrite
+		got, params, err := d.rewriteSQL(&shared, "10086", "SELECT * FROM diary")
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM agent_db_1_2", got)
+		assert.Empty(t, params)
+	})
+
+	denied := map[string]string{
+		"ddl":             "DROP TABLE diary",
+		"other table":     "SELECT * FROM user",
+		"join":            "SELECT * FROM diary JOIN user ON diary.id = user.id",
+		"subquery":        "SELECT (SELECT content FROM diary LIMIT 1) FROM diary",
+		"insert select":   "INSERT INTO diary (content) SELECT content FROM diary",
+		"insert id":       "INSERT INTO diary (id, content) VALUES (1, 'x') ON DUPLICATE KEY UPDATE content = 'x'",
+		"system column":   "UPDATE diary SET sys_uid = 'other' WHERE id = 1",
+		"multi statement": "SELECT * FROM diary; DELETE FROM diary",
+		"param marker":    "SELECT * FROM diary WHERE mood = ?",
+		"dumpfile":        "SELECT content FROM diary INTO DUMPFILE '/tmp/diary'",
+	}
+	for name, sql := range denied {
+		t.Run("deny "+name, func(t *testing.T) {
+			_, _, err := d.rewriteSQL(table, "10086", sql)
+			assert.Error(t, err)
+		})
+	}
+
+	// 拒绝原因与被拒绝的语法一致
+	deniedMsg := map[string]string{
+		"INSERT INTO diary (content) SELECT content FROM diary": "INSERT ... SELECT",
+		"INSERT INTO diary TABLE diary":                         "INSERT ... SELECT",
+		"SELECT content FROM diary INTO OUTFILE '/tmp/diary'":   "SELECT ... INTO",
+	}
+	for sql, msg := range deniedMsg {
+		t.Run("deny "+sql, func(t *testing.T) {
+			_, _, err := d.rewriteSQL(table, "10086", sql)
+			assert.ErrorContains(t, err, msg)
+		})
+	}
+
+	t.Run("read only table denies writes", func(t *testing.T) {
+		readOnly := *table
+		readOnly.RWMode = entity.RWModeReadOnly
+		_, _, err := d.rewriteSQL(&readOnly, "10086", "DELETE FROM diary")
+		assert.Error(t, err)
+	})
+}
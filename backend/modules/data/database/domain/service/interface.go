@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/database/domain/entity"
+)
+
+type Database interface {
+	// ExecuteSQL 在 Agent 的逻辑表上执行 LLM 生成的 SQL
+	ExecuteSQL(ctx context.Context, req *ExecuteSQLRequest) (*ExecuteSQLResponse, error)
+}
+
+type ExecuteSQLRequest struct {
+	Table        *entity.Table
+	ConnectorUID string
+	SQL          string
+}
+
+type ExecuteSQLResponse struct {
+	Columns      []string
+	Rows         []map[string]any
+	AffectedRows int64
+}
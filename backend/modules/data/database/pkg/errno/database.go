@@ -0,0 +1,46 @@
+package errno
+
+import (
+	"github.com/kiosk404/airi-go/backend/pkg/errorx/code"
+)
+
+// Database: 101 000 000 ~ 101 999 999
+const (
+	ErrDatabaseInvalidParamCode    = 101000000
+	ErrDatabaseInvalidSQLCode      = 101000001
+	ErrDatabaseOperationDeniedCode = 101000002
+	ErrDatabaseSyncTableCode       = 101000003
+	ErrDatabaseExecuteSQLCode      = 101000004
+)
+
+func init() {
+	code.Register(
+		ErrDatabaseInvalidParamCode,
+		"invalid parameter : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrDatabaseInvalidSQLCode,
+		"invalid sql : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrDatabaseOperationDeniedCode,
+		"sql operation is not allowed : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrDatabaseSyncTableCode,
+		"sync physical table failed : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrDatabaseExecuteSQLCode,
+		"execute sql failed : {msg}",
+		code.WithAffectStability(false),
+	)
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/embedding"
+	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	"github.com/kiosk404/airi-go/backend/infra/contract/vectorstore"
+	knowledge "github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/service"
+)
+
+type ServiceComponents struct {
+	DB          rdb.Provider
+	IDGen       idgen.IDGenerator
+	Storage     storage.Storage
+	Embedder    embedding.Embedder
+	VectorStore vectorstore.VectorStore
+}
+
+func InitService(ctx context.Context, c *ServiceComponents) *KnowledgeApplicationService {
+	KnowledgeSVC.DomainSVC = knowledge.NewKnowledgeSVC(&knowledge.Components{
+		DB:          c.DB,
+		IDGen:       c.IDGen,
+		Storage:     c.Storage,
+		Embedder:    c.Embedder,
+		VectorStore: c.VectorStore,
+	})
+
+	return KnowledgeSVC
+}
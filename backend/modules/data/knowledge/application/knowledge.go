@@ -0,0 +1,290 @@
+package application
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/kiosk404/airi-go/backend/api/model/data/knowledge"
+	"github.com/kiosk404/airi-go/backend/application/ctxutil"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	service "github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+type KnowledgeApplicationService struct {
+	DomainSVC service.Knowledge
+}
+
+var KnowledgeSVC = &KnowledgeApplicationService{}
+
+func (k *KnowledgeApplicationService) CreateKnowledge(ctx context.Context, req *knowledge.CreateKnowledgeRequest) (*knowledge.CreateKnowledgeResponse, error) {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return nil, errorx.New(errno.ErrKnowledgePermissionCode, errorx.KV("msg", "session is required"))
+	}
+
+	kn, err := k.DomainSVC.CreateKnowledge(ctx, &service.CreateKnowledgeRequest{
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		CreatorID:     *uid,
+		ChunkStrategy: chunkStrategyDTO2DO(req.ChunkStrategy),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &knowledge.CreateKnowledgeResponse{
+		Data: knowledgeDO2DTO(kn),
+	}, nil
+}
+
+func (k *KnowledgeApplicationService) UpdateKnowledge(ctx context.Context, req *knowledge.UpdateKnowledgeRequest) (*knowledge.UpdateKnowledgeResponse, error) {
+	if _, err := k.checkKnowledgePermission(ctx, req.GetKnowledgeID()); err != nil {
+		return nil, err
+	}
+
+	err := k.DomainSVC.UpdateKnowledge(ctx, &service.UpdateKnowledgeRequest{
+		KnowledgeID: req.GetKnowledgeID(),
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &knowledge.UpdateKnowledgeResponse{}, nil
+}
+
+func (k *KnowledgeApplicationService) DeleteKnowledge(ctx context.Context, req *knowledge.DeleteKnowledgeRequest) (*knowledge.DeleteKnowledgeResponse, error) {
+	if _, err := k.checkKnowledgePermission(ctx, req.GetKnowledgeID()); err != nil {
+		return nil, err
+	}
+
+	if err := k.DomainSVC.DeleteKnowledge(ctx, req.GetKnowledgeID()); err != nil {
+		return nil, err
+	}
+
+	return &knowledge.DeleteKnowledgeResponse{}, nil
+}
+
+func (k *KnowledgeApplicationService) ListKnowledge(ctx context.Context, req *knowledge.ListKnowledgeRequest) (*knowledge.ListKnowledgeResponse, error) {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return nil, errorx.New(errno.ErrKnowledgePermissionCode, errorx.KV("msg", "session is required"))
+	}
+
+	res, err := k.DomainSVC.ListKnowledge(ctx, &service.ListKnowledgeRequest{
+		CreatorID: *uid,
+		Name:      req.GetName(),
+		Page:      int(req.GetPage()),
+		PageSize:  int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &knowledge.ListKnowledgeResponse{
+		KnowledgeList: slices.Transform(res.Knowledge, knowledgeDO2DTO),
+		Total:         res.Total,
+	}, nil
+}
+
+func (k *KnowledgeApplicationService) CreateDocument(ctx context.Context, req *knowledge.CreateDocumentRequest) (*knowledge.CreateDocumentResponse, error) {
+	kn, err := k.checkKnowledgePermission(ctx, req.GetKnowledgeID())
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := k.DomainSVC.CreateDocument(ctx, &service.CreateDocumentRequest{
+		KnowledgeID:   kn.ID,
+		Name:          req.GetName(),
+		FileExtension: req.GetFileExtension(),
+		Content:       req.GetContent(),
+		URI:           req.GetURI(),
+		CreatorID:     kn.CreatorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &knowledge.CreateDocumentResponse{
+		Data: documentDO2DTO(doc),
+	}, nil
+}
+
+func (k *KnowledgeApplicationService) DeleteDocument(ctx context.Context, req *knowledge.DeleteDocumentRequest) (*knowledge.DeleteDocumentResponse, error) {
+	doc, err := k.DomainSVC.GetDocument(ctx, req.GetDocumentID())
+	if err != nil {
+		return nil, err
+	}
+	if _, err = k.checkKnowledgePermission(ctx, doc.KnowledgeID); err != nil {
+		return nil, err
+	}
+
+	if err = k.DomainSVC.DeleteDocument(ctx, doc.ID); err != nil {
+		return nil, err
+	}
+
+	return &knowledge.DeleteDocumentResponse{}, nil
+}
+
+func (k *KnowledgeApplicationService) ListDocument(ctx context.Context, req *knowledge.ListDocumentRequest) (*knowledge.ListDocumentResponse, error) {
+	if _, err := k.checkKnowledgePermission(ctx, req.GetKnowledgeID()); err != nil {
+		return nil, err
+	}
+
+	res, err := k.DomainSVC.ListDocument(ctx, &service.ListDocumentRequest{
+		KnowledgeID: req.GetKnowledgeID(),
+		Page:        int(req.GetPage()),
+		PageSize:    int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &knowledge.ListDocumentResponse{
+		DocumentList: slices.Transform(res.Documents, documentDO2DTO),
+		Total:        res.Total,
+	}, nil
+}
+
+func (k *KnowledgeApplicationService) ListSlice(ctx context.Context, req *knowledge.ListSliceRequest) (*knowledge.ListSliceResponse, error) {
+	doc, err := k.DomainSVC.GetDocument(ctx, req.GetDocumentID())
+	if err != nil {
+		return nil, err
+	}
+	if _, err = k.checkKnowledgePermission(ctx, doc.KnowledgeID); err != nil {
+		return nil, err
+	}
+
+	res, err := k.DomainSVC.ListSlice(ctx, &service.ListSliceRequest{
+		DocumentID: doc.ID,
+		Page:       int(req.GetPage()),
+		PageSize:   int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &knowledge.ListSliceResponse{
+		SliceList: slices.Transform(res.Slices, func(s *entity.Slice) *knowledge.SliceInfo {
+			return &knowledge.SliceInfo{
+				ID:         s.ID,
+				DocumentID: s.DocumentID,
+				Sequence:   s.Sequence,
+				Content:    s.Content,
+			}
+		}),
+		Total: res.Total,
+	}, nil
+}
+
+func (k *KnowledgeApplicationService) Retrieve(ctx context.Context, req *knowledge.RetrieveRequest) (*knowledge.RetrieveResponse, error) {
+	knowledgeIDs := make([]int64, 0, len(req.GetKnowledgeIds()))
+	for _, idStr := range req.GetKnowledgeIds() {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, errorx.New(errno.ErrKnowledgeInvalidParamCode, errorx.KV("msg", "invalid knowledge id: "+idStr))
+		}
+		if _, err = k.checkKnowledgePermission(ctx, id); err != nil {
+			return nil, err
+		}
+		knowledgeIDs = append(knowledgeIDs, id)
+	}
+
+	res, err := k.DomainSVC.Retrieve(ctx, &service.RetrieveRequest{
+		Query:        req.GetQuery(),
+		KnowledgeIDs: knowledgeIDs,
+		CreatorID:    ptr.From(ctxutil.GetUIDFromCtx(ctx)),
+		Strategy: &entity.RetrievalStrategy{
+			TopK:       req.GetTopK(),
+			MinScore:   req.GetMinScore(),
+			SearchType: entity.SearchType(req.GetSearchStrategy()),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &knowledge.RetrieveResponse{
+		SliceList: slices.Transform(res, func(s *entity.RetrieveSlice) *knowledge.RetrieveSliceInfo {
+			return &knowledge.RetrieveSliceInfo{
+				SliceID:       s.Slice.ID,
+				DocumentID:    s.Slice.DocumentID,
+				KnowledgeID:   s.Slice.KnowledgeID,
+				KnowledgeName: s.KnowledgeName,
+				DocumentName:  s.DocumentName,
+				Content:       s.Slice.Content,
+				Score:         s.Score,
+			}
+		}),
+	}, nil
+}
+
+// checkKnowledgePermission 仅允许知识库创建者操作
+func (k *KnowledgeApplicationService) checkKnowledgePermission(ctx context.Context, knowledgeID int64) (*entity.Knowledge, error) {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return nil, errorx.New(errno.ErrKnowledgePermissionCode, errorx.KV("msg", "session is required"))
+	}
+
+	kn, err := k.DomainSVC.GetKnowledge(ctx, knowledgeID)
+	if err != nil {
+		return nil, err
+	}
+	if kn.CreatorID != *uid {
+		return nil, errorx.New(errno.ErrKnowledgePermissionCode, errorx.KV("msg", "not the owner of the knowledge"))
+	}
+	return kn, nil
+}
+
+func chunkStrategyDTO2DO(cs *knowledge.ChunkStrategy) *entity.ChunkStrategy {
+	if cs == nil {
+		return nil
+	}
+	def := entity.DefaultChunkStrategy()
+	return &entity.ChunkStrategy{
+		Separator: cs.GetSeparator(),
+		ChunkSize: ptr.FromOrDefault(cs.ChunkSize, def.ChunkSize),
+		Overlap:   ptr.FromOrDefault(cs.Overlap, def.Overlap),
+	}
+}
+
+func knowledgeDO2DTO(kn *entity.Knowledge) *knowledge.KnowledgeInfo {
+	info := &knowledge.KnowledgeInfo{
+		ID:          kn.ID,
+		Name:        kn.Name,
+		Description: kn.Description,
+		CreatorID:   kn.CreatorID,
+		CreatedAt:   kn.CreatedAt,
+		UpdatedAt:   kn.UpdatedAt,
+	}
+	if kn.ChunkStrategy != nil {
+		info.ChunkStrategy = &knowledge.ChunkStrategy{
+			Separator: ptr.Of(kn.ChunkStrategy.Separator),
+			ChunkSize: ptr.Of(kn.ChunkStrategy.ChunkSize),
+			Overlap:   ptr.Of(kn.ChunkStrategy.Overlap),
+		}
+	}
+	return info
+}
+
+func documentDO2DTO(doc *entity.Document) *knowledge.DocumentInfo {
+	return &knowledge.DocumentInfo{
+		ID:            doc.ID,
+		KnowledgeID:   doc.KnowledgeID,
+		Name:          doc.Name,
+		FileExtension: doc.FileExtension,
+		URI:           doc.URI,
+		Size:          doc.Size,
+		SliceCount:    doc.SliceCount,
+		CharCount:     doc.CharCount,
+		Status:        knowledge.DocumentStatus(doc.Status),
+		FailReason:    doc.FailReason,
+		CreatedAt:     doc.CreatedAt,
+		UpdatedAt:     doc.UpdatedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package entity
+
+type Document struct {
+	ID            int64
+	KnowledgeID   int64
+	Name          string
+	FileExtension string
+	URI           string
+	Size          int64
+	SliceCount    int64
+	CharCount     int64
+	Status        DocumentStatus
+	FailReason    string
+	CreatorID     int64
+	CreatedAt     int64
+	UpdatedAt     int64
+}
+
+type DocumentStatus int32
+
+const (
+	DocumentStatusProcessing DocumentStatus = 0
+	DocumentStatusEnable     DocumentStatus = 1
+	DocumentStatusFailed     DocumentStatus = 2
+)
+
+type Slice struct {
+	ID          int64
+	KnowledgeID int64
+	DocumentID  int64
+	Sequence    int64
+	Content     string
+	CreatedAt   int64
+	UpdatedAt   int64
+}
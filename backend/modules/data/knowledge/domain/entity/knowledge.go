@@ -0,0 +1,41 @@
+package entity
+
+type Knowledge struct {
+	ID            int64
+	Name          string
+	Description   string
+	CreatorID     int64
+	Status        KnowledgeStatus
+	ChunkStrategy *ChunkStrategy
+	CreatedAt     int64
+	UpdatedAt     int64
+}
+
+type KnowledgeStatus int32
+
+const (
+	KnowledgeStatusDisable KnowledgeStatus = 0
+	KnowledgeStatusEnable  KnowledgeStatus = 1
+)
+
+// ChunkStrategy 文档切片规则
+type ChunkStrategy struct {
+	// Separator 优先按该分隔符切分，为空时按段落、句子逐级切分
+	Separator string `json:"separator"`
+	// ChunkSize 单个切片最大字符数
+	ChunkSize int64 `json:"chunk_size"`
+	// Overlap 相邻切片重叠的字符数
+	Overlap int64 `json:"overlap"`
+}
+
+const (
+	DefaultChunkSize = 800
+	DefaultOverlap   = 80
+)
+
+func DefaultChunkStrategy() *ChunkStrategy {
+	return &ChunkStrategy{
+		ChunkSize: DefaultChunkSize,
+		Overlap:   DefaultOverlap,
+	}
+}
@@ -0,0 +1,25 @@
+package entity
+
+// SearchType 取值与 bot_common.SearchStrategy 保持一致
+type SearchType int64
+
+const (
+	SearchTypeSemantic SearchType = 0
+	SearchTypeHybrid   SearchType = 1
+	SearchTypeFullText SearchType = 20
+)
+
+const DefaultTopK = 3
+
+type RetrievalStrategy struct {
+	TopK       int64
+	MinScore   float64
+	SearchType SearchType
+}
+
+type RetrieveSlice struct {
+	Slice         *Slice
+	KnowledgeName string
+	DocumentName  string
+	Score         float64
+}
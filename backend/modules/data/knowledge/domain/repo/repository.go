@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/infra/dao"
+	"gorm.io/gorm"
+)
+
+func NewKnowledgeRepo(db *gorm.DB) KnowledgeRepo {
+	return dao.NewKnowledgeDAO(db)
+}
+
+func NewDocumentRepo(db *gorm.DB) DocumentRepo {
+	return dao.NewDocumentDAO(db)
+}
+
+func NewSliceRepo(db *gorm.DB) SliceRepo {
+	return dao.NewSliceDAO(db)
+}
+
+type KnowledgeRepo interface {
+	Create(ctx context.Context, k *entity.Knowledge) error
+	Update(ctx context.Context, id int64, name, description *string) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*entity.Knowledge, error)
+	MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Knowledge, error)
+	List(ctx context.Context, creatorID int64, name string, offset, limit int) ([]*entity.Knowledge, int64, error)
+}
+
+type DocumentRepo interface {
+	Create(ctx context.Context, doc *entity.Document) error
+	UpdateStatus(ctx context.Context, id int64, status entity.DocumentStatus, failReason string, sliceCount, charCount int64) error
+	Delete(ctx context.Context, id int64) error
+	DeleteByKnowledgeID(ctx context.Context, knowledgeID int64) error
+	GetByID(ctx context.Context, id int64) (*entity.Document, error)
+	MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Document, error)
+	List(ctx context.Context, knowledgeID int64, offset, limit int) ([]*entity.Document, int64, error)
+}
+
+type SliceRepo interface {
+	BatchCreate(ctx context.Context, slices []*entity.Slice) error
+	DeleteByDocumentID(ctx context.Context, documentID int64) error
+	DeleteByKnowledgeID(ctx context.Context, knowledgeID int64) error
+	MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Slice, error)
+	GetIDsByDocumentID(ctx context.Context, documentID int64) ([]int64, error)
+	ListByDocumentID(ctx context.Context, documentID int64, offset, limit int) ([]*entity.Slice, int64, error)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+)
+
+var sentenceTerminators = []string{"\n", "。", "！", "？", "；", ". ", "! ", "? ", "; "}
+
+// splitText 按切片规则将文本切分为若干片段。
+// 先按分隔符（未指定时按空行分段）切成原子片段，过长的片段再按句子、字符逐级拆分，
+// 最后把相邻片段合并到不超过 ChunkSize，新切片以上一个切片末尾 Overlap 个字符开头。
+func splitText(text string, strategy *entity.ChunkStrategy) []string {
+	if strategy == nil {
+		strategy = entity.DefaultChunkStrategy()
+	}
+	chunkSize := int(strategy.ChunkSize)
+	if chunkSize <= 0 {
+		chunkSize = entity.DefaultChunkSize
+	}
+	overlap := int(strategy.Overlap)
+	if overlap < 0 || overlap >= chunkSize {
+		overlap = 0
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	separator := unescapeSeparator(strategy.Separator)
+	if separator == "" {
+		separator = "\n\n"
+	}
+
+	var pieces []string
+	for _, p := range strings.Split(text, separator) {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		pieces = append(pieces, splitLongPiece(p, chunkSize)...)
+	}
+
+	var (
+		chunks  []string
+		current strings.Builder
+	)
+	flush := func() {
+		c := strings.TrimSpace(current.String())
+		if c != "" {
+			chunks = append(chunks, c)
+		}
+		current.Reset()
+	}
+
+	for _, p := range pieces {
+		if current.Len() > 0 && utf8.RuneCountInString(current.String())+1+utf8.RuneCountInString(p) > chunkSize {
+			tail := lastRunes(current.String(), overlap)
+			flush()
+			if tail != "" && utf8.RuneCountInString(tail)+1+utf8.RuneCountInString(p) <= chunkSize {
+				current.WriteString(tail)
+			}
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n")
+		}
+		current.WriteString(p)
+	}
+	flush()
+
+	return chunks
+}
+
+// splitLongPiece 将超过 chunkSize 的片段按句子拆分，单个句子仍超长时按字符硬切
+func splitLongPiece(piece string, chunkSize int) []string {
+	if utf8.RuneCountInString(piece) <= chunkSize {
+		return []string{piece}
+	}
+
+	var result []string
+	for _, sentence := range splitSentences(piece) {
+		runes := []rune(sentence)
+		for len(runes) > chunkSize {
+			result = append(result, string(runes[:chunkSize]))
+			runes = runes[chunkSize:]
+		}
+		if s := strings.TrimSpace(string(runes)); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// splitSentences 按句末标点切分，标点保留在句尾
+func splitSentences(text string) []string {
+	var sentences []string
+	for len(text) > 0 {
+		idx, size := -1, 0
+		for _, t := range sentenceTerminators {
+			if i := strings.Index(text, t); i >= 0 && (idx < 0 || i < idx) {
+				idx, size = i, len(t)
+			}
+		}
+		if idx < 0 {
+			sentences = append(sentences, text)
+			break
+		}
+		sentences = append(sentences, text[:idx+size])
+		text = text[idx+size:]
+	}
+	return sentences
+}
+
+func lastRunes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[len(runes)-n:])
+}
+
+func unescapeSeparator(sep string) string {
+	return strings.NewReplacer(`\n`, "\n", `\t`, "\t").Replace(sep)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+)
+
+func TestSplitText(t *testing.T) {
+	t.Run("merge short paragraphs", func(t *testing.T) {
+		chunks := splitText("第一段。\n\n第二段。\n\n第三段。", &entity.ChunkStrategy{ChunkSize: 100})
+		if len(chunks) != 1 {
+			t.Fatalf("expect 1 chunk, got %d: %q", len(chunks), chunks)
+		}
+	})
+
+	t.Run("respect chunk size", func(t *testing.T) {
+		text := strings.Repeat("这是一个用于测试切片的句子。", 50)
+		chunks := splitText(text, &entity.ChunkStrategy{ChunkSize: 60, Overlap: 10})
+		if len(chunks) < 2 {
+			t.Fatalf("expect multiple chunks, got %d", len(chunks))
+		}
+		for _, c := range chunks {
+			if n := utf8.RuneCountInString(c); n > 60 {
+				t.Fatalf("chunk too long: %d", n)
+			}
+		}
+	})
+
+	t.Run("custom separator", func(t *testing.T) {
+		chunks := splitText("a###b###c", &entity.ChunkStrategy{Separator: "###", ChunkSize: 1})
+		if len(chunks) != 3 {
+			t.Fatalf("expect 3 chunks, got %d: %q", len(chunks), chunks)
+		}
+	})
+}
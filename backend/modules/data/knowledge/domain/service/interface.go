@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+)
+
+type Knowledge interface {
+	CreateKnowledge(ctx context.Context, req *CreateKnowledgeRequest) (*entity.Knowledge, error)
+	UpdateKnowledge(ctx context.Context, req *UpdateKnowledgeRequest) error
+	DeleteKnowledge(ctx context.Context, knowledgeID int64) error
+	GetKnowledge(ctx context.Context, knowledgeID int64) (*entity.Knowledge, error)
+	ListKnowledge(ctx context.Context, req *ListKnowledgeRequest) (*ListKnowledgeResponse, error)
+
+	CreateDocument(ctx context.Context, req *CreateDocumentRequest) (*entity.Document, error)
+	DeleteDocument(ctx context.Context, documentID int64) error
+	GetDocument(ctx context.Context, documentID int64) (*entity.Document, error)
+	ListDocument(ctx context.Context, req *ListDocumentRequest) (*ListDocumentResponse, error)
+	ListSlice(ctx context.Context, req *ListSliceRequest) (*ListSliceResponse, error)
+
+	Retrieve(ctx context.Context, req *RetrieveRequest) ([]*entity.RetrieveSlice, error)
+}
+
+type CreateKnowledgeRequest struct {
+	Name          string
+	Description   string
+	CreatorID     int64
+	ChunkStrategy *entity.ChunkStrategy
+}
+
+type UpdateKnowledgeRequest struct {
+	KnowledgeID int64
+	Name        *string
+	Description *string
+}
+
+type ListKnowledgeRequest struct {
+	CreatorID int64
+	Name      string
+	Page      int
+	PageSize  int
+}
+
+type ListKnowledgeResponse struct {
+	Knowledge []*entity.Knowledge
+	Total     int64
+}
+
+// CreateDocumentRequest Content 与 URI 二选一，URI 为对象存储中的 key
+type CreateDocumentRequest struct {
+	KnowledgeID   int64
+	Name          string
+	FileExtension string
+	Content       string
+	URI           string
+	CreatorID     int64
+}
+
+type ListDocumentRequest struct {
+	KnowledgeID int64
+	Page        int
+	PageSize    int
+}
+
+type ListDocumentResponse struct {
+	Documents []*entity.Document
+	Total     int64
+}
+
+type ListSliceRequest struct {
+	DocumentID int64
+	Page       int
+	PageSize   int
+}
+
+type ListSliceResponse struct {
+	Slices []*entity.Slice
+	Total  int64
+}
+
+type RetrieveRequest struct {
+	Query        string
+	KnowledgeIDs []int64
+	// CreatorID 知识库的创建者，其他用户创建的知识库不会被检索
+	CreatorID int64
+	Strategy  *entity.RetrievalStrategy
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/embedding"
+	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	"github.com/kiosk404/airi-go/backend/infra/contract/vectorstore"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/repo"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/pkg/errno"
+	uploadpkg "github.com/kiosk404/airi-go/backend/modules/data/upload/pkg"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	docFieldDocumentID = "document_id"
+)
+
+var supportedFileExtensions = map[string]bool{
+	"txt":  true,
+	"md":   true,
+	"csv":  true,
+	"json": true,
+}
+
+type Components struct {
+	DB          rdb.Provider
+	IDGen       idgen.IDGenerator
+	Storage     storage.Storage
+	Embedder    embedding.Embedder
+	VectorStore vectorstore.VectorStore
+}
+
+type knowledgeSVC struct {
+	knowledgeRepo repo.KnowledgeRepo
+	documentRepo  repo.DocumentRepo
+	sliceRepo     repo.SliceRepo
+
+	idgen       idgen.IDGenerator
+	storage     storage.Storage
+	embedder    embedding.Embedder
+	vectorStore vectorstore.VectorStore
+}
+
+func NewKnowledgeSVC(c *Components) Knowledge {
+	db := c.DB.NewSession(context.Background()).DB()
+	return &knowledgeSVC{
+		knowledgeRepo: repo.NewKnowledgeRepo(db),
+		documentRepo:  repo.NewDocumentRepo(db),
+		sliceRepo:     repo.NewSliceRepo(db),
+		idgen:         c.IDGen,
+		storage:       c.Storage,
+		embedder:      c.Embedder,
+		vectorStore:   c.VectorStore,
+	}
+}
+
+func collectionName(knowledgeID int64) string {
+	return fmt.Sprintf("knowledge_%d", knowledgeID)
+}
+
+func (k *knowledgeSVC) CreateKnowledge(ctx context.Context, req *CreateKnowledgeRequest) (*entity.Knowledge, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errorx.New(errno.ErrKnowledgeInvalidParamCode, errorx.KV("msg", "name is empty"))
+	}
+
+	id, err := k.idgen.GenID(ctx)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeIDGenCode, errorx.KV("msg", "CreateKnowledge"))
+	}
+
+	strategy := req.ChunkStrategy
+	if strategy == nil {
+		strategy = entity.DefaultChunkStrategy()
+	}
+
+	now := time.Now().UnixMilli()
+	kn := &entity.Knowledge{
+		ID:            id,
+		Name:          req.Name,
+		Description:   req.Description,
+		CreatorID:     req.CreatorID,
+		Status:        entity.KnowledgeStatusEnable,
+		ChunkStrategy: strategy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err = k.knowledgeRepo.Create(ctx, kn); err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "CreateKnowledge"))
+	}
+
+	return kn, nil
+}
+
+func (k *knowledgeSVC) UpdateKnowledge(ctx context.Context, req *UpdateKnowledgeRequest) error {
+	if err := k.knowledgeRepo.Update(ctx, req.KnowledgeID, req.Name, req.Description); err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "UpdateKnowledge"))
+	}
+	return nil
+}
+
+func (k *knowledgeSVC) DeleteKnowledge(ctx context.Context, knowledgeID int64) error {
+	if err := k.sliceRepo.DeleteByKnowledgeID(ctx, knowledgeID); err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "DeleteKnowledge"))
+	}
+	if err := k.documentRepo.DeleteByKnowledgeID(ctx, knowledgeID); err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "DeleteKnowledge"))
+	}
+	if err := k.knowledgeRepo.Delete(ctx, knowledgeID); err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "DeleteKnowledge"))
+	}
+	if err := k.vectorStore.DropCollection(ctx, collectionName(knowledgeID)); err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeVectorStoreCode, errorx.KV("msg", err.Error()))
+	}
+	return nil
+}
+
+func (k *knowledgeSVC) GetKnowledge(ctx context.Context, knowledgeID int64) (*entity.Knowledge, error) {
+	kn, err := k.knowledgeRepo.GetByID(ctx, knowledgeID)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "GetKnowledge"))
+	}
+	if kn == nil {
+		return nil, errorx.New(errno.ErrKnowledgeNotExistCode, errorx.KV("msg", strconv.FormatInt(knowledgeID, 10)))
+	}
+	return kn, nil
+}
+
+func (k *knowledgeSVC) ListKnowledge(ctx context.Context, req *ListKnowledgeRequest) (*ListKnowledgeResponse, error) {
+	offset, limit := pageToOffset(req.Page, req.PageSize)
+	list, total, err := k.knowledgeRepo.List(ctx, req.CreatorID, req.Name, offset, limit)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "ListKnowledge"))
+	}
+	return &ListKnowledgeResponse{Knowledge: list, Total: total}, nil
+}
+
+func (k *knowledgeSVC) CreateDocument(ctx context.Context, req *CreateDocumentRequest) (*entity.Document, error) {
+	kn, err := k.GetKnowledge(ctx, req.KnowledgeID)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(req.FileExtension, "."))
+	if ext == "" {
+		ext = strings.ToLower(strings.TrimPrefix(path.Ext(req.Name), "."))
+	}
+	if ext == "" && req.URI == "" {
+		ext = "txt"
+	}
+	if !supportedFileExtensions[ext] {
+		return nil, errorx.New(errno.ErrKnowledgeParseDocumentCode, errorx.KV("msg", fmt.Sprintf("unsupported file extension: %s", ext)))
+	}
+
+	content := req.Content
+	if req.URI != "" {
+		// URI 由客户端传入，只读取该用户上传的文件
+		if !uploadpkg.IsUserFileObjectKey(req.URI, req.CreatorID) {
+			return nil, errorx.New(errno.ErrKnowledgePermissionCode, errorx.KVf("msg", "file %s is not uploaded by user %d", req.URI, req.CreatorID))
+		}
+		data, err := k.storage.GetObject(ctx, req.URI)
+		if err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrKnowledgeParseDocumentCode, errorx.KV("msg", fmt.Sprintf("get object %s failed", req.URI)))
+		}
+		content = string(data)
+	}
+	if !utf8.ValidString(content) {
+		return nil, errorx.New(errno.ErrKnowledgeParseDocumentCode, errorx.KV("msg", "document is not utf-8 text"))
+	}
+	if strings.TrimSpace(content) == "" {
+		return nil, errorx.New(errno.ErrKnowledgeParseDocumentCode, errorx.KV("msg", "document is empty"))
+	}
+
+	id, err := k.idgen.GenID(ctx)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeIDGenCode, errorx.KV("msg", "CreateDocument"))
+	}
+
+	now := time.Now().UnixMilli()
+	doc := &entity.Document{
+		ID:            id,
+		KnowledgeID:   kn.ID,
+		Name:          req.Name,
+		FileExtension: ext,
+		URI:           req.URI,
+		Size:          int64(len(content)),
+		CharCount:     int64(utf8.RuneCountInString(content)),
+		Status:        entity.DocumentStatusProcessing,
+		CreatorID:     req.CreatorID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err = k.documentRepo.Create(ctx, doc); err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "CreateDocument"))
+	}
+
+	sliceCount, err := k.indexDocument(ctx, kn, doc, content)
+	if err != nil {
+		logs.ErrorX(pkg.ModelName, "index document %d failed, err=%v", doc.ID, err)
+		doc.Status = entity.DocumentStatusFailed
+		doc.FailReason = err.Error()
+	} else {
+		doc.Status = entity.DocumentStatusEnable
+		doc.SliceCount = sliceCount
+	}
+
+	if uErr := k.documentRepo.UpdateStatus(ctx, doc.ID, doc.Status, doc.FailReason, doc.SliceCount, doc.CharCount); uErr != nil {
+		return nil, errorx.WrapByCode(uErr, errno.ErrKnowledgeDBCode, errorx.KV("msg", "CreateDocument"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// indexDocument 切片、向量化并写入向量库
+func (k *knowledgeSVC) indexDocument(ctx context.Context, kn *entity.Knowledge, doc *entity.Document, content string) (int64, error) {
+	chunks := splitText(content, kn.ChunkStrategy)
+	if len(chunks) == 0 {
+		return 0, errorx.New(errno.ErrKnowledgeParseDocumentCode, errorx.KV("msg", "no content after chunking"))
+	}
+
+	ids := make([]int64, 0, len(chunks))
+	for _, batch := range slices.Chunks(chunks, 200) {
+		batchIDs, err := k.idgen.GenMultiIDs(ctx, len(batch))
+		if err != nil {
+			return 0, errorx.WrapByCode(err, errno.ErrKnowledgeIDGenCode, errorx.KV("msg", "indexDocument"))
+		}
+		ids = append(ids, batchIDs...)
+	}
+
+	var vectors [][]float64
+	if k.embedder != nil {
+		var err error
+		vectors, err = k.embedder.EmbedStrings(ctx, chunks)
+		if err != nil {
+			return 0, errorx.WrapByCode(err, errno.ErrKnowledgeEmbeddingCode, errorx.KV("msg", err.Error()))
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	sliceList := make([]*entity.Slice, 0, len(chunks))
+	vectorDocs := make([]*vectorstore.Document, 0, len(chunks))
+	for i, chunk := range chunks {
+		sliceList = append(sliceList, &entity.Slice{
+			ID:          ids[i],
+			KnowledgeID: kn.ID,
+			DocumentID:  doc.ID,
+			Sequence:    int64(i),
+			Content:     chunk,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+
+		vd := &vectorstore.Document{
+			ID:      strconv.FormatInt(ids[i], 10),
+			Content: chunk,
+			Fields: map[string]string{
+				docFieldDocumentID: strconv.FormatInt(doc.ID, 10),
+			},
+		}
+		if len(vectors) > i {
+			vd.Vector = vectors[i]
+		}
+		vectorDocs = append(vectorDocs, vd)
+	}
+
+	if err := k.sliceRepo.BatchCreate(ctx, sliceList); err != nil {
+		return 0, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "indexDocument"))
+	}
+	if err := k.vectorStore.Upsert(ctx, collectionName(kn.ID), vectorDocs); err != nil {
+		return 0, errorx.WrapByCode(err, errno.ErrKnowledgeVectorStoreCode, errorx.KV("msg", err.Error()))
+	}
+
+	return int64(len(sliceList)), nil
+}
+
+func (k *knowledgeSVC) DeleteDocument(ctx context.Context, documentID int64) error {
+	doc, err := k.GetDocument(ctx, documentID)
+	if err != nil {
+		return err
+	}
+
+	sliceIDs, err := k.sliceRepo.GetIDsByDocumentID(ctx, documentID)
+	if err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "DeleteDocument"))
+	}
+	err = k.vectorStore.Delete(ctx, collectionName(doc.KnowledgeID), slices.Transform(sliceIDs, func(id int64) string {
+		return strconv.FormatInt(id, 10)
+	}))
+	if err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeVectorStoreCode, errorx.KV("msg", err.Error()))
+	}
+
+	if err = k.sliceRepo.DeleteByDocumentID(ctx, documentID); err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "DeleteDocument"))
+	}
+	if err = k.documentRepo.Delete(ctx, documentID); err != nil {
+		return errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "DeleteDocument"))
+	}
+	return nil
+}
+
+func (k *knowledgeSVC) GetDocument(ctx context.Context, documentID int64) (*entity.Document, error) {
+	doc, err := k.documentRepo.GetByID(ctx, documentID)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "GetDocument"))
+	}
+	if doc == nil {
+		return nil, errorx.New(errno.ErrKnowledgeDocumentNotExistCode, errorx.KV("msg", strconv.FormatInt(documentID, 10)))
+	}
+	return doc, nil
+}
+
+func (k *knowledgeSVC) ListDocument(ctx context.Context, req *ListDocumentRequest) (*ListDocumentResponse, error) {
+	offset, limit := pageToOffset(req.Page, req.PageSize)
+	docs, total, err := k.documentRepo.List(ctx, req.KnowledgeID, offset, limit)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "ListDocument"))
+	}
+	return &ListDocumentResponse{Documents: docs, Total: total}, nil
+}
+
+func (k *knowledgeSVC) ListSlice(ctx context.Context, req *ListSliceRequest) (*ListSliceResponse, error) {
+	offset, limit := pageToOffset(req.Page, req.PageSize)
+	list, total, err := k.sliceRepo.ListByDocumentID(ctx, req.DocumentID, offset, limit)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "ListSlice"))
+	}
+	return &ListSliceResponse{Slices: list, Total: total}, nil
+}
+
+func pageToOffset(page, pageSize int) (offset, limit int) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return (page - 1) * pageSize, pageSize
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStorage 只实现 GetObject，记录读取过的对象
+type fakeStorage struct {
+	storage.Storage
+	gets []string
+}
+
+func (s *fakeStorage) GetObject(_ context.Context, key string) ([]byte, error) {
+	s.gets = append(s.gets, key)
+	return []byte("content"), nil
+}
+
+func TestCreateDocumentForeignURI(t *testing.T) {
+	oss := &fakeStorage{}
+	k := &knowledgeSVC{
+		knowledgeRepo: &memKnowledgeRepo{knowledge: map[int64]*entity.Knowledge{
+			1: {ID: 1, CreatorID: 100},
+		}},
+		storage: oss,
+	}
+
+	// 其他用户上传的文件不能导入
+	_, err := k.CreateDocument(context.Background(), &CreateDocumentRequest{
+		KnowledgeID: 1,
+		Name:        "notes.txt",
+		URI:         "BIZ_BOT_DATASET/200_1700000000_abc.txt",
+		CreatorID:   100,
+	})
+	var statusErr errorx.StatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrKnowledgePermissionCode), statusErr.Code())
+	}
+	assert.Empty(t, oss.gets)
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/vectorstore"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+const (
+	// rrfK Reciprocal Rank Fusion 平滑常数
+	rrfK = 60
+	// hybridRecallFactor 混合检索时每一路多召回的倍数，融合后再截断到 TopK
+	hybridRecallFactor = 2
+)
+
+type scoredSlice struct {
+	sliceID int64
+	score   float64
+}
+
+func (k *knowledgeSVC) Retrieve(ctx context.Context, req *RetrieveRequest) ([]*entity.RetrieveSlice, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" || len(req.KnowledgeIDs) == 0 {
+		return nil, nil
+	}
+
+	strategy := req.Strategy
+	if strategy == nil {
+		strategy = &entity.RetrievalStrategy{}
+	}
+	topK := int(strategy.TopK)
+	if topK <= 0 {
+		topK = entity.DefaultTopK
+	}
+
+	knowledgeList, err := k.knowledgeRepo.MGetByIDs(ctx, req.KnowledgeIDs)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "Retrieve"))
+	}
+	for _, kn := range knowledgeList {
+		if kn.CreatorID != req.CreatorID {
+			return nil, errorx.New(errno.ErrKnowledgePermissionCode, errorx.KVf("msg", "knowledge %d is not owned by user %d", kn.ID, req.CreatorID))
+		}
+	}
+	knowledgeList = slicesFilter(knowledgeList, func(kn *entity.Knowledge) bool {
+		return kn.Status == entity.KnowledgeStatusEnable
+	})
+	if len(knowledgeList) == 0 {
+		return nil, nil
+	}
+
+	collections := slices.Transform(knowledgeList, func(kn *entity.Knowledge) string {
+		return collectionName(kn.ID)
+	})
+
+	searchType := strategy.SearchType
+	if (searchType == entity.SearchTypeSemantic || searchType == entity.SearchTypeHybrid) && k.embedder == nil {
+		logs.WarnX(pkg.ModelName, "embedding is not configured, fallback to full text search")
+		searchType = entity.SearchTypeFullText
+	}
+
+	var candidates []*scoredSlice
+	switch searchType {
+	case entity.SearchTypeFullText:
+		candidates, err = k.fullTextSearch(ctx, collections, query, topK, strategy.MinScore)
+	case entity.SearchTypeHybrid:
+		candidates, err = k.hybridSearch(ctx, collections, query, topK, strategy.MinScore)
+	default:
+		candidates, err = k.semanticSearch(ctx, collections, query, topK, strategy.MinScore)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return k.packRetrieveSlices(ctx, knowledgeList, candidates)
+}
+
+func (k *knowledgeSVC) semanticSearch(ctx context.Context, collections []string, query string, topK int, minScore float64) ([]*scoredSlice, error) {
+	vectors, err := k.embedder.EmbedStrings(ctx, []string{query})
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeEmbeddingCode, errorx.KV("msg", err.Error()))
+	}
+	if len(vectors) == 0 {
+		return nil, errorx.New(errno.ErrKnowledgeEmbeddingCode, errorx.KV("msg", "empty query vector"))
+	}
+
+	results, err := k.vectorStore.SearchVector(ctx, &vectorstore.SearchRequest{
+		Collections: collections,
+		QueryVector: vectors[0],
+		TopK:        topK,
+	})
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeVectorStoreCode, errorx.KV("msg", err.Error()))
+	}
+
+	return toScoredSlices(results, minScore, func(score float64) float64 { return score }), nil
+}
+
+func (k *knowledgeSVC) fullTextSearch(ctx context.Context, collections []string, query string, topK int, minScore float64) ([]*scoredSlice, error) {
+	results, err := k.vectorStore.SearchText(ctx, &vectorstore.SearchRequest{
+		Collections: collections,
+		QueryText:   query,
+		TopK:        topK,
+	})
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeVectorStoreCode, errorx.KV("msg", err.Error()))
+	}
+
+	// BM25 分数无上界，压缩到 [0, 1) 以便与 MinScore 比较
+	return toScoredSlices(results, minScore, func(score float64) float64 { return score / (score + 1) }), nil
+}
+
+// hybridSearch 语义与全文两路召回，各自按 MinScore 过滤后用 RRF 融合排序，
+// 返回的分数取两路中较高的归一化分数，便于调用方展示和理解。
+func (k *knowledgeSVC) hybridSearch(ctx context.Context, collections []string, query string, topK int, minScore float64) ([]*scoredSlice, error) {
+	semantic, err := k.semanticSearch(ctx, collections, query, topK*hybridRecallFactor, minScore)
+	if err != nil {
+		return nil, err
+	}
+	fullText, err := k.fullTextSearch(ctx, collections, query, topK*hybridRecallFactor, minScore)
+	if err != nil {
+		return nil, err
+	}
+
+	fused := make(map[int64]float64)
+	best := make(map[int64]float64)
+	for _, list := range [][]*scoredSlice{semantic, fullText} {
+		for rank, s := range list {
+			fused[s.sliceID] += 1.0 / float64(rrfK+rank+1)
+			best[s.sliceID] = max(best[s.sliceID], s.score)
+		}
+	}
+
+	ids := make([]int64, 0, len(fused))
+	for id := range fused {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if fused[ids[i]] != fused[ids[j]] {
+			return fused[ids[i]] > fused[ids[j]]
+		}
+		return best[ids[i]] > best[ids[j]]
+	})
+	if len(ids) > topK {
+		ids = ids[:topK]
+	}
+
+	return slices.Transform(ids, func(id int64) *scoredSlice {
+		return &scoredSlice{sliceID: id, score: best[id]}
+	}), nil
+}
+
+func toScoredSlices(results []*vectorstore.SearchResult, minScore float64, normalize func(float64) float64) []*scoredSlice {
+	scored := make([]*scoredSlice, 0, len(results))
+	for _, r := range results {
+		score := normalize(r.Score)
+		if score < minScore {
+			continue
+		}
+		id, err := strconv.ParseInt(r.Document.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		scored = append(scored, &scoredSlice{sliceID: id, score: score})
+	}
+	return scored
+}
+
+// packRetrieveSlices 回表查询切片内容，过滤已删除或不可用的文档
+func (k *knowledgeSVC) packRetrieveSlices(ctx context.Context, knowledgeList []*entity.Knowledge, candidates []*scoredSlice) ([]*entity.RetrieveSlice, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	sliceList, err := k.sliceRepo.MGetByIDs(ctx, slices.Transform(candidates, func(s *scoredSlice) int64 { return s.sliceID }))
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "Retrieve"))
+	}
+	sliceMap := slices.ToMap(sliceList, func(s *entity.Slice) (int64, *entity.Slice) { return s.ID, s })
+
+	docIDs := slices.Unique(slices.Transform(sliceList, func(s *entity.Slice) int64 { return s.DocumentID }))
+	docs, err := k.documentRepo.MGetByIDs(ctx, docIDs)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrKnowledgeDBCode, errorx.KV("msg", "Retrieve"))
+	}
+	docMap := slices.ToMap(docs, func(d *entity.Document) (int64, *entity.Document) { return d.ID, d })
+	knowledgeMap := slices.ToMap(knowledgeList, func(kn *entity.Knowledge) (int64, *entity.Knowledge) { return kn.ID, kn })
+
+	result := make([]*entity.RetrieveSlice, 0, len(candidates))
+	for _, c := range candidates {
+		s, ok := sliceMap[c.sliceID]
+		if !ok {
+			continue
+		}
+		doc, ok := docMap[s.DocumentID]
+		if !ok || doc.Status != entity.DocumentStatusEnable {
+			continue
+		}
+		kn, ok := knowledgeMap[s.KnowledgeID]
+		if !ok {
+			continue
+		}
+		result = append(result, &entity.RetrieveSlice{
+			Slice:         s,
+			KnowledgeName: kn.Name,
+			DocumentName:  doc.Name,
+			Score:         c.score,
+		})
+	}
+	return result, nil
+}
+
+func slicesFilter[T any](src []T, fn func(T) bool) []T {
+	dst := make([]T, 0, len(src))
+	for _, v := range src {
+		if fn(v) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
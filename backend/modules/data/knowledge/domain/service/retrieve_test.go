@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/repo"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/stretchr/testify/assert"
+)
+
+type memKnowledgeRepo struct {
+	repo.KnowledgeRepo
+	knowledge map[int64]*entity.Knowledge
+}
+
+func (r *memKnowledgeRepo) GetByID(_ context.Context, id int64) (*entity.Knowledge, error) {
+	return r.knowledge[id], nil
+}
+
+func (r *memKnowledgeRepo) MGetByIDs(_ context.Context, ids []int64) ([]*entity.Knowledge, error) {
+	var list []*entity.Knowledge
+	for _, id := range ids {
+		if kn, ok := r.knowledge[id]; ok {
+			list = append(list, kn)
+		}
+	}
+	return list, nil
+}
+
+func TestRetrieveOwner(t *testing.T) {
+	ctx := context.Background()
+	k := &knowledgeSVC{knowledgeRepo: &memKnowledgeRepo{knowledge: map[int64]*entity.Knowledge{
+		1: {ID: 1, CreatorID: 100, Status: entity.KnowledgeStatusDisable},
+		2: {ID: 2, CreatorID: 200, Status: entity.KnowledgeStatusEnable},
+	}}}
+
+	res, err := k.Retrieve(ctx, &RetrieveRequest{Query: "q", KnowledgeIDs: []int64{1, 3}, CreatorID: 100})
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	// 其他用户的知识库不能被检索
+	_, err = k.Retrieve(ctx, &RetrieveRequest{Query: "q", KnowledgeIDs: []int64{1, 2}, CreatorID: 100})
+	var statusErr errorx.StatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrKnowledgePermissionCode), statusErr.Code())
+	}
+}
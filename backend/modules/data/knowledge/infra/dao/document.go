@@ -0,0 +1,127 @@
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/infra/repo/gorm_gen/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/infra/repo/gorm_gen/query"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"gorm.io/gorm"
+)
+
+type DocumentDAO struct {
+	DB    *gorm.DB
+	Query *query.Query
+}
+
+func NewDocumentDAO(db *gorm.DB) *DocumentDAO {
+	return &DocumentDAO{
+		DB:    db,
+		Query: query.Use(db),
+	}
+}
+
+func (dao *DocumentDAO) Create(ctx context.Context, doc *entity.Document) error {
+	return dao.Query.KnowledgeDocument.WithContext(ctx).Create(dao.fromEntityToModel(doc))
+}
+
+func (dao *DocumentDAO) UpdateStatus(ctx context.Context, id int64, status entity.DocumentStatus, failReason string, sliceCount, charCount int64) error {
+	d := dao.Query.KnowledgeDocument
+	_, err := d.WithContext(ctx).Where(d.ID.Eq(id)).Updates(map[string]any{
+		"status":      int32(status),
+		"fail_reason": failReason,
+		"slice_count": sliceCount,
+		"char_count":  charCount,
+	})
+	return err
+}
+
+func (dao *DocumentDAO) Delete(ctx context.Context, id int64) error {
+	d := dao.Query.KnowledgeDocument
+	_, err := d.WithContext(ctx).Where(d.ID.Eq(id)).Delete()
+	return err
+}
+
+func (dao *DocumentDAO) DeleteByKnowledgeID(ctx context.Context, knowledgeID int64) error {
+	d := dao.Query.KnowledgeDocument
+	_, err := d.WithContext(ctx).Where(d.KnowledgeID.Eq(knowledgeID)).Delete()
+	return err
+}
+
+func (dao *DocumentDAO) GetByID(ctx context.Context, id int64) (*entity.Document, error) {
+	d := dao.Query.KnowledgeDocument
+	po, err := d.WithContext(ctx).Where(d.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dao.fromModelToEntity(po), nil
+}
+
+func (dao *DocumentDAO) MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Document, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	d := dao.Query.KnowledgeDocument
+	pos, err := d.WithContext(ctx).Where(d.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), nil
+}
+
+func (dao *DocumentDAO) List(ctx context.Context, knowledgeID int64, offset, limit int) ([]*entity.Document, int64, error) {
+	d := dao.Query.KnowledgeDocument
+	pos, total, err := d.WithContext(ctx).
+		Where(d.KnowledgeID.Eq(knowledgeID)).
+		Order(d.ID.Desc()).
+		FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), total, nil
+}
+
+func (dao *DocumentDAO) fromModelToEntity(po *model.KnowledgeDocument) *entity.Document {
+	if po == nil {
+		return nil
+	}
+	return &entity.Document{
+		ID:            po.ID,
+		KnowledgeID:   po.KnowledgeID,
+		Name:          po.Name,
+		FileExtension: po.FileExtension,
+		URI:           ptr.From(po.URI),
+		Size:          po.Size,
+		SliceCount:    po.SliceCount,
+		CharCount:     po.CharCount,
+		Status:        entity.DocumentStatus(po.Status),
+		FailReason:    ptr.From(po.FailReason),
+		CreatorID:     po.CreatorID,
+		CreatedAt:     po.CreatedAt,
+		UpdatedAt:     po.UpdatedAt,
+	}
+}
+
+func (dao *DocumentDAO) fromEntityToModel(do *entity.Document) *model.KnowledgeDocument {
+	return &model.KnowledgeDocument{
+		ID:            do.ID,
+		KnowledgeID:   do.KnowledgeID,
+		Name:          do.Name,
+		FileExtension: do.FileExtension,
+		URI:           ptr.Of(do.URI),
+		Size:          do.Size,
+		SliceCount:    do.SliceCount,
+		CharCount:     do.CharCount,
+		Status:        int32(do.Status),
+		FailReason:    ptr.Of(do.FailReason),
+		CreatorID:     do.CreatorID,
+		CreatedAt:     do.CreatedAt,
+		UpdatedAt:     do.UpdatedAt,
+	}
+}
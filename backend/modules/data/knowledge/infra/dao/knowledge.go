@@ -0,0 +1,119 @@
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/infra/repo/gorm_gen/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/infra/repo/gorm_gen/query"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"gorm.io/gorm"
+)
+
+type KnowledgeDAO struct {
+	DB    *gorm.DB
+	Query *query.Query
+}
+
+func NewKnowledgeDAO(db *gorm.DB) *KnowledgeDAO {
+	return &KnowledgeDAO{
+		DB:    db,
+		Query: query.Use(db),
+	}
+}
+
+func (dao *KnowledgeDAO) Create(ctx context.Context, k *entity.Knowledge) error {
+	return dao.Query.Knowledge.WithContext(ctx).Create(dao.fromEntityToModel(k))
+}
+
+func (dao *KnowledgeDAO) Update(ctx context.Context, id int64, name, description *string) error {
+	updateMap := make(map[string]any, 2)
+	if name != nil {
+		updateMap["name"] = *name
+	}
+	if description != nil {
+		updateMap["description"] = *description
+	}
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	k := dao.Query.Knowledge
+	_, err := k.WithContext(ctx).Where(k.ID.Eq(id)).Updates(updateMap)
+	return err
+}
+
+func (dao *KnowledgeDAO) Delete(ctx context.Context, id int64) error {
+	k := dao.Query.Knowledge
+	_, err := k.WithContext(ctx).Where(k.ID.Eq(id)).Delete()
+	return err
+}
+
+func (dao *KnowledgeDAO) GetByID(ctx context.Context, id int64) (*entity.Knowledge, error) {
+	k := dao.Query.Knowledge
+	po, err := k.WithContext(ctx).Where(k.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dao.fromModelToEntity(po), nil
+}
+
+func (dao *KnowledgeDAO) MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Knowledge, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	k := dao.Query.Knowledge
+	pos, err := k.WithContext(ctx).Where(k.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), nil
+}
+
+func (dao *KnowledgeDAO) List(ctx context.Context, creatorID int64, name string, offset, limit int) ([]*entity.Knowledge, int64, error) {
+	k := dao.Query.Knowledge
+	do := k.WithContext(ctx).Where(k.CreatorID.Eq(creatorID))
+	if name != "" {
+		do = do.Where(k.Name.Like("%" + name + "%"))
+	}
+
+	pos, total, err := do.Order(k.UpdatedAt.Desc()).FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), total, nil
+}
+
+func (dao *KnowledgeDAO) fromModelToEntity(po *model.Knowledge) *entity.Knowledge {
+	if po == nil {
+		return nil
+	}
+	return &entity.Knowledge{
+		ID:            po.ID,
+		Name:          po.Name,
+		Description:   ptr.From(po.Description),
+		CreatorID:     po.CreatorID,
+		Status:        entity.KnowledgeStatus(po.Status),
+		ChunkStrategy: po.ChunkStrategy,
+		CreatedAt:     po.CreatedAt,
+		UpdatedAt:     po.UpdatedAt,
+	}
+}
+
+func (dao *KnowledgeDAO) fromEntityToModel(do *entity.Knowledge) *model.Knowledge {
+	return &model.Knowledge{
+		ID:            do.ID,
+		Name:          do.Name,
+		Description:   ptr.Of(do.Description),
+		CreatorID:     do.CreatorID,
+		Status:        int32(do.Status),
+		ChunkStrategy: do.ChunkStrategy,
+		CreatedAt:     do.CreatedAt,
+		UpdatedAt:     do.UpdatedAt,
+	}
+}
@@ -0,0 +1,104 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/infra/repo/gorm_gen/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/knowledge/infra/repo/gorm_gen/query"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"gorm.io/gorm"
+)
+
+const sliceBatchSize = 100
+
+type SliceDAO struct {
+	DB    *gorm.DB
+	Query *query.Query
+}
+
+func NewSliceDAO(db *gorm.DB) *SliceDAO {
+	return &SliceDAO{
+		DB:    db,
+		Query: query.Use(db),
+	}
+}
+
+func (dao *SliceDAO) BatchCreate(ctx context.Context, sliceList []*entity.Slice) error {
+	if len(sliceList) == 0 {
+		return nil
+	}
+	return dao.Query.KnowledgeDocumentSlice.WithContext(ctx).
+		CreateInBatches(slices.Transform(sliceList, dao.fromEntityToModel), sliceBatchSize)
+}
+
+func (dao *SliceDAO) DeleteByDocumentID(ctx context.Context, documentID int64) error {
+	s := dao.Query.KnowledgeDocumentSlice
+	_, err := s.WithContext(ctx).Where(s.DocumentID.Eq(documentID)).Delete()
+	return err
+}
+
+func (dao *SliceDAO) DeleteByKnowledgeID(ctx context.Context, knowledgeID int64) error {
+	s := dao.Query.KnowledgeDocumentSlice
+	_, err := s.WithContext(ctx).Where(s.KnowledgeID.Eq(knowledgeID)).Delete()
+	return err
+}
+
+func (dao *SliceDAO) MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Slice, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	s := dao.Query.KnowledgeDocumentSlice
+	pos, err := s.WithContext(ctx).Where(s.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), nil
+}
+
+func (dao *SliceDAO) GetIDsByDocumentID(ctx context.Context, documentID int64) ([]int64, error) {
+	s := dao.Query.KnowledgeDocumentSlice
+	var ids []int64
+	err := s.WithContext(ctx).Where(s.DocumentID.Eq(documentID)).Pluck(s.ID, &ids)
+	return ids, err
+}
+
+func (dao *SliceDAO) ListByDocumentID(ctx context.Context, documentID int64, offset, limit int) ([]*entity.Slice, int64, error) {
+	s := dao.Query.KnowledgeDocumentSlice
+	pos, total, err := s.WithContext(ctx).
+		Where(s.DocumentID.Eq(documentID)).
+		Order(s.Sequence.Asc()).
+		FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), total, nil
+}
+
+func (dao *SliceDAO) fromModelToEntity(po *model.KnowledgeDocumentSlice) *entity.Slice {
+	if po == nil {
+		return nil
+	}
+	return &entity.Slice{
+		ID:          po.ID,
+		KnowledgeID: po.KnowledgeID,
+		DocumentID:  po.DocumentID,
+		Sequence:    int64(po.Sequence),
+		Content:     ptr.From(po.Content),
+		CreatedAt:   po.CreatedAt,
+		UpdatedAt:   po.UpdatedAt,
+	}
+}
+
+func (dao *SliceDAO) fromEntityToModel(do *entity.Slice) *model.KnowledgeDocumentSlice {
+	return &model.KnowledgeDocumentSlice{
+		ID:          do.ID,
+		KnowledgeID: do.KnowledgeID,
+		DocumentID:  do.DocumentID,
+		Sequence:    int32(do.Sequence),
+		Content:     ptr.Of(do.Content),
+		CreatedAt:   do.CreatedAt,
+		UpdatedAt:   do.UpdatedAt,
+	}
+}
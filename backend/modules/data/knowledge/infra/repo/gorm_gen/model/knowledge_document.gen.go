@@ -0,0 +1,34 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+const TableNameKnowledgeDocument = "knowledge_document"
+
+// KnowledgeDocument 知识库文档表
+type KnowledgeDocument struct {
+	ID            int64          `gorm:"column:id;type:bigint(20) unsigned;primaryKey;comment:主键ID" json:"id"`                                                           // 主键ID
+	KnowledgeID   int64          `gorm:"column:knowledge_id;type:bigint(20) unsigned;not null;index:idx_knowledge_id,priority:1;comment:所属知识库ID" json:"knowledge_id"`    // 所属知识库ID
+	Name          string         `gorm:"column:name;type:varchar(255);not null;comment:文档名称" json:"name"`                                                                // 文档名称
+	FileExtension string         `gorm:"column:file_extension;type:varchar(20);not null;comment:文档类型, txt/md/csv/json" json:"file_extension"`                            // 文档类型, txt/md/csv/json
+	URI           *string        `gorm:"column:uri;type:text;comment:资源 URI，直接写入文本时为空" json:"uri"`                                                                       // 资源 URI，直接写入文本时为空
+	Size          int64          `gorm:"column:size;type:bigint(20) unsigned;not null;comment:文档大小，字节" json:"size"`                                                      // 文档大小，字节
+	SliceCount    int64          `gorm:"column:slice_count;type:bigint(20) unsigned;not null;comment:切片数量" json:"slice_count"`                                           // 切片数量
+	CharCount     int64          `gorm:"column:char_count;type:bigint(20) unsigned;not null;comment:字符数" json:"char_count"`                                              // 字符数
+	Status        int32          `gorm:"column:status;type:tinyint(4);not null;comment:状态,0处理中,1可用,2失败" json:"status"`                                                   // 状态,0处理中,1可用,2失败
+	FailReason    *string        `gorm:"column:fail_reason;type:text;comment:失败原因" json:"fail_reason"`                                                                   // 失败原因
+	CreatorID     int64          `gorm:"column:creator_id;type:bigint(20);not null;comment:创建者ID" json:"creator_id"`                                                     // 创建者ID
+	CreatedAt     int64          `gorm:"column:created_at;type:bigint(20) unsigned;not null;autoCreateTime:milli;comment:Create Time in Milliseconds" json:"created_at"` // Create Time in Milliseconds
+	UpdatedAt     int64          `gorm:"column:updated_at;type:bigint(20) unsigned;not null;autoUpdateTime:milli;comment:Update Time in Milliseconds" json:"updated_at"` // Update Time in Milliseconds
+	DeletedAt     gorm.DeletedAt `gorm:"column:deleted_at;type:datetime(3);comment:Delete Time" json:"deleted_at"`                                                       // Delete Time
+}
+
+// TableName KnowledgeDocument's table name
+func (*KnowledgeDocument) TableName() string {
+	return TableNameKnowledgeDocument
+}
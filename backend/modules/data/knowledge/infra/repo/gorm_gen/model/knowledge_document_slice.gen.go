@@ -0,0 +1,28 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+const TableNameKnowledgeDocumentSlice = "knowledge_document_slice"
+
+// KnowledgeDocumentSlice 知识库文档切片表
+type KnowledgeDocumentSlice struct {
+	ID          int64          `gorm:"column:id;type:bigint(20) unsigned;primaryKey;comment:主键ID" json:"id"`                                                             // 主键ID
+	KnowledgeID int64          `gorm:"column:knowledge_id;type:bigint(20) unsigned;not null;index:idx_knowledge_id,priority:1;comment:所属知识库ID" json:"knowledge_id"`      // 所属知识库ID
+	DocumentID  int64          `gorm:"column:document_id;type:bigint(20) unsigned;not null;index:idx_document_id_sequence,priority:1;comment:所属文档ID" json:"document_id"` // 所属文档ID
+	Sequence    int32          `gorm:"column:sequence;type:int(11);not null;index:idx_document_id_sequence,priority:2;comment:切片在文档中的序号" json:"sequence"`                // 切片在文档中的序号
+	Content     *string        `gorm:"column:content;type:mediumtext;comment:切片内容" json:"content"`                                                                       // 切片内容
+	CreatedAt   int64          `gorm:"column:created_at;type:bigint(20) unsigned;not null;autoCreateTime:milli;comment:Create Time in Milliseconds" json:"created_at"`   // Create Time in Milliseconds
+	UpdatedAt   int64          `gorm:"column:updated_at;type:bigint(20) unsigned;not null;autoUpdateTime:milli;comment:Update Time in Milliseconds" json:"updated_at"`   // Update Time in Milliseconds
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;type:datetime(3);comment:Delete Time" json:"deleted_at"`                                                         // Delete Time
+}
+
+// TableName KnowledgeDocumentSlice's table name
+func (*KnowledgeDocumentSlice) TableName() string {
+	return TableNameKnowledgeDocumentSlice
+}
@@ -0,0 +1,81 @@
+package errno
+
+import (
+	"github.com/kiosk404/airi-go/backend/pkg/errorx/code"
+)
+
+// Knowledge: 105 000 000 ~ 105 999 999
+const (
+	ErrKnowledgeInvalidParamCode     = 105000000
+	ErrKnowledgePermissionCode       = 105000001
+	ErrKnowledgeNotExistCode         = 105000002
+	ErrKnowledgeDocumentNotExistCode = 105000003
+	ErrKnowledgeDBCode               = 105000004
+	ErrKnowledgeIDGenCode            = 105000005
+	ErrKnowledgeEmbeddingCode        = 105000006
+	ErrKnowledgeVectorStoreCode      = 105000007
+	ErrKnowledgeParseDocumentCode    = 105000008
+	ErrKnowledgeSystemCode           = 105000009
+)
+
+func init() {
+	code.Register(
+		ErrKnowledgeInvalidParamCode,
+		"invalid parameter : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrKnowledgePermissionCode,
+		"unauthorized access : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrKnowledgeNotExistCode,
+		"knowledge not exist : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrKnowledgeDocumentNotExistCode,
+		"knowledge document not exist : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrKnowledgeDBCode,
+		"knowledge db error : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrKnowledgeIDGenCode,
+		"id gen error : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrKnowledgeEmbeddingCode,
+		"embedding failed : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrKnowledgeVectorStoreCode,
+		"vector store error : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrKnowledgeParseDocumentCode,
+		"parse document failed : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrKnowledgeSystemCode,
+		"system error : {msg}",
+		code.WithAffectStability(true),
+	)
+}
@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/embedding"
+	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	"github.com/kiosk404/airi-go/backend/infra/contract/vectorstore"
+	memory "github.com/kiosk404/airi-go/backend/modules/data/memory/domain/service"
+)
+
+type ServiceComponents struct {
+	DB          rdb.Provider
+	IDGen       idgen.IDGenerator
+	Embedder    embedding.Embedder
+	VectorStore vectorstore.VectorStore
+}
+
+func InitService(ctx context.Context, c *ServiceComponents) *MemoryApplicationService {
+	MemorySVC.DomainSVC = memory.NewMemorySVC(&memory.Components{
+		DB:          c.DB,
+		IDGen:       c.IDGen,
+		Embedder:    c.Embedder,
+		VectorStore: c.VectorStore,
+	})
+
+	return MemorySVC
+}
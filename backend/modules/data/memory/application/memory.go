@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/kiosk404/airi-go/backend/api/model/data/memory"
+	"github.com/kiosk404/airi-go/backend/application/ctxutil"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/entity"
+	service "github.com/kiosk404/airi-go/backend/modules/data/memory/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+type MemoryApplicationService struct {
+	DomainSVC service.Memory
+}
+
+var MemorySVC = &MemoryApplicationService{}
+
+func (m *MemoryApplicationService) ListMemories(ctx context.Context, req *memory.ListMemoriesRequest) (*memory.ListMemoriesResponse, error) {
+	uid, err := getUID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.DomainSVC.ListMemories(ctx, &service.ListMemoriesRequest{
+		AgentID:  req.GetAgentID(),
+		UserID:   uid,
+		Kind:     entity.MemoryKind(req.GetKind()),
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &memory.ListMemoriesResponse{
+		MemoryList: slices.Transform(res.Memories, toMemoryInfo),
+		Total:      res.Total,
+	}, nil
+}
+
+func (m *MemoryApplicationService) UpdateMemory(ctx context.Context, req *memory.UpdateMemoryRequest) (*memory.UpdateMemoryResponse, error) {
+	uid, err := getUID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mem, err := m.DomainSVC.GetMemory(ctx, req.GetMemoryID())
+	if err != nil {
+		return nil, err
+	}
+	if mem.UserID != uid {
+		return nil, errorx.New(errno.ErrMemoryPermissionCode, errorx.KV("msg", "memory does not belong to the user"))
+	}
+
+	updateReq := &service.UpdateMemoryRequest{
+		MemoryID:   mem.ID,
+		Content:    req.Content,
+		Importance: req.Importance,
+	}
+	if req.IsSetKind() {
+		updateReq.Kind = ptr.Of(entity.MemoryKind(req.GetKind()))
+	}
+	mem, err = m.DomainSVC.UpdateMemory(ctx, updateReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &memory.UpdateMemoryResponse{
+		Data: toMemoryInfo(mem),
+	}, nil
+}
+
+func (m *MemoryApplicationService) ForgetMemories(ctx context.Context, req *memory.ForgetMemoriesRequest) (*memory.ForgetMemoriesResponse, error) {
+	uid, err := getUID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(req.GetMemoryIds()))
+	for _, s := range req.GetMemoryIds() {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errorx.New(errno.ErrMemoryInvalidParamCode, errorx.KVf("msg", "invalid memory_id %s", s))
+		}
+		ids = append(ids, id)
+	}
+
+	err = m.DomainSVC.DeleteMemories(ctx, &service.DeleteMemoriesRequest{
+		AgentID:   req.GetAgentID(),
+		UserID:    uid,
+		MemoryIDs: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &memory.ForgetMemoriesResponse{}, nil
+}
+
+// getUID 记忆按用户隔离，只能访问当前登录用户自己的记忆
+func getUID(ctx context.Context) (string, error) {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return "", errorx.New(errno.ErrMemoryPermissionCode, errorx.KV("msg", "session is required"))
+	}
+	return conv.Int64ToStr(*uid), nil
+}
+
+func toMemoryInfo(m *entity.Memory) *memory.MemoryInfo {
+	return &memory.MemoryInfo{
+		ID:             m.ID,
+		AgentID:        m.AgentID,
+		ConversationID: m.ConversationID,
+		Kind:           memory.MemoryKind(m.Kind),
+		Content:        m.Content,
+		Importance:     m.Importance,
+		RecalledAt:     m.RecalledAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+// Memory 智能体对某个用户的一条长期记忆，从历史对话中提取，跨会话召回
+type Memory struct {
+	ID             int64
+	AgentID        int64
+	UserID         string
+	ConversationID int64
+	// SourceRunID 提取出该记忆的最后一个运行
+	SourceRunID int64
+	Kind        MemoryKind
+	Content     string
+	// Importance 重要程度 1~10，参与召回排序
+	Importance int32
+	// RecalledAt 最近一次被召回的时间，与 UpdatedAt 中较晚者用于计算时效性
+	RecalledAt int64
+	CreatedAt  int64
+	UpdatedAt  int64
+}
+
+type MemoryKind int32
+
+const (
+	// MemoryKindFact 关于用户或双方关系的事实，如姓名、喜好、约定
+	MemoryKindFact MemoryKind = 1
+	// MemoryKindEvent 对话中发生过的事件，如一起经历的剧情
+	MemoryKindEvent MemoryKind = 2
+)
+
+const (
+	MinImportance     = 1
+	MaxImportance     = 10
+	DefaultImportance = 5
+)
+
+// RecallMemory 召回的记忆，Score 为综合相关性、时效性与重要程度的排序分数
+type RecallMemory struct {
+	*Memory
+	// Relevance 与查询的相关性，归一化到 [0, 1]
+	Relevance float64
+	Score     float64
+}
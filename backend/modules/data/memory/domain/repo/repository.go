@@ -0,0 +1,26 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/infra/dao"
+	"gorm.io/gorm"
+)
+
+func NewMemoryRepo(db *gorm.DB) MemoryRepo {
+	return dao.NewMemoryDAO(db)
+}
+
+type MemoryRepo interface {
+	BatchCreate(ctx context.Context, memories []*entity.Memory) error
+	// Update 更新记忆的内容、类型、重要程度与来源，updated_at 自动刷新
+	Update(ctx context.Context, memory *entity.Memory) error
+	UpdateRecalledAt(ctx context.Context, ids []int64, recalledAt int64) error
+	Delete(ctx context.Context, ids []int64) error
+	GetByID(ctx context.Context, id int64) (*entity.Memory, error)
+	MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Memory, error)
+	// List 按更新时间倒序，kind 为 0 时不过滤类型
+	List(ctx context.Context, agentID int64, userID string, kind entity.MemoryKind, offset, limit int) ([]*entity.Memory, int64, error)
+	GetIDsByUser(ctx context.Context, agentID int64, userID string) ([]int64, error)
+}
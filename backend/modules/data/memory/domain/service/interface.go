@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/entity"
+)
+
+type Memory interface {
+	// AddMemories 写入从对话中提取的记忆，与已有记忆语义重复时合并到已有记忆
+	AddMemories(ctx context.Context, req *AddMemoriesRequest) ([]*entity.Memory, error)
+	UpdateMemory(ctx context.Context, req *UpdateMemoryRequest) (*entity.Memory, error)
+	// DeleteMemories MemoryIDs 为空时遗忘该用户在智能体下的全部记忆
+	DeleteMemories(ctx context.Context, req *DeleteMemoriesRequest) error
+	GetMemory(ctx context.Context, memoryID int64) (*entity.Memory, error)
+	ListMemories(ctx context.Context, req *ListMemoriesRequest) (*ListMemoriesResponse, error)
+
+	// Recall 按相关性、时效性与重要程度召回记忆，并刷新被召回记忆的召回时间
+	Recall(ctx context.Context, req *RecallRequest) ([]*entity.RecallMemory, error)
+
+	// GetExtractCursor 返回会话 section 中已提取过记忆的最后一个运行 ID，未提取过时为 0
+	GetExtractCursor(ctx context.Context, sectionID int64) (int64, error)
+	SetExtractCursor(ctx context.Context, sectionID, runID int64) error
+}
+
+type AddMemoriesRequest struct {
+	AgentID        int64
+	UserID         string
+	ConversationID int64
+	SourceRunID    int64
+	Memories       []*MemoryDraft
+}
+
+// MemoryDraft 待写入的记忆，Importance 超出 1~10 时取默认值
+type MemoryDraft struct {
+	Kind       entity.MemoryKind
+	Content    string
+	Importance int32
+}
+
+type UpdateMemoryRequest struct {
+	MemoryID   int64
+	Content    *string
+	Kind       *entity.MemoryKind
+	Importance *int32
+}
+
+type DeleteMemoriesRequest struct {
+	AgentID   int64
+	UserID    string
+	MemoryIDs []int64
+}
+
+type ListMemoriesRequest struct {
+	AgentID  int64
+	UserID   string
+	Kind     entity.MemoryKind
+	Page     int
+	PageSize int
+}
+
+type ListMemoriesResponse struct {
+	Memories []*entity.Memory
+	Total    int64
+}
+
+type RecallRequest struct {
+	AgentID int64
+	UserID  string
+	Query   string
+	TopK    int
+}
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/embedding"
+	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	"github.com/kiosk404/airi-go/backend/infra/contract/vectorstore"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/repo"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/kvstore"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	docFieldUserID = "user_id"
+
+	kvNamespaceExtractCursor = "memory_extract_cursor"
+
+	// maxContentRunes 单条记忆的最大字符数，记忆应是简短的陈述
+	maxContentRunes = 500
+	// dedupMinScore 新记忆与已有记忆的余弦相似度不低于该值时视为同一条记忆，合并而不是新增
+	dedupMinScore = 0.9
+)
+
+type Components struct {
+	DB          rdb.Provider
+	IDGen       idgen.IDGenerator
+	Embedder    embedding.Embedder
+	VectorStore vectorstore.VectorStore
+}
+
+type memorySVC struct {
+	memoryRepo  repo.MemoryRepo
+	cursorStore *kvstore.KVStore[extractCursor]
+
+	idgen       idgen.IDGenerator
+	embedder    embedding.Embedder
+	vectorStore vectorstore.VectorStore
+}
+
+type extractCursor struct {
+	RunID int64 `json:"run_id"`
+}
+
+func NewMemorySVC(c *Components) Memory {
+	db := c.DB.NewSession(context.Background()).DB()
+	return &memorySVC{
+		memoryRepo:  repo.NewMemoryRepo(db),
+		cursorStore: kvstore.New[extractCursor](db),
+		idgen:       c.IDGen,
+		embedder:    c.Embedder,
+		vectorStore: c.VectorStore,
+	}
+}
+
+// collectionName 每个智能体一个 collection，用户之间按 user_id 字段隔离
+func collectionName(agentID int64) string {
+	return fmt.Sprintf("memory_%d", agentID)
+}
+
+func (m *memorySVC) AddMemories(ctx context.Context, req *AddMemoriesRequest) ([]*entity.Memory, error) {
+	if err := validate(req.AgentID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	drafts := make([]*MemoryDraft, 0, len(req.Memories))
+	for _, d := range req.Memories {
+		if d = normalizeDraft(d); d != nil {
+			drafts = append(drafts, d)
+		}
+	}
+	if len(drafts) == 0 {
+		return nil, nil
+	}
+
+	vectors, err := m.embed(ctx, slices.Transform(drafts, func(d *MemoryDraft) string { return d.Content }))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	result := make([]*entity.Memory, 0, len(drafts))
+	created := make([]*entity.Memory, 0, len(drafts))
+	vectorDocs := make([]*vectorstore.Document, 0, len(drafts))
+	for i, d := range drafts {
+		var vector []float64
+		if len(vectors) > i {
+			vector = vectors[i]
+		}
+
+		existing, err := m.findDuplicate(ctx, req.AgentID, req.UserID, vector)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			existing.Content = d.Content
+			existing.Kind = d.Kind
+			existing.Importance = max(existing.Importance, d.Importance)
+			existing.ConversationID = req.ConversationID
+			existing.SourceRunID = req.SourceRunID
+			existing.UpdatedAt = now
+			if err = m.memoryRepo.Update(ctx, existing); err != nil {
+				return nil, errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "AddMemories"))
+			}
+			vectorDocs = append(vectorDocs, toVectorDoc(existing, vector))
+			result = append(result, existing)
+			continue
+		}
+
+		id, err := m.idgen.GenID(ctx)
+		if err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrMemoryIDGenCode, errorx.KV("msg", "AddMemories"))
+		}
+		mem := &entity.Memory{
+			ID:             id,
+			AgentID:        req.AgentID,
+			UserID:         req.UserID,
+			ConversationID: req.ConversationID,
+			SourceRunID:    req.SourceRunID,
+			Kind:           d.Kind,
+			Content:        d.Content,
+			Importance:     d.Importance,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		vectorDocs = append(vectorDocs, toVectorDoc(mem, vector))
+		created = append(created, mem)
+		result = append(result, mem)
+	}
+
+	if err = m.memoryRepo.BatchCreate(ctx, created); err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "AddMemories"))
+	}
+	if err = m.vectorStore.Upsert(ctx, collectionName(req.AgentID), vectorDocs); err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryVectorStoreCode, errorx.KV("msg", err.Error()))
+	}
+
+	return result, nil
+}
+
+// findDuplicate 未配置向量化时无法判断语义重复，总是新增
+func (m *memorySVC) findDuplicate(ctx context.Context, agentID int64, userID string, vector []float64) (*entity.Memory, error) {
+	if len(vector) == 0 {
+		return nil, nil
+	}
+
+	results, err := m.vectorStore.SearchVector(ctx, &vectorstore.SearchRequest{
+		Collections: []string{collectionName(agentID)},
+		QueryVector: vector,
+		TopK:        1,
+		Filter:      map[string][]string{docFieldUserID: {userID}},
+	})
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryVectorStoreCode, errorx.KV("msg", err.Error()))
+	}
+	if len(results) == 0 || results[0].Score < dedupMinScore {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(results[0].Document.ID, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+	mem, err := m.memoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "AddMemories"))
+	}
+	return mem, nil
+}
+
+func (m *memorySVC) UpdateMemory(ctx context.Context, req *UpdateMemoryRequest) (*entity.Memory, error) {
+	mem, err := m.GetMemory(ctx, req.MemoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	contentChanged := false
+	if req.Content != nil {
+		content := strings.TrimSpace(*req.Content)
+		if content == "" {
+			return nil, errorx.New(errno.ErrMemoryInvalidParamCode, errorx.KV("msg", "content is empty"))
+		}
+		if utf8.RuneCountInString(content) > maxContentRunes {
+			return nil, errorx.New(errno.ErrMemoryInvalidParamCode, errorx.KVf("msg", "content exceeds %d characters", maxContentRunes))
+		}
+		contentChanged = content != mem.Content
+		mem.Content = content
+	}
+	if req.Kind != nil {
+		if *req.Kind != entity.MemoryKindFact && *req.Kind != entity.MemoryKindEvent {
+			return nil, errorx.New(errno.ErrMemoryInvalidParamCode, errorx.KVf("msg", "invalid kind %d", *req.Kind))
+		}
+		mem.Kind = *req.Kind
+	}
+	if req.Importance != nil {
+		if *req.Importance < entity.MinImportance || *req.Importance > entity.MaxImportance {
+			return nil, errorx.New(errno.ErrMemoryInvalidParamCode, errorx.KVf("msg", "importance must be between %d and %d", entity.MinImportance, entity.MaxImportance))
+		}
+		mem.Importance = *req.Importance
+	}
+	mem.UpdatedAt = time.Now().UnixMilli()
+
+	if err = m.memoryRepo.Update(ctx, mem); err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "UpdateMemory"))
+	}
+
+	if contentChanged {
+		vectors, err := m.embed(ctx, []string{mem.Content})
+		if err != nil {
+			return nil, err
+		}
+		var vector []float64
+		if len(vectors) > 0 {
+			vector = vectors[0]
+		}
+		if err = m.vectorStore.Upsert(ctx, collectionName(mem.AgentID), []*vectorstore.Document{toVectorDoc(mem, vector)}); err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrMemoryVectorStoreCode, errorx.KV("msg", err.Error()))
+		}
+	}
+
+	return mem, nil
+}
+
+func (m *memorySVC) DeleteMemories(ctx context.Context, req *DeleteMemoriesRequest) error {
+	if err := validate(req.AgentID, req.UserID); err != nil {
+		return err
+	}
+
+	var ids []int64
+	if len(req.MemoryIDs) == 0 {
+		var err error
+		ids, err = m.memoryRepo.GetIDsByUser(ctx, req.AgentID, req.UserID)
+		if err != nil {
+			return errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "DeleteMemories"))
+		}
+	} else {
+		memories, err := m.memoryRepo.MGetByIDs(ctx, req.MemoryIDs)
+		if err != nil {
+			return errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "DeleteMemories"))
+		}
+		for _, mem := range memories {
+			if mem.AgentID != req.AgentID || mem.UserID != req.UserID {
+				return errorx.New(errno.ErrMemoryPermissionCode, errorx.KVf("msg", "memory %d does not belong to the user", mem.ID))
+			}
+			ids = append(ids, mem.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := m.vectorStore.Delete(ctx, collectionName(req.AgentID), slices.Transform(ids, func(id int64) string {
+		return strconv.FormatInt(id, 10)
+	}))
+	if err != nil {
+		return errorx.WrapByCode(err, errno.ErrMemoryVectorStoreCode, errorx.KV("msg", err.Error()))
+	}
+	if err = m.memoryRepo.Delete(ctx, ids); err != nil {
+		return errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "DeleteMemories"))
+	}
+	return nil
+}
+
+func (m *memorySVC) GetMemory(ctx context.Context, memoryID int64) (*entity.Memory, error) {
+	mem, err := m.memoryRepo.GetByID(ctx, memoryID)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "GetMemory"))
+	}
+	if mem == nil {
+		return nil, errorx.New(errno.ErrMemoryNotExistCode, errorx.KV("msg", strconv.FormatInt(memoryID, 10)))
+	}
+	return mem, nil
+}
+
+func (m *memorySVC) ListMemories(ctx context.Context, req *ListMemoriesRequest) (*ListMemoriesResponse, error) {
+	if err := validate(req.AgentID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	offset, limit := pageToOffset(req.Page, req.PageSize)
+	list, total, err := m.memoryRepo.List(ctx, req.AgentID, req.UserID, req.Kind, offset, limit)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "ListMemories"))
+	}
+	return &ListMemoriesResponse{Memories: list, Total: total}, nil
+}
+
+func (m *memorySVC) GetExtractCursor(ctx context.Context, sectionID int64) (int64, error) {
+	cursor, err := m.cursorStore.Get(ctx, kvNamespaceExtractCursor, strconv.FormatInt(sectionID, 10))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return 0, nil
+		}
+		return 0, errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "GetExtractCursor"))
+	}
+	return cursor.RunID, nil
+}
+
+func (m *memorySVC) SetExtractCursor(ctx context.Context, sectionID, runID int64) error {
+	err := m.cursorStore.Save(ctx, kvNamespaceExtractCursor, strconv.FormatInt(sectionID, 10), &extractCursor{RunID: runID})
+	if err != nil {
+		return errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "SetExtractCursor"))
+	}
+	return nil
+}
+
+// embed 未配置向量化时返回 nil，记忆只能通过全文检索召回
+func (m *memorySVC) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	if m.embedder == nil || len(texts) == 0 {
+		return nil, nil
+	}
+	vectors, err := m.embedder.EmbedStrings(ctx, texts)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryEmbeddingCode, errorx.KV("msg", err.Error()))
+	}
+	return vectors, nil
+}
+
+func normalizeDraft(d *MemoryDraft) *MemoryDraft {
+	if d == nil {
+		return nil
+	}
+	content := strings.TrimSpace(d.Content)
+	if content == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(content) > maxContentRunes {
+		content = string([]rune(content)[:maxContentRunes])
+	}
+
+	kind := d.Kind
+	if kind != entity.MemoryKindEvent {
+		kind = entity.MemoryKindFact
+	}
+	importance := d.Importance
+	if importance < entity.MinImportance || importance > entity.MaxImportance {
+		importance = entity.DefaultImportance
+	}
+	return &MemoryDraft{Kind: kind, Content: content, Importance: importance}
+}
+
+func toVectorDoc(mem *entity.Memory, vector []float64) *vectorstore.Document {
+	return &vectorstore.Document{
+		ID:      strconv.FormatInt(mem.ID, 10),
+		Content: mem.Content,
+		Vector:  vector,
+		Fields: map[string]string{
+			docFieldUserID: mem.UserID,
+		},
+	}
+}
+
+func validate(agentID int64, userID string) error {
+	if agentID <= 0 {
+		return errorx.New(errno.ErrMemoryInvalidParamCode, errorx.KV("msg", "agent_id is required"))
+	}
+	if userID == "" {
+		return errorx.New(errno.ErrMemoryInvalidParamCode, errorx.KV("msg", "user_id is required"))
+	}
+	return nil
+}
+
+func pageToOffset(page, pageSize int) (offset, limit int) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return (page - 1) * pageSize, pageSize
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/vectorstore"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+const (
+	defaultRecallTopK = 5
+	// recallFactor 按相关性多召回的倍数，结合时效性与重要程度重新排序后再截断到 TopK
+	recallFactor = 4
+	// minRelevance 相关性低于该值的记忆不参与排序，避免召回与当前话题无关的记忆
+	minRelevance = 0.25
+	// recencyHalfLife 时效性的半衰期，超过该时长未被提及或召回的记忆时效性减半
+	recencyHalfLife = 7 * 24 * time.Hour
+
+	weightRelevance  = 0.6
+	weightRecency    = 0.2
+	weightImportance = 0.2
+)
+
+func (m *memorySVC) Recall(ctx context.Context, req *RecallRequest) ([]*entity.RecallMemory, error) {
+	if err := validate(req.AgentID, req.UserID); err != nil {
+		return nil, err
+	}
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, nil
+	}
+	topK := req.TopK
+	if topK <= 0 {
+		topK = defaultRecallTopK
+	}
+
+	searchReq := &vectorstore.SearchRequest{
+		Collections: []string{collectionName(req.AgentID)},
+		QueryText:   query,
+		TopK:        topK * recallFactor,
+		Filter:      map[string][]string{docFieldUserID: {req.UserID}},
+	}
+
+	var (
+		results   []*vectorstore.SearchResult
+		normalize func(float64) float64
+		err       error
+	)
+	if m.embedder != nil {
+		var vectors [][]float64
+		vectors, err = m.embed(ctx, []string{query})
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) == 0 {
+			return nil, errorx.New(errno.ErrMemoryEmbeddingCode, errorx.KV("msg", "empty query vector"))
+		}
+		searchReq.QueryVector = vectors[0]
+		results, err = m.vectorStore.SearchVector(ctx, searchReq)
+		if err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrMemoryVectorStoreCode, errorx.KV("msg", err.Error()))
+		}
+		normalize = func(score float64) float64 { return math.Max(score, 0) }
+	} else {
+		results, err = m.vectorStore.SearchText(ctx, searchReq)
+		if err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrMemoryVectorStoreCode, errorx.KV("msg", err.Error()))
+		}
+		// BM25 分数无上界，压缩到 [0, 1)
+		normalize = func(score float64) float64 { return score / (score + 1) }
+	}
+
+	ids := make([]int64, 0, len(results))
+	relevance := make(map[int64]float64, len(results))
+	for _, r := range results {
+		score := normalize(r.Score)
+		if score < minRelevance {
+			continue
+		}
+		id, err := strconv.ParseInt(r.Document.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+		relevance[id] = score
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	memories, err := m.memoryRepo.MGetByIDs(ctx, ids)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrMemoryDBCode, errorx.KV("msg", "Recall"))
+	}
+	candidates := make([]*entity.RecallMemory, 0, len(memories))
+	for _, mem := range memories {
+		candidates = append(candidates, &entity.RecallMemory{Memory: mem, Relevance: relevance[mem.ID]})
+	}
+
+	now := time.Now()
+	recalled := rankMemories(candidates, now, topK)
+
+	ids = slices.Transform(recalled, func(r *entity.RecallMemory) int64 { return r.ID })
+	if err = m.memoryRepo.UpdateRecalledAt(ctx, ids, now.UnixMilli()); err != nil {
+		// 召回时间只影响后续排序，更新失败不影响本次召回
+		logs.WarnX(pkg.ModelName, "update recalled_at of memories %v failed, err=%v", ids, err)
+	}
+	return recalled, nil
+}
+
+// rankMemories 综合分数 = 相关性 * 0.6 + 时效性 * 0.2 + 重要程度 * 0.2，三项都归一化到 [0, 1]
+//
+// 时效性按最近一次被召回或更新的时间指数衰减，经常被提及的记忆不容易被遗忘
+func rankMemories(candidates []*entity.RecallMemory, now time.Time, topK int) []*entity.RecallMemory {
+	for _, c := range candidates {
+		last := max(c.RecalledAt, c.UpdatedAt)
+		age := max(now.Sub(time.UnixMilli(last)), 0)
+		recency := math.Pow(0.5, float64(age)/float64(recencyHalfLife))
+		importance := float64(c.Importance) / entity.MaxImportance
+
+		c.Score = weightRelevance*c.Relevance + weightRecency*recency + weightImportance*importance
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if len(candidates) > topK {
+		candidates = candidates[:topK]
+	}
+	return candidates
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/entity"
+)
+
+func TestRankMemories(t *testing.T) {
+	now := time.Now()
+	recall := func(id int64, relevance float64, importance int32, age time.Duration) *entity.RecallMemory {
+		return &entity.RecallMemory{
+			Memory: &entity.Memory{
+				ID:         id,
+				Importance: importance,
+				UpdatedAt:  now.Add(-age).UnixMilli(),
+			},
+			Relevance: relevance,
+		}
+	}
+
+	t.Run("relevance dominates", func(t *testing.T) {
+		ranked := rankMemories([]*entity.RecallMemory{
+			recall(1, 0.3, 5, time.Hour),
+			recall(2, 0.9, 5, time.Hour),
+		}, now, 2)
+		if ranked[0].ID != 2 {
+			t.Fatalf("expect memory 2 first, got %d", ranked[0].ID)
+		}
+	})
+
+	t.Run("recent and important memories win ties", func(t *testing.T) {
+		ranked := rankMemories([]*entity.RecallMemory{
+			recall(1, 0.5, 5, 30*24*time.Hour),
+			recall(2, 0.5, 5, time.Hour),
+			recall(3, 0.5, 10, 30*24*time.Hour),
+		}, now, 3)
+		if ranked[0].ID != 2 || ranked[1].ID != 3 || ranked[2].ID != 1 {
+			t.Fatalf("unexpected order: %d, %d, %d", ranked[0].ID, ranked[1].ID, ranked[2].ID)
+		}
+	})
+
+	t.Run("recall refreshes recency", func(t *testing.T) {
+		old := recall(1, 0.5, 5, 30*24*time.Hour)
+		recalled := recall(2, 0.5, 5, 30*24*time.Hour)
+		recalled.RecalledAt = now.Add(-time.Hour).UnixMilli()
+		ranked := rankMemories([]*entity.RecallMemory{old, recalled}, now, 2)
+		if ranked[0].ID != 2 {
+			t.Fatalf("expect recently recalled memory first, got %d", ranked[0].ID)
+		}
+	})
+
+	t.Run("truncate to top k", func(t *testing.T) {
+		ranked := rankMemories([]*entity.RecallMemory{
+			recall(1, 0.9, 5, time.Hour),
+			recall(2, 0.8, 5, time.Hour),
+			recall(3, 0.7, 5, time.Hour),
+		}, now, 2)
+		if len(ranked) != 2 {
+			t.Fatalf("expect 2 memories, got %d", len(ranked))
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/infra/repo/gorm_gen/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/memory/infra/repo/gorm_gen/query"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+	"gorm.io/gorm"
+)
+
+const memoryBatchSize = 100
+
+type MemoryDAO struct {
+	DB    *gorm.DB
+	Query *query.Query
+}
+
+func NewMemoryDAO(db *gorm.DB) *MemoryDAO {
+	return &MemoryDAO{
+		DB:    db,
+		Query: query.Use(db),
+	}
+}
+
+func (dao *MemoryDAO) BatchCreate(ctx context.Context, memories []*entity.Memory) error {
+	if len(memories) == 0 {
+		return nil
+	}
+	return dao.Query.AgentMemory.WithContext(ctx).
+		CreateInBatches(slices.Transform(memories, dao.fromEntityToModel), memoryBatchSize)
+}
+
+func (dao *MemoryDAO) Update(ctx context.Context, memory *entity.Memory) error {
+	m := dao.Query.AgentMemory
+	_, err := m.WithContext(ctx).Where(m.ID.Eq(memory.ID)).Updates(map[string]any{
+		"content":         memory.Content,
+		"kind":            int32(memory.Kind),
+		"importance":      memory.Importance,
+		"conversation_id": memory.ConversationID,
+		"source_run_id":   memory.SourceRunID,
+	})
+	return err
+}
+
+// UpdateRecalledAt 只更新召回时间，不改变 updated_at
+func (dao *MemoryDAO) UpdateRecalledAt(ctx context.Context, ids []int64, recalledAt int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	m := dao.Query.AgentMemory
+	_, err := m.WithContext(ctx).Where(m.ID.In(ids...)).UpdateColumnSimple(m.RecalledAt.Value(recalledAt))
+	return err
+}
+
+func (dao *MemoryDAO) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	m := dao.Query.AgentMemory
+	_, err := m.WithContext(ctx).Where(m.ID.In(ids...)).Delete()
+	return err
+}
+
+func (dao *MemoryDAO) GetByID(ctx context.Context, id int64) (*entity.Memory, error) {
+	m := dao.Query.AgentMemory
+	po, err := m.WithContext(ctx).Where(m.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dao.fromModelToEntity(po), nil
+}
+
+func (dao *MemoryDAO) MGetByIDs(ctx context.Context, ids []int64) ([]*entity.Memory, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	m := dao.Query.AgentMemory
+	pos, err := m.WithContext(ctx).Where(m.ID.In(ids...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), nil
+}
+
+func (dao *MemoryDAO) List(ctx context.Context, agentID int64, userID string, kind entity.MemoryKind, offset, limit int) ([]*entity.Memory, int64, error) {
+	m := dao.Query.AgentMemory
+	do := m.WithContext(ctx).Where(m.AgentID.Eq(agentID), m.UserID.Eq(userID))
+	if kind != 0 {
+		do = do.Where(m.Kind.Eq(int32(kind)))
+	}
+	pos, total, err := do.Order(m.UpdatedAt.Desc(), m.ID.Desc()).FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Transform(pos, dao.fromModelToEntity), total, nil
+}
+
+func (dao *MemoryDAO) GetIDsByUser(ctx context.Context, agentID int64, userID string) ([]int64, error) {
+	m := dao.Query.AgentMemory
+	var ids []int64
+	err := m.WithContext(ctx).Where(m.AgentID.Eq(agentID), m.UserID.Eq(userID)).Pluck(m.ID, &ids)
+	return ids, err
+}
+
+func (dao *MemoryDAO) fromEntityToModel(e *entity.Memory) *model.AgentMemory {
+	return &model.AgentMemory{
+		ID:             e.ID,
+		AgentID:        e.AgentID,
+		UserID:         e.UserID,
+		ConversationID: e.ConversationID,
+		SourceRunID:    e.SourceRunID,
+		Kind:           int32(e.Kind),
+		Content:        ptr.Of(e.Content),
+		Importance:     e.Importance,
+		RecalledAt:     e.RecalledAt,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+func (dao *MemoryDAO) fromModelToEntity(po *model.AgentMemory) *entity.Memory {
+	return &entity.Memory{
+		ID:             po.ID,
+		AgentID:        po.AgentID,
+		UserID:         po.UserID,
+		ConversationID: po.ConversationID,
+		SourceRunID:    po.SourceRunID,
+		Kind:           entity.MemoryKind(po.Kind),
+		Content:        ptr.From(po.Content),
+		Importance:     po.Importance,
+		RecalledAt:     po.RecalledAt,
+		CreatedAt:      po.CreatedAt,
+		UpdatedAt:      po.UpdatedAt,
+	}
+}
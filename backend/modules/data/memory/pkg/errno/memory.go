@@ -0,0 +1,60 @@
+package errno
+
+import (
+	"github.com/kiosk404/airi-go/backend/pkg/errorx/code"
+)
+
+// Memory: 107 000 000 ~ 107 999 999
+const (
+	ErrMemoryInvalidParamCode = 107000000
+	ErrMemoryPermissionCode   = 107000001
+	ErrMemoryNotExistCode     = 107000002
+	ErrMemoryDBCode           = 107000003
+	ErrMemoryIDGenCode        = 107000004
+	ErrMemoryEmbeddingCode    = 107000005
+	ErrMemoryVectorStoreCode  = 107000006
+)
+
+func init() {
+	code.Register(
+		ErrMemoryInvalidParamCode,
+		"invalid parameter : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrMemoryPermissionCode,
+		"unauthorized access : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrMemoryNotExistCode,
+		"memory not exist : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrMemoryDBCode,
+		"memory db error : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrMemoryIDGenCode,
+		"id gen error : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrMemoryEmbeddingCode,
+		"embedding failed : {msg}",
+		code.WithAffectStability(true),
+	)
+
+	code.Register(
+		ErrMemoryVectorStoreCode,
+		"vector store error : {msg}",
+		code.WithAffectStability(true),
+	)
+}
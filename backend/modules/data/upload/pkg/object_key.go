@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/api/model/app/developer_api"
+)
+
+// UserFileObjectKey /api/bot/upload_file 上传文件的对象名：{biz_type}/{uid}_{timestamp}_{secret}.{file_type}
+func UserFileObjectKey(bizType developer_api.FileBizType, uid int64, secret, fileType string) string {
+	return fmt.Sprintf("%s/%d_%d_%s.%s", bizType.String(), uid, time.Now().UnixNano(), secret, fileType)
+}
+
+// IsUserFileObjectKey 判断对象名是否由该用户通过 /api/bot/upload_file 上传，
+// 客户端传入的对象名读取存储前需校验，避免读取其他用户的文件
+func IsUserFileObjectKey(key string, uid int64) bool {
+	if uid <= 0 || path.Clean(key) != key {
+		return false
+	}
+	bizType, name, ok := strings.Cut(key, "/")
+	if !ok || strings.Contains(name, "/") {
+		return false
+	}
+	if _, err := developer_api.FileBizTypeFromString(bizType); err != nil {
+		return false
+	}
+	return strings.HasPrefix(name, strconv.FormatInt(uid, 10)+"_")
+}
@@ -0,0 +1,19 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/kiosk404/airi-go/backend/api/model/app/developer_api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUserFileObjectKey(t *testing.T) {
+	assert.True(t, IsUserFileObjectKey(UserFileObjectKey(developer_api.FileBizType_BIZ_BOT_DATASET, 7, "abc", "mp3"), 7))
+	assert.True(t, IsUserFileObjectKey("BIZ_BOT_DATASET/7_1700000000_abc.mp3", 7))
+	assert.False(t, IsUserFileObjectKey("BIZ_BOT_DATASET/8_1700000000_abc.mp3", 7))
+	assert.False(t, IsUserFileObjectKey("BIZ_BOT_DATASET/77_1700000000_abc.mp3", 7))
+	assert.False(t, IsUserFileObjectKey("tts/7_1/1.mp3", 7))
+	assert.False(t, IsUserFileObjectKey("bot_files/7_1700000000_abc.mp3", 7))
+	assert.False(t, IsUserFileObjectKey("BIZ_BOT_DATASET/../tts/7_1.mp3", 7))
+	assert.False(t, IsUserFileObjectKey("BIZ_BOT_DATASET/0_1.mp3", 0))
+}
@@ -0,0 +1,20 @@
+package application
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	variables "github.com/kiosk404/airi-go/backend/modules/data/variables/domain/service"
+)
+
+type ServiceComponents struct {
+	DB rdb.Provider
+}
+
+func InitService(ctx context.Context, c *ServiceComponents) *VariablesApplicationService {
+	VariablesSVC.DomainSVC = variables.NewVariablesSVC(&variables.Components{
+		DB: c.DB,
+	})
+
+	return VariablesSVC
+}
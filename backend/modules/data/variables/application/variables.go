@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/api/model/data/variables"
+	"github.com/kiosk404/airi-go/backend/application/ctxutil"
+	crossagent "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent"
+	agentmodel "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/agent/model"
+	"github.com/kiosk404/airi-go/backend/modules/data/variables/domain/entity"
+	service "github.com/kiosk404/airi-go/backend/modules/data/variables/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/data/variables/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+type VariablesApplicationService struct {
+	DomainSVC service.Variables
+}
+
+var VariablesSVC = &VariablesApplicationService{}
+
+func (v *VariablesApplicationService) ListVariables(ctx context.Context, req *variables.ListVariablesRequest) (*variables.ListVariablesResponse, error) {
+	uid, metas, err := v.getVariableMetas(ctx, req.GetAgentID(), req.GetIsDraft())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := v.DomainSVC.GetVariables(ctx, &service.GetVariablesRequest{
+		AgentID:      req.GetAgentID(),
+		ConnectorUID: uid,
+		Metas:        metas,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &variables.ListVariablesResponse{
+		Data: slices.Transform(res, toVariableInfo),
+	}, nil
+}
+
+func (v *VariablesApplicationService) UpdateVariables(ctx context.Context, req *variables.UpdateVariablesRequest) (*variables.UpdateVariablesResponse, error) {
+	uid, metas, err := v.getVariableMetas(ctx, req.GetAgentID(), req.GetIsDraft())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := v.DomainSVC.SetVariables(ctx, &service.SetVariablesRequest{
+		AgentID:      req.GetAgentID(),
+		ConnectorUID: uid,
+		Metas:        metas,
+		KVs:          req.GetValues(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &variables.UpdateVariablesResponse{
+		Data: slices.Transform(res, toVariableInfo),
+	}, nil
+}
+
+func (v *VariablesApplicationService) ResetVariables(ctx context.Context, req *variables.ResetVariablesRequest) (*variables.ResetVariablesResponse, error) {
+	uid, metas, err := v.getVariableMetas(ctx, req.GetAgentID(), req.GetIsDraft())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := v.DomainSVC.ResetVariables(ctx, &service.ResetVariablesRequest{
+		AgentID:      req.GetAgentID(),
+		ConnectorUID: uid,
+		Metas:        metas,
+		Keywords:     req.GetKeywords(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &variables.ResetVariablesResponse{
+		Data: slices.Transform(res, toVariableInfo),
+	}, nil
+}
+
+// getVariableMetas 获取当前用户及 Agent 的变量定义，草稿只允许创建者访问
+func (v *VariablesApplicationService) getVariableMetas(ctx context.Context, agentID int64, isDraft bool) (string, []*entity.VariableMeta, error) {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return "", nil, errorx.New(errno.ErrVariablesPermissionCode, errorx.KV("msg", "session is required"))
+	}
+
+	agent, err := crossagent.DefaultSVC().ObtainAgentByIdentity(ctx, &agentmodel.AgentIdentity{
+		AgentID: agentID,
+		IsDraft: isDraft,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if agent == nil {
+		return "", nil, errorx.New(errno.ErrVariablesInvalidParamCode, errorx.KVf("msg", "agent %d not found", agentID))
+	}
+	if isDraft && agent.CreatorID != *uid {
+		return "", nil, errorx.New(errno.ErrVariablesPermissionCode, errorx.KV("msg", "only the creator can access the draft agent"))
+	}
+
+	return conv.Int64ToStr(*uid), entity.MetasFromBotVariables(agent.Variables), nil
+}
+
+func toVariableInfo(v *entity.Variable) *variables.VariableInfo {
+	return &variables.VariableInfo{
+		Keyword:        v.Keyword,
+		Description:    v.Description,
+		DefaultValue:   v.DefaultValue,
+		Value:          v.Value,
+		IsSystem:       v.IsSystem,
+		PromptDisabled: v.PromptDisabled,
+		UpdateTime:     v.UpdateTime,
+	}
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
+)
+
+// VariableMeta Agent 上定义的记忆变量，取自 bot_common.Variable
+type VariableMeta struct {
+	Keyword        string
+	Description    string
+	DefaultValue   string
+	IsSystem       bool
+	PromptDisabled bool
+}
+
+// Variable 变量定义及当前用户的取值
+type Variable struct {
+	*VariableMeta
+	Value      string
+	UpdateTime int64
+}
+
+// UserValues 某个用户在某个 Agent 下的全部变量取值，每个变量单独存储在 kvstore 中
+type UserValues struct {
+	Values map[string]*Value `json:"values"`
+}
+
+type Value struct {
+	Value      string `json:"value"`
+	UpdateTime int64  `json:"update_time"`
+}
+
+// MetasFromBotVariables 转换 Agent 上的变量定义，忽略已禁用和未命名的变量
+func MetasFromBotVariables(vars []*bot_common.Variable) []*VariableMeta {
+	metas := make([]*VariableMeta, 0, len(vars))
+	for _, v := range vars {
+		if v == nil || v.GetKey() == "" || v.GetIsDisabled() {
+			continue
+		}
+		metas = append(metas, &VariableMeta{
+			Keyword:        v.GetKey(),
+			Description:    v.GetDescription(),
+			DefaultValue:   v.GetDefaultValue(),
+			IsSystem:       v.GetIsSystem(),
+			PromptDisabled: v.GetPromptDisabled(),
+		})
+	}
+	return metas
+}
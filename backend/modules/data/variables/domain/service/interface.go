@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/variables/domain/entity"
+)
+
+type Variables interface {
+	// GetVariables 获取用户在 Agent 下的变量取值，首次使用时以默认值初始化
+	GetVariables(ctx context.Context, req *GetVariablesRequest) ([]*entity.Variable, error)
+	SetVariables(ctx context.Context, req *SetVariablesRequest) ([]*entity.Variable, error)
+	ResetVariables(ctx context.Context, req *ResetVariablesRequest) ([]*entity.Variable, error)
+}
+
+type GetVariablesRequest struct {
+	AgentID      int64
+	ConnectorUID string
+	Metas        []*entity.VariableMeta
+}
+
+type SetVariablesRequest struct {
+	AgentID      int64
+	ConnectorUID string
+	Metas        []*entity.VariableMeta
+	KVs          map[string]string
+}
+
+type ResetVariablesRequest struct {
+	AgentID      int64
+	ConnectorUID string
+	Metas        []*entity.VariableMeta
+	Keywords     []string // 为空时重置全部变量
+}
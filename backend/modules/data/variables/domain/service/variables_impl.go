@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
+	"github.com/kiosk404/airi-go/backend/modules/data/variables/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/data/variables/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/kvstore"
+)
+
+const kvNamespace = "agent_variables"
+
+type Components struct {
+	DB rdb.Provider
+}
+
+func NewVariablesSVC(c *Components) Variables {
+	return &variablesImpl{
+		store: kvstore.New[entity.Value](c.DB.NewSession(context.Background()).DB()),
+	}
+}
+
+// valueStore 每个变量的取值单独存储，同一轮对话中并行的工具调用写入不同变量时互不覆盖
+type valueStore interface {
+	Get(ctx context.Context, namespace, k string) (*entity.Value, error)
+	Save(ctx context.Context, namespace, k string, v *entity.Value) error
+}
+
+type variablesImpl struct {
+	store valueStore
+}
+
+func (v *variablesImpl) GetVariables(ctx context.Context, req *GetVariablesRequest) ([]*entity.Variable, error) {
+	if err := validate(req.AgentID, req.ConnectorUID); err != nil {
+		return nil, err
+	}
+
+	values, err := v.load(ctx, req.AgentID, req.ConnectorUID, req.Metas)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildVariables(req.Metas, values), nil
+}
+
+func (v *variablesImpl) SetVariables(ctx context.Context, req *SetVariablesRequest) ([]*entity.Variable, error) {
+	if err := validate(req.AgentID, req.ConnectorUID); err != nil {
+		return nil, err
+	}
+
+	metas := metaMap(req.Metas)
+	for k := range req.KVs {
+		meta, ok := metas[k]
+		if !ok {
+			return nil, errorx.New(errno.ErrVariablesNotExistCode, errorx.KVf("msg", "keyword '%s' is not defined", k))
+		}
+		if meta.IsSystem {
+			return nil, errorx.New(errno.ErrVariablesReadOnlyCode, errorx.KVf("msg", "keyword '%s' is a system variable", k))
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	for k, val := range req.KVs {
+		if err := v.save(ctx, req.AgentID, req.ConnectorUID, k, &entity.Value{Value: val, UpdateTime: now}); err != nil {
+			return nil, err
+		}
+	}
+
+	values, err := v.load(ctx, req.AgentID, req.ConnectorUID, req.Metas)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildVariables(req.Metas, values), nil
+}
+
+func (v *variablesImpl) ResetVariables(ctx context.Context, req *ResetVariablesRequest) ([]*entity.Variable, error) {
+	if err := validate(req.AgentID, req.ConnectorUID); err != nil {
+		return nil, err
+	}
+
+	metas := metaMap(req.Metas)
+	keywords := req.Keywords
+	if len(keywords) == 0 {
+		keywords = make([]string, 0, len(req.Metas))
+		for _, m := range req.Metas {
+			keywords = append(keywords, m.Keyword)
+		}
+	}
+	for _, k := range keywords {
+		if _, ok := metas[k]; !ok {
+			return nil, errorx.New(errno.ErrVariablesNotExistCode, errorx.KVf("msg", "keyword '%s' is not defined", k))
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	for _, k := range keywords {
+		if err := v.save(ctx, req.AgentID, req.ConnectorUID, k, &entity.Value{Value: metas[k].DefaultValue, UpdateTime: now}); err != nil {
+			return nil, err
+		}
+	}
+
+	values, err := v.load(ctx, req.AgentID, req.ConnectorUID, req.Metas)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildVariables(req.Metas, values), nil
+}
+
+// load 读取用户的变量取值，未设置过的变量使用默认值
+func (v *variablesImpl) load(ctx context.Context, agentID int64, uid string, metas []*entity.VariableMeta) (*entity.UserValues, error) {
+	values := &entity.UserValues{Values: make(map[string]*entity.Value, len(metas))}
+	for _, m := range metas {
+		val, err := v.store.Get(ctx, kvNamespace, storeKey(agentID, uid, m.Keyword))
+		if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errorx.WrapByCode(err, errno.ErrVariablesStoreCode, errorx.KV("msg", err.Error()))
+		}
+		if val == nil {
+			val = &entity.Value{Value: m.DefaultValue}
+		}
+		values.Values[m.Keyword] = val
+	}
+	return values, nil
+}
+
+func (v *variablesImpl) save(ctx context.Context, agentID int64, uid, keyword string, value *entity.Value) error {
+	if err := v.store.Save(ctx, kvNamespace, storeKey(agentID, uid, keyword), value); err != nil {
+		return errorx.WrapByCode(err, errno.ErrVariablesStoreCode, errorx.KV("msg", err.Error()))
+	}
+	return nil
+}
+
+func validate(agentID int64, uid string) error {
+	if agentID <= 0 {
+		return errorx.New(errno.ErrVariablesInvalidParamCode, errorx.KV("msg", "agent id is required"))
+	}
+	if uid == "" {
+		return errorx.New(errno.ErrVariablesInvalidParamCode, errorx.KV("msg", "connector uid is required"))
+	}
+	return nil
+}
+
+func storeKey(agentID int64, uid, keyword string) string {
+	return fmt.Sprintf("%d:%s:%s", agentID, uid, keyword)
+}
+
+func metaMap(metas []*entity.VariableMeta) map[string]*entity.VariableMeta {
+	m := make(map[string]*entity.VariableMeta, len(metas))
+	for _, meta := range metas {
+		m[meta.Keyword] = meta
+	}
+	return m
+}
+
+func buildVariables(metas []*entity.VariableMeta, values *entity.UserValues) []*entity.Variable {
+	res := make([]*entity.Variable, 0, len(metas))
+	for _, m := range metas {
+		val := values.Values[m.Keyword]
+		res = append(res, &entity.Variable{
+			VariableMeta: m,
+			Value:        val.Value,
+			UpdateTime:   val.UpdateTime,
+		})
+	}
+	return res
+}
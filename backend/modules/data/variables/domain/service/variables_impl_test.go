@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiosk404/airi-go/backend/modules/data/variables/domain/entity"
+	"github.com/kiosk404/airi-go/backend/pkg/kvstore"
+)
+
+type memStore struct {
+	mu     sync.Mutex
+	values map[string]entity.Value
+}
+
+func (m *memStore) Get(_ context.Context, namespace, k string) (*entity.Value, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.values[namespace+"/"+k]
+	if !ok {
+		return nil, kvstore.ErrKeyNotFound
+	}
+	return &v, nil
+}
+
+func (m *memStore) Save(_ context.Context, namespace, k string, v *entity.Value) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[namespace+"/"+k] = *v
+	return nil
+}
+
+func valueOf(vars []*entity.Variable, keyword string) string {
+	for _, v := range vars {
+		if v.Keyword == keyword {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+func TestVariables(t *testing.T) {
+	ctx := context.Background()
+	metas := []*entity.VariableMeta{
+		{Keyword: "name", DefaultValue: "friend"},
+		{Keyword: "city"},
+		{Keyword: "sys_uuid", IsSystem: true},
+	}
+	svc := &variablesImpl{store: &memStore{values: map[string]entity.Value{}}}
+
+	t.Run("defaults", func(t *testing.T) {
+		vars, err := svc.GetVariables(ctx, &GetVariablesRequest{AgentID: 1, ConnectorUID: "u1", Metas: metas})
+		assert.NoError(t, err)
+		assert.Equal(t, "friend", valueOf(vars, "name"))
+		assert.Equal(t, "", valueOf(vars, "city"))
+	})
+
+	t.Run("parallel sets of different keys are all kept", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := svc.SetVariables(ctx, &SetVariablesRequest{AgentID: 1, ConnectorUID: "u1", Metas: metas, KVs: map[string]string{"name": "Airi"}})
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := svc.SetVariables(ctx, &SetVariablesRequest{AgentID: 1, ConnectorUID: "u1", Metas: metas, KVs: map[string]string{"city": "Tokyo"}})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		vars, err := svc.GetVariables(ctx, &GetVariablesRequest{AgentID: 1, ConnectorUID: "u1", Metas: metas})
+		assert.NoError(t, err)
+		assert.Equal(t, "Airi", valueOf(vars, "name"))
+		assert.Equal(t, "Tokyo", valueOf(vars, "city"))
+
+		other, err := svc.GetVariables(ctx, &GetVariablesRequest{AgentID: 1, ConnectorUID: "u2", Metas: metas})
+		assert.NoError(t, err)
+		assert.Equal(t, "friend", valueOf(other, "name"))
+	})
+
+	t.Run("system and undefined variables can not be set", func(t *testing.T) {
+		_, err := svc.SetVariables(ctx, &SetVariablesRequest{AgentID: 1, ConnectorUID: "u1", Metas: metas, KVs: map[string]string{"sys_uuid": "x"}})
+		assert.Error(t, err)
+		_, err = svc.SetVariables(ctx, &SetVariablesRequest{AgentID: 1, ConnectorUID: "u1", Metas: metas, KVs: map[string]string{"unknown": "x"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		vars, err := svc.ResetVariables(ctx, &ResetVariablesRequest{AgentID: 1, ConnectorUID: "u1", Metas: metas, Keywords: []string{"name"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "friend", valueOf(vars, "name"))
+		assert.Equal(t, "Tokyo", valueOf(vars, "city"))
+	})
+}
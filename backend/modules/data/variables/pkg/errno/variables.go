@@ -0,0 +1,46 @@
+package errno
+
+import (
+	"github.com/kiosk404/airi-go/backend/pkg/errorx/code"
+)
+
+// Variables: 106 000 000 ~ 106 999 999
+const (
+	ErrVariablesInvalidParamCode = 106000000
+	ErrVariablesPermissionCode   = 106000001
+	ErrVariablesNotExistCode     = 106000002
+	ErrVariablesReadOnlyCode     = 106000003
+	ErrVariablesStoreCode        = 106000004
+)
+
+func init() {
+	code.Register(
+		ErrVariablesInvalidParamCode,
+		"invalid parameter : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrVariablesPermissionCode,
+		"unauthorized access : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrVariablesNotExistCode,
+		"variable not exist : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrVariablesReadOnlyCode,
+		"variable is read only : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrVariablesStoreCode,
+		"variable store error : {msg}",
+		code.WithAffectStability(true),
+	)
+}
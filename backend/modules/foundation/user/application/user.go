@@ -91,6 +91,29 @@ func (u *UserApplicationService) allowRegisterChecker(account string) bool {
 	return slices.Contains(strings.Split(allowedAccounts, ","), strings.ToLower(account))
 }
 
+// CheckAdmin 只有 ADMIN_ACCOUNTS 中配置的账号可以访问管理接口，未配置时拒绝所有用户
+func (u *UserApplicationService) CheckAdmin(ctx context.Context) error {
+	uid := ctxutil.GetUIDFromCtx(ctx)
+	if uid == nil {
+		return errorx.New(errno.ErrUserAuthenticationFailed, errorx.KV("reason", "missing session"))
+	}
+
+	adminAccounts := os.Getenv(consts.AdminAccounts)
+	if adminAccounts == "" {
+		return errorx.New(errno.ErrUserPermissionCode, errorx.KV("msg", "admin only"))
+	}
+
+	userDO, err := u.DomainSVC.GetUserInfo(ctx, *uid)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(strings.Split(strings.ToLower(adminAccounts), ","), strings.ToLower(userDO.Account)) {
+		return errorx.New(errno.ErrUserPermissionCode, errorx.KV("msg", "admin only"))
+	}
+
+	return nil
+}
+
 // WebLogout handle user logout requests
 func (u *UserApplicationService) WebLogout(ctx context.Context,
 	req *user.LogoutRequest) (resp *user.LogoutResponse, err error) {
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/claude"
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+)
+
+type claudeModelBuilder struct {
+	cfg *model.Model
+}
+
+func newClaudeModelBuilder(cfg *model.Model) Service {
+	return &claudeModelBuilder{
+		cfg: cfg,
+	}
+}
+
+func (c *claudeModelBuilder) getDefaultConfig() *claude.Config {
+	return &claude.Config{
+		MaxTokens: 4096,
+	}
+}
+
+func (c *claudeModelBuilder) applyParamsToClaudeConfig(conf *claude.Config, params *model.LLMParams) {
+	if params == nil {
+		return
+	}
+
+	if params.Temperature != nil {
+		conf.Temperature = ptr.Of(*params.Temperature)
+	}
+
+	if params.MaxTokens != 0 {
+		conf.MaxTokens = params.MaxTokens
+	}
+
+	conf.TopP = params.TopP
+	conf.TopK = params.TopK
+
+	if params.EnableThinking != nil {
+		if *params.EnableThinking {
+			conf.Thinking = &claude.Thinking{}
+		} else {
+			conf.Thinking = nil
+		}
+	}
+}
+
+func (c *claudeModelBuilder) Build(ctx context.Context, params *model.LLMParams) (ToolCallingChatModel, error) {
+	base := c.cfg.Connection.BaseConnInfo
+
+	conf := c.getDefaultConfig()
+	conf.APIKey = base.APIKey
+	conf.Model = base.Model
+	conf.BaseURL = base.BaseURL
+
+	// Messages API 没有自动思考模式，Auto 与 Default 均不开启
+	if base.ThinkingType == model.ThinkingType_Enable {
+		conf.Thinking = &claude.Thinking{}
+	}
+
+	c.applyParamsToClaudeConfig(conf, params)
+
+	return claude.NewChatModel(ctx, conf)
+}
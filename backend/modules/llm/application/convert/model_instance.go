@@ -124,6 +124,8 @@ func convertModelConnection(connection entity.Connection) *modelapi.Connection {
 		Qwen:         convertQwenConnInfo(connection.Qwen),
 		Ollama:       convertOllamaConnInfo(connection.Ollama),
 		Claude:       convertClaudeConnInfo(connection.Claude),
+
+		OpenaiCompatible: convertOpenAICompatibleConnInfo(connection.OpenAICompatible),
 	}
 }
 func convertModelParameters(parameters []modelmgr.ModelParameter) []*developer_api.ModelParameter {
@@ -192,6 +194,8 @@ func convertModelClass(modelClass modelmgr.ModelClass) developer_api.ModelClass
 		return developer_api.ModelClass_QWen
 	case modelmgr.ModelClass_Claude:
 		return developer_api.ModelClass_Claude
+	case modelmgr.ModelClass_OpenAICompatible:
+		return developer_api.ModelClass_OpenAICompatible
 	default:
 		return developer_api.ModelClass_Other
 	}
@@ -258,6 +262,21 @@ func convertClaudeConnInfo(claudeConnInfo *modelmgr.ClaudeConnInfo) *modelapi.Cl
 	return &modelapi.ClaudeConnInfo{}
 }
 
+func convertOpenAICompatibleConnInfo(compatConnInfo *modelmgr.OpenAICompatibleConnInfo) *modelapi.OpenAICompatibleConnInfo {
+	if compatConnInfo == nil {
+		return nil
+	}
+	return &modelapi.OpenAICompatibleConnInfo{
+		ExtraHeaders:          compatConnInfo.ExtraHeaders,
+		ChatCompletionsPath:   compatConnInfo.ChatCompletionsPath,
+		DisableStreamOptions:  compatConnInfo.DisableStreamOptions,
+		DisableToolCall:       compatConnInfo.DisableToolCall,
+		DisableToolChoice:     compatConnInfo.DisableToolChoice,
+		DisableResponseFormat: compatConnInfo.DisableResponseFormat,
+		ReasoningField:        compatConnInfo.ReasoningField,
+	}
+}
+
 func encryptConn(ctx context.Context, conn entity.Connection) (entity.Connection, error) {
 	// encrypt conn if you need
 	return conn, nil
@@ -0,0 +1,226 @@
+package convert
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/domain/runtime"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+)
+
+// LLMParams 将运行时接口的模型配置转换为构建模型的参数，未设置的字段使用模型默认值
+func LLMParams(conf *runtime.ModelConfig) *modelmgr.LLMParams {
+	params := &modelmgr.LLMParams{}
+	if conf.Temperature != nil {
+		params.Temperature = ptr.Of(float32(*conf.Temperature))
+	}
+	if conf.MaxTokens != nil {
+		params.MaxTokens = int(*conf.MaxTokens)
+	}
+	if conf.TopP != nil {
+		params.TopP = ptr.Of(float32(*conf.TopP))
+	}
+	params.TopK = conf.TopK
+	if conf.PresencePenalty != nil {
+		params.PresencePenalty = float32(*conf.PresencePenalty)
+	}
+	if conf.FrequencyPenalty != nil {
+		params.FrequencyPenalty = float32(*conf.FrequencyPenalty)
+	}
+	if conf.ResponseFormat != nil && conf.ResponseFormat.Type != nil {
+		switch *conf.ResponseFormat.Type {
+		case runtime.ResponseFormatJSONObject:
+			params.ResponseFormat = modelmgr.ModelResponseFormat_JSON
+		case runtime.ResourceFormatMarkdown:
+			params.ResponseFormat = modelmgr.ModelResponseFormat_Markdown
+		}
+	}
+	return params
+}
+
+// ModelOptions 转换构建参数之外的单次调用选项
+func ModelOptions(conf *runtime.ModelConfig) []model.Option {
+	var opts []model.Option
+	if len(conf.Stop) > 0 {
+		opts = append(opts, model.WithStop(conf.Stop))
+	}
+	if conf.ToolChoice != nil {
+		switch *conf.ToolChoice {
+		case runtime.ToolChoiceAuto:
+			opts = append(opts, model.WithToolChoice(schema.ToolChoiceAllowed))
+		case runtime.ToolChoiceRequired:
+			opts = append(opts, model.WithToolChoice(schema.ToolChoiceForced))
+		case runtime.ToolChoiceNone:
+			opts = append(opts, model.WithToolChoice(schema.ToolChoiceForbidden))
+		}
+	}
+	return opts
+}
+
+func SchemaMessages(msgs []*runtime.Message) ([]*schema.Message, error) {
+	out := make([]*schema.Message, 0, len(msgs))
+	for i, msg := range msgs {
+		if msg == nil {
+			return nil, fmt.Errorf("messages[%d] is nil", i)
+		}
+		m, err := SchemaMessage(msg)
+		if err != nil {
+			return nil, fmt.Errorf("messages[%d]: %w", i, err)
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func SchemaMessage(msg *runtime.Message) (*schema.Message, error) {
+	m := &schema.Message{
+		Content:          ptr.From(msg.Content),
+		ReasoningContent: ptr.From(msg.ReasoningContent),
+		ToolCallID:       ptr.From(msg.ToolCallID),
+	}
+
+	switch msg.Role {
+	case runtime.RoleSystem:
+		m.Role = schema.System
+	case runtime.RoleUser:
+		m.Role = schema.User
+	case runtime.RoleAssistant:
+		m.Role = schema.Assistant
+	case runtime.RoleTool:
+		m.Role = schema.Tool
+		if m.ToolCallID == "" {
+			return nil, fmt.Errorf("tool_call_id is required for tool message")
+		}
+	default:
+		return nil, fmt.Errorf("unknown role %q", msg.Role)
+	}
+
+	for _, part := range msg.MultimodalContents {
+		if part == nil || part.Type == nil {
+			continue
+		}
+		switch *part.Type {
+		case runtime.ChatMessagePartTypeText:
+			m.MultiContent = append(m.MultiContent, schema.ChatMessagePart{
+				Type: schema.ChatMessagePartTypeText,
+				Text: ptr.From(part.Text),
+			})
+		case runtime.ChatMessagePartTypeImageURL:
+			if part.ImageURL == nil || ptr.From(part.ImageURL.URL) == "" {
+				return nil, fmt.Errorf("image_url is required for image_url part")
+			}
+			m.MultiContent = append(m.MultiContent, schema.ChatMessagePart{
+				Type: schema.ChatMessagePartTypeImageURL,
+				ImageURL: &schema.ChatMessageImageURL{
+					URL:      *part.ImageURL.URL,
+					Detail:   schema.ImageURLDetail(ptr.From(part.ImageURL.Detail)),
+					MIMEType: ptr.From(part.ImageURL.MimeType),
+				},
+			})
+		default:
+			return nil, fmt.Errorf("unsupported multimodal content type %q", *part.Type)
+		}
+	}
+
+	for _, tc := range msg.ToolCalls {
+		if tc == nil || tc.FunctionCall == nil {
+			continue
+		}
+		call := schema.ToolCall{
+			ID:   ptr.From(tc.ID),
+			Type: string(ptr.FromOrDefault(tc.Type, runtime.ToolTypeFunction)),
+			Function: schema.FunctionCall{
+				Name:      ptr.From(tc.FunctionCall.Name),
+				Arguments: ptr.From(tc.FunctionCall.Arguments),
+			},
+		}
+		if tc.Index != nil {
+			call.Index = ptr.Of(int(*tc.Index))
+		}
+		m.ToolCalls = append(m.ToolCalls, call)
+	}
+
+	return m, nil
+}
+
+// SchemaTools 转换工具定义，def 为 openapi3.Schema 序列化后的 json，描述工具的参数对象
+func SchemaTools(tools []*runtime.Tool) ([]*schema.ToolInfo, error) {
+	out := make([]*schema.ToolInfo, 0, len(tools))
+	for i, tool := range tools {
+		if tool == nil || ptr.From(tool.Name) == "" {
+			return nil, fmt.Errorf("tools[%d]: name is required", i)
+		}
+		info := &schema.ToolInfo{
+			Name: *tool.Name,
+			Desc: ptr.From(tool.Desc),
+		}
+		if def := ptr.From(tool.Def); def != "" {
+			defType := ptr.FromOrDefault(tool.DefType, runtime.ToolDefTypeOpenAPIV3)
+			if defType != runtime.ToolDefTypeOpenAPIV3 {
+				return nil, fmt.Errorf("tools[%d]: unsupported def_type %q", i, defType)
+			}
+			// openapi3 的参数对象与 JSON Schema 在工具定义中用到的字段一致
+			s := &jsonschema.Schema{}
+			if err := json.Unmarshal([]byte(def), s); err != nil {
+				return nil, fmt.Errorf("tools[%d]: invalid def: %w", i, err)
+			}
+			info.ParamsOneOf = schema.NewParamsOneOfByJSONSchema(s)
+		}
+		out = append(out, info)
+	}
+	return out, nil
+}
+
+func RuntimeMessage(msg *schema.Message) *runtime.Message {
+	if msg == nil {
+		return nil
+	}
+
+	m := &runtime.Message{
+		Role: string(msg.Role),
+	}
+	if msg.Content != "" {
+		m.Content = ptr.Of(msg.Content)
+	}
+	if msg.ReasoningContent != "" {
+		m.ReasoningContent = ptr.Of(msg.ReasoningContent)
+	}
+	if msg.ToolCallID != "" {
+		m.ToolCallID = ptr.Of(msg.ToolCallID)
+	}
+
+	for _, tc := range msg.ToolCalls {
+		call := &runtime.ToolCall{
+			ID:   ptr.Of(tc.ID),
+			Type: ptr.Of(tc.Type),
+			FunctionCall: &runtime.FunctionCall{
+				Name:      ptr.Of(tc.Function.Name),
+				Arguments: ptr.Of(tc.Function.Arguments),
+			},
+		}
+		if tc.Index != nil {
+			call.Index = ptr.Of(int64(*tc.Index))
+		}
+		m.ToolCalls = append(m.ToolCalls, call)
+	}
+
+	if meta := msg.ResponseMeta; meta != nil {
+		m.ResponseMeta = &runtime.ResponseMeta{}
+		if meta.FinishReason != "" {
+			m.ResponseMeta.FinishReason = ptr.Of(meta.FinishReason)
+		}
+		if meta.Usage != nil {
+			m.ResponseMeta.Usage = &runtime.TokenUsage{
+				PromptTokens:     ptr.Of(int64(meta.Usage.PromptTokens)),
+				CompletionTokens: ptr.Of(int64(meta.Usage.CompletionTokens)),
+				TotalTokens:      ptr.Of(int64(meta.Usage.TotalTokens)),
+			}
+		}
+	}
+
+	return m
+}
@@ -3,12 +3,16 @@ package application
 import (
 	"context"
 
+	"github.com/kiosk404/airi-go/backend/infra/contract/cache"
 	"github.com/kiosk404/airi-go/backend/infra/contract/idgen"
 	"github.com/kiosk404/airi-go/backend/infra/contract/rdb"
 	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
+	limiterimpl "github.com/kiosk404/airi-go/backend/infra/impl/limiter"
 	"github.com/kiosk404/airi-go/backend/modules/llm/domain/repo"
 	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg"
 	"github.com/kiosk404/airi-go/backend/pkg/conf"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
 )
 
 type (
@@ -22,10 +26,11 @@ type ServiceComponents struct {
 	DB        rdb.Provider
 	TosClient storage.Storage
 	IConf     conf.IConfigLoaderFactory
+	CacheCli  cache.Cmdable
 }
 
 func InitService(ctx context.Context, idGen idgen.IDGenerator, db rdb.Provider,
-	tosClient storage.Storage, iConf conf.IConfigLoaderFactory) *ModelManagerApplicationService {
+	tosClient storage.Storage, iConf conf.IConfigLoaderFactory, cacheCli cache.Cmdable) *ModelManagerApplicationService {
 	modelManageRepo := repo.NewModelMgrRepo(db, idGen)
 	modelMgrDomainSVC := modelmgr.NewService(tosClient, modelManageRepo, iConf)
 	ModelMgrSVC = newApplicationService(&ServiceComponents{
@@ -33,6 +38,20 @@ func InitService(ctx context.Context, idGen idgen.IDGenerator, db rdb.Provider,
 		DB:        db,
 		TosClient: tosClient,
 		IConf:     iConf,
+		CacheCli:  cacheCli,
 	}, modelMgrDomainSVC)
+
+	runtimeConf, err := loadModelRuntimeConfig(ctx, iConf)
+	if err != nil {
+		// 配置读取失败时不限制模型配额与预算
+		logs.WarnX(pkg.ModelName, "load model runtime config failed, model quota and budget disabled, err: %v", err)
+	}
+	ModelMgrSVC.runtimeConf = runtimeConf
+	if cacheCli != nil {
+		ModelMgrSVC.rateLimiter = limiterimpl.NewRateLimiterFactory(cacheCli).NewRateLimiter()
+	}
+
+	recordRepo := repo.NewModelRunRecordRepo(db.NewSession(ctx).DB())
+	ModelMgrSVC.usageLedger = modelmgr.NewUsageLedger(recordRepo, iConf, runtimeConf.GetUsageBudgets())
 	return ModelMgrSVC
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/cloudwego/eino/components/model"
 	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
 	"github.com/kiosk404/airi-go/backend/modules/llm/application/convert"
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/fallback"
 	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
 	"github.com/kiosk404/airi-go/backend/modules/llm/pkg"
 	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
@@ -22,11 +23,13 @@ type Service interface {
 }
 
 var modelClass2NewModelBuilder = map[modelmgr.ModelClass]func(m *modelmgr.Model) Service{
-	modelmgr.ModelClass_Ollama:   newOllamaModelBuilder,
-	modelmgr.ModelClass_GPT:      newOpenaiModelBuilder,
-	modelmgr.ModelClass_DeepSeek: newDeepseekModelBuilder,
-	modelmgr.ModelClass_Gemini:   newGeminiModelBuilder,
-	modelmgr.ModelClass_QWen:     newQwenModelBuilder,
+	modelmgr.ModelClass_Ollama:           newOllamaModelBuilder,
+	modelmgr.ModelClass_GPT:              newOpenaiModelBuilder,
+	modelmgr.ModelClass_DeepSeek:         newDeepseekModelBuilder,
+	modelmgr.ModelClass_Gemini:           newGeminiModelBuilder,
+	modelmgr.ModelClass_QWen:             newQwenModelBuilder,
+	modelmgr.ModelClass_Claude:           newClaudeModelBuilder,
+	modelmgr.ModelClass_OpenAICompatible: newOpenaiCompatibleModelBuilder,
 }
 
 func NewModelBuilder(modelClass modelmgr.ModelClass, cfg *modelmgr.Model) (Service, error) {
@@ -50,18 +53,65 @@ func NewModelBuilder(modelClass modelmgr.ModelClass, cfg *modelmgr.Model) (Servi
 	return buildFn(cfg), nil
 }
 
-func BuildModelByID(ctx context.Context, modelID int64, params *modelmgr.LLMParams) (bcm ToolCallingChatModel, info *modelmgr.Model, err error) {
+// BuildModelByID 构建模型，fallbackModelIDs 不为空时按顺序作为降级模型
+//
+// 有可用的降级模型时返回的模型带有重试与熔断，否则直接返回主模型，info 始终为 modelID 对应的模型。
+// 每个模型按 ctx 中的 modelmgr.CallScope 与 model_runtime_config.yaml 的配置检查 QPM/TPM 配额并记录用量，
+// 调用归属的用户或智能体超出费用预算时返回错误
+func BuildModelByID(ctx context.Context, modelID int64, params *modelmgr.LLMParams, fallbackModelIDs ...int64) (bcm ToolCallingChatModel, info *modelmgr.Model, err error) {
+	if err = checkBudget(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	primary, mm, err := buildModelByID(ctx, modelID, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := []*fallback.Candidate{primary}
+	for _, id := range fallbackModelIDs {
+		if id == modelID {
+			continue
+		}
+		// 降级模型不可用时只跳过，不影响主模型
+		cand, _, err := buildModelByID(ctx, id, params)
+		if err != nil {
+			logs.WarnX(pkg.ModelName, "build fallback model %d failed, skip, err: %v", id, err)
+			continue
+		}
+		candidates = append(candidates, cand)
+	}
+	if len(candidates) == 1 {
+		return primary.Model, mm, nil
+	}
+
+	bcm, err = fallback.NewChatModel(candidates, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build fallback model failed: %w", err)
+	}
+
+	return bcm, mm, nil
+}
+
+func buildModelByID(ctx context.Context, modelID int64, params *modelmgr.LLMParams) (*fallback.Candidate, *modelmgr.Model, error) {
 	m, err := ModelMgrSVC.DomainSVC.GetModelByID(ctx, modelID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("get model by id failed: %w", err)
 	}
 	mm := convert.ModelInstance(m)
-	bcm, err = buildModelWithConfParams(ctx, mm, params)
+	bcm, err := buildModelWithConfParams(ctx, mm, params)
 	if err != nil {
 		return nil, nil, fmt.Errorf("build model failed: %w", err)
 	}
 
-	return bcm, mm, nil
+	cand := &fallback.Candidate{
+		ID:    mm.ID,
+		Model: bcm,
+	}
+	if mm.DisplayInfo != nil {
+		cand.Name = mm.DisplayInfo.Name
+	}
+	return withQuota(ctx, withUsage(ctx, cand, mm)), mm, nil
 }
 
 func BuildModelBySettings(ctx context.Context, appSettings *bot_common.ModelInfo) (bcm ToolCallingChatModel, info *modelmgr.Model, err error) {
@@ -76,7 +126,7 @@ func BuildModelBySettings(ctx context.Context, appSettings *bot_common.ModelInfo
 
 	params := newLLMParamsWithSettings(appSettings)
 
-	return BuildModelByID(ctx, *appSettings.ModelId, params)
+	return BuildModelByID(ctx, *appSettings.ModelId, params, appSettings.FallbackModelIds...)
 }
 
 func buildModelWithConfParams(ctx context.Context, m *modelmgr.Model, params *modelmgr.LLMParams) (bcm ToolCallingChatModel, err error) {
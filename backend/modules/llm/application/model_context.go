@@ -0,0 +1,24 @@
+package application
+
+import (
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+)
+
+// contextWindowConfig 对应 model_runtime_config.yaml 中的 context_window
+type contextWindowConfig struct {
+	// DefaultMaxContextTokens 模型元数据未声明上下文长度时使用，为 0 表示不限制
+	DefaultMaxContextTokens int64 `json:"default_max_context_tokens"`
+}
+
+// GetMaxContextTokens 返回模型的上下文长度（输入与输出 token 之和），为 0 表示不限制
+//
+// 优先使用 model_meta.json 中声明的 max_tokens，未声明时使用 model_runtime_config.yaml 的默认值
+func GetMaxContextTokens(info *modelmgr.Model) int64 {
+	if info != nil && info.DisplayInfo != nil && info.DisplayInfo.MaxTokens > 0 {
+		return info.DisplayInfo.MaxTokens
+	}
+	if ModelMgrSVC == nil || ModelMgrSVC.runtimeConf == nil || ModelMgrSVC.runtimeConf.ContextWindow == nil {
+		return 0
+	}
+	return ModelMgrSVC.runtimeConf.ContextWindow.DefaultMaxContextTokens
+}
@@ -67,6 +67,18 @@ func getModelProviderList() []*modelapi.ModelProvider {
 			},
 			ModelClass: developer_api.ModelClass_GPT,
 		},
+		{
+			Name: &modelapi.I18nText{
+				ZhCn: "OpenAI 兼容模型",
+				EnUs: "OpenAI Compatible Model",
+			},
+			IconURI: "default_icon/openai_v2.png",
+			Description: &modelapi.I18nText{
+				ZhCn: "兼容 OpenAI 协议的自部署推理服务，如 vLLM、llama.cpp server、LM Studio",
+				EnUs: "self-hosted inference servers speaking the openai protocol, such as vLLM, llama.cpp server and LM Studio",
+			},
+			ModelClass: developer_api.ModelClass_OpenAICompatible,
+		},
 		{
 			Name: &modelapi.I18nText{
 				ZhCn: "Qwen 模型",
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiosk404/airi-go/backend/api/model/llm/domain/common"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/domain/manage"
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/fallback"
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/quota"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/domain/entity"
+	"github.com/kiosk404/airi-go/backend/pkg/conf"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+)
+
+const modelRuntimeConfigFile = "model_runtime_config.yaml"
+
+// modelRuntimeConfig 对应 model_runtime_config.yaml 中与模型调用相关的配置
+type modelRuntimeConfig struct {
+	// ScenarioConfigs 所有模型默认的场景配置，key 为场景
+	ScenarioConfigs map[string]*manage.ScenarioConfig `json:"scenario_configs"`
+	// ModelScenarioConfigs 按模型 ID 覆盖的场景配置
+	ModelScenarioConfigs map[string]map[string]*manage.ScenarioConfig `json:"model_scenario_configs"`
+	// UsageBudgets 用户与智能体的费用预算
+	UsageBudgets []*entity.UsageBudget `json:"usage_budgets"`
+	// ContextWindow 智能体运行时的上下文预算
+	ContextWindow *contextWindowConfig `json:"context_window"`
+}
+
+func loadModelRuntimeConfig(ctx context.Context, factory conf.IConfigLoaderFactory) (*modelRuntimeConfig, error) {
+	c := &modelRuntimeConfig{}
+	if factory == nil {
+		return c, nil
+	}
+
+	loader, err := factory.NewConfigLoader(modelRuntimeConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", modelRuntimeConfigFile, err)
+	}
+	if err = loader.Unmarshal(ctx, c, conf.WithTagName("json")); err != nil {
+		return nil, fmt.Errorf("error Unmarshal %s: %w", modelRuntimeConfigFile, err)
+	}
+
+	return c, nil
+}
+
+func (c *modelRuntimeConfig) GetUsageBudgets() []*entity.UsageBudget {
+	if c == nil {
+		return nil
+	}
+	return c.UsageBudgets
+}
+
+// getQuota 模型单独配置了该场景时优先使用，否则使用默认配置
+func (c *modelRuntimeConfig) getQuota(modelID int64, scenario string) *manage.Quota {
+	if c == nil {
+		return nil
+	}
+	if sc := c.ModelScenarioConfigs[conv.Int64ToStr(modelID)][scenario]; sc != nil {
+		return sc.Quota
+	}
+	if sc := c.ScenarioConfigs[scenario]; sc != nil {
+		return sc.Quota
+	}
+	return nil
+}
+
+// withQuota 按 ctx 中的配额维度为模型加上 QPM/TPM 限制
+func withQuota(ctx context.Context, cand *fallback.Candidate) *fallback.Candidate {
+	if ModelMgrSVC == nil || ModelMgrSVC.rateLimiter == nil {
+		return cand
+	}
+
+	scope := modelmgr.GetCallScope(ctx)
+	if scope == nil {
+		scope = &modelmgr.CallScope{}
+	}
+	scenario := scope.Scenario
+	if scenario == "" {
+		scenario = common.ScenarioDefault
+	}
+
+	q := ModelMgrSVC.runtimeConf.getQuota(cand.ID, scenario)
+	if q == nil {
+		return cand
+	}
+
+	cand.Model = quota.NewChatModel(cand.Model, &quota.Config{
+		Limiter:   ModelMgrSVC.rateLimiter,
+		Scenario:  scenario,
+		ModelID:   cand.ID,
+		ModelName: cand.Name,
+		UserID:    scope.UserID,
+		QPM:       q.GetQpm(),
+		TPM:       q.GetTpm(),
+	})
+	return cand
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/domain/common"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/domain/runtime"
+	llmruntime "github.com/kiosk404/airi-go/backend/api/model/llm/runtime"
+	"github.com/kiosk404/airi-go/backend/application/ctxutil"
+	"github.com/kiosk404/airi-go/backend/modules/llm/application/convert"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+)
+
+// Chat 直接调用模型，不经过智能体，使用平台中保存的模型凭证，配额与用量按当前登录用户与 biz_param 中的场景计算
+func (s *ModelManagerApplicationService) Chat(ctx context.Context, req *llmruntime.ChatRequest) (*llmruntime.ChatResponse, error) {
+	ctx, cm, in, opts, err := s.buildRuntimeChat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := cm.Generate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &llmruntime.ChatResponse{
+		Message: convert.RuntimeMessage(out),
+	}, nil
+}
+
+// ChatStream 流式调用模型，返回的每个消息为一个分片
+func (s *ModelManagerApplicationService) ChatStream(ctx context.Context, req *llmruntime.ChatRequest) (*schema.StreamReader[*runtime.Message], error) {
+	ctx, cm, in, opts, err := s.buildRuntimeChat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := cm.Stream(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.StreamReaderWithConvert(sr, func(msg *schema.Message) (*runtime.Message, error) {
+		return convert.RuntimeMessage(msg), nil
+	}), nil
+}
+
+func (s *ModelManagerApplicationService) buildRuntimeChat(ctx context.Context, req *llmruntime.ChatRequest) (
+	context.Context, model.BaseChatModel, []*schema.Message, []model.Option, error) {
+	conf := req.GetModelConfig()
+	if conf == nil || conf.ModelID == 0 {
+		return nil, nil, nil, nil, errorx.New(errno.ErrModelInvalidParamCode, errorx.KV("msg", "model_config.model_id is required"))
+	}
+	if len(req.GetMessages()) == 0 {
+		return nil, nil, nil, nil, errorx.New(errno.ErrModelInvalidParamCode, errorx.KV("msg", "messages is required"))
+	}
+
+	in, err := convert.SchemaMessages(req.GetMessages())
+	if err != nil {
+		return nil, nil, nil, nil, errorx.New(errno.ErrModelInvalidParamCode, errorx.KV("msg", err.Error()))
+	}
+	tools, err := convert.SchemaTools(req.GetTools())
+	if err != nil {
+		return nil, nil, nil, nil, errorx.New(errno.ErrModelInvalidParamCode, errorx.KV("msg", err.Error()))
+	}
+
+	scope, err := runtimeCallScope(ctx, req.GetBizParam())
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	ctx = modelmgr.WithCallScope(ctx, scope)
+	cm, _, err := BuildModelByID(ctx, conf.ModelID, convert.LLMParams(conf))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if len(tools) > 0 {
+		if cm, err = cm.WithTools(tools); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	return ctx, cm, in, convert.ModelOptions(conf), nil
+}
+
+// runtimeScenarios 允许客户端通过 biz_param 指定的场景
+var runtimeScenarios = map[string]bool{
+	common.ScenarioDefault:   true,
+	common.ScenarioEvaluator: true,
+}
+
+// runtimeCallScope 配额与用量始终归属到当前登录用户，忽略 biz_param 中的 user_id；场景只能取服务端定义的值
+func runtimeCallScope(ctx context.Context, bizParam *runtime.BizParam) (*modelmgr.CallScope, error) {
+	scope := &modelmgr.CallScope{}
+	if bizParam != nil {
+		scope.Scenario = ptr.From(bizParam.Scenario)
+	}
+	if scope.Scenario != "" && !runtimeScenarios[scope.Scenario] {
+		return nil, errorx.New(errno.ErrModelInvalidParamCode, errorx.KVf("msg", "unsupported scenario %q", scope.Scenario))
+	}
+	if uid := ctxutil.GetUIDFromCtx(ctx); uid != nil {
+		scope.UserID = conv.Int64ToStr(*uid)
+	}
+	return scope, nil
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/domain/common"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/domain/manage"
+	"github.com/kiosk404/airi-go/backend/api/model/llm/domain/runtime"
+	"github.com/kiosk404/airi-go/backend/infra/contract/limiter"
+	userEntity "github.com/kiosk404/airi-go/backend/modules/foundation/user/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/fallback"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	modelmgrservice "github.com/kiosk404/airi-go/backend/modules/llm/domain/service"
+	"github.com/kiosk404/airi-go/backend/modules/llm/internal/fakemodel"
+	"github.com/kiosk404/airi-go/backend/pkg/ctxcache"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/types/consts"
+	"github.com/stretchr/testify/assert"
+)
+
+// keyLimiter 记录配额计数使用的 key，不做限制
+type keyLimiter struct {
+	keys []string
+}
+
+func (l *keyLimiter) AllowN(_ context.Context, key string, _ int, _ ...limiter.LimitOptionFn) (*limiter.Result, error) {
+	l.keys = append(l.keys, key)
+	return &limiter.Result{Allowed: true, OriginKey: key, LimitKey: key}, nil
+}
+
+// scopeLedger 记录预算检查时的调用归属
+type scopeLedger struct {
+	modelmgrservice.UsageLedger
+	scopes []modelmgr.CallScope
+}
+
+func (l *scopeLedger) CheckBudget(_ context.Context, scope *modelmgr.CallScope) error {
+	l.scopes = append(l.scopes, *scope)
+	return nil
+}
+
+func sessionCtx(uid int64) context.Context {
+	ctx := ctxcache.Init(context.Background())
+	ctxcache.Store(ctx, consts.SessionDataKeyInCtx, &userEntity.Session{UserID: uid})
+	return ctx
+}
+
+func TestRuntimeCallScope(t *testing.T) {
+	ctx := sessionCtx(42)
+
+	// 客户端传入的 user_id 被忽略
+	scope, err := runtimeCallScope(ctx, &runtime.BizParam{
+		UserID:   ptr.Of("7"),
+		Scenario: ptr.Of(common.ScenarioEvaluator),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "42", scope.UserID)
+	assert.Equal(t, common.ScenarioEvaluator, scope.Scenario)
+
+	scope, err = runtimeCallScope(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", scope.UserID)
+	assert.Empty(t, scope.Scenario)
+
+	// 未配置配额的场景可以绕过限制，不允许客户端自定义
+	_, err = runtimeCallScope(ctx, &runtime.BizParam{Scenario: ptr.Of("no_quota")})
+	assert.Error(t, err)
+}
+
+func TestRuntimeChargedToSessionUser(t *testing.T) {
+	prev := ModelMgrSVC
+	defer func() { ModelMgrSVC = prev }()
+
+	l := &keyLimiter{}
+	ledger := &scopeLedger{}
+	ModelMgrSVC = &ModelManagerApplicationService{
+		rateLimiter: l,
+		usageLedger: ledger,
+		runtimeConf: &modelRuntimeConfig{ScenarioConfigs: map[string]*manage.ScenarioConfig{
+			common.ScenarioDefault: {Quota: &manage.Quota{Qpm: ptr.Of(int64(10))}},
+		}},
+	}
+
+	ctx := sessionCtx(42)
+	scope, err := runtimeCallScope(ctx, &runtime.BizParam{UserID: ptr.Of("7")})
+	assert.NoError(t, err)
+	ctx = modelmgr.WithCallScope(ctx, scope)
+
+	assert.NoError(t, checkBudget(ctx))
+	if assert.Len(t, ledger.scopes, 1) {
+		assert.Equal(t, "42", ledger.scopes[0].UserID)
+	}
+
+	cand := withQuota(ctx, &fallback.Candidate{ID: 1, Name: "m1", Model: &fakemodel.Model{
+		Chunks: []*schema.Message{schema.AssistantMessage("ok", nil)},
+	}})
+	_, err = cand.Model.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, l.keys) {
+		assert.Contains(t, l.keys[0], ":42")
+		assert.NotContains(t, l.keys[0], ":7")
+	}
+}
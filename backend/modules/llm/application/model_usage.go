@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/api/model/llm/usage"
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/fallback"
+	usagecomp "github.com/kiosk404/airi-go/backend/modules/llm/component/usage"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/slices"
+)
+
+const (
+	usageDateLayout      = "2006-01-02"
+	defaultUsageDays     = 7
+	defaultUsageTopLimit = 10
+	maxUsageTopLimit     = 100
+)
+
+// checkBudget 调用归属的用户或智能体超出预算时拒绝构建模型
+func checkBudget(ctx context.Context) error {
+	if ModelMgrSVC == nil || ModelMgrSVC.usageLedger == nil {
+		return nil
+	}
+	return ModelMgrSVC.usageLedger.CheckBudget(ctx, modelmgr.GetCallScope(ctx))
+}
+
+// withUsage 记录模型每次调用的用量，需要在配额之前包装，被配额拒绝的调用不记录
+func withUsage(ctx context.Context, cand *fallback.Candidate, m *modelmgr.Model) *fallback.Candidate {
+	if ModelMgrSVC == nil || ModelMgrSVC.usageLedger == nil {
+		return cand
+	}
+
+	scope := modelmgr.CallScope{}
+	if s := modelmgr.GetCallScope(ctx); s != nil {
+		scope = *s
+	}
+	conf := &usagecomp.Config{
+		Recorder:  ModelMgrSVC.usageLedger,
+		ModelID:   cand.ID,
+		ModelName: cand.Name,
+		Scope:     scope,
+	}
+	if m.Provider != nil {
+		conf.ModelClass = m.Provider.ModelClass
+	}
+	if m.Connection != nil && m.Connection.BaseConnInfo != nil {
+		conf.ModelIdentification = m.Connection.BaseConnInfo.Model
+	}
+
+	cand.Model = usagecomp.NewChatModel(cand.Model, conf)
+	return cand
+}
+
+func (s *ModelManagerApplicationService) GetDailyUsage(ctx context.Context, req *usage.GetDailyUsageRequest) (*usage.GetDailyUsageResponse, error) {
+	filter, err := toUsageFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := s.usageLedger.DailyUsage(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usage.GetDailyUsageResponse{
+		Data: slices.Transform(daily, func(d *entity.DailyUsage) *usage.DailyUsage {
+			return &usage.DailyUsage{Day: d.Day, Stat: toUsageStatDTO(&d.UsageStat)}
+		}),
+		Currency: s.usageLedger.Currency(),
+	}, nil
+}
+
+func (s *ModelManagerApplicationService) GetTopAgents(ctx context.Context, req *usage.GetTopAgentsRequest) (*usage.GetTopAgentsResponse, error) {
+	filter, err := toUsageFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	agents, err := s.usageLedger.TopAgents(ctx, filter, toUsageTopLimit(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &usage.GetTopAgentsResponse{
+		Data: slices.Transform(agents, func(a *entity.AgentUsage) *usage.AgentUsage {
+			return &usage.AgentUsage{AgentID: a.AgentID, Stat: toUsageStatDTO(&a.UsageStat)}
+		}),
+		Currency: s.usageLedger.Currency(),
+	}, nil
+}
+
+func (s *ModelManagerApplicationService) GetUserSpend(ctx context.Context, req *usage.GetUserSpendRequest) (*usage.GetUserSpendResponse, error) {
+	filter, err := toUsageFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.usageLedger.UserSpend(ctx, filter, toUsageTopLimit(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &usage.GetUserSpendResponse{
+		Data: slices.Transform(users, func(u *entity.UserUsage) *usage.UserUsage {
+			return &usage.UserUsage{UserID: u.UserID, Stat: toUsageStatDTO(&u.UsageStat)}
+		}),
+		Currency: s.usageLedger.Currency(),
+	}, nil
+}
+
+// toUsageFilter 日期按服务所在时区解析，结束日期当天包含在内
+func toUsageFilter(f *usage.UsageFilter) (*entity.UsageFilter, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	filter := &entity.UsageFilter{
+		StartTime: today.AddDate(0, 0, 1-defaultUsageDays),
+		EndTime:   today.AddDate(0, 0, 1),
+	}
+	if f == nil {
+		return filter, nil
+	}
+
+	if f.IsSetStartDate() {
+		start, err := time.ParseInLocation(usageDateLayout, f.GetStartDate(), time.Local)
+		if err != nil {
+			return nil, errorx.New(errno.ErrModelInvalidParamCode, errorx.KV("msg", fmt.Sprintf("invalid start_date %q", f.GetStartDate())))
+		}
+		filter.StartTime = start
+	}
+	if f.IsSetEndDate() {
+		end, err := time.ParseInLocation(usageDateLayout, f.GetEndDate(), time.Local)
+		if err != nil {
+			return nil, errorx.New(errno.ErrModelInvalidParamCode, errorx.KV("msg", fmt.Sprintf("invalid end_date %q", f.GetEndDate())))
+		}
+		filter.EndTime = end.AddDate(0, 0, 1)
+	}
+	if !filter.StartTime.Before(filter.EndTime) {
+		return nil, errorx.New(errno.ErrModelInvalidParamCode, errorx.KV("msg", "start_date must not be after end_date"))
+	}
+
+	filter.UserID = f.GetUserID()
+	filter.AgentID = f.GetAgentID()
+	if f.IsSetModelID() {
+		filter.ModelID = conv.Int64ToStr(f.GetModelID())
+	}
+	return filter, nil
+}
+
+func toUsageTopLimit(limit int32) int {
+	if limit <= 0 {
+		return defaultUsageTopLimit
+	}
+	return int(min(limit, maxUsageTopLimit))
+}
+
+func toUsageStatDTO(stat *entity.UsageStat) *usage.UsageStat {
+	return &usage.UsageStat{
+		Calls:           stat.Calls,
+		FailedCalls:     stat.FailedCalls,
+		InputTokens:     stat.InputToken,
+		OutputTokens:    stat.OutputToken,
+		ReasoningTokens: stat.ReasoningToken,
+		Cost:            stat.Cost,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/kiosk404/airi-go/backend/api/model/app/developer_api"
 	"github.com/kiosk404/airi-go/backend/api/model/modelapi"
+	"github.com/kiosk404/airi-go/backend/infra/contract/limiter"
 	"github.com/kiosk404/airi-go/backend/infra/contract/storage"
 	"github.com/kiosk404/airi-go/backend/modules/llm/application/convert"
 	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
@@ -27,6 +28,10 @@ type ModelManagerApplicationService struct {
 	appContext *ServiceComponents
 	DomainSVC  modelmgrservice.ModelManager
 	TosClient  storage.Storage
+
+	runtimeConf *modelRuntimeConfig
+	rateLimiter limiter.IRateLimiter
+	usageLedger modelmgrservice.UsageLedger
 }
 
 func newApplicationService(c *ServiceComponents, domain modelmgrservice.ModelManager) *ModelManagerApplicationService {
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/openaicompat"
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+)
+
+type openaiCompatibleModelBuilder struct {
+	cfg *model.Model
+}
+
+func newOpenaiCompatibleModelBuilder(cfg *model.Model) Service {
+	return &openaiCompatibleModelBuilder{
+		cfg: cfg,
+	}
+}
+
+func (o *openaiCompatibleModelBuilder) getDefaultConfig() *openaicompat.Config {
+	return &openaicompat.Config{
+		ChatModelConfig: openai.ChatModelConfig{
+			MaxTokens: ptr.Of(4096),
+		},
+	}
+}
+
+func (o *openaiCompatibleModelBuilder) applyParamsToConfig(conf *openaicompat.Config, params *model.LLMParams) {
+	if params == nil {
+		return
+	}
+
+	if params.Temperature != nil {
+		conf.Temperature = ptr.Of(*params.Temperature)
+	}
+
+	if params.MaxTokens != 0 {
+		conf.MaxTokens = ptr.Of(params.MaxTokens)
+	}
+
+	if params.FrequencyPenalty != 0 {
+		conf.FrequencyPenalty = ptr.Of(params.FrequencyPenalty)
+	}
+
+	if params.PresencePenalty != 0 {
+		conf.PresencePenalty = ptr.Of(params.PresencePenalty)
+	}
+
+	conf.TopP = params.TopP
+
+	// 部分推理服务（如 LM Studio）不接受 type 为 text 的 response_format，只在需要 JSON 时下发
+	if params.ResponseFormat == model.ModelResponseFormat_JSON {
+		conf.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		}
+	}
+}
+
+func (o *openaiCompatibleModelBuilder) Build(ctx context.Context, params *model.LLMParams) (ToolCallingChatModel, error) {
+	base := o.cfg.Connection.BaseConnInfo
+
+	conf := o.getDefaultConfig()
+	conf.APIKey = base.APIKey
+	conf.Model = base.Model
+	conf.BaseURL = base.BaseURL
+
+	if compat := o.cfg.Connection.OpenAICompatible; compat != nil {
+		conf.ExtraHeaders = compat.ExtraHeaders
+		conf.ChatCompletionsPath = compat.ChatCompletionsPath
+		conf.DisableStreamOptions = compat.DisableStreamOptions
+		conf.DisableToolCall = compat.DisableToolCall
+		conf.DisableToolChoice = compat.DisableToolChoice
+		conf.DisableResponseFormat = compat.DisableResponseFormat
+		conf.ReasoningField = compat.ReasoningField
+	}
+
+	o.applyParamsToConfig(conf, params)
+
+	return openaicompat.NewChatModel(ctx, conf)
+}
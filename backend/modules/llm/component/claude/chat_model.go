@@ -0,0 +1,179 @@
+package claude
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+)
+
+const (
+	defaultBaseURL    = "https://api.anthropic.com"
+	defaultMaxTokens  = 4096
+	anthropicVersion  = "2023-06-01"
+	messagesPath      = "/v1/messages"
+	minThinkingBudget = 1024
+)
+
+// Config Anthropic Messages API 的连接与生成参数
+type Config struct {
+	APIKey string
+	// BaseURL 为空时使用官方地址，兼容以 /v1 结尾的地址
+	BaseURL string
+	Model   string
+	// MaxTokens Messages API 要求必填，为 0 时使用 4096
+	MaxTokens     int
+	Temperature   *float32
+	TopP          *float32
+	TopK          *int32
+	StopSequences []string
+
+	// Thinking 不为空时开启 extended thinking
+	Thinking *Thinking
+
+	HTTPClient *http.Client
+}
+
+// Thinking extended thinking 配置，BudgetTokens 不足 1024 时按 1024 处理
+type Thinking struct {
+	BudgetTokens int
+}
+
+// ChatModel 基于 Anthropic Messages API 的 ToolCallingChatModel 实现
+type ChatModel struct {
+	conf     *Config
+	endpoint string
+	client   *http.Client
+	tools    []*schema.ToolInfo
+}
+
+func NewChatModel(_ context.Context, conf *Config) (*ChatModel, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("[claude] config is nil")
+	}
+	if conf.Model == "" {
+		return nil, fmt.Errorf("[claude] model is empty")
+	}
+
+	baseURL := strings.TrimRight(conf.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	endpoint := baseURL + messagesPath
+	if strings.HasSuffix(baseURL, "/v1") {
+		endpoint = baseURL + strings.TrimPrefix(messagesPath, "/v1")
+	}
+
+	client := conf.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &ChatModel{
+		conf:     conf,
+		endpoint: endpoint,
+		client:   client,
+	}, nil
+}
+
+func (c *ChatModel) GetType() string {
+	return "Claude"
+}
+
+func (c *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	if len(tools) == 0 {
+		return nil, fmt.Errorf("[claude] no tools to bind")
+	}
+
+	nc := *c
+	nc.tools = tools
+	return &nc, nil
+}
+
+func (c *ChatModel) Generate(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	req, err := c.buildRequest(in, false, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	resp := &messageResponse{}
+	if err = json.NewDecoder(body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("[claude] decode response failed: %w", err)
+	}
+	return toSchemaMessage(resp)
+}
+
+func (c *ChatModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	req, err := c.buildRequest(in, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, sw := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				sw.Send(nil, fmt.Errorf("[claude] panic while reading stream: %v", r))
+			}
+			body.Close()
+			sw.Close()
+		}()
+		readStream(body, sw)
+	}()
+
+	return sr, nil
+}
+
+func (c *ChatModel) do(ctx context.Context, req *messageRequest) (io.ReadCloser, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("[claude] marshal request failed: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", c.conf.APIKey)
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
+	if req.Stream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("[claude] request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, parseErrorResponse(resp)
+	}
+	return resp.Body, nil
+}
+
+func parseErrorResponse(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	errResp := &errorResponse{}
+	if err := json.Unmarshal(data, errResp); err == nil && errResp.Error != nil {
+		return fmt.Errorf("[claude] status %d, %s: %s", resp.StatusCode, errResp.Error.Type, errResp.Error.Message)
+	}
+	return fmt.Errorf("[claude] status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+}
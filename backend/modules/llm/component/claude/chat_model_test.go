@@ -0,0 +1,202 @@
+package claude
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, check func(req *messageRequest), respond func(w http.ResponseWriter)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := &messageRequest{}
+		assert.NoError(t, json.Unmarshal(body, req))
+		check(req)
+		respond(w)
+	}))
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t, func(req *messageRequest) {
+		assert.Equal(t, "claude-test", req.Model)
+		assert.Equal(t, "be nice", req.System)
+		assert.False(t, req.Stream)
+		assert.Equal(t, "enabled", req.Thinking.Type)
+		assert.Equal(t, minThinkingBudget, req.Thinking.BudgetTokens)
+		assert.Nil(t, req.Temperature)
+		if assert.Len(t, req.Tools, 1) {
+			assert.Equal(t, "get_weather", req.Tools[0].Name)
+		}
+
+		// user, assistant(thinking + tool_use), user(tool_result + text)
+		if assert.Len(t, req.Messages, 3) {
+			user := req.Messages[0]
+			assert.Equal(t, "image", user.Content[1].Type)
+			assert.Equal(t, "base64", user.Content[1].Source.Type)
+			assert.Equal(t, "image/png", user.Content[1].Source.MediaType)
+
+			assistant := req.Messages[1]
+			assert.Equal(t, "thinking", assistant.Content[0].Type)
+			assert.Equal(t, "sig", assistant.Content[0].Signature)
+			assert.Equal(t, "tool_use", assistant.Content[1].Type)
+			assert.JSONEq(t, `{"city":"paris"}`, string(assistant.Content[1].Input))
+
+			result := req.Messages[2]
+			assert.Equal(t, "user", result.Role)
+			assert.Equal(t, "tool_result", result.Content[0].Type)
+			assert.Equal(t, "call_1", result.Content[0].ToolUseID)
+			assert.Equal(t, "text", result.Content[1].Type)
+		}
+	}, func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte(`{
+			"id": "msg_1", "role": "assistant", "stop_reason": "tool_use",
+			"content": [
+				{"type": "thinking", "thinking": "need weather", "signature": "sig2"},
+				{"type": "text", "text": "let me check"},
+				{"type": "tool_use", "id": "call_2", "name": "get_weather", "input": {"city": "rome"}}
+			],
+			"usage": {"input_tokens": 10, "cache_read_input_tokens": 5, "output_tokens": 7}
+		}`))
+	})
+	defer srv.Close()
+
+	cm, err := NewChatModel(ctx, &Config{
+		APIKey:      "test-key",
+		BaseURL:     srv.URL,
+		Model:       "claude-test",
+		MaxTokens:   512,
+		Temperature: ptr.Of(float32(0.5)),
+		Thinking:    &Thinking{},
+	})
+	assert.NoError(t, err)
+	tcm, err := cm.WithTools([]*schema.ToolInfo{{
+		Name: "get_weather",
+		Desc: "get weather of a city",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"city": {Type: schema.String, Required: true},
+		}),
+	}})
+	assert.NoError(t, err)
+
+	out, err := tcm.Generate(ctx, []*schema.Message{
+		schema.SystemMessage("be nice"),
+		{
+			Role: schema.User,
+			UserInputMultiContent: []schema.MessageInputPart{
+				{Type: schema.ChatMessagePartTypeText, Text: "weather?"},
+				{Type: schema.ChatMessagePartTypeImageURL, Image: &schema.MessageInputImage{
+					MessagePartCommon: schema.MessagePartCommon{URL: ptr.Of("data:image/png;base64,aGk=")},
+				}},
+			},
+		},
+		{
+			Role:             schema.Assistant,
+			ReasoningContent: "think",
+			Extra:            map[string]any{extraKeyThinkingSignature: "sig"},
+			ToolCalls: []schema.ToolCall{{
+				ID: "call_1", Function: schema.FunctionCall{Name: "get_weather", Arguments: `{"city":"paris"}`},
+			}},
+		},
+		schema.ToolMessage("sunny", "call_1"),
+		schema.UserMessage("and rome?"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "let me check", out.Content)
+	assert.Equal(t, "need weather", out.ReasoningContent)
+	assert.Equal(t, "sig2", out.Extra[extraKeyThinkingSignature])
+	if assert.Len(t, out.ToolCalls, 1) {
+		assert.Equal(t, "call_2", out.ToolCalls[0].ID)
+		assert.JSONEq(t, `{"city":"rome"}`, out.ToolCalls[0].Function.Arguments)
+	}
+	assert.Equal(t, "tool_use", out.ResponseMeta.FinishReason)
+	assert.Equal(t, 15, out.ResponseMeta.Usage.PromptTokens)
+	assert.Equal(t, 5, out.ResponseMeta.Usage.PromptTokenDetails.CachedTokens)
+	assert.Equal(t, 22, out.ResponseMeta.Usage.TotalTokens)
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	events := []string{
+		`{"type":"message_start","message":{"id":"msg_1","role":"assistant","content":[],"usage":{"input_tokens":12,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"ping"}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":" world"}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"call_1","name":"get_weather","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"city\":"}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"paris\"}"}}`,
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":20}}`,
+		`{"type":"message_stop"}`,
+	}
+	srv := newTestServer(t, func(req *messageRequest) {
+		assert.True(t, req.Stream)
+		assert.Nil(t, req.Thinking)
+		assert.Equal(t, []*message{{Role: "user", Content: []*contentBlock{{Type: "text", Text: "hi"}}}}, req.Messages)
+	}, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		var sb strings.Builder
+		for _, e := range events {
+			sb.WriteString("event: x\ndata: " + e + "\n\n")
+		}
+		_, _ = w.Write([]byte(sb.String()))
+	})
+	defer srv.Close()
+
+	cm, err := NewChatModel(ctx, &Config{APIKey: "test-key", BaseURL: srv.URL + "/v1", Model: "claude-test"})
+	assert.NoError(t, err)
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+
+	out, err := schema.ConcatMessages(chunks)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello world", out.Content)
+	if assert.Len(t, out.ToolCalls, 1) {
+		assert.Equal(t, "call_1", out.ToolCalls[0].ID)
+		assert.Equal(t, "get_weather", out.ToolCalls[0].Function.Name)
+		assert.JSONEq(t, `{"city":"paris"}`, out.ToolCalls[0].Function.Arguments)
+	}
+	assert.Equal(t, "tool_use", out.ResponseMeta.FinishReason)
+	assert.Equal(t, 12, out.ResponseMeta.Usage.PromptTokens)
+	assert.Equal(t, 20, out.ResponseMeta.Usage.CompletionTokens)
+}
+
+func TestErrorResponse(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t, func(*messageRequest) {}, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type":"error","error":{"type":"invalid_request_error","message":"max_tokens is too large"}}`))
+	})
+	defer srv.Close()
+
+	cm, err := NewChatModel(ctx, &Config{APIKey: "test-key", BaseURL: srv.URL, Model: "claude-test"})
+	assert.NoError(t, err)
+
+	_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.ErrorContains(t, err, "invalid_request_error: max_tokens is too large")
+}
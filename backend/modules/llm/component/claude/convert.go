@@ -0,0 +1,373 @@
+package claude
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+)
+
+// extraKeyThinkingSignature 模型返回的 thinking 签名保存在消息的 Extra 中，
+// 工具调用后继续对话时需要原样回传，否则开启 thinking 时请求会被拒绝
+const extraKeyThinkingSignature = "claude_thinking_signature"
+
+type messageRequest struct {
+	Model         string          `json:"model"`
+	MaxTokens     int             `json:"max_tokens"`
+	System        string          `json:"system,omitempty"`
+	Messages      []*message      `json:"messages"`
+	Temperature   *float32        `json:"temperature,omitempty"`
+	TopP          *float32        `json:"top_p,omitempty"`
+	TopK          *int32          `json:"top_k,omitempty"`
+	StopSequences []string        `json:"stop_sequences,omitempty"`
+	Tools         []*toolDef      `json:"tools,omitempty"`
+	ToolChoice    *toolChoice     `json:"tool_choice,omitempty"`
+	Thinking      *thinkingConfig `json:"thinking,omitempty"`
+	Stream        bool            `json:"stream,omitempty"`
+}
+
+type message struct {
+	Role    string          `json:"role"`
+	Content []*contentBlock `json:"content"`
+}
+
+// contentBlock 请求与响应共用的内容块，仅与 Type 对应的字段有值
+type contentBlock struct {
+	Type string `json:"type"`
+
+	// text
+	Text string `json:"text,omitempty"`
+
+	// image
+	Source *imageSource `json:"source,omitempty"`
+
+	// tool_use
+	ID    string             `json:"id,omitempty"`
+	Name  string             `json:"name,omitempty"`
+	Input stdjson.RawMessage `json:"input,omitempty"`
+
+	// tool_result
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	Content   string `json:"content,omitempty"`
+
+	// thinking
+	Thinking  string `json:"thinking,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+type imageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type toolDef struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"input_schema"`
+}
+
+type toolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type thinkingConfig struct {
+	Type         string `json:"type"`
+	BudgetTokens int    `json:"budget_tokens,omitempty"`
+}
+
+type messageResponse struct {
+	ID         string          `json:"id"`
+	Role       string          `json:"role"`
+	Content    []*contentBlock `json:"content"`
+	StopReason string          `json:"stop_reason"`
+	Usage      *usage          `json:"usage"`
+}
+
+type usage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+type errorResponse struct {
+	Error *apiError `json:"error"`
+}
+
+type apiError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (c *ChatModel) buildRequest(in []*schema.Message, stream bool, opts ...model.Option) (*messageRequest, error) {
+	maxTokens := c.conf.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokens
+	}
+	options := model.GetCommonOptions(&model.Options{
+		Model:       &c.conf.Model,
+		MaxTokens:   &maxTokens,
+		Temperature: c.conf.Temperature,
+		TopP:        c.conf.TopP,
+		Stop:        c.conf.StopSequences,
+		Tools:       c.tools,
+	}, opts...)
+
+	req := &messageRequest{
+		Model:         *options.Model,
+		MaxTokens:     *options.MaxTokens,
+		Temperature:   options.Temperature,
+		TopP:          options.TopP,
+		TopK:          c.conf.TopK,
+		StopSequences: options.Stop,
+		Stream:        stream,
+	}
+
+	if c.conf.Thinking != nil {
+		budget := max(c.conf.Thinking.BudgetTokens, minThinkingBudget)
+		req.Thinking = &thinkingConfig{Type: "enabled", BudgetTokens: budget}
+		// budget_tokens 必须小于 max_tokens，且开启 thinking 时不支持调整采样参数
+		if req.MaxTokens <= budget {
+			req.MaxTokens = budget + defaultMaxTokens
+		}
+		req.Temperature, req.TopP, req.TopK = nil, nil, nil
+	}
+
+	var err error
+	if req.System, req.Messages, err = toClaudeMessages(in); err != nil {
+		return nil, err
+	}
+	if req.Tools, err = toToolDefs(options.Tools); err != nil {
+		return nil, err
+	}
+	if len(req.Tools) > 0 {
+		req.ToolChoice = toToolChoice(options.ToolChoice, options.AllowedToolNames)
+	}
+
+	return req, nil
+}
+
+// toClaudeMessages system 消息合并为顶层的 system，tool 消息转为 user 角色的 tool_result，相邻的同角色消息合并为一条
+func toClaudeMessages(in []*schema.Message) (string, []*message, error) {
+	var system []string
+	msgs := make([]*message, 0, len(in))
+	for _, m := range in {
+		if m == nil {
+			continue
+		}
+
+		var role string
+		var blocks []*contentBlock
+		var err error
+		switch m.Role {
+		case schema.System:
+			system = append(system, m.Content)
+			continue
+		case schema.User:
+			role = "user"
+			blocks, err = toUserBlocks(m)
+		case schema.Assistant:
+			role = "assistant"
+			blocks, err = toAssistantBlocks(m)
+		case schema.Tool:
+			role = "user"
+			blocks = []*contentBlock{{Type: "tool_result", ToolUseID: m.ToolCallID, Content: m.Content}}
+		default:
+			return "", nil, fmt.Errorf("[claude] unknown role %q", m.Role)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if len(blocks) == 0 {
+			continue
+		}
+
+		if last := len(msgs) - 1; last >= 0 && msgs[last].Role == role {
+			msgs[last].Content = append(msgs[last].Content, blocks...)
+			continue
+		}
+		msgs = append(msgs, &message{Role: role, Content: blocks})
+	}
+
+	return strings.Join(system, "\n"), msgs, nil
+}
+
+func toUserBlocks(m *schema.Message) ([]*contentBlock, error) {
+	var blocks []*contentBlock
+	switch {
+	case len(m.UserInputMultiContent) > 0:
+		for _, part := range m.UserInputMultiContent {
+			switch part.Type {
+			case schema.ChatMessagePartTypeText:
+				blocks = append(blocks, &contentBlock{Type: "text", Text: part.Text})
+			case schema.ChatMessagePartTypeImageURL:
+				if part.Image == nil {
+					continue
+				}
+				src, err := toImageSource(part.Image.URL, part.Image.Base64Data, part.Image.MIMEType)
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, &contentBlock{Type: "image", Source: src})
+			default:
+				return nil, fmt.Errorf("[claude] unsupported input part type %q", part.Type)
+			}
+		}
+	case len(m.MultiContent) > 0:
+		for _, part := range m.MultiContent {
+			switch part.Type {
+			case schema.ChatMessagePartTypeText:
+				blocks = append(blocks, &contentBlock{Type: "text", Text: part.Text})
+			case schema.ChatMessagePartTypeImageURL:
+				if part.ImageURL == nil {
+					continue
+				}
+				src, err := toImageSource(&part.ImageURL.URL, nil, part.ImageURL.MIMEType)
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, &contentBlock{Type: "image", Source: src})
+			default:
+				return nil, fmt.Errorf("[claude] unsupported content part type %q", part.Type)
+			}
+		}
+	case m.Content != "":
+		blocks = append(blocks, &contentBlock{Type: "text", Text: m.Content})
+	}
+	return blocks, nil
+}
+
+// toImageSource 支持 http(s) 链接、data URL 以及单独传入的 base64 数据
+func toImageSource(url, base64Data *string, mimeType string) (*imageSource, error) {
+	if base64Data != nil && *base64Data != "" {
+		if mimeType == "" {
+			return nil, fmt.Errorf("[claude] mime type is required for base64 image")
+		}
+		return &imageSource{Type: "base64", MediaType: mimeType, Data: *base64Data}, nil
+	}
+	if url == nil || *url == "" {
+		return nil, fmt.Errorf("[claude] image url is empty")
+	}
+
+	if rest, ok := strings.CutPrefix(*url, "data:"); ok {
+		meta, data, found := strings.Cut(rest, ",")
+		mediaType, isBase64 := strings.CutSuffix(meta, ";base64")
+		if !found || !isBase64 {
+			return nil, fmt.Errorf("[claude] only base64 data url is supported")
+		}
+		return &imageSource{Type: "base64", MediaType: mediaType, Data: data}, nil
+	}
+	return &imageSource{Type: "url", URL: *url}, nil
+}
+
+func toAssistantBlocks(m *schema.Message) ([]*contentBlock, error) {
+	var blocks []*contentBlock
+	if signature, _ := m.Extra[extraKeyThinkingSignature].(string); signature != "" && m.ReasoningContent != "" {
+		blocks = append(blocks, &contentBlock{Type: "thinking", Thinking: m.ReasoningContent, Signature: signature})
+	}
+	if m.Content != "" {
+		blocks = append(blocks, &contentBlock{Type: "text", Text: m.Content})
+	}
+	for _, tc := range m.ToolCalls {
+		input := stdjson.RawMessage("{}")
+		if args := strings.TrimSpace(tc.Function.Arguments); args != "" {
+			if !json.Valid([]byte(args)) {
+				return nil, fmt.Errorf("[claude] invalid arguments of tool call %s", tc.ID)
+			}
+			input = stdjson.RawMessage(args)
+		}
+		blocks = append(blocks, &contentBlock{Type: "tool_use", ID: tc.ID, Name: tc.Function.Name, Input: input})
+	}
+	return blocks, nil
+}
+
+func toToolDefs(tools []*schema.ToolInfo) ([]*toolDef, error) {
+	defs := make([]*toolDef, 0, len(tools))
+	for _, t := range tools {
+		var inputSchema any = map[string]any{"type": "object", "properties": map[string]any{}}
+		if t.ParamsOneOf != nil {
+			s, err := t.ParamsOneOf.ToJSONSchema()
+			if err != nil {
+				return nil, fmt.Errorf("[claude] convert schema of tool %s failed: %w", t.Name, err)
+			}
+			if s != nil {
+				inputSchema = s
+			}
+		}
+		defs = append(defs, &toolDef{Name: t.Name, Description: t.Desc, InputSchema: inputSchema})
+	}
+	return defs, nil
+}
+
+func toToolChoice(choice *schema.ToolChoice, allowed []string) *toolChoice {
+	if choice == nil {
+		return nil
+	}
+	switch *choice {
+	case schema.ToolChoiceForbidden:
+		return &toolChoice{Type: "none"}
+	case schema.ToolChoiceForced:
+		if len(allowed) == 1 {
+			return &toolChoice{Type: "tool", Name: allowed[0]}
+		}
+		return &toolChoice{Type: "any"}
+	default:
+		return &toolChoice{Type: "auto"}
+	}
+}
+
+func toSchemaMessage(resp *messageResponse) (*schema.Message, error) {
+	msg := &schema.Message{
+		Role: schema.Assistant,
+		ResponseMeta: &schema.ResponseMeta{
+			FinishReason: resp.StopReason,
+			Usage:        toTokenUsage(resp.Usage),
+		},
+	}
+
+	var text, reasoning strings.Builder
+	for _, block := range resp.Content {
+		switch block.Type {
+		case "text":
+			text.WriteString(block.Text)
+		case "thinking":
+			reasoning.WriteString(block.Thinking)
+			if block.Signature != "" {
+				msg.Extra = map[string]any{extraKeyThinkingSignature: block.Signature}
+			}
+		case "tool_use":
+			args := string(block.Input)
+			if args == "" {
+				args = "{}"
+			}
+			msg.ToolCalls = append(msg.ToolCalls, schema.ToolCall{
+				ID:       block.ID,
+				Type:     "function",
+				Function: schema.FunctionCall{Name: block.Name, Arguments: args},
+			})
+		}
+	}
+	msg.Content = text.String()
+	msg.ReasoningContent = reasoning.String()
+	return msg, nil
+}
+
+// toTokenUsage 缓存命中与写入缓存的 token 均计入 PromptTokens
+func toTokenUsage(u *usage) *schema.TokenUsage {
+	if u == nil {
+		return nil
+	}
+	prompt := u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+	return &schema.TokenUsage{
+		PromptTokens:       prompt,
+		PromptTokenDetails: schema.PromptTokenDetails{CachedTokens: u.CacheReadInputTokens},
+		CompletionTokens:   u.OutputTokens,
+		TotalTokens:        prompt + u.OutputTokens,
+	}
+}
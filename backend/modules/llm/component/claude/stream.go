@@ -0,0 +1,161 @@
+package claude
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+)
+
+// maxEventSize 单个 SSE 事件的最大长度
+const maxEventSize = 4 << 20
+
+type streamEvent struct {
+	Type string `json:"type"`
+
+	// message_start
+	Message *messageResponse `json:"message,omitempty"`
+
+	// content_block_start / content_block_delta
+	Index        int           `json:"index"`
+	ContentBlock *contentBlock `json:"content_block,omitempty"`
+	Delta        *streamDelta  `json:"delta,omitempty"`
+
+	// message_delta
+	Usage *usage `json:"usage,omitempty"`
+
+	// error
+	Error *apiError `json:"error,omitempty"`
+}
+
+type streamDelta struct {
+	Type        string `json:"type"`
+	Text        string `json:"text,omitempty"`
+	PartialJSON string `json:"partial_json,omitempty"`
+	Thinking    string `json:"thinking,omitempty"`
+	Signature   string `json:"signature,omitempty"`
+	StopReason  string `json:"stop_reason,omitempty"`
+}
+
+// readStream 将 Messages API 的 SSE 事件转为消息分片写入 sw
+//
+// 工具调用按出现顺序编号作为 ToolCall.Index，分片拼接时据此合并参数；
+// usage 在 message_start 中给出输入 token，在 message_delta 中给出累计的输出 token，统一在最后一个分片中返回
+func readStream(body io.Reader, sw *schema.StreamWriter[*schema.Message]) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var inputUsage *usage
+	toolIndexes := make(map[int]int)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		event := &streamEvent{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), event); err != nil {
+			sw.Send(nil, fmt.Errorf("[claude] decode stream event failed: %w", err))
+			return
+		}
+
+		var chunk *schema.Message
+		switch event.Type {
+		case "message_start":
+			if event.Message != nil {
+				inputUsage = event.Message.Usage
+			}
+		case "content_block_start":
+			if event.ContentBlock != nil && event.ContentBlock.Type == "tool_use" {
+				idx := len(toolIndexes)
+				toolIndexes[event.Index] = idx
+				chunk = &schema.Message{
+					Role: schema.Assistant,
+					ToolCalls: []schema.ToolCall{{
+						Index:    ptr.Of(idx),
+						ID:       event.ContentBlock.ID,
+						Type:     "function",
+						Function: schema.FunctionCall{Name: event.ContentBlock.Name},
+					}},
+				}
+			}
+		case "content_block_delta":
+			chunk = deltaToMessage(event, toolIndexes)
+		case "message_delta":
+			chunk = &schema.Message{Role: schema.Assistant, ResponseMeta: &schema.ResponseMeta{}}
+			if event.Delta != nil {
+				chunk.ResponseMeta.FinishReason = event.Delta.StopReason
+			}
+			chunk.ResponseMeta.Usage = toTokenUsage(mergeUsage(inputUsage, event.Usage))
+		case "error":
+			if event.Error != nil {
+				sw.Send(nil, fmt.Errorf("[claude] stream error, %s: %s", event.Error.Type, event.Error.Message))
+			} else {
+				sw.Send(nil, fmt.Errorf("[claude] stream error"))
+			}
+			return
+		case "message_stop":
+			return
+		}
+
+		if chunk != nil {
+			if closed := sw.Send(chunk, nil); closed {
+				return
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		sw.Send(nil, fmt.Errorf("[claude] read stream failed: %w", err))
+	}
+}
+
+func deltaToMessage(event *streamEvent, toolIndexes map[int]int) *schema.Message {
+	if event.Delta == nil {
+		return nil
+	}
+
+	switch event.Delta.Type {
+	case "text_delta":
+		return &schema.Message{Role: schema.Assistant, Content: event.Delta.Text}
+	case "thinking_delta":
+		return &schema.Message{Role: schema.Assistant, ReasoningContent: event.Delta.Thinking}
+	case "signature_delta":
+		return &schema.Message{Role: schema.Assistant, Extra: map[string]any{extraKeyThinkingSignature: event.Delta.Signature}}
+	case "input_json_delta":
+		idx, ok := toolIndexes[event.Index]
+		if !ok || event.Delta.PartialJSON == "" {
+			return nil
+		}
+		return &schema.Message{
+			Role: schema.Assistant,
+			ToolCalls: []schema.ToolCall{{
+				Index:    ptr.Of(idx),
+				Function: schema.FunctionCall{Arguments: event.Delta.PartialJSON},
+			}},
+		}
+	}
+	return nil
+}
+
+// mergeUsage message_delta 中的 usage 为累计值，输入相关的 token 可能只在 message_start 中给出
+func mergeUsage(start, delta *usage) *usage {
+	if start == nil && delta == nil {
+		return nil
+	}
+
+	merged := &usage{}
+	if start != nil {
+		*merged = *start
+	}
+	if delta != nil {
+		merged.OutputTokens = delta.OutputTokens
+		merged.InputTokens = max(merged.InputTokens, delta.InputTokens)
+		merged.CacheCreationInputTokens = max(merged.CacheCreationInputTokens, delta.CacheCreationInputTokens)
+		merged.CacheReadInputTokens = max(merged.CacheReadInputTokens, delta.CacheReadInputTokens)
+	}
+	return merged
+}
@@ -0,0 +1,107 @@
+package fallback
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// defaultBreakers 进程内共享的熔断器，同一个模型在不同的智能体与请求之间共用熔断状态
+var defaultBreakers = NewBreakerGroup(defaultBreakerThreshold, defaultBreakerCooldown)
+
+// BreakerGroup 按模型 ID 维护的熔断器
+//
+// 连续 threshold 次可重试错误后熔断，cooldown 内跳过该模型；
+// cooldown 结束后进入半开状态，只放行一个探测请求，探测成功则恢复，失败则重新熔断
+type BreakerGroup struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	breakers map[int64]*breaker
+	now      func() time.Time
+}
+
+type breaker struct {
+	failures  int
+	openUntil time.Time
+	// probeAt 半开状态下探测请求的开始时间，探测请求未回报结果时超过 cooldown 可再次探测
+	probeAt time.Time
+}
+
+func NewBreakerGroup(threshold int, cooldown time.Duration) *BreakerGroup {
+	if threshold <= 0 {
+		threshold = defaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return &BreakerGroup{
+		threshold: threshold,
+		cooldown:  cooldown,
+		breakers:  make(map[int64]*breaker),
+		now:       time.Now,
+	}
+}
+
+// Allow 判断是否可以请求该模型
+func (g *BreakerGroup) Allow(modelID int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.breakers[modelID]
+	if !ok || b.openUntil.IsZero() {
+		return true
+	}
+
+	now := g.now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+	if !b.probeAt.IsZero() && now.Sub(b.probeAt) < g.cooldown {
+		return false
+	}
+	b.probeAt = now
+	return true
+}
+
+// Success 请求成功
+func (g *BreakerGroup) Success(modelID int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.breakers, modelID)
+}
+
+// Release 请求失败但原因与服务可用性无关，不计入成功或失败，只释放半开状态下的探测名额
+func (g *BreakerGroup) Release(modelID int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if b, ok := g.breakers[modelID]; ok {
+		b.probeAt = time.Time{}
+	}
+}
+
+// Failure 请求遇到可重试错误
+func (g *BreakerGroup) Failure(modelID int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.breakers[modelID]
+	if !ok {
+		b = &breaker{}
+		g.breakers[modelID] = b
+	}
+
+	b.failures++
+	halfOpen := !b.probeAt.IsZero()
+	if halfOpen || b.failures >= g.threshold {
+		b.openUntil = g.now().Add(g.cooldown)
+		b.probeAt = time.Time{}
+	}
+}
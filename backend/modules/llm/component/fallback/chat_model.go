@@ -0,0 +1,357 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+)
+
+const (
+	defaultMaxRetries = 1
+	defaultBackoff    = 500 * time.Millisecond
+	defaultMaxBackoff = 5 * time.Second
+)
+
+// Candidate 降级链中的一个模型
+type Candidate struct {
+	ID    int64
+	Name  string
+	Model model.ToolCallingChatModel
+}
+
+// Config 重试与熔断配置，零值使用默认配置
+type Config struct {
+	// MaxRetries 同一模型遇到可重试错误时的重试次数，为 0 时重试 1 次，小于 0 时不重试
+	MaxRetries int
+	// Backoff 首次重试前的等待时间，之后每次翻倍并加入随机抖动，不超过 MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Breakers 为空时使用进程内共享的熔断器
+	Breakers *BreakerGroup
+}
+
+// ChatModel 按顺序尝试多个模型的 ToolCallingChatModel
+//
+// 每个模型遇到超时、429、5xx 等可重试错误时按退避重试，并计入该模型的熔断器；
+// 重试耗尽或熔断时切换到下一个模型，配额超限、参数错误等不可重试的错误直接返回，不再尝试其他模型。
+// 流式请求在第一个有效分片之前失败同样会切换，之后的错误直接返回给调用方。实际应答的模型通过 modelmgr.SetAnswerModel 记录在消息中
+type ChatModel struct {
+	candidates []*Candidate
+	conf       *Config
+	tools      []*schema.ToolInfo
+}
+
+func NewChatModel(candidates []*Candidate, conf *Config) (*ChatModel, error) {
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("[fallback] no candidate model")
+	}
+
+	c := &Config{}
+	if conf != nil {
+		*c = *conf
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = defaultMaxRetries
+	}
+	if c.Backoff <= 0 {
+		c.Backoff = defaultBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+	if c.Breakers == nil {
+		c.Breakers = defaultBreakers
+	}
+
+	return &ChatModel{
+		candidates: candidates,
+		conf:       c,
+	}, nil
+}
+
+func (c *ChatModel) GetType() string {
+	return "Fallback"
+}
+
+// IsCallbacksEnabled 回调由降级链统一触发一次，各个模型的单次尝试不再触发
+func (c *ChatModel) IsCallbacksEnabled() bool {
+	return true
+}
+
+func (c *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	candidates := make([]*Candidate, 0, len(c.candidates))
+	for _, cand := range c.candidates {
+		m, err := cand.Model.WithTools(tools)
+		if err != nil {
+			return nil, fmt.Errorf("[fallback] model %d bind tools failed: %w", cand.ID, err)
+		}
+		candidates = append(candidates, &Candidate{ID: cand.ID, Name: cand.Name, Model: m})
+	}
+
+	return &ChatModel{
+		candidates: candidates,
+		conf:       c.conf,
+		tools:      tools,
+	}, nil
+}
+
+func (c *ChatModel) Generate(ctx context.Context, in []*schema.Message, opts ...model.Option) (out *schema.Message, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, c.GetType(), components.ComponentOfChatModel)
+	ctx = callbacks.OnStart(ctx, &model.CallbackInput{Messages: in, Tools: c.tools})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	out, err = fallThrough(ctx, c, func(ctx context.Context, cand *Candidate) (*schema.Message, error) {
+		msg, err := cand.Model.Generate(ctx, in, opts...)
+		if err != nil {
+			return nil, err
+		}
+		msg = withAnswerModel(msg, cand)
+		return msg, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, toCallbackOutput(out))
+	return out, nil
+}
+
+func (c *ChatModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (out *schema.StreamReader[*schema.Message], err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, c.GetType(), components.ComponentOfChatModel)
+	ctx = callbacks.OnStart(ctx, &model.CallbackInput{Messages: in, Tools: c.tools})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	sr, err := fallThrough(ctx, c, func(ctx context.Context, cand *Candidate) (*schema.StreamReader[*schema.Message], error) {
+		sr, err := cand.Model.Stream(ctx, in, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return peekFirstToken(ctx, sr, cand)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, cbSR := callbacks.OnEndWithStreamOutput(ctx, schema.StreamReaderWithConvert(sr,
+		func(msg *schema.Message) (callbacks.CallbackOutput, error) {
+			return toCallbackOutput(msg), nil
+		}))
+	return schema.StreamReaderWithConvert(cbSR, func(out callbacks.CallbackOutput) (*schema.Message, error) {
+		return model.ConvCallbackOutput(out).Message, nil
+	}), nil
+}
+
+// fallThrough 按顺序尝试候选模型，返回第一个成功的结果
+func fallThrough[T any](ctx context.Context, c *ChatModel, call func(ctx context.Context, cand *Candidate) (T, error)) (T, error) {
+	var (
+		zero T
+		errs []error
+	)
+	for i, cand := range c.candidates {
+		if !c.conf.Breakers.Allow(cand.ID) {
+			logs.WarnX(pkg.ModelName, "[fallback] model %d is circuit broken, skip", cand.ID)
+			errs = append(errs, fmt.Errorf("model %d: circuit broken", cand.ID))
+			continue
+		}
+
+		out, err := callWithRetry(ctx, c, cand, call)
+		if err == nil {
+			if i > 0 {
+				logs.InfoX(pkg.ModelName, "[fallback] answered by fallback model %d", cand.ID)
+			}
+			return out, nil
+		}
+		if ctx.Err() != nil || !isRetryable(err) {
+			return zero, err
+		}
+
+		logs.WarnX(pkg.ModelName, "[fallback] model %d failed, err: %v", cand.ID, err)
+		errs = append(errs, fmt.Errorf("model %d: %w", cand.ID, err))
+	}
+
+	return zero, fmt.Errorf("[fallback] all models failed: %w", errors.Join(errs...))
+}
+
+func callWithRetry[T any](ctx context.Context, c *ChatModel, cand *Candidate, call func(ctx context.Context, cand *Candidate) (T, error)) (T, error) {
+	// 单次尝试不触发回调，避免失败的尝试被当作模型输出
+	typ, _ := components.GetType(cand.Model)
+	attemptCtx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{
+		Type:      typ,
+		Component: components.ComponentOfChatModel,
+	})
+
+	var (
+		out T
+		err error
+	)
+	for attempt := 0; ; attempt++ {
+		out, err = call(attemptCtx, cand)
+		if err == nil {
+			c.conf.Breakers.Success(cand.ID)
+			return out, nil
+		}
+		if ctx.Err() != nil {
+			c.conf.Breakers.Release(cand.ID)
+			return out, err
+		}
+		if !isRetryable(err) {
+			c.conf.Breakers.Release(cand.ID)
+			return out, err
+		}
+
+		c.conf.Breakers.Failure(cand.ID)
+		if attempt >= c.conf.MaxRetries || !c.conf.Breakers.Allow(cand.ID) {
+			return out, err
+		}
+
+		logs.WarnX(pkg.ModelName, "[fallback] model %d attempt %d failed, retry, err: %v", cand.ID, attempt+1, err)
+		if sErr := sleep(ctx, c.backoff(attempt)); sErr != nil {
+			return out, err
+		}
+	}
+}
+
+func (c *ChatModel) backoff(attempt int) time.Duration {
+	d := c.conf.Backoff << attempt
+	if d <= 0 || d > c.conf.MaxBackoff {
+		d = c.conf.MaxBackoff
+	}
+	// 在 [d/2, d] 之间随机，避免多个请求同时重试
+	return d/2 + rand.N(d/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// statusCodePattern 匹配各家 SDK 错误信息中的 HTTP 状态码，如 "status code: 503"、"status 500"
+var statusCodePattern = regexp.MustCompile(`(?i)status(?:\s*code)?[\s:=]+(\d{3})\b`)
+
+// isRetryable 超时、网络错误、429 与 5xx 视为可重试，其他错误（如参数错误）重试也不会成功
+func isRetryable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	m := statusCodePattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return false
+	}
+	code, _ := strconv.Atoi(m[1])
+	return code == 429 || code >= 500
+}
+
+// peekFirstToken 读取到第一个有效分片为止，期间出错视为本次尝试失败，以便切换模型
+func peekFirstToken(ctx context.Context, sr *schema.StreamReader[*schema.Message], cand *Candidate) (*schema.StreamReader[*schema.Message], error) {
+	var buffered []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			sr.Close()
+			if len(buffered) > 0 {
+				buffered[0] = withAnswerModel(buffered[0], cand)
+			}
+			return schema.StreamReaderFromArray(buffered), nil
+		}
+		if err != nil {
+			sr.Close()
+			return nil, err
+		}
+		if chunk == nil {
+			continue
+		}
+
+		buffered = append(buffered, chunk)
+		if chunk.Content != "" || chunk.ReasoningContent != "" || len(chunk.ToolCalls) > 0 {
+			break
+		}
+	}
+	buffered[0] = withAnswerModel(buffered[0], cand)
+
+	out, sw := schema.Pipe[*schema.Message](len(buffered))
+	safego.Go(ctx, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				sw.Send(nil, fmt.Errorf("[fallback] panic while forwarding stream: %v", r))
+			}
+			sr.Close()
+			sw.Close()
+		}()
+
+		for _, chunk := range buffered {
+			if closed := sw.Send(chunk, nil); closed {
+				return
+			}
+		}
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if closed := sw.Send(chunk, err); closed || err != nil {
+				return
+			}
+		}
+	})
+
+	return out, nil
+}
+
+// withAnswerModel 复制消息并记录应答模型，不修改模型实现返回的原始消息
+func withAnswerModel(msg *schema.Message, cand *Candidate) *schema.Message {
+	nm := *msg
+	nm.Extra = make(map[string]any, len(msg.Extra)+2)
+	for k, v := range msg.Extra {
+		nm.Extra[k] = v
+	}
+	modelmgr.SetAnswerModel(&nm, cand.ID, cand.Name)
+	return &nm
+}
+
+func toCallbackOutput(msg *schema.Message) *model.CallbackOutput {
+	out := &model.CallbackOutput{Message: msg}
+	if msg != nil && msg.ResponseMeta != nil && msg.ResponseMeta.Usage != nil {
+		usage := msg.ResponseMeta.Usage
+		out.TokenUsage = &model.TokenUsage{
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			TotalTokens:      usage.TotalTokens,
+		}
+	}
+	return out
+}
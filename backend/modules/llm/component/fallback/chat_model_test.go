@@ -0,0 +1,172 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/internal/fakemodel"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestModel(t *testing.T, breakers *BreakerGroup, models ...*fakemodel.Model) *ChatModel {
+	candidates := make([]*Candidate, 0, len(models))
+	for i, m := range models {
+		candidates = append(candidates, &Candidate{ID: int64(i + 1), Name: "m" + string(rune('a'+i)), Model: m})
+	}
+	cm, err := NewChatModel(candidates, &Config{Backoff: time.Millisecond, Breakers: breakers})
+	assert.NoError(t, err)
+	return cm
+}
+
+func TestGenerateFallback(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakemodel.Model{Err: errors.New("error, status code: 503, message: overloaded")}
+	backup := &fakemodel.Model{Chunks: []*schema.Message{schema.AssistantMessage("ok", nil)}}
+	cm := newTestModel(t, NewBreakerGroup(5, time.Minute), primary, backup)
+
+	out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", out.Content)
+	assert.Equal(t, 2, primary.Calls, "retryable error should be retried once")
+	assert.Equal(t, 1, backup.Calls)
+
+	id, name, ok := modelmgr.GetAnswerModel(out)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), id)
+	assert.Equal(t, "mb", name)
+}
+
+func TestGenerateNotRetryable(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakemodel.Model{Err: errors.New("[claude] status 400, invalid_request_error: bad")}
+	backup := &fakemodel.Model{Err: errors.New("status code: 401")}
+	cm := newTestModel(t, NewBreakerGroup(5, time.Minute), primary, backup)
+
+	_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.ErrorContains(t, err, "status 400")
+	assert.Equal(t, 1, primary.Calls)
+	assert.Equal(t, 0, backup.Calls, "non-retryable error should not fall through")
+}
+
+func TestGenerateQuotaExceeded(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakemodel.Model{Err: errorx.New(errno.ErrModelQuotaExceededCode,
+		errorx.KV("model", "ma"), errorx.KV("quota", "rpm"), errorx.KV("scenario", "default"), errorx.KV("retry_after", "1"))}
+	backup := &fakemodel.Model{Chunks: []*schema.Message{schema.AssistantMessage("ok", nil)}}
+	cm := newTestModel(t, NewBreakerGroup(5, time.Minute), primary, backup)
+
+	_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	var statusErr errorx.StatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, int32(errno.ErrModelQuotaExceededCode), statusErr.Code())
+	}
+	assert.Equal(t, 0, backup.Calls)
+}
+
+func TestStreamFallbackBeforeFirstToken(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakemodel.Model{
+		Chunks:    []*schema.Message{{Role: schema.Assistant}},
+		StreamErr: io.ErrUnexpectedEOF,
+	}
+	backup := &fakemodel.Model{Chunks: []*schema.Message{
+		{Role: schema.Assistant},
+		{Role: schema.Assistant, Content: "hel"},
+		{Role: schema.Assistant, Content: "lo"},
+	}}
+	cm := newTestModel(t, NewBreakerGroup(5, time.Minute), primary, backup)
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	out, err := schema.ConcatMessages(chunks)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", out.Content)
+	id, _, _ := modelmgr.GetAnswerModel(out)
+	assert.Equal(t, int64(2), id)
+}
+
+func TestStreamErrorAfterFirstToken(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakemodel.Model{
+		Chunks:    []*schema.Message{{Role: schema.Assistant, Content: "partial"}},
+		StreamErr: io.ErrUnexpectedEOF,
+	}
+	backup := &fakemodel.Model{}
+	cm := newTestModel(t, NewBreakerGroup(5, time.Minute), primary, backup)
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	chunk, err := sr.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "partial", chunk.Content)
+	_, err = sr.Recv()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, 0, backup.Calls)
+}
+
+func TestBreaker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	breakers := NewBreakerGroup(2, time.Minute)
+	breakers.now = func() time.Time { return now }
+
+	primary := &fakemodel.Model{Err: context.DeadlineExceeded}
+	backup := &fakemodel.Model{Chunks: []*schema.Message{schema.AssistantMessage("ok", nil)}}
+	cm := newTestModel(t, breakers, primary, backup)
+
+	_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, primary.Calls)
+	assert.False(t, breakers.Allow(1))
+
+	// 熔断期间直接跳过主模型
+	_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, primary.Calls)
+
+	// 冷却结束后放行一次探测，探测成功后恢复
+	now = now.Add(time.Minute)
+	primary.Err = nil
+	primary.Chunks = []*schema.Message{schema.AssistantMessage("back", nil)}
+	out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	assert.Equal(t, "back", out.Content)
+	assert.True(t, breakers.Allow(1))
+}
+
+func TestBreakerNotRetryableIsNeutral(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	breakers := NewBreakerGroup(1, time.Minute)
+	breakers.now = func() time.Time { return now }
+
+	primary := &fakemodel.Model{Err: context.DeadlineExceeded}
+	cm := newTestModel(t, breakers, primary)
+	_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.Error(t, err)
+	assert.False(t, breakers.Allow(1))
+
+	// 半开探测遇到不可重试错误，既不恢复也不重新熔断，下一个请求可以继续探测
+	now = now.Add(time.Minute)
+	primary.Err = errors.New("status code: 400")
+	_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.ErrorContains(t, err, "status code: 400")
+	assert.True(t, breakers.Allow(1))
+	assert.False(t, breakers.Allow(1), "breaker should stay half-open")
+}
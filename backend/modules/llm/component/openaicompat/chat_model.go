@@ -0,0 +1,97 @@
+package openaicompat
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/model/openai"
+)
+
+const defaultReasoningField = "reasoning_content"
+
+// Config 兼容 OpenAI 协议的自部署推理服务（vLLM、llama.cpp server、LM Studio 等）的连接配置
+//
+// 请求仍由 eino-ext 的 openai 实现发出，这里的选项通过包装 HTTPClient 的 Transport 生效
+type Config struct {
+	openai.ChatModelConfig
+
+	// ExtraHeaders 每次请求附带的额外 header，会覆盖同名 header
+	ExtraHeaders map[string]string
+	// ChatCompletionsPath 覆盖拼接在 BaseURL 之后的路径，为空时为 /chat/completions
+	ChatCompletionsPath string
+
+	// DisableStreamOptions 不发送 stream_options，流式返回时将拿不到 usage
+	DisableStreamOptions bool
+	// DisableToolCall 服务不支持工具调用，不发送 tools、tool_choice 与 parallel_tool_calls
+	DisableToolCall bool
+	// DisableToolChoice 服务不支持 tool_choice 参数
+	DisableToolChoice bool
+	// DisableResponseFormat 服务不支持 response_format 参数
+	DisableResponseFormat bool
+
+	// ReasoningField 思考内容所在的字段，如 reasoning，为空时为 reasoning_content
+	ReasoningField string
+}
+
+func NewChatModel(ctx context.Context, conf *Config) (*openai.ChatModel, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("[openai_compatible] config is nil")
+	}
+	if conf.BaseURL == "" {
+		return nil, fmt.Errorf("[openai_compatible] base url is empty")
+	}
+
+	t, err := newTransport(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: conf.Timeout}
+	if conf.HTTPClient != nil {
+		*client = *conf.HTTPClient
+	}
+	if t.base = client.Transport; t.base == nil {
+		t.base = http.DefaultTransport
+	}
+	client.Transport = t
+
+	oc := conf.ChatModelConfig
+	oc.HTTPClient = client
+	oc.ByAzure = false
+	return openai.NewChatModel(ctx, &oc)
+}
+
+func newTransport(conf *Config) (*transport, error) {
+	t := &transport{
+		headers: conf.ExtraHeaders,
+	}
+
+	if conf.ChatCompletionsPath != "" {
+		endpoint, err := url.Parse(strings.TrimRight(conf.BaseURL, "/") + "/" + strings.TrimLeft(conf.ChatCompletionsPath, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("[openai_compatible] invalid chat completions path %q: %w", conf.ChatCompletionsPath, err)
+		}
+		t.endpoint = endpoint
+	}
+
+	if conf.DisableStreamOptions {
+		t.dropFields = append(t.dropFields, "stream_options")
+	}
+	if conf.DisableToolCall {
+		t.dropFields = append(t.dropFields, "tools", "tool_choice", "parallel_tool_calls")
+	} else if conf.DisableToolChoice {
+		t.dropFields = append(t.dropFields, "tool_choice")
+	}
+	if conf.DisableResponseFormat {
+		t.dropFields = append(t.dropFields, "response_format")
+	}
+
+	if conf.ReasoningField != "" && conf.ReasoningField != defaultReasoningField {
+		t.reasoningField = conf.ReasoningField
+	}
+
+	return t, nil
+}
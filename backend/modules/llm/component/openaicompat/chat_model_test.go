@@ -0,0 +1,119 @@
+package openaicompat
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, path string, check func(body map[string]any), respond func(w http.ResponseWriter)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, path, r.URL.Path)
+		assert.Equal(t, "tenant-a", r.Header.Get("X-Tenant"))
+
+		data, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		body := map[string]any{}
+		assert.NoError(t, json.Unmarshal(data, &body))
+		check(body)
+		respond(w)
+	}))
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t, "/api/chat", func(body map[string]any) {
+		assert.Equal(t, "qwen3-8b", body["model"])
+		assert.NotContains(t, body, "tool_choice")
+		assert.NotContains(t, body, "response_format")
+		assert.Contains(t, body, "tools")
+	}, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "1", "object": "chat.completion", "model": "qwen3-8b",
+			"choices": [{"index": 0, "finish_reason": "stop",
+				"message": {"role": "assistant", "content": "2", "reasoning": "1+1=2"}}],
+			"usage": {"prompt_tokens": 5, "completion_tokens": 3, "total_tokens": 8}
+		}`))
+	})
+	defer srv.Close()
+
+	cm, err := NewChatModel(ctx, &Config{
+		ChatModelConfig: openai.ChatModelConfig{
+			BaseURL: srv.URL,
+			Model:   "qwen3-8b",
+			ResponseFormat: &openai.ChatCompletionResponseFormat{
+				Type: openai.ChatCompletionResponseFormatTypeText,
+			},
+		},
+		ExtraHeaders:          map[string]string{"X-Tenant": "tenant-a"},
+		ChatCompletionsPath:   "/api/chat",
+		DisableToolChoice:     true,
+		DisableResponseFormat: true,
+		ReasoningField:        "reasoning",
+	})
+	assert.NoError(t, err)
+	tcm, err := cm.WithTools([]*schema.ToolInfo{{Name: "calc", Desc: "calculator"}})
+	assert.NoError(t, err)
+
+	out, err := tcm.Generate(ctx, []*schema.Message{schema.UserMessage("1+1=?")})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", out.Content)
+	assert.Equal(t, "1+1=2", out.ReasoningContent)
+	assert.Equal(t, 8, out.ResponseMeta.Usage.TotalTokens)
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	events := []string{
+		`{"id":"1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"role":"assistant","reasoning":"think "}}]}`,
+		`{"id":"1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"reasoning":"hard"}}]}`,
+		`{"id":"1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"content":"done"},"finish_reason":"stop"}]}`,
+	}
+	srv := newTestServer(t, "/v1/chat/completions", func(body map[string]any) {
+		assert.Equal(t, true, body["stream"])
+		assert.NotContains(t, body, "stream_options")
+	}, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		var sb strings.Builder
+		for _, e := range events {
+			sb.WriteString("data: " + e + "\n\n")
+		}
+		sb.WriteString("data: [DONE]\n\n")
+		_, _ = w.Write([]byte(sb.String()))
+	})
+	defer srv.Close()
+
+	cm, err := NewChatModel(ctx, &Config{
+		ChatModelConfig:      openai.ChatModelConfig{BaseURL: srv.URL + "/v1", Model: "qwen3-8b"},
+		ExtraHeaders:         map[string]string{"X-Tenant": "tenant-a"},
+		DisableStreamOptions: true,
+		ReasoningField:       "reasoning",
+	})
+	assert.NoError(t, err)
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+
+	out, err := schema.ConcatMessages(chunks)
+	assert.NoError(t, err)
+	assert.Equal(t, "done", out.Content)
+	assert.Equal(t, "think hard", out.ReasoningContent)
+}
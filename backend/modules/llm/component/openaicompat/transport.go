@@ -0,0 +1,190 @@
+package openaicompat
+
+import (
+	"bufio"
+	"bytes"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kiosk404/airi-go/backend/pkg/json"
+)
+
+const chatCompletionsSuffix = "/chat/completions"
+
+// transport 在 openai 客户端与推理服务之间改写请求与响应
+//
+// 请求：附带额外 header、替换 chat completions 路径、删除服务不支持的参数；
+// 响应：将自定义字段中的思考内容改名为 reasoning_content，使 openai 客户端能够识别
+type transport struct {
+	base http.RoundTripper
+
+	headers        map[string]string
+	endpoint       *url.URL
+	dropFields     []string
+	reasoningField string
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	isChat := strings.HasSuffix(req.URL.Path, chatCompletionsSuffix)
+	if isChat && t.endpoint != nil {
+		u := *t.endpoint
+		u.RawQuery = req.URL.RawQuery
+		req.URL = &u
+		req.Host = u.Host
+	}
+
+	if isChat && len(t.dropFields) > 0 && req.Body != nil {
+		if err := t.rewriteRequestBody(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !isChat || t.reasoningField == "" || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		resp.Body = &reasoningStreamReader{
+			src:    bufio.NewReader(resp.Body),
+			closer: resp.Body,
+			field:  t.reasoningField,
+		}
+		return resp, nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("[openai_compatible] read response failed: %w", err)
+	}
+	data = renameReasoningField(data, t.reasoningField, "message")
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+func (t *transport) rewriteRequestBody(req *http.Request) error {
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return fmt.Errorf("[openai_compatible] read request failed: %w", err)
+	}
+
+	body := map[string]stdjson.RawMessage{}
+	if err = json.Unmarshal(data, &body); err != nil {
+		return fmt.Errorf("[openai_compatible] decode request failed: %w", err)
+	}
+	for _, field := range t.dropFields {
+		delete(body, field)
+	}
+	if data, err = json.Marshal(body); err != nil {
+		return fmt.Errorf("[openai_compatible] encode request failed: %w", err)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.ContentLength = int64(len(data))
+	return nil
+}
+
+// renameReasoningField 将 choices[].<key>.<field> 改名为 reasoning_content，无法解析时原样返回
+func renameReasoningField(data []byte, field, key string) []byte {
+	body := map[string]stdjson.RawMessage{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return data
+	}
+	var choices []map[string]stdjson.RawMessage
+	if err := json.Unmarshal(body["choices"], &choices); err != nil || len(choices) == 0 {
+		return data
+	}
+
+	changed := false
+	for _, choice := range choices {
+		msg := map[string]stdjson.RawMessage{}
+		err := json.Unmarshal(choice[key], &msg)
+		if err != nil {
+			continue
+		}
+		reasoning, ok := msg[field]
+		if !ok {
+			continue
+		}
+		delete(msg, field)
+		if _, exist := msg[defaultReasoningField]; !exist {
+			msg[defaultReasoningField] = reasoning
+		}
+		if choice[key], err = json.Marshal(msg); err != nil {
+			return data
+		}
+		changed = true
+	}
+	if !changed {
+		return data
+	}
+
+	var err error
+	if body["choices"], err = json.Marshal(choices); err != nil {
+		return data
+	}
+	out, err := json.Marshal(body)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+// reasoningStreamReader 逐行改写 SSE 中 data 事件的思考字段
+type reasoningStreamReader struct {
+	src    *bufio.Reader
+	closer io.Closer
+	field  string
+
+	buf []byte
+	err error
+}
+
+func (r *reasoningStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		var line []byte
+		line, r.err = r.src.ReadBytes('\n')
+		r.buf = r.rewriteLine(line)
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *reasoningStreamReader) rewriteLine(line []byte) []byte {
+	payload, ok := bytes.CutPrefix(line, []byte("data:"))
+	if !ok {
+		return line
+	}
+	payload = bytes.TrimSpace(payload)
+	if len(payload) == 0 || payload[0] != '{' {
+		return line
+	}
+
+	out := append([]byte("data: "), renameReasoningField(payload, r.field, "delta")...)
+	return append(out, '\n')
+}
+
+func (r *reasoningStreamReader) Close() error {
+	return r.closer.Close()
+}
@@ -0,0 +1,168 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/infra/contract/limiter"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+)
+
+const (
+	window = time.Minute
+
+	quotaQPM = "qpm"
+	quotaTPM = "tpm"
+)
+
+// Config 单个模型在某个场景下对某个用户的配额，QPM/TPM 小于等于 0 表示不限制
+type Config struct {
+	Limiter limiter.IRateLimiter
+
+	Scenario  string
+	ModelID   int64
+	ModelName string
+	UserID    string
+
+	QPM int64
+	TPM int64
+}
+
+// ChatModel 在调用模型前检查每分钟请求数与 token 数，token 数按模型返回的 usage 在调用结束后累计
+//
+// 配额超限时返回 errno.ErrModelQuotaExceededCode，错误的 Extra 中带有需要等待的时间
+type ChatModel struct {
+	inner model.ToolCallingChatModel
+	conf  *Config
+}
+
+// NewChatModel 未配置任何限制时直接返回 inner
+func NewChatModel(inner model.ToolCallingChatModel, conf *Config) model.ToolCallingChatModel {
+	if conf == nil || conf.Limiter == nil || (conf.QPM <= 0 && conf.TPM <= 0) {
+		return inner
+	}
+	return &ChatModel{inner: inner, conf: conf}
+}
+
+func (c *ChatModel) GetType() string {
+	typ, _ := components.GetType(c.inner)
+	return typ
+}
+
+// IsCallbacksEnabled 与被包装的模型保持一致，配额检查本身不触发回调
+func (c *ChatModel) IsCallbacksEnabled() bool {
+	return components.IsCallbacksEnabled(c.inner)
+}
+
+func (c *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	inner, err := c.inner.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return &ChatModel{inner: inner, conf: c.conf}, nil
+}
+
+func (c *ChatModel) Generate(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	out, err := c.inner.Generate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if out != nil && out.ResponseMeta != nil && out.ResponseMeta.Usage != nil {
+		c.consume(ctx, out.ResponseMeta.Usage.TotalTokens)
+	}
+	return out, nil
+}
+
+func (c *ChatModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	sr, err := c.inner.Stream(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// 部分模型在多个分片中返回累计的 usage，只累计增加的部分
+	var counted int
+	return schema.StreamReaderWithConvert(sr, func(msg *schema.Message) (*schema.Message, error) {
+		if msg != nil && msg.ResponseMeta != nil && msg.ResponseMeta.Usage != nil {
+			if total := msg.ResponseMeta.Usage.TotalTokens; total > counted {
+				c.consume(ctx, total-counted)
+				counted = total
+			}
+		}
+		return msg, nil
+	}), nil
+}
+
+func (c *ChatModel) key(quota string) string {
+	return fmt.Sprintf("model_quota:%s:%s:%d:%s", quota, c.conf.Scenario, c.conf.ModelID, c.conf.UserID)
+}
+
+// acquire 先检查 TPM 再占用 QPM，避免 TPM 超限的请求消耗 QPM
+func (c *ChatModel) acquire(ctx context.Context) error {
+	if c.conf.TPM > 0 {
+		// n 为 0 时只读取当前窗口已使用的 token 数
+		if err := c.allow(ctx, quotaTPM, 0, c.conf.TPM); err != nil {
+			return err
+		}
+	}
+	if c.conf.QPM > 0 {
+		if err := c.allow(ctx, quotaQPM, 1, c.conf.QPM); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ChatModel) allow(ctx context.Context, quota string, n int, limit int64) error {
+	res, err := c.conf.Limiter.AllowN(ctx, c.key(quota), n, limiter.WithLimit(&limiter.Limit{
+		Burst:  int(min(limit, math.MaxInt32)),
+		Period: window,
+	}))
+	if err != nil {
+		// 限流存储不可用时放行，不影响模型调用
+		logs.WarnX(pkg.ModelName, "[quota] check %s quota of model %d failed, err: %v", quota, c.conf.ModelID, err)
+		return nil
+	}
+	if res.Allowed {
+		return nil
+	}
+
+	retryAfter := res.RetryAfter.Round(time.Millisecond)
+	// 提示信息中向上取整到秒，避免提示等待 0 秒
+	return errorx.New(errno.ErrModelQuotaExceededCode,
+		errorx.KV("model", c.conf.ModelName),
+		errorx.KV("quota", quota),
+		errorx.KV("scenario", c.conf.Scenario),
+		errorx.KV("retry_after", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10)),
+		errorx.Extra(modelmgr.QuotaExtraKeyRetryAfterMs, strconv.FormatInt(retryAfter.Milliseconds(), 10)),
+	)
+}
+
+func (c *ChatModel) consume(ctx context.Context, tokens int) {
+	if c.conf.TPM <= 0 || tokens <= 0 {
+		return
+	}
+	if _, err := c.conf.Limiter.AllowN(ctx, c.key(quotaTPM), tokens, limiter.WithLimit(&limiter.Limit{
+		Burst:  int(min(c.conf.TPM, math.MaxInt32)),
+		Period: window,
+	})); err != nil {
+		logs.WarnX(pkg.ModelName, "[quota] count tokens of model %d failed, err: %v", c.conf.ModelID, err)
+	}
+}
@@ -0,0 +1,96 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/infra/contract/limiter"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/internal/fakemodel"
+	"github.com/stretchr/testify/assert"
+)
+
+// memLimiter 单窗口的内存计数器
+type memLimiter struct {
+	counts map[string]int
+}
+
+func (m *memLimiter) AllowN(_ context.Context, key string, n int, opts ...limiter.LimitOptionFn) (*limiter.Result, error) {
+	opt := &limiter.LimitOption{}
+	for _, fn := range opts {
+		fn(opt)
+	}
+	m.counts[key] += n
+	allowed := m.counts[key] <= opt.Limit.Burst
+	res := &limiter.Result{Allowed: allowed, OriginKey: key, LimitKey: key}
+	if !allowed {
+		res.RetryAfter = 1500 * time.Millisecond
+	}
+	return res, nil
+}
+
+// newTestModel 内部模型一次调用共消耗 60 个 token，流式输出时 usage 为累计值
+func newTestModel(l limiter.IRateLimiter, qpm, tpm int64) (*fakemodel.Model, model.ToolCallingChatModel) {
+	inner := &fakemodel.Model{Chunks: []*schema.Message{
+		schema.AssistantMessage("o", nil),
+		{Role: schema.Assistant, Content: "k", ResponseMeta: &schema.ResponseMeta{Usage: &schema.TokenUsage{TotalTokens: 30}}},
+		{Role: schema.Assistant, ResponseMeta: &schema.ResponseMeta{Usage: &schema.TokenUsage{TotalTokens: 60}}},
+	}}
+	return inner, NewChatModel(inner, &Config{
+		Limiter:   l,
+		Scenario:  "default",
+		ModelID:   1,
+		ModelName: "m1",
+		UserID:    "u1",
+		QPM:       qpm,
+		TPM:       tpm,
+	})
+}
+
+func TestNoLimit(t *testing.T) {
+	inner, cm := newTestModel(&memLimiter{counts: map[string]int{}}, 0, 0)
+	assert.Same(t, model.ToolCallingChatModel(inner), cm)
+}
+
+func TestQPM(t *testing.T) {
+	ctx := context.Background()
+	inner, cm := newTestModel(&memLimiter{counts: map[string]int{}}, 2, 0)
+
+	for i := 0; i < 2; i++ {
+		_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+		assert.NoError(t, err)
+	}
+	_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	retryAfter, ok := modelmgr.QuotaExceeded(err)
+	assert.True(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, retryAfter)
+	assert.ErrorContains(t, err, "retry after 2s")
+	assert.Equal(t, 2, inner.Calls)
+}
+
+func TestTPMFromStreamUsage(t *testing.T) {
+	ctx := context.Background()
+	l := &memLimiter{counts: map[string]int{}}
+	inner, cm := newTestModel(l, 0, 100)
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	for {
+		if _, err := sr.Recv(); errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	assert.Equal(t, 60, l.counts["model_quota:tpm:default:1:u1"], "cumulative usage should be counted once")
+
+	_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	_, err = cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	_, ok := modelmgr.QuotaExceeded(err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, inner.Calls)
+}
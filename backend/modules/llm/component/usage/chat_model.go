@@ -0,0 +1,160 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+)
+
+// Usage 一次模型调用的用量，调用失败时 Err 不为空
+type Usage struct {
+	ModelID    int64
+	ModelName  string
+	ModelClass modelmgr.ModelClass
+	// ModelIdentification 调用模型服务时使用的模型名
+	ModelIdentification string
+	Scope               modelmgr.CallScope
+
+	PromptTokens     int64
+	CompletionTokens int64
+	ReasoningTokens  int64
+	Latency          time.Duration
+	Err              error
+}
+
+// Recorder 记录模型调用的用量，实现方不应阻塞调用方
+type Recorder interface {
+	RecordUsage(ctx context.Context, usage *Usage)
+}
+
+// Config 被记录模型的信息
+type Config struct {
+	Recorder Recorder
+
+	ModelID             int64
+	ModelName           string
+	ModelClass          modelmgr.ModelClass
+	ModelIdentification string
+	Scope               modelmgr.CallScope
+}
+
+// ChatModel 在每次调用结束后记录 token 用量与耗时，流式调用在流结束或被关闭时记录
+type ChatModel struct {
+	inner model.ToolCallingChatModel
+	conf  *Config
+}
+
+// NewChatModel 未设置 Recorder 时直接返回 inner
+func NewChatModel(inner model.ToolCallingChatModel, conf *Config) model.ToolCallingChatModel {
+	if conf == nil || conf.Recorder == nil {
+		return inner
+	}
+	return &ChatModel{inner: inner, conf: conf}
+}
+
+func (c *ChatModel) GetType() string {
+	typ, _ := components.GetType(c.inner)
+	return typ
+}
+
+// IsCallbacksEnabled 与被包装的模型保持一致，用量记录本身不触发回调
+func (c *ChatModel) IsCallbacksEnabled() bool {
+	return components.IsCallbacksEnabled(c.inner)
+}
+
+func (c *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	inner, err := c.inner.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return &ChatModel{inner: inner, conf: c.conf}, nil
+}
+
+func (c *ChatModel) Generate(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	start := time.Now()
+	u := c.newUsage()
+
+	out, err := c.inner.Generate(ctx, in, opts...)
+	if err != nil {
+		u.Err = err
+	} else {
+		u.add(out)
+	}
+	u.Latency = time.Since(start)
+	c.conf.Recorder.RecordUsage(ctx, u)
+
+	return out, err
+}
+
+func (c *ChatModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	start := time.Now()
+	u := c.newUsage()
+
+	sr, err := c.inner.Stream(ctx, in, opts...)
+	if err != nil {
+		u.Err = err
+		u.Latency = time.Since(start)
+		c.conf.Recorder.RecordUsage(ctx, u)
+		return nil, err
+	}
+
+	out, sw := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				u.Err = fmt.Errorf("[usage] panic while forwarding stream: %v", r)
+				sw.Send(nil, u.Err)
+			}
+			sr.Close()
+			sw.Close()
+
+			u.Latency = time.Since(start)
+			c.conf.Recorder.RecordUsage(ctx, u)
+		}()
+
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				u.Err = err
+			} else {
+				u.add(chunk)
+			}
+			if closed := sw.Send(chunk, err); closed || err != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (c *ChatModel) newUsage() *Usage {
+	return &Usage{
+		ModelID:             c.conf.ModelID,
+		ModelName:           c.conf.ModelName,
+		ModelClass:          c.conf.ModelClass,
+		ModelIdentification: c.conf.ModelIdentification,
+		Scope:               c.conf.Scope,
+	}
+}
+
+// add 累计消息中的 usage，流式返回时不同模型可能在多个分片中返回累计值或分别返回输入与输出，因此逐项取最大值
+func (u *Usage) add(msg *schema.Message) {
+	if msg == nil || msg.ResponseMeta == nil || msg.ResponseMeta.Usage == nil {
+		return
+	}
+	tu := msg.ResponseMeta.Usage
+	u.PromptTokens = max(u.PromptTokens, int64(tu.PromptTokens))
+	u.CompletionTokens = max(u.CompletionTokens, int64(tu.CompletionTokens))
+	u.ReasoningTokens = max(u.ReasoningTokens, int64(tu.CompletionTokensDetails.ReasoningTokens))
+}
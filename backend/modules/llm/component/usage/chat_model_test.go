@@ -0,0 +1,92 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	modelmgr "github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/internal/fakemodel"
+	"github.com/stretchr/testify/assert"
+)
+
+type memRecorder struct {
+	mu     sync.Mutex
+	usages []*Usage
+	done   chan struct{}
+}
+
+func (m *memRecorder) RecordUsage(_ context.Context, usage *Usage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usages = append(m.usages, usage)
+	m.done <- struct{}{}
+}
+
+func newTestModel(inner *fakemodel.Model) (*memRecorder, model.ToolCallingChatModel) {
+	rec := &memRecorder{done: make(chan struct{}, 1)}
+	return rec, NewChatModel(inner, &Config{
+		Recorder:            rec,
+		ModelID:             1,
+		ModelName:           "m1",
+		ModelClass:          modelmgr.ModelClass_GPT,
+		ModelIdentification: "gpt-4o",
+		Scope:               modelmgr.CallScope{UserID: "u1", AgentID: 2},
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	rec, cm := newTestModel(&fakemodel.Model{Chunks: []*schema.Message{
+		fakemodel.WithUsage(schema.AssistantMessage("ok", nil), 10, 5, 2),
+	}})
+
+	_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	<-rec.done
+	assert.Len(t, rec.usages, 1)
+	u := rec.usages[0]
+	assert.Equal(t, int64(10), u.PromptTokens)
+	assert.Equal(t, int64(5), u.CompletionTokens)
+	assert.Equal(t, int64(2), u.ReasoningTokens)
+	assert.Equal(t, "u1", u.Scope.UserID)
+	assert.Equal(t, modelmgr.ModelClass_GPT, u.ModelClass)
+	assert.NoError(t, u.Err)
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	rec, cm := newTestModel(&fakemodel.Model{Chunks: []*schema.Message{
+		fakemodel.WithUsage(&schema.Message{Role: schema.Assistant}, 10, 0, 0),
+		{Role: schema.Assistant, Content: "ok"},
+		fakemodel.WithUsage(&schema.Message{Role: schema.Assistant}, 0, 7, 0),
+	}})
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	for {
+		_, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+	}
+	<-rec.done
+	assert.Len(t, rec.usages, 1)
+	assert.Equal(t, int64(10), rec.usages[0].PromptTokens)
+	assert.Equal(t, int64(7), rec.usages[0].CompletionTokens)
+}
+
+func TestStreamError(t *testing.T) {
+	ctx := context.Background()
+	rec, cm := newTestModel(&fakemodel.Model{Err: errors.New("status code: 500")})
+
+	_, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.Error(t, err)
+	<-rec.done
+	assert.ErrorContains(t, rec.usages[0].Err, "500")
+}
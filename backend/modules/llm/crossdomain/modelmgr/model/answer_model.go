@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/cloudwego/eino/schema"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+)
+
+// 模型降级链在应答消息的 Extra 中记录实际给出回答的模型，流式返回时只写在第一个分片上
+const (
+	MessageExtraKeyAnswerModelID   = "answer_model_id"
+	MessageExtraKeyAnswerModelName = "answer_model_name"
+)
+
+// SetAnswerModel 在消息的 Extra 中记录应答模型
+func SetAnswerModel(msg *schema.Message, id int64, name string) {
+	if msg.Extra == nil {
+		msg.Extra = make(map[string]any, 2)
+	}
+	msg.Extra[MessageExtraKeyAnswerModelID] = conv.Int64ToStr(id)
+	msg.Extra[MessageExtraKeyAnswerModelName] = name
+}
+
+// GetAnswerModel 读取消息中记录的应答模型，未记录时 ok 为 false
+func GetAnswerModel(msg *schema.Message) (id int64, name string, ok bool) {
+	if msg == nil || msg.Extra == nil {
+		return 0, "", false
+	}
+	idStr, _ := msg.Extra[MessageExtraKeyAnswerModelID].(string)
+	if idStr == "" {
+		return 0, "", false
+	}
+	name, _ = msg.Extra[MessageExtraKeyAnswerModelName].(string)
+	return conv.StrToInt64D(idStr, 0), name, true
+}
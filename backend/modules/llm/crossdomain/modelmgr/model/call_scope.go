@@ -0,0 +1,26 @@
+package model
+
+import (
+	"context"
+)
+
+// CallScope 模型调用的归属，构建模型时用于配额计数、用量记录与预算检查
+type CallScope struct {
+	// Scenario 为空时使用默认场景
+	Scenario       string
+	UserID         string
+	AgentID        int64
+	ConversationID int64
+}
+
+type callScopeKey struct{}
+
+// WithCallScope 在构建模型前设置调用归属，未设置时使用默认场景且不区分用户
+func WithCallScope(ctx context.Context, scope *CallScope) context.Context {
+	return context.WithValue(ctx, callScopeKey{}, scope)
+}
+
+func GetCallScope(ctx context.Context) *CallScope {
+	scope, _ := ctx.Value(callScopeKey{}).(*CallScope)
+	return scope
+}
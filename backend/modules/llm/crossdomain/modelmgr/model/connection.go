@@ -12,6 +12,8 @@ type Connection struct {
 	Qwen         *QwenConnInfo       `json:"qwen,omitempty" query:"qwen"`
 	Ollama       *OllamaConnInfo     `json:"ollama,omitempty" query:"ollama"`
 	Claude       *ClaudeConnInfo     `json:"claude,omitempty" query:"claude"`
+
+	OpenAICompatible *OpenAICompatibleConnInfo `json:"openai_compatible,omitempty" query:"openai_compatible"`
 }
 
 type BaseConnectionInfo struct {
@@ -130,6 +132,45 @@ func NewClaudeConnInfo() *ClaudeConnInfo {
 func (p *ClaudeConnInfo) InitDefault() {
 }
 
+// OpenAICompatibleConnInfo vLLM、llama.cpp server、LM Studio 等兼容 OpenAI 协议的自部署推理服务
+type OpenAICompatibleConnInfo struct {
+	// ExtraHeaders 每次请求附带的额外 header
+	ExtraHeaders map[string]string `json:"extra_headers,omitempty" query:"extra_headers"`
+	// ChatCompletionsPath 覆盖拼接在 BaseURL 之后的路径，为空时为 /chat/completions
+	ChatCompletionsPath string `json:"chat_completions_path,omitempty" query:"chat_completions_path"`
+
+	// DisableStreamOptions 不发送 stream_options，流式返回时将拿不到 usage
+	DisableStreamOptions bool `json:"disable_stream_options" query:"disable_stream_options"`
+	// DisableToolCall 服务不支持工具调用，不发送 tools 与 tool_choice
+	DisableToolCall bool `json:"disable_tool_call" query:"disable_tool_call"`
+	// DisableToolChoice 服务不支持 tool_choice 参数
+	DisableToolChoice bool `json:"disable_tool_choice" query:"disable_tool_choice"`
+	// DisableResponseFormat 服务不支持 response_format 参数
+	DisableResponseFormat bool `json:"disable_response_format" query:"disable_response_format"`
+
+	// ReasoningField 思考内容所在的字段，如 reasoning，为空时为 reasoning_content
+	ReasoningField string `json:"reasoning_field,omitempty" query:"reasoning_field"`
+}
+
+func NewOpenAICompatibleConnInfo() *OpenAICompatibleConnInfo {
+	return &OpenAICompatibleConnInfo{}
+}
+
+func (p *OpenAICompatibleConnInfo) InitDefault() {
+}
+
+func (p *OpenAICompatibleConnInfo) GetExtraHeaders() (v map[string]string) {
+	return p.ExtraHeaders
+}
+
+func (p *OpenAICompatibleConnInfo) GetChatCompletionsPath() (v string) {
+	return p.ChatCompletionsPath
+}
+
+func (p *OpenAICompatibleConnInfo) GetReasoningField() (v string) {
+	return p.ReasoningField
+}
+
 type ThinkingType int64
 
 const (
@@ -33,6 +33,9 @@ func (c *KnowledgeConfig) GetKnowledgeConfig(ctx context.Context) (*config.Knowl
 	if errors.Is(err, kvstore.ErrKeyNotFound) {
 		return getKnowledgeConfigurationFromOldConfig(), nil
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	return conf, nil
 }
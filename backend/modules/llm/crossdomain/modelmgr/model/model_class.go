@@ -7,13 +7,14 @@ import (
 type ModelClass int64
 
 const (
-	ModelClass_GPT      ModelClass = 1
-	ModelClass_QWen     ModelClass = 2
-	ModelClass_Gemini   ModelClass = 3
-	ModelClass_DeepSeek ModelClass = 4
-	ModelClass_Ollama   ModelClass = 5
-	ModelClass_Claude   ModelClass = 6
-	ModelClass_Other    ModelClass = 999
+	ModelClass_GPT              ModelClass = 1
+	ModelClass_QWen             ModelClass = 2
+	ModelClass_Gemini           ModelClass = 3
+	ModelClass_DeepSeek         ModelClass = 4
+	ModelClass_Ollama           ModelClass = 5
+	ModelClass_Claude           ModelClass = 6
+	ModelClass_OpenAICompatible ModelClass = 7
+	ModelClass_Other            ModelClass = 999
 )
 
 func (p ModelClass) String() string {
@@ -30,6 +31,8 @@ func (p ModelClass) String() string {
 		return "ollama"
 	case ModelClass_Claude:
 		return "claude"
+	case ModelClass_OpenAICompatible:
+		return "openai_compatible"
 	case ModelClass_Other:
 		return "other"
 	}
@@ -48,6 +51,10 @@ func ModelClassFromString(s string) (ModelClass, error) {
 		return ModelClass_DeepSeek, nil
 	case "Ollama":
 		return ModelClass_Ollama, nil
+	case "Claude":
+		return ModelClass_Claude, nil
+	case "OpenAICompatible":
+		return ModelClass_OpenAICompatible, nil
 	case "Other":
 		return ModelClass_Other, nil
 	}
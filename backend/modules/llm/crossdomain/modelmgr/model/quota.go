@@ -0,0 +1,23 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+)
+
+// QuotaExtraKeyRetryAfterMs 配额超限错误的 Extra 中记录需要等待的毫秒数
+const QuotaExtraKeyRetryAfterMs = "retry_after_ms"
+
+// QuotaExceeded 判断错误是否由模型配额超限引起，并返回需要等待的时间
+func QuotaExceeded(err error) (retryAfter time.Duration, ok bool) {
+	var statusErr errorx.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code() != errno.ErrModelQuotaExceededCode {
+		return 0, false
+	}
+	ms := conv.StrToInt64D(statusErr.Extra()[QuotaExtraKeyRetryAfterMs], 0)
+	return time.Duration(ms) * time.Millisecond, true
+}
@@ -16,6 +16,11 @@ type ModelRequestRecord struct {
 	ModelName           string    `json:"model_name"`
 	InputToken          int64     `json:"input_token"`
 	OutputToken         int64     `json:"output_token"`
+	ReasoningToken      int64     `json:"reasoning_token"`
+	AgentID             int64     `json:"agent_id"`
+	ConversationID      int64     `json:"conversation_id"`
+	LatencyMs           int64     `json:"latency_ms"`
+	Cost                float64   `json:"cost"`
 	LogId               string    `json:"log_id"`
 	ErrorCode           string    `json:"error_code"`
 	ErrorMsg            *string   `json:"error_msg"`
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+)
+
+// UsageFilter 用量统计的过滤条件，时间范围为 [StartTime, EndTime)，其他条件为零值时不过滤
+type UsageFilter struct {
+	StartTime time.Time
+	EndTime   time.Time
+	UserID    string
+	AgentID   int64
+	ModelID   string
+}
+
+// UsageStat 一组模型调用的用量合计
+type UsageStat struct {
+	Calls          int64   `json:"calls"`
+	FailedCalls    int64   `json:"failed_calls"`
+	InputToken     int64   `json:"input_token"`
+	OutputToken    int64   `json:"output_token"`
+	ReasoningToken int64   `json:"reasoning_token"`
+	Cost           float64 `json:"cost"`
+}
+
+// DailyUsage 按天的用量合计，Day 格式为 2006-01-02
+type DailyUsage struct {
+	Day string `json:"day"`
+	UsageStat
+}
+
+// AgentUsage 按智能体的用量合计
+type AgentUsage struct {
+	AgentID int64 `json:"agent_id"`
+	UsageStat
+}
+
+// UserUsage 按用户的用量合计
+type UserUsage struct {
+	UserID string `json:"user_id"`
+	UsageStat
+}
+
+type BudgetScope string
+
+const (
+	BudgetScopeUser  BudgetScope = "user"
+	BudgetScopeAgent BudgetScope = "agent"
+)
+
+type BudgetPeriod string
+
+const (
+	BudgetPeriodDay   BudgetPeriod = "day"
+	BudgetPeriodMonth BudgetPeriod = "month"
+)
+
+// UsageBudget 用户或智能体在一个周期内的费用上限，达到上限后拒绝新的运行
+type UsageBudget struct {
+	Scope  BudgetScope  `json:"scope"`
+	Period BudgetPeriod `json:"period"`
+	// ID 为空时对每个用户或智能体分别生效，否则只对该用户或智能体生效并优先于通用配置
+	ID string `json:"id"`
+	// Limit 费用上限，货币单位与 model_pricing.json 一致，小于等于 0 表示不限制
+	Limit float64 `json:"limit"`
+}
@@ -15,4 +15,11 @@ func NewModelRunRecordRepo(db *gorm.DB) ModelRunRecordRepository {
 type ModelRunRecordRepository interface {
 	Create(ctx context.Context, runRecord *entity.ModelRequestRecord) (err error)
 	List(ctx context.Context, modelID string, limit int) (modelRunRecordList []*entity.ModelRequestRecord)
+
+	DailyUsage(ctx context.Context, filter *entity.UsageFilter) ([]*entity.DailyUsage, error)
+	// AgentUsage 按费用从高到低返回前 limit 个智能体
+	AgentUsage(ctx context.Context, filter *entity.UsageFilter, limit int) ([]*entity.AgentUsage, error)
+	// UserUsage 按费用从高到低返回前 limit 个用户
+	UserUsage(ctx context.Context, filter *entity.UsageFilter, limit int) ([]*entity.UserUsage, error)
+	SumCost(ctx context.Context, filter *entity.UsageFilter) (float64, error)
 }
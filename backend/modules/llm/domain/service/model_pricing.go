@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	confpkg "github.com/kiosk404/airi-go/backend/pkg/conf"
+)
+
+const (
+	tokensPerPriceUnit = 1_000_000
+	defaultPriceModel  = "default"
+)
+
+// ModelPricingConf 模型价格配置，从 model_pricing.json 文件中加载
+//
+// 价格按列表配置而不是以模型名为 key，因为 viper 会把模型名中的 "." 当作层级分隔符
+type ModelPricingConf struct {
+	Currency string        `json:"currency"`
+	Prices   []*ModelPrice `json:"prices"`
+
+	class2Model2Price map[string]map[string]*ModelPrice
+}
+
+// ModelPrice 每百万 token 的价格，Reasoning 为 0 时推理 token 按 Output 计价
+type ModelPrice struct {
+	// ModelClass 与 model.ModelClass.String() 一致，如 gpt、claude
+	ModelClass string `json:"model_class"`
+	// Model 调用模型服务时使用的模型名，default 表示该模型类型的默认价格
+	Model     string  `json:"model"`
+	Input     float64 `json:"input"`
+	Output    float64 `json:"output"`
+	Reasoning float64 `json:"reasoning"`
+}
+
+func initModelPricingConf(ctx context.Context, factory confpkg.IConfigLoaderFactory) (*ModelPricingConf, error) {
+	loader, err := factory.NewConfigLoader("model_pricing.json")
+	if err != nil {
+		return nil, fmt.Errorf("error reading model_pricing.json: %w", err)
+	}
+
+	pricing := &ModelPricingConf{}
+	if err = loader.Unmarshal(ctx, pricing, confpkg.WithTagName("json")); err != nil {
+		return nil, fmt.Errorf("error Unmarshal model_pricing.json: %w", err)
+	}
+
+	pricing.class2Model2Price = make(map[string]map[string]*ModelPrice)
+	for _, price := range pricing.Prices {
+		class := strings.ToLower(price.ModelClass)
+		if pricing.class2Model2Price[class] == nil {
+			pricing.class2Model2Price[class] = make(map[string]*ModelPrice)
+		}
+		pricing.class2Model2Price[class][strings.ToLower(price.Model)] = price
+	}
+
+	return pricing, nil
+}
+
+// GetModelPrice 优先使用模型名对应的价格，否则使用该模型类型的 default 价格
+func (c *ModelPricingConf) GetModelPrice(modelClass model.ModelClass, modelName string) *ModelPrice {
+	if c == nil {
+		return nil
+	}
+	model2Price, ok := c.class2Model2Price[modelClass.String()]
+	if !ok {
+		return nil
+	}
+	if price, ok := model2Price[strings.ToLower(modelName)]; ok {
+		return price
+	}
+	return model2Price[defaultPriceModel]
+}
+
+// Cost 推理 token 包含在输出 token 中
+func (p *ModelPrice) Cost(inputTokens, outputTokens, reasoningTokens int64) float64 {
+	if p == nil {
+		return 0
+	}
+	reasoningPrice := p.Reasoning
+	if reasoningPrice == 0 {
+		reasoningPrice = p.Output
+	}
+	reasoningTokens = min(reasoningTokens, outputTokens)
+	cost := float64(inputTokens)*p.Input +
+		float64(outputTokens-reasoningTokens)*p.Output +
+		float64(reasoningTokens)*reasoningPrice
+	return cost / tokensPerPriceUnit
+}
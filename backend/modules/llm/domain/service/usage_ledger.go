@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/usage"
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/domain/entity"
+)
+
+// UsageLedger 模型调用用量台账，记录每次调用的 token 用量、耗时与费用，并按预算限制运行
+type UsageLedger interface {
+	usage.Recorder
+
+	// Currency 费用的货币单位
+	Currency() string
+	DailyUsage(ctx context.Context, filter *entity.UsageFilter) ([]*entity.DailyUsage, error)
+	TopAgents(ctx context.Context, filter *entity.UsageFilter, limit int) ([]*entity.AgentUsage, error)
+	UserSpend(ctx context.Context, filter *entity.UsageFilter, limit int) ([]*entity.UserUsage, error)
+	// CheckBudget 调用归属的用户或智能体在当前周期内的费用达到预算时返回 errno.ErrModelBudgetExceededCode
+	CheckBudget(ctx context.Context, scope *model.CallScope) error
+}
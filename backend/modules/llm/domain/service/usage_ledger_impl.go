@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/modules/llm/component/usage"
+	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
+	"github.com/kiosk404/airi-go/backend/modules/llm/domain/entity"
+	"github.com/kiosk404/airi-go/backend/modules/llm/domain/repo"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg"
+	"github.com/kiosk404/airi-go/backend/modules/llm/pkg/errno"
+	"github.com/kiosk404/airi-go/backend/pkg/conf"
+	"github.com/kiosk404/airi-go/backend/pkg/errorx"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/conv"
+	"github.com/kiosk404/airi-go/backend/pkg/lang/ptr"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+)
+
+const defaultCurrency = "USD"
+
+type usageLedgerImpl struct {
+	recordRepo repo.ModelRunRecordRepository
+	pricing    *ModelPricingConf
+	budgets    []*entity.UsageBudget
+	now        func() time.Time
+}
+
+func NewUsageLedger(recordRepo repo.ModelRunRecordRepository, configFactory conf.IConfigLoaderFactory, budgets []*entity.UsageBudget) UsageLedger {
+	pricing, err := initModelPricingConf(context.Background(), configFactory)
+	if err != nil {
+		// 未配置价格时只记录用量，费用均为 0
+		logs.WarnX(pkg.ModelName, "init model pricing conf failed, cost will not be calculated, err: %v", err)
+	}
+	return &usageLedgerImpl{
+		recordRepo: recordRepo,
+		pricing:    pricing,
+		budgets:    budgets,
+		now:        time.Now,
+	}
+}
+
+func (u *usageLedgerImpl) Currency() string {
+	if u.pricing == nil || u.pricing.Currency == "" {
+		return defaultCurrency
+	}
+	return u.pricing.Currency
+}
+
+// RecordUsage 异步写入，不阻塞模型调用，调用方的 ctx 结束后仍然写入
+func (u *usageLedgerImpl) RecordUsage(ctx context.Context, usg *usage.Usage) {
+	record := u.toRecord(usg)
+	ctx = context.WithoutCancel(ctx)
+	safego.Go(ctx, func() {
+		if err := u.recordRepo.Create(ctx, record); err != nil {
+			logs.WarnX(pkg.ModelName, "create model request record failed, model: %d, err: %v", usg.ModelID, err)
+		}
+	})
+}
+
+func (u *usageLedgerImpl) toRecord(usg *usage.Usage) *entity.ModelRequestRecord {
+	record := &entity.ModelRequestRecord{
+		UserID:              usg.Scope.UserID,
+		UsageScene:          usg.Scope.Scenario,
+		Protocol:            usg.ModelClass.String(),
+		ModelIdentification: usg.ModelIdentification,
+		ModelID:             conv.Int64ToStr(usg.ModelID),
+		ModelName:           usg.ModelName,
+		InputToken:          usg.PromptTokens,
+		OutputToken:         usg.CompletionTokens,
+		ReasoningToken:      usg.ReasoningTokens,
+		AgentID:             usg.Scope.AgentID,
+		ConversationID:      usg.Scope.ConversationID,
+		LatencyMs:           usg.Latency.Milliseconds(),
+	}
+	if usg.Scope.AgentID != 0 {
+		record.UsageSceneEntityID = conv.Int64ToStr(usg.Scope.AgentID)
+	}
+
+	price := u.pricing.GetModelPrice(usg.ModelClass, usg.ModelIdentification)
+	record.Cost = price.Cost(usg.PromptTokens, usg.CompletionTokens, usg.ReasoningTokens)
+
+	if usg.Err != nil {
+		record.ErrorCode = "-1"
+		var statusErr errorx.StatusError
+		if errors.As(usg.Err, &statusErr) {
+			record.ErrorCode = strconv.Itoa(int(statusErr.Code()))
+		}
+		record.ErrorMsg = ptr.Of(errorx.ErrorWithoutStack(usg.Err))
+	}
+	return record
+}
+
+func (u *usageLedgerImpl) DailyUsage(ctx context.Context, filter *entity.UsageFilter) ([]*entity.DailyUsage, error) {
+	return u.recordRepo.DailyUsage(ctx, filter)
+}
+
+func (u *usageLedgerImpl) TopAgents(ctx context.Context, filter *entity.UsageFilter, limit int) ([]*entity.AgentUsage, error) {
+	return u.recordRepo.AgentUsage(ctx, filter, limit)
+}
+
+func (u *usageLedgerImpl) UserSpend(ctx context.Context, filter *entity.UsageFilter, limit int) ([]*entity.UserUsage, error) {
+	return u.recordRepo.UserUsage(ctx, filter, limit)
+}
+
+func (u *usageLedgerImpl) CheckBudget(ctx context.Context, scope *model.CallScope) error {
+	if scope == nil || len(u.budgets) == 0 {
+		return nil
+	}
+
+	if scope.UserID != "" {
+		if err := u.checkBudget(ctx, entity.BudgetScopeUser, scope.UserID); err != nil {
+			return err
+		}
+	}
+	if scope.AgentID != 0 {
+		if err := u.checkBudget(ctx, entity.BudgetScopeAgent, conv.Int64ToStr(scope.AgentID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *usageLedgerImpl) checkBudget(ctx context.Context, budgetScope entity.BudgetScope, id string) error {
+	for _, budget := range u.matchBudgets(budgetScope, id) {
+		filter := &entity.UsageFilter{
+			StartTime: u.periodStart(budget.Period),
+			EndTime:   u.now().Add(time.Minute),
+		}
+		if budgetScope == entity.BudgetScopeUser {
+			filter.UserID = id
+		} else {
+			filter.AgentID = conv.StrToInt64D(id, 0)
+		}
+
+		spent, err := u.recordRepo.SumCost(ctx, filter)
+		if err != nil {
+			// 统计失败时放行，不影响运行
+			logs.WarnX(pkg.ModelName, "sum cost of %s %s failed, err: %v", budgetScope, id, err)
+			continue
+		}
+		if spent < budget.Limit {
+			continue
+		}
+
+		return errorx.New(errno.ErrModelBudgetExceededCode,
+			errorx.KV("scope", string(budgetScope)),
+			errorx.KV("id", id),
+			errorx.KV("period", string(budget.Period)),
+			errorx.KV("spent", strconv.FormatFloat(spent, 'f', 4, 64)),
+			errorx.KV("limit", strconv.FormatFloat(budget.Limit, 'f', -1, 64)),
+			errorx.KV("currency", u.Currency()),
+		)
+	}
+	return nil
+}
+
+// matchBudgets 同一周期内指定了 ID 的预算优先于通用预算
+func (u *usageLedgerImpl) matchBudgets(budgetScope entity.BudgetScope, id string) []*entity.UsageBudget {
+	period2Budget := make(map[entity.BudgetPeriod]*entity.UsageBudget)
+	for _, budget := range u.budgets {
+		if budget.Scope != budgetScope || budget.Limit <= 0 {
+			continue
+		}
+		if budget.ID != "" && budget.ID != id {
+			continue
+		}
+		if exist, ok := period2Budget[budget.Period]; ok && exist.ID != "" {
+			continue
+		}
+		period2Budget[budget.Period] = budget
+	}
+
+	budgets := make([]*entity.UsageBudget, 0, len(period2Budget))
+	for _, period := range []entity.BudgetPeriod{entity.BudgetPeriodDay, entity.BudgetPeriodMonth} {
+		if budget, ok := period2Budget[period]; ok {
+			budgets = append(budgets, budget)
+		}
+	}
+	return budgets
+}
+
+func (u *usageLedgerImpl) periodStart(period entity.BudgetPeriod) time.Time {
+	now := u.now()
+	if period == entity.BudgetPeriodMonth {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
@@ -20,6 +20,22 @@ func ModelConnectionDto(connection *modelapi.Connection) *modelmgr.Connection {
 			Model:        connection.BaseConnInfo.Model,
 			ThinkingType: modelmgr.ThinkingType(connection.BaseConnInfo.ThinkingType),
 		},
+		OpenAICompatible: modelOpenAICompatibleConnInfoDto(connection.OpenaiCompatible),
+	}
+}
+
+func modelOpenAICompatibleConnInfoDto(connInfo *modelapi.OpenAICompatibleConnInfo) *modelmgr.OpenAICompatibleConnInfo {
+	if connInfo == nil {
+		return nil
+	}
+	return &modelmgr.OpenAICompatibleConnInfo{
+		ExtraHeaders:          connInfo.ExtraHeaders,
+		ChatCompletionsPath:   connInfo.ChatCompletionsPath,
+		DisableStreamOptions:  connInfo.DisableStreamOptions,
+		DisableToolCall:       connInfo.DisableToolCall,
+		DisableToolChoice:     connInfo.DisableToolChoice,
+		DisableResponseFormat: connInfo.DisableResponseFormat,
+		ReasoningField:        connInfo.ReasoningField,
 	}
 }
 
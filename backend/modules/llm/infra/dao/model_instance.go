@@ -164,7 +164,7 @@ func (m *ModelMgrDao) modelInstancePo2Do(do *entity.ModelInstance) *model.ModelI
 	if do.Extra.ModelExtra != nil {
 		extraByte, err := json.Marshal(do.Extra.ModelExtra)
 		if err != nil {
-			logs.ErrorX(pkg.ModelName, "marshal extra failed, err: %w", err)
+			logs.ErrorX(pkg.ModelName, "marshal extra failed, err: %v", err)
 		}
 
 		extraStr = string(extraByte)
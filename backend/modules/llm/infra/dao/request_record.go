@@ -2,6 +2,8 @@ package dao
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/kiosk404/airi-go/backend/modules/llm/domain/entity"
 	"github.com/kiosk404/airi-go/backend/modules/llm/infra/repo/gorm_gen/model"
@@ -43,6 +45,106 @@ func (mrd *ModelRunRecordDao) List(ctx context.Context, modelID string, limit in
 	return modelRunRecordList
 }
 
+// usageDayLayout 与 entity.DailyUsage 的 Day 格式一致
+const usageDayLayout = "2006-01-02"
+
+// usageStatSelect 与 entity.UsageStat 的字段对应
+const usageStatSelect = "COUNT(*) AS calls, " +
+	"COALESCE(SUM(CASE WHEN error_code <> '' THEN 1 ELSE 0 END), 0) AS failed_calls, " +
+	"COALESCE(SUM(input_token), 0) AS input_token, " +
+	"COALESCE(SUM(output_token), 0) AS output_token, " +
+	"COALESCE(SUM(reasoning_token), 0) AS reasoning_token, " +
+	"COALESCE(SUM(cost), 0) AS cost"
+
+func (mrd *ModelRunRecordDao) filter(ctx context.Context, filter *entity.UsageFilter) *gorm.DB {
+	table := mrd.dbQuery.ModelRequestRecord
+	do := table.WithContext(ctx).Where(table.CreatedAt.Gte(filter.StartTime), table.CreatedAt.Lt(filter.EndTime))
+	if filter.UserID != "" {
+		do = do.Where(table.UserID.Eq(filter.UserID))
+	}
+	if filter.AgentID != 0 {
+		do = do.Where(table.AgentID.Eq(filter.AgentID))
+	}
+	if filter.ModelID != "" {
+		do = do.Where(table.ModelID.Eq(filter.ModelID))
+	}
+	return do.UnderlyingDB()
+}
+
+// DailyUsage 各数据库的日期函数不同，按本地时区的天分组在内存中完成
+func (mrd *ModelRunRecordDao) DailyUsage(ctx context.Context, filter *entity.UsageFilter) ([]*entity.DailyUsage, error) {
+	tx := mrd.filter(ctx, filter).
+		Select("created_at, error_code, input_token, output_token, reasoning_token, cost")
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := make(map[string]*entity.DailyUsage)
+	for rows.Next() {
+		po := &model.ModelRequestRecord{}
+		if err = tx.ScanRows(rows, po); err != nil {
+			return nil, err
+		}
+
+		day := po.CreatedAt.In(time.Local).Format(usageDayLayout)
+		d, ok := days[day]
+		if !ok {
+			d = &entity.DailyUsage{Day: day}
+			days[day] = d
+		}
+		d.Calls++
+		if po.ErrorCode != "" {
+			d.FailedCalls++
+		}
+		d.InputToken += po.InputToken
+		d.OutputToken += po.OutputToken
+		d.ReasoningToken += po.ReasoningToken
+		d.Cost += po.Cost
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	daily := make([]*entity.DailyUsage, 0, len(days))
+	for _, d := range days {
+		daily = append(daily, d)
+	}
+	sort.Slice(daily, func(i, j int) bool {
+		return daily[i].Day < daily[j].Day
+	})
+	return daily, nil
+}
+
+func (mrd *ModelRunRecordDao) AgentUsage(ctx context.Context, filter *entity.UsageFilter, limit int) ([]*entity.AgentUsage, error) {
+	var rows []*entity.AgentUsage
+	err := mrd.filter(ctx, filter).
+		Where("agent_id > 0").
+		Select("agent_id, " + usageStatSelect).
+		Group("agent_id").Order("cost DESC, output_token DESC").Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (mrd *ModelRunRecordDao) UserUsage(ctx context.Context, filter *entity.UsageFilter, limit int) ([]*entity.UserUsage, error) {
+	var rows []*entity.UserUsage
+	err := mrd.filter(ctx, filter).
+		Where("user_id <> ''").
+		Select("user_id, " + usageStatSelect).
+		Group("user_id").Order("cost DESC, output_token DESC").Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (mrd *ModelRunRecordDao) SumCost(ctx context.Context, filter *entity.UsageFilter) (float64, error) {
+	var cost float64
+	err := mrd.filter(ctx, filter).
+		Select("COALESCE(SUM(cost), 0)").
+		Scan(&cost).Error
+	return cost, err
+}
+
 func (mrd *ModelRunRecordDao) modelRunRecordPo2Do(runRecord *model.ModelRequestRecord) *entity.ModelRequestRecord {
 	return &entity.ModelRequestRecord{
 		ID:                  runRecord.ID,
@@ -56,6 +158,11 @@ func (mrd *ModelRunRecordDao) modelRunRecordPo2Do(runRecord *model.ModelRequestR
 		ModelName:           runRecord.ModelName,
 		InputToken:          runRecord.InputToken,
 		OutputToken:         runRecord.OutputToken,
+		ReasoningToken:      runRecord.ReasoningToken,
+		AgentID:             runRecord.AgentID,
+		ConversationID:      runRecord.ConversationID,
+		LatencyMs:           runRecord.LatencyMs,
+		Cost:                runRecord.Cost,
 		LogId:               runRecord.Logid,
 		ErrorCode:           runRecord.ErrorCode,
 		ErrorMsg:            runRecord.ErrorMsg,
@@ -77,6 +184,11 @@ func (mrd *ModelRunRecordDao) modelRunRecordDo2Po(runRecord *entity.ModelRequest
 		ModelName:           runRecord.ModelName,
 		InputToken:          runRecord.InputToken,
 		OutputToken:         runRecord.OutputToken,
+		ReasoningToken:      runRecord.ReasoningToken,
+		AgentID:             runRecord.AgentID,
+		ConversationID:      runRecord.ConversationID,
+		LatencyMs:           runRecord.LatencyMs,
+		Cost:                runRecord.Cost,
 		Logid:               runRecord.LogId,
 		ErrorCode:           runRecord.ErrorCode,
 		ErrorMsg:            runRecord.ErrorMsg,
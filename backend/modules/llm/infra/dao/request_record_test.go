@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kiosk404/airi-go/backend/modules/llm/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const requestRecordDDL = `CREATE TABLE model_request_record (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id TEXT NOT NULL DEFAULT '',
+	usage_scene TEXT NOT NULL DEFAULT '',
+	usage_scene_entity_id TEXT NOT NULL DEFAULT '',
+	protocol TEXT NOT NULL DEFAULT '',
+	model_identification TEXT NOT NULL DEFAULT '',
+	model_ak TEXT NOT NULL DEFAULT '',
+	model_id TEXT NOT NULL DEFAULT '',
+	model_name TEXT NOT NULL DEFAULT '',
+	input_token INTEGER NOT NULL DEFAULT 0,
+	output_token INTEGER NOT NULL DEFAULT 0,
+	reasoning_token INTEGER NOT NULL DEFAULT 0,
+	agent_id INTEGER NOT NULL DEFAULT 0,
+	conversation_id INTEGER NOT NULL DEFAULT 0,
+	latency_ms INTEGER NOT NULL DEFAULT 0,
+	cost REAL NOT NULL DEFAULT 0,
+	logid TEXT NOT NULL DEFAULT '',
+	error_code TEXT NOT NULL DEFAULT '',
+	error_msg TEXT,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+)`
+
+func newTestRecordDao(t *testing.T) *ModelRunRecordDao {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Exec(requestRecordDDL).Error)
+	return NewModelRunRecordDao(db)
+}
+
+func TestUsageStatsOnSqlite(t *testing.T) {
+	ctx := context.Background()
+	d := newTestRecordDao(t)
+
+	day1 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	records := []*entity.ModelRequestRecord{
+		{UserID: "1", AgentID: 10, ModelID: "1", InputToken: 10, OutputToken: 5, Cost: 0.5, CreatedAt: day1.Add(time.Hour)},
+		{UserID: "1", AgentID: 10, ModelID: "1", InputToken: 20, OutputToken: 5, Cost: 1, ErrorCode: "500", CreatedAt: day1.Add(23 * time.Hour)},
+		{UserID: "2", AgentID: 20, ModelID: "2", InputToken: 30, OutputToken: 10, ReasoningToken: 4, Cost: 2, CreatedAt: day2.Add(time.Minute)},
+		// 不在统计区间内
+		{UserID: "2", AgentID: 20, ModelID: "2", InputToken: 100, Cost: 100, CreatedAt: day2.AddDate(0, 0, 1)},
+	}
+	for _, r := range records {
+		r.UpdatedAt = r.CreatedAt
+		assert.NoError(t, d.Create(ctx, r))
+	}
+
+	filter := &entity.UsageFilter{StartTime: day1, EndTime: day2.AddDate(0, 0, 1)}
+	daily, err := d.DailyUsage(ctx, filter)
+	assert.NoError(t, err)
+	if assert.Len(t, daily, 2) {
+		assert.Equal(t, "2026-10-01", daily[0].Day)
+		assert.Equal(t, entity.UsageStat{Calls: 2, FailedCalls: 1, InputToken: 30, OutputToken: 10, Cost: 1.5}, daily[0].UsageStat)
+		assert.Equal(t, "2026-10-02", daily[1].Day)
+		assert.Equal(t, entity.UsageStat{Calls: 1, InputToken: 30, OutputToken: 10, ReasoningToken: 4, Cost: 2}, daily[1].UsageStat)
+	}
+
+	agents, err := d.AgentUsage(ctx, filter, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, agents, 2) {
+		assert.Equal(t, int64(20), agents[0].AgentID)
+		assert.Equal(t, int64(10), agents[1].AgentID)
+		assert.Equal(t, int64(2), agents[1].Calls)
+	}
+
+	users, err := d.UserUsage(ctx, &entity.UsageFilter{StartTime: day1, EndTime: day2.AddDate(0, 0, 2)}, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, "2", users[0].UserID)
+		assert.Equal(t, float64(102), users[0].Cost)
+	}
+
+	cost, err := d.SumCost(ctx, &entity.UsageFilter{StartTime: day1, EndTime: day2, UserID: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, cost)
+}
@@ -38,6 +38,11 @@ func newModelRequestRecord(db *gorm.DB, opts ...gen.DOOption) modelRequestRecord
 	_modelRequestRecord.ModelName = field.NewString(tableName, "model_name")
 	_modelRequestRecord.InputToken = field.NewInt64(tableName, "input_token")
 	_modelRequestRecord.OutputToken = field.NewInt64(tableName, "output_token")
+	_modelRequestRecord.ReasoningToken = field.NewInt64(tableName, "reasoning_token")
+	_modelRequestRecord.AgentID = field.NewInt64(tableName, "agent_id")
+	_modelRequestRecord.ConversationID = field.NewInt64(tableName, "conversation_id")
+	_modelRequestRecord.LatencyMs = field.NewInt64(tableName, "latency_ms")
+	_modelRequestRecord.Cost = field.NewFloat64(tableName, "cost")
 	_modelRequestRecord.Logid = field.NewString(tableName, "logid")
 	_modelRequestRecord.ErrorCode = field.NewString(tableName, "error_code")
 	_modelRequestRecord.ErrorMsg = field.NewString(tableName, "error_msg")
@@ -54,22 +59,27 @@ type modelRequestRecord struct {
 	modelRequestRecordDo modelRequestRecordDo
 
 	ALL                 field.Asterisk
-	ID                  field.Int64  // 自增主键ID
-	UserID              field.String // user id
-	UsageScene          field.String // 场景
-	UsageSceneEntityID  field.String // 场景实体id
-	Protocol            field.String // 使用的协议，如ark/deepseek等
-	ModelIdentification field.String // 模型唯一标识
-	ModelAk             field.String // 模型的AK
-	ModelID             field.String // model id
-	ModelName           field.String // 模型展示名称
-	InputToken          field.Int64  // 输入token数量
-	OutputToken         field.Int64  // 输出token数量
-	Logid               field.String // logid
-	ErrorCode           field.String // error_code
-	ErrorMsg            field.String // error_msg
-	CreatedAt           field.Time   // 创建时间
-	UpdatedAt           field.Time   // 更新时间
+	ID                  field.Int64   // 自增主键ID
+	UserID              field.String  // user id
+	UsageScene          field.String  // 场景
+	UsageSceneEntityID  field.String  // 场景实体id
+	Protocol            field.String  // 使用的协议，如ark/deepseek等
+	ModelIdentification field.String  // 模型唯一标识
+	ModelAk             field.String  // 模型的AK
+	ModelID             field.String  // model id
+	ModelName           field.String  // 模型展示名称
+	InputToken          field.Int64   // 输入token数量
+	OutputToken         field.Int64   // 输出token数量
+	ReasoningToken      field.Int64   // 推理token数量，包含在输出token中
+	AgentID             field.Int64   // agent id
+	ConversationID      field.Int64   // conversation id
+	LatencyMs           field.Int64   // 调用耗时，单位毫秒
+	Cost                field.Float64 // 按模型价格计算的费用
+	Logid               field.String  // logid
+	ErrorCode           field.String  // error_code
+	ErrorMsg            field.String  // error_msg
+	CreatedAt           field.Time    // 创建时间
+	UpdatedAt           field.Time    // 更新时间
 
 	fieldMap map[string]field.Expr
 }
@@ -97,6 +107,11 @@ func (m *modelRequestRecord) updateTableName(table string) *modelRequestRecord {
 	m.ModelName = field.NewString(table, "model_name")
 	m.InputToken = field.NewInt64(table, "input_token")
 	m.OutputToken = field.NewInt64(table, "output_token")
+	m.ReasoningToken = field.NewInt64(table, "reasoning_token")
+	m.AgentID = field.NewInt64(table, "agent_id")
+	m.ConversationID = field.NewInt64(table, "conversation_id")
+	m.LatencyMs = field.NewInt64(table, "latency_ms")
+	m.Cost = field.NewFloat64(table, "cost")
 	m.Logid = field.NewString(table, "logid")
 	m.ErrorCode = field.NewString(table, "error_code")
 	m.ErrorMsg = field.NewString(table, "error_msg")
@@ -130,7 +145,7 @@ func (m *modelRequestRecord) GetFieldByName(fieldName string) (field.OrderExpr,
 }
 
 func (m *modelRequestRecord) fillFieldMap() {
-	m.fieldMap = make(map[string]field.Expr, 16)
+	m.fieldMap = make(map[string]field.Expr, 21)
 	m.fieldMap["id"] = m.ID
 	m.fieldMap["user_id"] = m.UserID
 	m.fieldMap["usage_scene"] = m.UsageScene
@@ -142,6 +157,11 @@ func (m *modelRequestRecord) fillFieldMap() {
 	m.fieldMap["model_name"] = m.ModelName
 	m.fieldMap["input_token"] = m.InputToken
 	m.fieldMap["output_token"] = m.OutputToken
+	m.fieldMap["reasoning_token"] = m.ReasoningToken
+	m.fieldMap["agent_id"] = m.AgentID
+	m.fieldMap["conversation_id"] = m.ConversationID
+	m.fieldMap["latency_ms"] = m.LatencyMs
+	m.fieldMap["cost"] = m.Cost
 	m.fieldMap["logid"] = m.Logid
 	m.fieldMap["error_code"] = m.ErrorCode
 	m.fieldMap["error_msg"] = m.ErrorMsg
@@ -0,0 +1,61 @@
+// Package fakemodel 提供 llm 模块测试共用的 ToolCallingChatModel
+package fakemodel
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Model 按 Chunks 输出的模型，Err 不为空时直接返回该错误
+type Model struct {
+	Calls  int
+	Err    error
+	Chunks []*schema.Message
+	// StreamErr 流式输出 Chunks 之后返回的错误
+	StreamErr error
+}
+
+// Generate 只有一个分片时原样返回，否则返回拼接后的消息
+func (f *Model) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	f.Calls++
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if len(f.Chunks) == 1 {
+		return f.Chunks[0], nil
+	}
+	return schema.ConcatMessages(f.Chunks)
+}
+
+func (f *Model) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	f.Calls++
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	sr, sw := schema.Pipe[*schema.Message](len(f.Chunks) + 1)
+	for _, c := range f.Chunks {
+		sw.Send(c, nil)
+	}
+	if f.StreamErr != nil {
+		sw.Send(nil, f.StreamErr)
+	}
+	sw.Close()
+	return sr, nil
+}
+
+func (f *Model) WithTools(_ []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return f, nil
+}
+
+// WithUsage 为消息设置 token 用量
+func WithUsage(msg *schema.Message, prompt, completion, reasoning int) *schema.Message {
+	msg.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{
+		PromptTokens:            prompt,
+		CompletionTokens:        completion,
+		TotalTokens:             prompt + completion,
+		CompletionTokensDetails: schema.CompletionTokensDetails{ReasoningTokens: reasoning},
+	}}
+	return msg
+}
@@ -1,6 +1,33 @@
 package errno
 
+import (
+	"github.com/kiosk404/airi-go/backend/pkg/errorx/code"
+)
+
 const (
-	ErrModelIDGenFailCode = 207000000
-	ErrModelNotFoundCode  = 207000001
+	ErrModelIDGenFailCode      = 207000000
+	ErrModelNotFoundCode       = 207000001
+	ErrModelQuotaExceededCode  = 207000002
+	ErrModelBudgetExceededCode = 207000003
+	ErrModelInvalidParamCode   = 207000004
 )
+
+func init() {
+	code.Register(
+		ErrModelInvalidParamCode,
+		"invalid parameter : {msg}",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrModelQuotaExceededCode,
+		"model {model} exceeded {quota} quota in scenario {scenario}, please retry after {retry_after}s",
+		code.WithAffectStability(false),
+	)
+
+	code.Register(
+		ErrModelBudgetExceededCode,
+		"{scope} {id} has spent {spent} {currency}, exceeding the {period} budget of {limit} {currency}",
+		code.WithAffectStability(false),
+	)
+}
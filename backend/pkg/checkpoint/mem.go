@@ -3,31 +3,68 @@ package checkpoint
 import (
 	"context"
 	"sync"
-
-	"github.com/cloudwego/eino/compose"
+	"time"
 )
 
+type memEntry struct {
+	data     []byte
+	expireAt time.Time
+}
+
+func (e *memEntry) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && now.After(e.expireAt)
+}
+
 type inMemoryStore struct {
-	m  map[string][]byte
-	mu sync.RWMutex
+	m   map[string]*memEntry
+	mu  sync.RWMutex
+	ttl time.Duration
 }
 
 func (i *inMemoryStore) Get(_ context.Context, checkPointID string) ([]byte, bool, error) {
 	i.mu.RLock()
-	v, ok := i.m[checkPointID]
+	e, ok := i.m[checkPointID]
 	i.mu.RUnlock()
-	return v, ok, nil
+	if !ok || e.expired(time.Now()) {
+		return nil, false, nil
+	}
+	return e.data, true, nil
 }
 
 func (i *inMemoryStore) Set(_ context.Context, checkPointID string, checkPoint []byte) error {
 	i.mu.Lock()
-	i.m[checkPointID] = checkPoint
+	i.m[checkPointID] = &memEntry{data: checkPoint, expireAt: expireAt(i.ttl)}
 	i.mu.Unlock()
 	return nil
 }
 
-func NewInMemoryStore() compose.CheckPointStore {
+func (i *inMemoryStore) Delete(_ context.Context, checkPointIDs ...string) error {
+	i.mu.Lock()
+	for _, id := range checkPointIDs {
+		delete(i.m, id)
+	}
+	i.mu.Unlock()
+	return nil
+}
+
+func (i *inMemoryStore) GC(_ context.Context) (int64, error) {
+	now := time.Now()
+	var n int64
+	i.mu.Lock()
+	for id, e := range i.m {
+		if e.expired(now) {
+			delete(i.m, id)
+			n++
+		}
+	}
+	i.mu.Unlock()
+	return n, nil
+}
+
+// NewInMemoryStore 进程内存储，重启后中断的执行无法恢复，仅用于本地调试
+func NewInMemoryStore(ttl time.Duration) Store {
 	return &inMemoryStore{
-		m: make(map[string][]byte),
+		m:   make(map[string]*memEntry),
+		ttl: ttl,
 	}
 }
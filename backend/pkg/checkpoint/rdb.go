@@ -0,0 +1,83 @@
+package checkpoint
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const tableNameCheckpoint = "checkpoint"
+
+// checkpointPO 对应 checkpoint 表，同时兼容 mysql 与 sqlite
+type checkpointPO struct {
+	ID        string `gorm:"column:id;type:varchar(128);primaryKey"`
+	Data      []byte `gorm:"column:data"`
+	ExpiredAt int64  `gorm:"column:expired_at;not null;default:0;index:idx_expired_at"`
+	CreatedAt int64  `gorm:"column:created_at;not null;autoCreateTime:milli"`
+	UpdatedAt int64  `gorm:"column:updated_at;not null;autoUpdateTime:milli"`
+}
+
+func (*checkpointPO) TableName() string {
+	return tableNameCheckpoint
+}
+
+type rdbStore struct {
+	db  *gorm.DB
+	ttl time.Duration
+}
+
+func (r *rdbStore) Get(ctx context.Context, checkPointID string) ([]byte, bool, error) {
+	// 未命中是常态，使用 Find 避免 gorm 打印 record not found
+	var pos []*checkpointPO
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND (expired_at = 0 OR expired_at > ?)", checkPointID, time.Now().UnixMilli()).
+		Limit(1).Find(&pos).Error
+	if err != nil {
+		return nil, false, err
+	}
+	if len(pos) == 0 {
+		return nil, false, nil
+	}
+	return pos[0].Data, true, nil
+}
+
+func (r *rdbStore) Set(ctx context.Context, checkPointID string, checkPoint []byte) error {
+	po := &checkpointPO{
+		ID:   checkPointID,
+		Data: checkPoint,
+	}
+	if t := expireAt(r.ttl); !t.IsZero() {
+		po.ExpiredAt = t.UnixMilli()
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "expired_at", "updated_at"}),
+	}).Create(po).Error
+}
+
+func (r *rdbStore) Delete(ctx context.Context, checkPointIDs ...string) error {
+	if len(checkPointIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("id IN ?", checkPointIDs).Delete(&checkpointPO{}).Error
+}
+
+func (r *rdbStore) GC(ctx context.Context) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Where("expired_at > 0 AND expired_at <= ?", time.Now().UnixMilli()).
+		Delete(&checkpointPO{})
+	return res.RowsAffected, res.Error
+}
+
+// NewRDBStore mysql 的表结构见 init-sql，sqlite 等未初始化表结构的数据库在首次使用时建表
+func NewRDBStore(db *gorm.DB, ttl time.Duration) (Store, error) {
+	if !db.Migrator().HasTable(&checkpointPO{}) {
+		if err := db.Migrator().CreateTable(&checkpointPO{}); err != nil {
+			return nil, err
+		}
+	}
+	return &rdbStore{db: db, ttl: ttl}, nil
+}
@@ -6,17 +6,16 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/cloudwego/eino/compose"
 	"github.com/kiosk404/airi-go/backend/infra/contract/cache"
 )
 
 type redisStore struct {
 	client cache.Cmdable
+	ttl    time.Duration
 }
 
 const (
 	checkpointKeyTpl = "checkpoint_key:%s"
-	checkpointExpire = 24 * 7 * 3600 * time.Second
 )
 
 func (r *redisStore) Get(ctx context.Context, checkPointID string) ([]byte, bool, error) {
@@ -31,9 +30,21 @@ func (r *redisStore) Get(ctx context.Context, checkPointID string) ([]byte, bool
 }
 
 func (r *redisStore) Set(ctx context.Context, checkPointID string, checkPoint []byte) error {
-	return r.client.Set(ctx, fmt.Sprintf(checkpointKeyTpl, checkPointID), checkPoint, checkpointExpire).Err()
+	return r.client.Set(ctx, fmt.Sprintf(checkpointKeyTpl, checkPointID), checkPoint, r.ttl).Err()
 }
 
-func NewRedisStore(client cache.Cmdable) compose.CheckPointStore {
-	return &redisStore{client: client}
+func (r *redisStore) Delete(ctx context.Context, checkPointIDs ...string) error {
+	if len(checkPointIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(checkPointIDs))
+	for _, id := range checkPointIDs {
+		keys = append(keys, fmt.Sprintf(checkpointKeyTpl, id))
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+// NewRedisStore 过期由 key 的 TTL 保证，ttl 为 0 时不过期
+func NewRedisStore(client cache.Cmdable, ttl time.Duration) Store {
+	return &redisStore{client: client, ttl: ttl}
 }
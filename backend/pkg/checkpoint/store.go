@@ -0,0 +1,93 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/kiosk404/airi-go/backend/infra/contract/cache"
+	"github.com/kiosk404/airi-go/backend/pkg/logs"
+	"github.com/kiosk404/airi-go/backend/pkg/utils/safego"
+	"gorm.io/gorm"
+)
+
+const (
+	TypeMemory = "memory"
+	TypeRedis  = "redis"
+	TypeRDB    = "rdb"
+
+	DefaultTTL        = 7 * 24 * time.Hour
+	DefaultGCInterval = time.Hour
+)
+
+// Store 在 compose.CheckPointStore 的基础上支持按 ID 清理中断现场
+type Store interface {
+	compose.CheckPointStore
+	// Delete 删除 checkpoint，ID 不存在时不报错
+	Delete(ctx context.Context, checkPointIDs ...string) error
+}
+
+// Collector 需要主动清理过期数据的存储，redis 依赖 key 的过期时间，无需实现
+type Collector interface {
+	// GC 删除已过期的 checkpoint，返回删除的数量
+	GC(ctx context.Context) (int64, error)
+}
+
+type Config struct {
+	// Type 存储类型 memory / redis / rdb
+	Type string
+	// TTL checkpoint 的保留时间，为 0 时不过期
+	TTL time.Duration
+	// GCInterval 清理过期 checkpoint 的间隔
+	GCInterval time.Duration
+}
+
+func New(conf *Config, cacheCli cache.Cmdable, db *gorm.DB) (Store, error) {
+	switch conf.Type {
+	case TypeMemory:
+		return NewInMemoryStore(conf.TTL), nil
+	case TypeRedis:
+		return NewRedisStore(cacheCli, conf.TTL), nil
+	case TypeRDB, "":
+		return NewRDBStore(db, conf.TTL)
+	default:
+		return nil, fmt.Errorf("unknown checkpoint store type: %s", conf.Type)
+	}
+}
+
+// StartGC 定期清理过期的 checkpoint，store 未实现 Collector 时直接返回
+func StartGC(ctx context.Context, store Store, interval time.Duration) {
+	collector, ok := store.(Collector)
+	if !ok || interval <= 0 {
+		return
+	}
+
+	safego.Go(ctx, func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := collector.GC(ctx)
+				if err != nil {
+					logs.Warn("[checkpoint] gc failed, err: %v", err)
+					continue
+				}
+				if n > 0 {
+					logs.Info("[checkpoint] gc removed %d expired checkpoints", n)
+				}
+			}
+		}
+	})
+}
+
+func expireAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
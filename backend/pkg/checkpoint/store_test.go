@@ -0,0 +1,112 @@
+package checkpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTestRDBStore(t *testing.T, ttl time.Duration) (Store, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	store, err := NewRDBStore(db, ttl)
+	assert.NoError(t, err)
+	return store, db
+}
+
+// expire 将 checkpoint 的过期时间改到过去，避免测试依赖 sleep
+func expire(t *testing.T, store Store, db *gorm.DB, id string) {
+	past := time.Now().Add(-time.Minute)
+	switch s := store.(type) {
+	case *inMemoryStore:
+		s.mu.Lock()
+		s.m[id].expireAt = past
+		s.mu.Unlock()
+	case *rdbStore:
+		err := db.Model(&checkpointPO{}).Where("id = ?", id).Update("expired_at", past.UnixMilli()).Error
+		assert.NoError(t, err)
+	}
+}
+
+func testStores(t *testing.T, ttl time.Duration) map[string]func() (Store, *gorm.DB) {
+	return map[string]func() (Store, *gorm.DB){
+		TypeMemory: func() (Store, *gorm.DB) { return NewInMemoryStore(ttl), nil },
+		TypeRDB:    func() (Store, *gorm.DB) { return newTestRDBStore(t, ttl) },
+	}
+}
+
+func TestStoreSetGetDelete(t *testing.T) {
+	ctx := context.Background()
+	for name, newStore := range testStores(t, time.Hour) {
+		t.Run(name, func(t *testing.T) {
+			store, _ := newStore()
+
+			_, ok, err := store.Get(ctx, "cp1")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			assert.NoError(t, store.Set(ctx, "cp1", []byte("v1")))
+			assert.NoError(t, store.Set(ctx, "cp1", []byte("v2")))
+			assert.NoError(t, store.Set(ctx, "cp2", []byte("v3")))
+
+			data, ok, err := store.Get(ctx, "cp1")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("v2"), data)
+
+			assert.NoError(t, store.Delete(ctx, "cp1", "missing"))
+			_, ok, _ = store.Get(ctx, "cp1")
+			assert.False(t, ok)
+			_, ok, _ = store.Get(ctx, "cp2")
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestStoreTTLAndGC(t *testing.T) {
+	ctx := context.Background()
+	for name, newStore := range testStores(t, time.Hour) {
+		t.Run(name, func(t *testing.T) {
+			store, db := newStore()
+			assert.NoError(t, store.Set(ctx, "expired", []byte("v1")))
+			assert.NoError(t, store.Set(ctx, "alive", []byte("v2")))
+			expire(t, store, db, "expired")
+
+			_, ok, err := store.Get(ctx, "expired")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			n, err := store.(Collector).GC(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), n)
+
+			n, err = store.(Collector).GC(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(0), n)
+
+			_, ok, _ = store.Get(ctx, "alive")
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestStoreWithoutTTL(t *testing.T) {
+	ctx := context.Background()
+	for name, newStore := range testStores(t, 0) {
+		t.Run(name, func(t *testing.T) {
+			store, _ := newStore()
+			assert.NoError(t, store.Set(ctx, "cp", []byte("v")))
+
+			n, err := store.(Collector).GC(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(0), n)
+
+			_, ok, _ := store.Get(ctx, "cp")
+			assert.True(t, ok)
+		})
+	}
+}
@@ -3,8 +3,12 @@ package sse
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/airi-go/backend/pkg/json"
 )
 
 // SSESender SSE发送器接口
@@ -18,6 +22,8 @@ type SSenderImpl struct {
 	c      *gin.Context
 	writer gin.ResponseWriter
 	closed bool
+	// lastID 最近一次发送的事件 ID，未指定 ID 的事件在此基础上递增
+	lastID int64
 }
 
 // NewSSESender 创建新的SSE发送器
@@ -49,10 +55,15 @@ func (s *SSenderImpl) Send(ctx context.Context, event *sse.Event) error {
 	default:
 	}
 
-	// 构建SSE数据格式
-	if event.Id != "" {
-		s.writer.WriteString(fmt.Sprintf("id: %s\n", event.Id))
+	// 每个事件都带上 id，客户端断线重连时通过 Last-Event-ID 续传
+	id := event.Id
+	if id == "" {
+		s.lastID++
+		id = strconv.FormatInt(s.lastID, 10)
+	} else if n, err := strconv.ParseInt(id, 10, 64); err == nil {
+		s.lastID = n
 	}
+	s.writer.WriteString(fmt.Sprintf("id: %s\n", id))
 
 	if event.Event != "" {
 		s.writer.WriteString(fmt.Sprintf("event: %s\n", event.Event))
@@ -63,16 +74,25 @@ func (s *SSenderImpl) Send(ctx context.Context, event *sse.Event) error {
 	}
 
 	// 写入数据
-	switch data := event.Data.(type) {
+	var data string
+	switch d := event.Data.(type) {
 	case string:
-		s.writer.WriteString(fmt.Sprintf("data: %s\n\n", data))
+		data = d
 	case []byte:
-		s.writer.WriteString(fmt.Sprintf("data: %s\n\n", string(data)))
+		data = string(d)
 	default:
 		// 对于其他类型，使用JSON序列化
-		s.c.SSEvent("data", data)
-		s.writer.WriteString("\n")
+		b, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		data = string(b)
+	}
+	// 多行数据按协议拆成多个 data 字段
+	for _, line := range strings.Split(data, "\n") {
+		s.writer.WriteString(fmt.Sprintf("data: %s\n", line))
 	}
+	s.writer.WriteString("\n")
 
 	// 刷新缓冲区
 	s.writer.Flush()
@@ -0,0 +1,52 @@
+package sse
+
+import (
+	"context"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var idLine = regexp.MustCompile(`(?m)^id: (.*)$`)
+
+func TestSendEventIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	s := NewSSESender(c)
+	ctx := context.Background()
+
+	assert.NoError(t, s.SendString(ctx, "message", "a"))
+	assert.NoError(t, s.SendString(ctx, "message", "b"))
+	// 指定数字 ID 后，之后的事件在其基础上递增
+	assert.NoError(t, s.SendWithID(ctx, "10", "message", "c"))
+	assert.NoError(t, s.SendString(ctx, "message", "d"))
+	// 非数字 ID 原样发送，不影响递增
+	assert.NoError(t, s.SendWithID(ctx, "abc", "message", "e"))
+	assert.NoError(t, s.SendString(ctx, "message", "f"))
+
+	var ids []string
+	for _, m := range idLine.FindAllStringSubmatch(w.Body.String(), -1) {
+		ids = append(ids, m[1])
+	}
+	assert.Equal(t, []string{"1", "2", "10", "11", "abc", "12"}, ids)
+}
+
+func TestSendMultilineData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	s := NewSSESender(c)
+
+	assert.NoError(t, s.Send(context.Background(), &sse.Event{Event: "message", Data: map[string]string{"k": "v"}}))
+	assert.NoError(t, s.SendString(context.Background(), "message", "line1\nline2"))
+	assert.Equal(t, "id: 1\nevent: message\ndata: {\"k\":\"v\"}\n\n"+
+		"id: 2\nevent: message\ndata: line1\ndata: line2\n\n", w.Body.String())
+
+	assert.NoError(t, s.Close())
+	assert.Error(t, s.SendString(context.Background(), "message", "x"))
+}
@@ -0,0 +1,192 @@
+package ws
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// writeWait 发送队列已满时等待的最长时间，超过后认为客户端消费过慢并断开连接
+	writeWait = 10 * time.Second
+	// pongWait 超过该时间未收到客户端的任何消息或 pong 时断开连接
+	pongWait = 60 * time.Second
+	// pingPeriod 服务端发送 ping 的间隔，必须小于 pongWait
+	pingPeriod = pongWait * 9 / 10
+
+	defaultSendQueueSize   = 64
+	defaultMaxMessageBytes = 1 << 20
+)
+
+var (
+	ErrConnClosed   = errors.New("websocket connection is closed")
+	ErrSlowConsumer = errors.New("websocket client is too slow to consume messages")
+)
+
+// Options 连接参数，零值使用默认配置
+type Options struct {
+	// AllowedOrigins 允许跨域连接的来源，"*" 表示不限制，为空时只允许同源连接
+	AllowedOrigins []string
+	// SendQueueSize 每个连接待发送消息的队列长度，队列满时发送方阻塞
+	SendQueueSize int
+	// MaxMessageBytes 单条客户端消息的最大字节数
+	MaxMessageBytes int64
+}
+
+// Conn WebSocket 连接，读取只能在一个 goroutine 中进行，写入可以并发，由单独的 goroutine 按顺序发送
+type Conn struct {
+	conn *websocket.Conn
+	send chan []byte
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Upgrade 将 HTTP 请求升级为 WebSocket 连接，失败时已向客户端返回错误响应
+func Upgrade(c *gin.Context, opts Options) (*Conn, error) {
+	if opts.SendQueueSize <= 0 {
+		opts.SendQueueSize = defaultSendQueueSize
+	}
+	if opts.MaxMessageBytes <= 0 {
+		opts.MaxMessageBytes = defaultMaxMessageBytes
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: checkOrigin(opts.AllowedOrigins),
+	}
+	wsConn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &Conn{
+		conn:   wsConn,
+		send:   make(chan []byte, opts.SendQueueSize),
+		closed: make(chan struct{}),
+	}
+	wsConn.SetReadLimit(opts.MaxMessageBytes)
+	_ = wsConn.SetReadDeadline(time.Now().Add(pongWait))
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	go conn.writePump()
+	return conn, nil
+}
+
+// checkOrigin 没有 Origin 头的请求不是来自浏览器，不受跨域限制
+func checkOrigin(allowed []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, o := range allowed {
+			if o == "*" || strings.EqualFold(o, origin) {
+				return true
+			}
+		}
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// ReadMessage 读取客户端的数据消息，连接断开或超时返回错误
+func (c *Conn) ReadMessage() ([]byte, error) {
+	for {
+		msgType, data, err := c.conn.ReadMessage()
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		if msgType == websocket.TextMessage || msgType == websocket.BinaryMessage {
+			return data, nil
+		}
+	}
+}
+
+// Send 队列满时阻塞等待，超过 writeWait 仍无法入队时断开连接，避免慢客户端占用服务端内存
+func (c *Conn) Send(data []byte) error {
+	select {
+	case <-c.closed:
+		return ErrConnClosed
+	default:
+	}
+
+	timer := time.NewTimer(writeWait)
+	defer timer.Stop()
+	select {
+	case c.send <- data:
+		return nil
+	case <-c.closed:
+		return ErrConnClosed
+	case <-timer.C:
+		c.Close()
+		return ErrSlowConsumer
+	}
+}
+
+// Done 连接关闭时返回的 channel 被关闭
+func (c *Conn) Done() <-chan struct{} {
+	return c.closed
+}
+
+func (c *Conn) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
+}
+
+func (c *Conn) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.Close()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case data := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-c.closed:
+			// 关闭前发出已入队的消息，客户端可以收到最后的结束事件
+			c.flush()
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			_ = c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
+
+func (c *Conn) flush() {
+	for {
+		select {
+		case data := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer 建立连接后交给 handle 处理，handle 返回时关闭连接
+func newTestServer(t *testing.T, opts Options, handle func(conn *Conn)) string {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ws", func(c *gin.Context) {
+		conn, err := Upgrade(c, opts)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	})
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+}
+
+func dial(url, origin string) (*websocket.Conn, *http.Response, error) {
+	header := http.Header{}
+	if origin != "" {
+		header.Set("Origin", origin)
+	}
+	return websocket.DefaultDialer.Dial(url, header)
+}
+
+func TestEcho(t *testing.T) {
+	url := newTestServer(t, Options{}, func(conn *Conn) {
+		for {
+			data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err = conn.Send(data); err != nil {
+				return
+			}
+		}
+	})
+
+	client, _, err := dial(url, "")
+	assert.NoError(t, err)
+	defer client.Close()
+
+	assert.NoError(t, client.WriteMessage(websocket.TextMessage, []byte("hello")))
+	_, data, err := client.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+}
+
+func TestCloseFlushesQueuedMessages(t *testing.T) {
+	url := newTestServer(t, Options{}, func(conn *Conn) {
+		_ = conn.Send([]byte("1"))
+		_ = conn.Send([]byte("2"))
+		conn.Close()
+		<-conn.Done()
+		assert.ErrorIs(t, conn.Send([]byte("3")), ErrConnClosed)
+	})
+
+	client, _, err := dial(url, "")
+	assert.NoError(t, err)
+	defer client.Close()
+
+	for _, want := range []string{"1", "2"} {
+		_, data, err := client.ReadMessage()
+		assert.NoError(t, err)
+		assert.Equal(t, want, string(data))
+	}
+	_, _, err = client.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+}
+
+func TestMaxMessageBytes(t *testing.T) {
+	readErr := make(chan error, 1)
+	url := newTestServer(t, Options{MaxMessageBytes: 8}, func(conn *Conn) {
+		_, err := conn.ReadMessage()
+		readErr <- err
+	})
+
+	client, _, err := dial(url, "")
+	assert.NoError(t, err)
+	defer client.Close()
+
+	assert.NoError(t, client.WriteMessage(websocket.TextMessage, []byte("0123456789")))
+	assert.Error(t, <-readErr)
+}
+
+func TestCheckOrigin(t *testing.T) {
+	handle := func(conn *Conn) {}
+	sameOrigin := newTestServer(t, Options{}, handle)
+	allowed := newTestServer(t, Options{AllowedOrigins: []string{"https://app.example.com"}}, handle)
+	anyOrigin := newTestServer(t, Options{AllowedOrigins: []string{"*"}}, handle)
+	host := "http://" + strings.TrimSuffix(strings.TrimPrefix(sameOrigin, "ws://"), "/ws")
+
+	cases := []struct {
+		name   string
+		url    string
+		origin string
+		ok     bool
+	}{
+		{"no origin", sameOrigin, "", true},
+		{"same origin", sameOrigin, host, true},
+		{"cross origin", sameOrigin, "https://evil.example.com", false},
+		{"allowed origin", allowed, "https://APP.example.com", true},
+		{"not allowed origin", allowed, "https://evil.example.com", false},
+		{"any origin", anyOrigin, "https://evil.example.com", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client, resp, err := dial(c.url, c.origin)
+			if c.ok {
+				assert.NoError(t, err)
+				client.Close()
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		})
+	}
+}
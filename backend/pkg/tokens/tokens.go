@@ -0,0 +1,97 @@
+// Package tokens 估算文本与消息占用的 token 数量
+//
+// 各模型的分词器不同，这里不追求精确，只用于上下文预算：
+// 中日韩等表意文字约 1 个字符 1 个 token，其余文本约 4 个字节 1 个 token，结果略微偏大以留出余量。
+package tokens
+
+import (
+	"encoding/json"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// messageOverhead 每条消息的角色、分隔符等额外开销
+	messageOverhead = 4
+	// bytesPerToken 非表意文字平均每个 token 的字节数
+	bytesPerToken = 4
+	// mediaTokens 图片、音视频等多模态内容按固定数量估算
+	mediaTokens = 512
+)
+
+// Estimate 估算一段文本的 token 数量
+func Estimate(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	var wide, other int
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if isWide(r) {
+			wide++
+		} else {
+			other += size
+		}
+		i += size
+	}
+	return wide + (other+bytesPerToken-1)/bytesPerToken
+}
+
+// EstimateMessage 估算一条消息的 token 数量，包含推理内容、工具调用与多模态内容
+func EstimateMessage(msg *schema.Message) int {
+	if msg == nil {
+		return 0
+	}
+
+	n := messageOverhead + Estimate(msg.Content) + Estimate(msg.ReasoningContent) + Estimate(msg.Name)
+	for _, tc := range msg.ToolCalls {
+		n += Estimate(tc.Function.Name) + Estimate(tc.Function.Arguments)
+	}
+	for _, part := range msg.MultiContent {
+		if part.Type == schema.ChatMessagePartTypeText {
+			n += Estimate(part.Text)
+		} else {
+			n += mediaTokens
+		}
+	}
+	return n
+}
+
+// EstimateMessages 估算一组消息的 token 数量
+func EstimateMessages(msgs []*schema.Message) int {
+	var n int
+	for _, msg := range msgs {
+		n += EstimateMessage(msg)
+	}
+	return n
+}
+
+// EstimateTools 估算工具定义（名称、描述与参数 schema）的 token 数量
+func EstimateTools(tools []*schema.ToolInfo) int {
+	var n int
+	for _, t := range tools {
+		if t == nil {
+			continue
+		}
+		n += messageOverhead + Estimate(t.Name) + Estimate(t.Desc)
+		if t.ParamsOneOf == nil {
+			continue
+		}
+		if s, err := t.ParamsOneOf.ToJSONSchema(); err == nil && s != nil {
+			if b, err := json.Marshal(s); err == nil {
+				n += Estimate(string(b))
+			}
+		}
+	}
+	return n
+}
+
+func isWide(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
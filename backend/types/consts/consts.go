@@ -47,6 +47,11 @@ const (
 	RMQConsumeGroupResource  = "cg_search_resource"
 	RMQConsumeGroupApp       = "cg_search_app"
 	RMQConsumeGroupKnowledge = "cg_knowledge"
+	RMQTopicChatCancel       = "airi_chat_cancel"
+	// RMQConsumeGroupChatCancel 取消事件需要广播到所有实例，实际的消费组为该前缀加实例 ID
+	RMQConsumeGroupChatCancel = "cg_chat_cancel"
+	RMQTopicChatAsyncRun      = "airi_chat_async_run"
+	RMQConsumeGroupAsyncRun   = "cg_chat_async_run"
 )
 
 const (
@@ -57,9 +62,44 @@ const (
 const (
 	DisableUserRegistration  = "DISABLE_USER_REGISTRATION"
 	AllowRegistrationAccount = "ALLOW_REGISTRATION_ACCOUNT"
+	AdminAccounts            = "ADMIN_ACCOUNTS"
+)
+
+const (
+	CheckPointStoreType  = "CHECKPOINT_STORE_TYPE"
+	CheckPointTTL        = "CHECKPOINT_TTL"
+	CheckPointGCInterval = "CHECKPOINT_GC_INTERVAL"
+)
+
+const (
+	AsyncRunWorkerNum = "ASYNC_RUN_WORKER_NUM"
+	RunEventTTL       = "RUN_EVENT_TTL"
+	WSAllowedOrigins  = "WS_ALLOWED_ORIGINS"
+)
+
+const (
+	MemoryExtractInterval = "MEMORY_EXTRACT_INTERVAL"
+)
+
+const (
+	TTSType    = "TTS_TYPE"
+	TTSBaseURL = "TTS_BASE_URL"
+	TTSAPIKey  = "TTS_API_KEY"
+	TTSModel   = "TTS_MODEL"
+	TTSVoice   = "TTS_VOICE"
+	TTSFormat  = "TTS_FORMAT"
+)
+
+const (
+	STTType     = "STT_TYPE"
+	STTBaseURL  = "STT_BASE_URL"
+	STTAPIKey   = "STT_API_KEY"
+	STTModel    = "STT_MODEL"
+	STTLanguage = "STT_LANGUAGE"
 )
 
 const (
 	SearchESVersion = "SEARCH_ES_VERSION"
 	BleveIndexPath  = "BLEVE_INDEX_PATH"
+	VectorStorePath = "VECTOR_STORE_PATH"
 )
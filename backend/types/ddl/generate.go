@@ -10,7 +10,9 @@ import (
 
 	"github.com/kiosk404/airi-go/backend/api/model/app/bot_common"
 	pluginentity "github.com/kiosk404/airi-go/backend/modules/component/crossdomain/plugin/model"
+	workflowentity "github.com/kiosk404/airi-go/backend/modules/component/workflow/domain/entity"
 	agentrunentity "github.com/kiosk404/airi-go/backend/modules/conversation/agent_run/domain/entity"
+	knowledgeentity "github.com/kiosk404/airi-go/backend/modules/data/knowledge/domain/entity"
 	"github.com/kiosk404/airi-go/backend/modules/llm/crossdomain/modelmgr/model"
 	"gorm.io/gen"
 	"gorm.io/gorm"
@@ -32,6 +34,8 @@ var table2Columns2Model = map[string]map[string]any{
 		"database_config":            []*bot_common.Database{},
 		"shortcut_command":           []string{},
 		"layout_info":                &bot_common.LayoutInfo{},
+		"avatar_info":                &bot_common.AvatarInfo{},
+		"voices_info":                &bot_common.VoicesInfo{},
 	},
 	"single_agent_version": {
 		"variable":                   []*bot_common.Variable{},
@@ -47,6 +51,8 @@ var table2Columns2Model = map[string]map[string]any{
 		"database_config":            []*bot_common.Database{},
 		"shortcut_command":           []string{},
 		"layout_info":                &bot_common.LayoutInfo{},
+		"avatar_info":                &bot_common.AvatarInfo{},
+		"voices_info":                &bot_common.VoicesInfo{},
 	},
 	"plugin": {
 		"manifest":    &pluginentity.PluginManifest{},
@@ -85,6 +91,18 @@ var table2Columns2Model = map[string]map[string]any{
 	"run_record": {
 		"usage": &agentrunentity.Usage{},
 	},
+	"knowledge": {
+		"chunk_strategy": &knowledgeentity.ChunkStrategy{},
+	},
+	"knowledge_document":       {},
+	"knowledge_document_slice": {},
+	"agent_memory":             {},
+	"workflow": {
+		"canvas": &workflowentity.Canvas{},
+	},
+	"workflow_version": {
+		"canvas": &workflowentity.Canvas{},
+	},
 	"model_instance": {
 		"provider":     &model.ModelProvider{},
 		"display_info": &model.DisplayInfo{},
@@ -162,6 +180,10 @@ func generateForComponent(db *gorm.DB) {
 	tableList = []string{"agent_tool_draft", "agent_tool_version", "plugin",
 		"plugin_draft", "plugin_oauth_auth", "plugin_version", "tool", "tool_draft", "tool_version"}
 	generateFunc(db, path, tableList)
+
+	path = "modules/component/workflow/infra/repo/gorm_gen"
+	tableList = []string{"workflow", "workflow_version"}
+	generateFunc(db, path, tableList)
 }
 
 func generateForData(db *gorm.DB) {
@@ -171,6 +193,14 @@ func generateForData(db *gorm.DB) {
 	path = "modules/data/upload/infra/repo/gorm_gen"
 	tableList = []string{"files"}
 	generateFunc(db, path, tableList)
+
+	path = "modules/data/knowledge/infra/repo/gorm_gen"
+	tableList = []string{"knowledge", "knowledge_document", "knowledge_document_slice"}
+	generateFunc(db, path, tableList)
+
+	path = "modules/data/memory/infra/repo/gorm_gen"
+	tableList = []string{"agent_memory"}
+	generateFunc(db, path, tableList)
 }
 
 func generateForConversation(db *gorm.DB) {
@@ -189,7 +219,7 @@ func generateForConversation(db *gorm.DB) {
 
 	// Agent Run
 	path = "modules/conversation/agent_run/infra/repo/gorm_gen"
-	tableList = []string{"run_record"}
+	tableList = []string{"run_record", "history_summary"}
 	generateFunc(db, path, tableList)
 }
 
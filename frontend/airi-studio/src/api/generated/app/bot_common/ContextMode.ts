@@ -8,5 +8,7 @@ export enum ContextMode {
     Chat = 0,
     FunctionCall_1 = 1,
     FunctionCall_2 = 2,
-    FunctionCall_3 = 3
+    FunctionCall_3 = 3,
+    TruncateOldest = 4,
+    RollingSummary = 5
 }
@@ -5,12 +5,17 @@ include "./app/developer_api.thrift"
 include "./app/intelligence.thrift"
 include "./app/model_api.thrift"
 include "./data/resource/resource.thrift"
+include "./data/knowledge/knowledge.thrift"
+include "./data/variables/variables.thrift"
+include "./data/memory/memory.thrift"
 include "./foundation/openapiauth.thrift"
 include "./foundation/user.thrift"
 include "./llm/manage.thrift"
 include "./llm/runtime.thrift"
+include "./llm/usage.thrift"
 include "./component/plugin/plugin_develop.thrift"
 include "./component/playground/playground.thrift"
+include "./component/workflow/workflow.thrift"
 include "./conversation/agent_run_service.thrift"
 include "./conversation/message_service.thrift"
 include "./conversation/conversation_service.thrift"
@@ -23,6 +28,7 @@ service ModelConfigService extends model_api.ModelConfigService{}
 service UserService extends user.UserService {}
 service LLMManageService extends manage.LLMManageService {}
 service LLMRuntimeService extends runtime.LLMRuntimeService {}
+service LLMUsageService extends usage.LLMUsageService {}
 service PluginDevelopService extends plugin_develop.PluginDevelopService {}
 service AgentRunService extends agent_run_service.AgentRunService {}
 service MessageService extends message_service.MessageService {}
@@ -31,3 +37,7 @@ service PlaygroundService extends playground.PlaygroundService {}
 service IntelligenceService extends intelligence.IntelligenceService {}
 service ResourceService extends resource.ResourceService {}
 service UploadService extends upload.UploadService {}
+service KnowledgeService extends knowledge.KnowledgeService {}
+service VariablesService extends variables.VariablesService {}
+service MemoryService extends memory.MemoryService {}
+service WorkflowService extends workflow.WorkflowService {}
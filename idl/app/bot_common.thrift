@@ -27,6 +27,7 @@ struct ModelInfo {
     8: optional i32                 TopK              (api.body="top_k", go.tag='json:"top_k"')                                             , // When generating, sample the size of the candidate set
     9: optional ModelResponseFormat ResponseFormat    (api.body="response_format", go.tag='json:"response_format"')                         , // model reply content format
     10: optional ModelStyle         ModelStyle        (api.body="model_style", go.tag='json:"model_style"')                                 , // User-selected model style
+    11: optional list<i64>          FallbackModelIds  (agw.js_conv="str", api.js_conv="true", api.body="fallback_model_ids", go.tag='json:"fallback_model_ids"'), // Ordered fallback model IDs, tried in turn when the model above is unavailable
 }
 
 enum ModelStyle {
@@ -47,6 +48,8 @@ enum ContextMode {
     FunctionCall_1 = 1,
     FunctionCall_2 = 2,
     FunctionCall_3 = 3,
+    TruncateOldest = 4, // Drop the oldest history once the context token budget is exceeded
+    RollingSummary = 5, // Compress older history into a rolling summary per conversation section
 }
 
 enum ModelFuncConfigType {
@@ -167,6 +170,14 @@ struct VoicesInfo {                                 // Airi Voices
     6: optional DefaultUserInputType   DefaultUserInputType (api.body="default_user_input_type"), // Default user input type
 }
 
+// avatar cues
+struct AvatarInfo {                                     // Airi Live2D/VRM avatar
+    1: optional bool         Enabled     (api.body="enabled")    , // Whether to let the model emit avatar cues such as [emotion:happy]
+    2: optional list<string> Emotions    (api.body="emotions")   , // Allowed emotion names
+    3: optional list<string> Motions     (api.body="motions")    , // Allowed motion names
+    4: optional list<string> Expressions (api.body="expressions"), // Allowed expression names
+}
+
 enum DefaultUserInputType {
     NotSet = 0, // Not set
     Text  = 1,  // Text
@@ -470,6 +481,7 @@ struct BotInfo {
     33: UserQueryCollectConf UserQueryCollectConf (go.tag='json:"user_query_collect_conf"', api.body="user_query_collect_conf") , // User query collection configuration
     34: LayoutInfo         LayoutInfo       (go.tag='json:"layout_info"', api.body="layout_info")                                        , // Orchestration information for workflow patterns
     35: BusinessType       BusinessType     (go.tag='json:"business_type"', api.body="business_type")
+    36: AvatarInfo         AvatarInfo       (go.tag='json:"avatar_info"', api.body="avatar_info")                                        , // Avatar cue configuration
 }
 
 
@@ -616,6 +628,7 @@ struct BotInfoForUpdate {
     31: optional HookInfo             HookInfo (api.body="hook_info",go.tag='json:"hook_info,omitempty"')
     32: optional UserQueryCollectConf     UserQueryCollectConf (api.body="user_query_collect_conf",go.tag='json:"user_query_collect_conf,omitempty"')// User query collection configuration
     33: optional LayoutInfo               LayoutInfo(api.body="layout_info",go.tag='json:"layout_info,omitempty"')                                   // Orchestration information for workflow patterns
+    34: optional AvatarInfo               AvatarInfo(api.body="avatar_info",go.tag='json:"avatar_info,omitempty"')                                   // Avatar cue configuration
 }
 
 struct AgentForUpdate {
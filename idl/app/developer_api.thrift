@@ -511,6 +511,7 @@ enum ModelClass {
     DeepSeek        = 4
     Ollama          = 5
     Claude          = 6
+    OpenAICompatible = 7
 
     Other           = 999
 }
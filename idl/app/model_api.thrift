@@ -86,6 +86,7 @@ struct Connection {
     5: optional QwenConnInfo qwen
     6: optional OllamaConnInfo ollama
     7: optional ClaudeConnInfo claude
+    8: optional OpenAICompatibleConnInfo openai_compatible
 }
 
 struct BaseConnectionInfo {
@@ -120,6 +121,17 @@ struct OllamaConnInfo {}
 
 struct ClaudeConnInfo {}
 
+// vLLM、llama.cpp server、LM Studio 等兼容 OpenAI 协议的自部署推理服务
+struct OpenAICompatibleConnInfo {
+    1: map<string,string> extra_headers     // 每次请求附带的额外 header
+    2: string chat_completions_path         // 覆盖拼接在 base_url 之后的路径，默认 /chat/completions
+    3: bool disable_stream_options          // 不发送 stream_options，流式 usage 将缺失
+    4: bool disable_tool_call               // 服务不支持工具调用，不发送 tools/tool_choice
+    5: bool disable_tool_choice             // 服务不支持 tool_choice 参数
+    6: bool disable_response_format         // 服务不支持 response_format 参数
+    7: string reasoning_field               // 思考内容所在字段，默认 reasoning_content
+}
+
 struct CreateModelReq {
     1: developer_api.ModelClass model_class
     2: string model_name
@@ -0,0 +1,181 @@
+namespace go component.workflow
+
+include "../../base.thrift"
+
+// 与 bot_common.WorkflowMode 取值一致
+enum WorkflowMode {
+    Workflow = 0 // 工作流
+    ChatFlow = 3 // 对话流
+}
+
+enum TerminatePlan {
+    ReturnVariables  = 1 // 返回输出变量
+    UseAnswerContent = 2 // 返回渲染的回答，作为 Agent 工具时直接回复用户
+}
+
+struct WorkflowInfo {
+    1: i64          workflow_id    (api.js_conv="true", go.tag='json:"workflow_id,string"')
+    2: string       name
+    3: string       description
+    4: string       icon_uri
+    5: WorkflowMode mode
+    6: string       canvas         // 草稿画布 JSON，列表接口不返回
+    7: string       latest_version // 最新发布的版本，为空表示未发布
+    8: i64          creator_id     (api.js_conv="true", go.tag='json:"creator_id,string"')
+    9: i64          created_at
+    10: i64         updated_at
+}
+
+struct WorkflowVersionInfo {
+    1: i64    workflow_id         (api.js_conv="true", go.tag='json:"workflow_id,string"')
+    2: string version
+    3: string version_description
+    4: i64    creator_id          (api.js_conv="true", go.tag='json:"creator_id,string"')
+    5: i64    created_at
+}
+
+// name 同时作为 Agent 工具名，只能包含字母、数字和下划线，且以字母开头
+struct CreateWorkflowRequest {
+    1: required string       name
+    2: optional string       description
+    3: optional string       icon_uri
+    4: optional WorkflowMode mode
+
+    255: optional base.Base Base
+}
+
+struct CreateWorkflowResponse {
+    1: i64          code
+    2: string       msg
+    3: WorkflowInfo data
+}
+
+struct UpdateWorkflowRequest {
+    1: required i64    workflow_id (api.js_conv="true", go.tag='json:"workflow_id,string"')
+    2: optional string name
+    3: optional string description
+    4: optional string icon_uri
+
+    255: optional base.Base Base
+}
+
+struct UpdateWorkflowResponse {
+    1: i64    code
+    2: string msg
+}
+
+struct SaveCanvasRequest {
+    1: required i64    workflow_id (api.js_conv="true", go.tag='json:"workflow_id,string"')
+    2: required string canvas      // 画布 JSON
+
+    255: optional base.Base Base
+}
+
+struct SaveCanvasResponse {
+    1: i64    code
+    2: string msg
+}
+
+struct DeleteWorkflowRequest {
+    1: required i64 workflow_id (api.js_conv="true", go.tag='json:"workflow_id,string"')
+
+    255: optional base.Base Base
+}
+
+struct DeleteWorkflowResponse {
+    1: i64    code
+    2: string msg
+}
+
+struct GetWorkflowRequest {
+    1: required i64 workflow_id (api.js_conv="true", go.tag='json:"workflow_id,string"')
+
+    255: optional base.Base Base
+}
+
+struct GetWorkflowResponse {
+    1: i64          code
+    2: string       msg
+    3: WorkflowInfo data
+}
+
+struct ListWorkflowRequest {
+    1: optional string name
+    2: optional i32    page
+    3: optional i32    page_size
+
+    255: optional base.Base Base
+}
+
+struct ListWorkflowResponse {
+    1: i64                code
+    2: string             msg
+    3: list<WorkflowInfo> workflow_list
+    4: i64                total
+}
+
+// version 为空时按发布次数自动生成
+struct PublishWorkflowRequest {
+    1: required i64    workflow_id         (api.js_conv="true", go.tag='json:"workflow_id,string"')
+    2: optional string version
+    3: optional string version_description
+
+    255: optional base.Base Base
+}
+
+struct PublishWorkflowResponse {
+    1: i64                 code
+    2: string              msg
+    3: WorkflowVersionInfo data
+}
+
+struct ListVersionRequest {
+    1: required i64 workflow_id (api.js_conv="true", go.tag='json:"workflow_id,string"')
+    2: optional i32 page
+    3: optional i32 page_size
+
+    255: optional base.Base Base
+}
+
+struct ListVersionResponse {
+    1: i64                       code
+    2: string                    msg
+    3: list<WorkflowVersionInfo> version_list
+    4: i64                       total
+}
+
+// version 为空时运行草稿
+struct RunWorkflowRequest {
+    1: required i64    workflow_id (api.js_conv="true", go.tag='json:"workflow_id,string"')
+    2: optional string input       // 入参 JSON 对象
+    3: optional string version
+
+    255: optional base.Base Base
+}
+
+struct RunWorkflowResult {
+    1: string        execute_id
+    2: TerminatePlan terminate_plan
+    3: string        output // 输出变量 JSON
+    4: string        answer
+}
+
+struct RunWorkflowResponse {
+    1: i64               code
+    2: string            msg
+    3: RunWorkflowResult data
+}
+
+service WorkflowService {
+    CreateWorkflowResponse CreateWorkflow(1: CreateWorkflowRequest request)(api.post='/api/workflow/create', api.category="workflow", api.gen_path="workflow")
+    UpdateWorkflowResponse UpdateWorkflow(1: UpdateWorkflowRequest request)(api.post='/api/workflow/update', api.category="workflow", api.gen_path="workflow")
+    SaveCanvasResponse SaveCanvas(1: SaveCanvasRequest request)(api.post='/api/workflow/save_canvas', api.category="workflow", api.gen_path="workflow")
+    DeleteWorkflowResponse DeleteWorkflow(1: DeleteWorkflowRequest request)(api.post='/api/workflow/delete', api.category="workflow", api.gen_path="workflow")
+    GetWorkflowResponse GetWorkflow(1: GetWorkflowRequest request)(api.post='/api/workflow/get', api.category="workflow", api.gen_path="workflow")
+    ListWorkflowResponse ListWorkflow(1: ListWorkflowRequest request)(api.post='/api/workflow/list', api.category="workflow", api.gen_path="workflow")
+
+    PublishWorkflowResponse PublishWorkflow(1: PublishWorkflowRequest request)(api.post='/api/workflow/publish', api.category="workflow", api.gen_path="workflow")
+    ListVersionResponse ListVersion(1: ListVersionRequest request)(api.post='/api/workflow/version/list', api.category="workflow", api.gen_path="workflow")
+
+    RunWorkflowResponse RunWorkflow(1: RunWorkflowRequest request)(api.post='/api/workflow/run', api.category="workflow", api.gen_path="workflow")
+}
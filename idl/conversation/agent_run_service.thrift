@@ -5,4 +5,7 @@ service AgentRunService {
     run.AgentRunResponse AgentRun(1: run.AgentRunRequest request)(api.post='/api/conversation/chat', api.category="conversation", api.gen_path= "agent_run")
     run.ChatV3Response ChatV3(1: run.ChatV3Request request)(api.post = "/v3/chat", api.category="chat", api.tag="openapi", api.gen_path="chat")
     run.CancelChatApiResponse CancelChatApi(1: run.CancelChatApiRequest request) (api.post = '/v3/chat/cancel', api.category = "chat", api.tag="openapi", agw.preserve_base = "true")
+    run.RetrieveChatApiResponse RetrieveChatApi(1: run.RetrieveChatApiRequest request) (api.get = '/api/conversation/chat/retrieve', api.category="conversation", api.gen_path= "agent_run")
+    run.AgentRunResponse ReattachChatApi(1: run.ReattachChatApiRequest request) (api.get = '/api/conversation/chat/reattach', api.category="conversation", api.gen_path= "agent_run")
+    run.ListChatMessageApiResponse ListChatMessageApi(1: run.ListChatMessageApiRequest request) (api.get = '/api/conversation/chat/message/list', api.category="conversation", api.gen_path= "agent_run")
 }
\ No newline at end of file
@@ -120,6 +120,13 @@ struct ChatMessage {
     18: optional list<ChatMessageMetaInfo> meta_infos, // Text markup such as quoting, highlighting, etc
     19: optional map<string,string> card_status  // Card Status
     20: optional string reasoning_content  //Model Thinking Chain
+    21: optional BranchInfo branch_info    // 重新生成或编辑产生多个分支时返回，用于切换分支
+}
+
+struct BranchInfo {
+    1: i32          index    // 当前分支的序号，从 1 开始
+    2: i32          total    // 同一位置的分支数
+    3: list<string> chat_ids // 各分支对应的对话 ID，按创建时间排序，切换分支时传入
 }
 
 
@@ -178,6 +185,15 @@ struct BreakMessageResponse  {
     2: string msg
 }
 
+struct SwitchBranchRequest {
+    1: required i64 conversation_id (api.js_conv='true', go.tag='json:"conversation_id,string"')
+    2: required i64 chat_id (api.js_conv='true', go.tag='json:"chat_id,string"') // 切换到的分支对应的对话 ID
+}
+struct SwitchBranchResponse {
+    1: i64    code
+    2: string msg
+}
+
 //batch query
 struct ListMessageApiRequest {
     1:   required  i64    conversation_id (api.query = "conversation_id",api.js_conv='true', go.tag='json:"conversation_id,string"') //session id
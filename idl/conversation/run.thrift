@@ -27,6 +27,8 @@ const string ContentTypeMixApi = "object_string"
 const string RunEventMessage = "message"
 const string RunEventDone    = "done"
 const string RunEventError   = "error"
+const string RunEventDirective = "directive"
+const string RunEventAudio     = "audio"
 
 
 
@@ -102,6 +104,8 @@ struct AgentRunRequest  {
     21: optional string     sub_scene // Scene granularity further distinguish scenes, currently only used for bot templates = bot_template
     22: optional DiffModeIdentifier diff_mode_identifier // Chat configuration in diff mode, draft only single bot
     23: optional i64 shortcut_cmd_id  (api.js_conv='true')
+    24: optional bool stream // 为 false 时运行进入队列异步执行，立即返回运行 ID，默认流式返回
+    25: optional i64  edit_message_id (api.js_conv='true') // 编辑后重新发送的用户消息 ID，新的提问与原提问互为分支
 }
 
 
@@ -117,11 +121,35 @@ struct RunStreamResponse {
 struct AgentRunResponse  {
     1: i64    code
     2: string msg
+    3: optional ChatV3ChatDetail data // 异步执行时返回排队中的运行
 }
 
 struct ErrorData {
     1: i64 code
     2: string msg
+    3: optional i64 retry_after_ms // 模型配额超限时需要等待的毫秒数
+}
+
+// 模型回答中解析出的形象指令，如 [emotion:happy]
+struct DirectiveData {
+    1: string conversation_id
+    2: string message_id
+    3: string type       // emotion / motion / expression
+    4: string name
+    5: i32    offset     // 指令在回答正文中的位置，按去除指令后的字符数计
+    6: i64    elapsed_ms // 距运行开始的毫秒数
+}
+
+// 回答中一句话合成的语音，回答仍在生成时即可开始播放
+struct AudioData {
+    1: string conversation_id
+    2: string message_id
+    3: i32    index        // 语音在该回答中的序号，从 0 开始
+    4: string text
+    5: i32    offset       // 该句在回答正文中的起始位置，按字符数计
+    6: string url
+    7: string content_type
+    8: bool   autoplay     // 智能体配置为自动播放
 }
 
 
@@ -159,19 +187,19 @@ struct ChatV3Request {
 }
 
 struct ChatV3MessageDetail {
-    1: required string ID (api.body = "id"),
-    2: required string ConversationID (api.body = "conversation_id"),
-    3: required string BotID (api.body = "bot_id"),
-    4: required string Role (api.body = "role"),
-    5: required string Type (api.body = "type"),
-    6: required string Content (api.body = "content"),
-    7: required string ContentType (api.body = "content_type"),
-    8: optional map<string, string> MetaData (api.body = "meta_data"),
-    9: required string ChatID (api.body = "chat_id")
-    10: optional string SectionID (api.body="section_id")
-    11: optional i64 CreatedAt (api.body = "created_at")
-    12: optional i64 UpdatedAt (api.body = "updated_at")
-    13: optional string ReasoningContent (api.body = "reasoning_content")
+    1: required string ID (api.body = "id", go.tag='json:"id"'),
+    2: required string ConversationID (api.body = "conversation_id", go.tag='json:"conversation_id"'),
+    3: required string BotID (api.body = "bot_id", go.tag='json:"bot_id"'),
+    4: required string Role (api.body = "role", go.tag='json:"role"'),
+    5: required string Type (api.body = "type", go.tag='json:"type"'),
+    6: required string Content (api.body = "content", go.tag='json:"content"'),
+    7: required string ContentType (api.body = "content_type", go.tag='json:"content_type"'),
+    8: optional map<string, string> MetaData (api.body = "meta_data", go.tag='json:"meta_data,omitempty"'),
+    9: required string ChatID (api.body = "chat_id", go.tag='json:"chat_id"')
+    10: optional string SectionID (api.body="section_id", go.tag='json:"section_id,omitempty"')
+    11: optional i64 CreatedAt (api.body = "created_at", go.tag='json:"created_at,omitempty"')
+    12: optional i64 UpdatedAt (api.body = "updated_at", go.tag='json:"updated_at,omitempty"')
+    13: optional string ReasoningContent (api.body = "reasoning_content", go.tag='json:"reasoning_content,omitempty"')
 }
 
 
@@ -252,4 +280,32 @@ struct CancelChatApiRequest {
 struct CancelChatApiResponse {
     1:   ChatV3ChatDetail ChatV3ChatDetail (agw.key = "data")
     255: base.BaseResp               BaseResp
-}
\ No newline at end of file
+}
+
+struct RetrieveChatApiRequest {
+    1:   required  i64 ChatID (api.query = "chat_id", api.js_conv='true', go.tag='form:"chat_id" json:"chat_id,string" query:"chat_id"')
+    2:   required  i64 ConversationID (api.query = "conversation_id", api.js_conv='true', go.tag='form:"conversation_id" json:"conversation_id,string" query:"conversation_id"')
+}
+
+struct RetrieveChatApiResponse {
+    1: i64 code
+    2: string msg
+    3: optional ChatV3ChatDetail data
+}
+
+struct ListChatMessageApiRequest {
+    1:   required  i64 ChatID (api.query = "chat_id", api.js_conv='true', go.tag='form:"chat_id" json:"chat_id,string" query:"chat_id"')
+    2:   required  i64 ConversationID (api.query = "conversation_id", api.js_conv='true', go.tag='form:"conversation_id" json:"conversation_id,string" query:"conversation_id"')
+}
+
+struct ListChatMessageApiResponse {
+    1: i64 code
+    2: string msg
+    3: list<ChatV3MessageDetail> data
+}
+
+struct ReattachChatApiRequest {
+    1:   required  i64 ChatID (api.query = "chat_id", api.js_conv='true', go.tag='form:"chat_id" json:"chat_id,string" query:"chat_id"')
+    2:   required  i64 ConversationID (api.query = "conversation_id", api.js_conv='true', go.tag='form:"conversation_id" json:"conversation_id,string" query:"conversation_id"')
+    3:   optional  i64 LastEventID (api.query = "last_event_id", api.js_conv='true', go.tag='form:"last_event_id" json:"last_event_id,string,omitempty" query:"last_event_id"') // 未传时读取 Last-Event-ID 请求头
+}
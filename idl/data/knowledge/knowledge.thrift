@@ -0,0 +1,206 @@
+namespace go data.knowledge
+
+include "../../base.thrift"
+
+enum DocumentStatus {
+    Processing = 0 // 处理中
+    Enable     = 1 // 可用
+    Failed     = 2 // 处理失败
+}
+
+// 与 bot_common.SearchStrategy 取值一致
+enum SearchStrategy {
+    SemanticSearch = 0  // 语义检索
+    HybirdSearch   = 1  // 混合检索
+    FullTextSearch = 20 // 全文检索
+}
+
+struct ChunkStrategy {
+    1: optional string separator  // 分隔符，为空时按段落切分
+    2: optional i64    chunk_size // 单个切片最大字符数
+    3: optional i64    overlap    // 相邻切片重叠字符数
+}
+
+struct KnowledgeInfo {
+    1: i64                    id             (api.js_conv="true", go.tag='json:"id,string"')
+    2: string                 name
+    3: string                 description
+    4: optional ChunkStrategy chunk_strategy
+    5: i64                    creator_id     (api.js_conv="true", go.tag='json:"creator_id,string"')
+    6: i64                    created_at
+    7: i64                    updated_at
+}
+
+struct DocumentInfo {
+    1:  i64            id             (api.js_conv="true", go.tag='json:"id,string"')
+    2:  i64            knowledge_id   (api.js_conv="true", go.tag='json:"knowledge_id,string"')
+    3:  string         name
+    4:  string         file_extension
+    5:  string         uri
+    6:  i64            size
+    7:  i64            slice_count
+    8:  i64            char_count
+    9:  DocumentStatus status
+    10: string         fail_reason
+    11: i64            created_at
+    12: i64            updated_at
+}
+
+struct SliceInfo {
+    1: i64    id          (api.js_conv="true", go.tag='json:"id,string"')
+    2: i64    document_id (api.js_conv="true", go.tag='json:"document_id,string"')
+    3: i64    sequence
+    4: string content
+}
+
+struct RetrieveSliceInfo {
+    1: i64    slice_id       (api.js_conv="true", go.tag='json:"slice_id,string"')
+    2: i64    document_id    (api.js_conv="true", go.tag='json:"document_id,string"')
+    3: i64    knowledge_id   (api.js_conv="true", go.tag='json:"knowledge_id,string"')
+    4: string knowledge_name
+    5: string document_name
+    6: string content
+    7: double score
+}
+
+struct CreateKnowledgeRequest {
+    1: required string        name
+    2: optional string        description
+    3: optional ChunkStrategy chunk_strategy
+
+    255: optional base.Base Base
+}
+
+struct CreateKnowledgeResponse {
+    1: i64           code
+    2: string        msg
+    3: KnowledgeInfo data
+}
+
+struct UpdateKnowledgeRequest {
+    1: required i64    knowledge_id (api.js_conv="true", go.tag='json:"knowledge_id,string"')
+    2: optional string name
+    3: optional string description
+
+    255: optional base.Base Base
+}
+
+struct UpdateKnowledgeResponse {
+    1: i64    code
+    2: string msg
+}
+
+struct DeleteKnowledgeRequest {
+    1: required i64 knowledge_id (api.js_conv="true", go.tag='json:"knowledge_id,string"')
+
+    255: optional base.Base Base
+}
+
+struct DeleteKnowledgeResponse {
+    1: i64    code
+    2: string msg
+}
+
+struct ListKnowledgeRequest {
+    1: optional string name
+    2: optional i32    page
+    3: optional i32    page_size
+
+    255: optional base.Base Base
+}
+
+struct ListKnowledgeResponse {
+    1: i64                 code
+    2: string              msg
+    3: list<KnowledgeInfo> knowledge_list
+    4: i64                 total
+}
+
+// content 与 uri 二选一，uri 为通过上传接口得到的对象存储 key
+struct CreateDocumentRequest {
+    1: required i64    knowledge_id   (api.js_conv="true", go.tag='json:"knowledge_id,string"')
+    2: required string name
+    3: optional string file_extension
+    4: optional string content
+    5: optional string uri
+
+    255: optional base.Base Base
+}
+
+struct CreateDocumentResponse {
+    1: i64          code
+    2: string       msg
+    3: DocumentInfo data
+}
+
+struct DeleteDocumentRequest {
+    1: required i64 document_id (api.js_conv="true", go.tag='json:"document_id,string"')
+
+    255: optional base.Base Base
+}
+
+struct DeleteDocumentResponse {
+    1: i64    code
+    2: string msg
+}
+
+struct ListDocumentRequest {
+    1: required i64 knowledge_id (api.js_conv="true", go.tag='json:"knowledge_id,string"')
+    2: optional i32 page
+    3: optional i32 page_size
+
+    255: optional base.Base Base
+}
+
+struct ListDocumentResponse {
+    1: i64                code
+    2: string             msg
+    3: list<DocumentInfo> document_list
+    4: i64                total
+}
+
+struct ListSliceRequest {
+    1: required i64 document_id (api.js_conv="true", go.tag='json:"document_id,string"')
+    2: optional i32 page
+    3: optional i32 page_size
+
+    255: optional base.Base Base
+}
+
+struct ListSliceResponse {
+    1: i64             code
+    2: string          msg
+    3: list<SliceInfo> slice_list
+    4: i64             total
+}
+
+// 召回测试
+struct RetrieveRequest {
+    1: required string         query
+    2: required list<string>   knowledge_ids
+    3: optional i64            top_k
+    4: optional double         min_score
+    5: optional SearchStrategy search_strategy
+
+    255: optional base.Base Base
+}
+
+struct RetrieveResponse {
+    1: i64                     code
+    2: string                  msg
+    3: list<RetrieveSliceInfo> slice_list
+}
+
+service KnowledgeService {
+    CreateKnowledgeResponse CreateKnowledge(1: CreateKnowledgeRequest request)(api.post='/api/knowledge/create', api.category="knowledge", api.gen_path="knowledge")
+    UpdateKnowledgeResponse UpdateKnowledge(1: UpdateKnowledgeRequest request)(api.post='/api/knowledge/update', api.category="knowledge", api.gen_path="knowledge")
+    DeleteKnowledgeResponse DeleteKnowledge(1: DeleteKnowledgeRequest request)(api.post='/api/knowledge/delete', api.category="knowledge", api.gen_path="knowledge")
+    ListKnowledgeResponse ListKnowledge(1: ListKnowledgeRequest request)(api.post='/api/knowledge/list', api.category="knowledge", api.gen_path="knowledge")
+
+    CreateDocumentResponse CreateDocument(1: CreateDocumentRequest request)(api.post='/api/knowledge/document/create', api.category="knowledge", api.gen_path="knowledge")
+    DeleteDocumentResponse DeleteDocument(1: DeleteDocumentRequest request)(api.post='/api/knowledge/document/delete', api.category="knowledge", api.gen_path="knowledge")
+    ListDocumentResponse ListDocument(1: ListDocumentRequest request)(api.post='/api/knowledge/document/list', api.category="knowledge", api.gen_path="knowledge")
+    ListSliceResponse ListSlice(1: ListSliceRequest request)(api.post='/api/knowledge/slice/list', api.category="knowledge", api.gen_path="knowledge")
+
+    RetrieveResponse Retrieve(1: RetrieveRequest request)(api.post='/api/knowledge/retrieve', api.category="knowledge", api.gen_path="knowledge")
+}
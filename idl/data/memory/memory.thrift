@@ -0,0 +1,69 @@
+namespace go data.memory
+
+include "../../base.thrift"
+
+enum MemoryKind {
+    Fact  = 1 // 关于用户或双方关系的事实
+    Event = 2 // 对话中发生过的事件
+}
+
+struct MemoryInfo {
+    1: i64        id              (api.js_conv="true", go.tag='json:"id,string"')
+    2: i64        agent_id        (api.js_conv="true", go.tag='json:"agent_id,string"')
+    3: i64        conversation_id (api.js_conv="true", go.tag='json:"conversation_id,string"') // 提取出该记忆的会话
+    4: MemoryKind kind
+    5: string     content
+    6: i32        importance // 重要程度 1~10
+    7: i64        recalled_at
+    8: i64        created_at
+    9: i64        updated_at
+}
+
+struct ListMemoriesRequest {
+    1: required i64        agent_id  (api.js_conv="true", go.tag='json:"agent_id,string"')
+    2: optional MemoryKind kind      // 为空时返回全部类型
+    3: optional i32        page
+    4: optional i32        page_size
+
+    255: optional base.Base Base
+}
+
+struct ListMemoriesResponse {
+    1: i64              code
+    2: string           msg
+    3: list<MemoryInfo> memory_list
+    4: i64              total
+}
+
+struct UpdateMemoryRequest {
+    1: required i64        memory_id  (api.js_conv="true", go.tag='json:"memory_id,string"')
+    2: optional string     content
+    3: optional MemoryKind kind
+    4: optional i32        importance
+
+    255: optional base.Base Base
+}
+
+struct UpdateMemoryResponse {
+    1: i64        code
+    2: string     msg
+    3: MemoryInfo data
+}
+
+struct ForgetMemoriesRequest {
+    1: required i64          agent_id   (api.js_conv="true", go.tag='json:"agent_id,string"')
+    2: optional list<string> memory_ids // 为空时遗忘当前用户在该智能体下的全部记忆
+
+    255: optional base.Base Base
+}
+
+struct ForgetMemoriesResponse {
+    1: i64    code
+    2: string msg
+}
+
+service MemoryService {
+    ListMemoriesResponse ListMemories(1: ListMemoriesRequest request)(api.post='/api/memory/list', api.category="memory", api.gen_path="memory")
+    UpdateMemoryResponse UpdateMemory(1: UpdateMemoryRequest request)(api.post='/api/memory/update', api.category="memory", api.gen_path="memory")
+    ForgetMemoriesResponse ForgetMemories(1: ForgetMemoriesRequest request)(api.post='/api/memory/forget', api.category="memory", api.gen_path="memory")
+}
@@ -0,0 +1,60 @@
+namespace go data.variables
+
+include "../../base.thrift"
+
+struct VariableInfo {
+    1: string keyword
+    2: string description
+    3: string default_value
+    4: string value
+    5: bool   is_system       // 系统变量只读
+    6: bool   prompt_disabled // 不注入到提示词
+    7: i64    update_time
+}
+
+struct ListVariablesRequest {
+    1: required i64  agent_id (api.js_conv="true", go.tag='json:"agent_id,string"')
+    2: optional bool is_draft // 为 true 时使用草稿的变量定义，否则使用最新发布版本
+
+    255: optional base.Base Base
+}
+
+struct ListVariablesResponse {
+    1: i64                code
+    2: string             msg
+    3: list<VariableInfo> data
+}
+
+struct UpdateVariablesRequest {
+    1: required i64                agent_id (api.js_conv="true", go.tag='json:"agent_id,string"')
+    2: optional bool               is_draft
+    3: required map<string,string> values   // keyword -> value
+
+    255: optional base.Base Base
+}
+
+struct UpdateVariablesResponse {
+    1: i64                code
+    2: string             msg
+    3: list<VariableInfo> data
+}
+
+struct ResetVariablesRequest {
+    1: required i64          agent_id (api.js_conv="true", go.tag='json:"agent_id,string"')
+    2: optional bool         is_draft
+    3: optional list<string> keywords // 为空时重置全部变量
+
+    255: optional base.Base Base
+}
+
+struct ResetVariablesResponse {
+    1: i64                code
+    2: string             msg
+    3: list<VariableInfo> data
+}
+
+service VariablesService {
+    ListVariablesResponse ListVariables(1: ListVariablesRequest request)(api.post='/api/variables/list', api.category="variables", api.gen_path="variables")
+    UpdateVariablesResponse UpdateVariables(1: UpdateVariablesRequest request)(api.post='/api/variables/update', api.category="variables", api.gen_path="variables")
+    ResetVariablesResponse ResetVariables(1: ResetVariablesRequest request)(api.post='/api/variables/reset', api.category="variables", api.gen_path="variables")
+}
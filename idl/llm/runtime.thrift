@@ -23,7 +23,7 @@ struct ChatResponse {
 
 service LLMRuntimeService {
     // 非流式接口
-    ChatResponse Chat(1: ChatRequest req)
-    // 流式接口
-    ChatResponse ChatStream(1: ChatRequest req) (streaming.mode="server")
+    ChatResponse Chat(1: ChatRequest req) (api.post="/api/llm/v1/chat")
+    // 流式接口，以 SSE 返回，每个 message 事件携带一个消息分片
+    ChatResponse ChatStream(1: ChatRequest req) (streaming.mode="server", api.post="/api/llm/v1/chat/stream")
 }
\ No newline at end of file
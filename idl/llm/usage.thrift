@@ -0,0 +1,86 @@
+namespace go llm.usage
+
+include "../base.thrift"
+
+// 时间范围为 [start_date, end_date]，格式为 2006-01-02，默认最近 7 天；其他条件为空时不过滤
+struct UsageFilter {
+    1: optional string start_date
+    2: optional string end_date
+    3: optional string user_id
+    4: optional i64    agent_id (api.js_conv="true", go.tag='json:"agent_id,string"')
+    5: optional i64    model_id (api.js_conv="true", go.tag='json:"model_id,string"')
+}
+
+struct UsageStat {
+    1: i64    calls
+    2: i64    failed_calls
+    3: i64    input_tokens
+    4: i64    output_tokens
+    5: i64    reasoning_tokens // 包含在 output_tokens 中
+    6: double cost
+}
+
+struct DailyUsage {
+    1: string    day
+    2: UsageStat stat
+}
+
+struct AgentUsage {
+    1: i64       agent_id (api.js_conv="true", go.tag='json:"agent_id,string"')
+    2: UsageStat stat
+}
+
+struct UserUsage {
+    1: string    user_id
+    2: UsageStat stat
+}
+
+struct GetDailyUsageRequest {
+    1: optional UsageFilter filter
+
+    255: optional base.Base Base
+}
+
+struct GetDailyUsageResponse {
+    1: list<DailyUsage> data
+    2: string           currency
+
+    253: i64    code
+    254: string msg
+}
+
+struct GetTopAgentsRequest {
+    1: optional UsageFilter filter
+    2: optional i32         limit // 默认 10，最大 100
+
+    255: optional base.Base Base
+}
+
+struct GetTopAgentsResponse {
+    1: list<AgentUsage> data
+    2: string           currency
+
+    253: i64    code
+    254: string msg
+}
+
+struct GetUserSpendRequest {
+    1: optional UsageFilter filter
+    2: optional i32         limit // 默认 10，最大 100
+
+    255: optional base.Base Base
+}
+
+struct GetUserSpendResponse {
+    1: list<UserUsage> data
+    2: string          currency
+
+    253: i64    code
+    254: string msg
+}
+
+service LLMUsageService {
+    GetDailyUsageResponse GetDailyUsage(1: GetDailyUsageRequest req)(api.post='/api/admin/usage/daily', api.category="admin")
+    GetTopAgentsResponse GetTopAgents(1: GetTopAgentsRequest req)(api.post='/api/admin/usage/top_agents', api.category="admin")
+    GetUserSpendResponse GetUserSpend(1: GetUserSpendRequest req)(api.post='/api/admin/usage/user_spend', api.category="admin")
+}